@@ -56,6 +56,7 @@ type StackConfig struct {
 // LifecycleConfig details the configuration of the Lifecycle
 type LifecycleConfig struct {
 	URI     string `toml:"uri"`
+	SHA256  string `toml:"sha256,omitempty"`
 	Version string `toml:"version"`
 }
 
@@ -50,10 +50,15 @@ func testConfig(t *testing.T, when spec.G, it spec.S) {
 
 [[buildpacks]]
   uri = "https://example.com/buildpack-3.tgz"
+  sha256 = "sha256:abc123"
 
 [[order]]
 [[order.group]]
   id = "buildpack/1"
+
+[lifecycle]
+  uri = "https://example.com/lifecycle.tgz"
+  sha256 = "def456"
 `), 0666))
 			})
 
@@ -74,8 +79,12 @@ func testConfig(t *testing.T, when spec.G, it spec.S) {
 				h.AssertEq(t, builderConfig.Buildpacks[2].ID, "")
 				h.AssertEq(t, builderConfig.Buildpacks[2].URI, "https://example.com/buildpack-3.tgz")
 				h.AssertEq(t, builderConfig.Buildpacks[2].ImageName, "")
+				h.AssertEq(t, builderConfig.Buildpacks[2].SHA256, "sha256:abc123")
 
 				h.AssertEq(t, builderConfig.Order[0].Group[0].ID, "buildpack/1")
+
+				h.AssertEq(t, builderConfig.Lifecycle.URI, "https://example.com/lifecycle.tgz")
+				h.AssertEq(t, builderConfig.Lifecycle.SHA256, "def456")
 			})
 		})
 
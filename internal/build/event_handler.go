@@ -0,0 +1,110 @@
+package build
+
+import (
+	"bytes"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+
+	dcontainer "github.com/docker/docker/api/types/container"
+
+	"github.com/buildpacks/pack/internal/container"
+	"github.com/buildpacks/pack/pkg/events"
+)
+
+var (
+	// Logged by the lifecycle at debug level before running each buildpack or extension.
+	runningModuleRegexp = regexp.MustCompile(`^Running (?:build|generate) for (?:buildpack|extension) (\S+)$`)
+	// Logged by the lifecycle at the start of each phase when running the creator.
+	creatorPhaseRegexp = regexp.MustCompile(`^===> `)
+)
+
+// eventHandler returns a container handler copying the output of a phase to out and errOut, like the default
+// handler, while emitting an event for each line.
+func eventHandler(emitter events.Emitter, phase string, out, errOut io.Writer) container.Handler {
+	return func(bodyChan <-chan dcontainer.WaitResponse, errChan <-chan error, reader io.Reader) error {
+		p := &phaseOutput{emitter: emitter, phase: phase}
+		stdout := &eventWriter{output: p, stream: "stdout", out: out}
+		stderr := &eventWriter{output: p, stream: "stderr", out: errOut}
+		err := container.DefaultHandler(stdout, stderr)(bodyChan, errChan, reader)
+
+		// the default handler may return before closing the writers
+		stdout.flush()
+		stderr.flush()
+		return err
+	}
+}
+
+// phaseOutput interprets the lines written by a phase.
+type phaseOutput struct {
+	mu        sync.Mutex
+	emitter   events.Emitter
+	phase     string
+	buildpack string
+}
+
+func (p *phaseOutput) line(stream, line string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if creatorPhaseRegexp.MatchString(line) {
+		p.buildpack = ""
+	}
+	if m := runningModuleRegexp.FindStringSubmatch(line); m != nil {
+		p.buildpack = m[1]
+	}
+
+	p.emitter.Emit(events.Event{
+		Type:      events.Output,
+		Phase:     p.phase,
+		Buildpack: p.buildpack,
+		Stream:    stream,
+		Line:      line,
+	})
+}
+
+// eventWriter splits what it is written into lines for phaseOutput, and copies it to out.
+type eventWriter struct {
+	mu     sync.Mutex
+	output *phaseOutput
+	stream string
+	out    io.Writer
+	buf    bytes.Buffer
+}
+
+func (w *eventWriter) Write(data []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf.Write(data)
+	for {
+		i := bytes.IndexByte(w.buf.Bytes(), '\n')
+		if i < 0 {
+			break
+		}
+		line := w.buf.Next(i + 1)
+		w.output.line(w.stream, strings.TrimRight(string(line), "\r\n"))
+	}
+	return w.out.Write(data)
+}
+
+// flush passes on any incomplete last line.
+func (w *eventWriter) flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.buf.Len() > 0 {
+		w.output.line(w.stream, strings.TrimRight(w.buf.String(), "\r\n"))
+		w.buf.Reset()
+	}
+}
+
+// Close flushes any incomplete last line, and closes out if it is a closer.
+func (w *eventWriter) Close() error {
+	w.flush()
+	if closer, ok := w.out.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package fakes
+
+import (
+	"sync"
+
+	"github.com/buildpacks/pack/internal/build"
+	"github.com/buildpacks/pack/pkg/events"
+)
+
+type FakeEmitter struct {
+	mu     sync.Mutex
+	events []events.Event
+}
+
+func (f *FakeEmitter) Emit(event events.Event) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.events = append(f.events, event)
+}
+
+// Events returns the events emitted so far of the given types, or of any type if none are given.
+func (f *FakeEmitter) Events(types ...events.Type) []events.Event {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var matching []events.Event
+	for _, event := range f.events {
+		if len(types) == 0 {
+			matching = append(matching, event)
+			continue
+		}
+		for _, t := range types {
+			if event.Type == t {
+				matching = append(matching, event)
+				break
+			}
+		}
+	}
+	return matching
+}
+
+func WithEvents(emitter events.Emitter) func(*build.LifecycleOptions) {
+	return func(opts *build.LifecycleOptions) {
+		opts.Events = emitter
+	}
+}
@@ -0,0 +1,82 @@
+package kubernetes
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"os"
+
+	"github.com/BurntSushi/toml"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/builder"
+	"github.com/buildpacks/pack/pkg/archive"
+)
+
+// archive returns the tar uploaded to the build volume: the app, and the files the lifecycle reads from the layers
+// directory, owned by the builder user.
+func (p *buildPod) archive() io.ReadCloser {
+	uid, gid := p.opts.Builder.UID(), p.opts.Builder.GID()
+
+	return archive.GenerateTar(func(tw archive.TarWriter) error {
+		if err := writeDir(tw, "layers", uid, gid); err != nil {
+			return err
+		}
+		if err := writeToml(tw, "layers/"+projectMetadataFile, p.opts.ProjectMetadata, uid, gid); err != nil {
+			return err
+		}
+		if p.platformAPI.LessThan("0.12") {
+			if err := writeToml(tw, "layers/"+stackFile, p.opts.Builder.Stack(), uid, gid); err != nil {
+				return err
+			}
+		} else {
+			if err := writeToml(tw, "layers/"+runFile, builder.RunImages{Images: p.opts.Builder.RunImages()}, uid, gid); err != nil {
+				return err
+			}
+		}
+
+		fi, err := os.Stat(p.opts.AppPath)
+		if err != nil {
+			return err
+		}
+		if fi.IsDir() {
+			return archive.WriteDirToTar(tw, p.opts.AppPath, "app", uid, gid, -1, false, true, p.opts.FileFilter)
+		}
+		if err := writeDir(tw, "app", uid, gid); err != nil {
+			return err
+		}
+		return archive.WriteZipToTar(tw, p.opts.AppPath, "app", uid, gid, -1, false, p.opts.FileFilter)
+	})
+}
+
+func writeDir(tw archive.TarWriter, name string, uid, gid int) error {
+	return tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeDir,
+		Name:     name,
+		Mode:     0755,
+		Uid:      uid,
+		Gid:      gid,
+		ModTime:  archive.NormalizedDateTime,
+	})
+}
+
+func writeToml(tw archive.TarWriter, name string, data interface{}, uid, gid int) error {
+	buf := &bytes.Buffer{}
+	if err := toml.NewEncoder(buf).Encode(data); err != nil {
+		return errors.Wrapf(err, "marshaling data to %s", name)
+	}
+
+	if err := tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     0644,
+		Size:     int64(buf.Len()),
+		Uid:      uid,
+		Gid:      gid,
+		ModTime:  archive.NormalizedDateTime,
+	}); err != nil {
+		return err
+	}
+	_, err := tw.Write(buf.Bytes())
+	return err
+}
@@ -0,0 +1,335 @@
+// Package kubernetes runs the lifecycle in a Kubernetes cluster rather than with the docker daemon.
+package kubernetes
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/buildpacks/lifecycle/auth"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/buildpacks/pack/internal/build"
+	strs "github.com/buildpacks/pack/internal/strings"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/cache"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+const (
+	managedByLabel = "app.kubernetes.io/managed-by"
+	managedByValue = "pack"
+
+	defaultPollInterval = time.Second
+)
+
+// Size of the volumes holding the app and layers of a build, and the build cache.
+var defaultVolumeSize = resource.MustParse("5Gi")
+
+// Executor runs the lifecycle as the containers of a pod in a Kubernetes cluster. The app is uploaded to the pod,
+// layers are kept on a volume claimed for the build, and the build cache on a volume claimed for each app image,
+// unless it is kept in an image. Images are always published, since there is no daemon to export them to.
+type Executor struct {
+	logger       logging.Logger
+	clientset    kubernetes.Interface
+	uploader     AppUploader
+	namespace    string
+	pollInterval time.Duration
+}
+
+// NewExecutor returns an executor running builds in the namespace of the cluster of the clientset.
+func NewExecutor(logger logging.Logger, clientset kubernetes.Interface, uploader AppUploader, namespace string) *Executor {
+	return &Executor{
+		logger:       logger,
+		clientset:    clientset,
+		uploader:     uploader,
+		namespace:    namespace,
+		pollInterval: defaultPollInterval,
+	}
+}
+
+// NewExecutorFromKubeconfig returns an executor for the cluster of the current kubeconfig context, loaded the way
+// kubectl loads it. Builds run in the namespace of the context unless a namespace is given.
+func NewExecutorFromKubeconfig(logger logging.Logger, namespace string) (*Executor, error) {
+	config := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		clientcmd.NewDefaultClientConfigLoadingRules(),
+		&clientcmd.ConfigOverrides{},
+	)
+	restConfig, err := config.ClientConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "loading kubeconfig")
+	}
+	if namespace == "" {
+		if namespace, _, err = config.Namespace(); err != nil {
+			return nil, errors.Wrap(err, "reading namespace from kubeconfig")
+		}
+	}
+
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating kubernetes client")
+	}
+	return NewExecutor(logger, clientset, NewAttachUploader(restConfig, clientset), namespace), nil
+}
+
+func (e *Executor) Execute(ctx context.Context, opts build.LifecycleOptions) error {
+	if err := validate(opts); err != nil {
+		return err
+	}
+
+	descriptor := opts.Builder.LifecycleDescriptor()
+	platformAPI, err := build.FindLatestSupported(append(descriptor.APIs.Platform.Deprecated, descriptor.APIs.Platform.Supported...), opts.LifecycleApis)
+	if err != nil {
+		return err
+	}
+	if platformAPI.LessThan("0.7") {
+		return errors.Errorf("platform API %s is not supported by the kubernetes executor, 0.7 or newer is required", platformAPI)
+	}
+
+	suffix, err := strs.Random(10)
+	if err != nil {
+		return errors.Wrap(err, "generating build name")
+	}
+	name := "pack-build-" + suffix
+	pod := &buildPod{
+		name:        name,
+		volumeClaim: name,
+		secret:      name,
+		appDir:      path.Join("/", "workspace"),
+		platformAPI: platformAPI,
+		opts:        opts,
+	}
+	if opts.Workspace != "" {
+		pod.appDir = path.Join("/", opts.Workspace)
+	}
+	pod.cacheImage = cacheImage(opts)
+	if pod.cacheImage == "" {
+		pod.cacheClaim = cacheClaimName(opts)
+		if err := e.ensureCacheClaim(ctx, pod.cacheClaim); err != nil {
+			return err
+		}
+	}
+
+	authConfig, err := auth.BuildEnvVar(opts.Keychain, opts.Image.String(), opts.RunImage, pod.cacheImage, opts.PreviousImage)
+	if err != nil {
+		return err
+	}
+	if _, err := e.clientset.CoreV1().Secrets(e.namespace).Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: pod.secret, Labels: map[string]string{managedByLabel: managedByValue}},
+		StringData: map[string]string{registryAuthKey: authConfig},
+	}, metav1.CreateOptions{}); err != nil {
+		return errors.Wrap(err, "creating registry credentials secret")
+	}
+	defer e.cleanup("secret", pod.secret, e.clientset.CoreV1().Secrets(e.namespace).Delete)
+
+	if _, err := e.clientset.CoreV1().PersistentVolumeClaims(e.namespace).Create(ctx, volumeClaim(pod.volumeClaim, defaultVolumeSize), metav1.CreateOptions{}); err != nil {
+		return errors.Wrap(err, "creating build volume claim")
+	}
+	defer e.cleanup("volume claim", pod.volumeClaim, e.clientset.CoreV1().PersistentVolumeClaims(e.namespace).Delete)
+
+	phases := pod.phases()
+	if _, err := e.clientset.CoreV1().Pods(e.namespace).Create(ctx, pod.spec(phases, e.logger.IsVerbose()), metav1.CreateOptions{}); err != nil {
+		return errors.Wrap(err, "creating build pod")
+	}
+	defer e.cleanup("pod", pod.name, e.clientset.CoreV1().Pods(e.namespace).Delete)
+	e.logger.Debugf("Running build in pod %s of namespace %s", style.Symbol(pod.name), style.Symbol(e.namespace))
+
+	if err := e.upload(ctx, pod); err != nil {
+		return err
+	}
+
+	for _, ph := range phases {
+		e.logger.Info(style.Step(ph.step))
+		if err := build.RunPhase(opts.Events, ph.name, func() error { return e.runContainer(ctx, pod.name, ph.name) }); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validate(opts build.LifecycleOptions) error {
+	switch {
+	case !opts.Publish:
+		return errors.New("the kubernetes executor can only publish images")
+	case opts.Layout:
+		return errors.New("exporting to OCI layout is not supported by the kubernetes executor")
+	case opts.Interactive:
+		return errors.New("interactive mode is not supported by the kubernetes executor")
+	case len(opts.Builder.OrderExtensions()) > 0:
+		return errors.New("builders with image extensions are not supported by the kubernetes executor")
+	case opts.Cache.Build.Format == cache.CacheBind:
+		return errors.New("bind caches are not supported by the kubernetes executor")
+	case len(opts.Volumes) > 0:
+		return errors.New("volumes are not supported by the kubernetes executor")
+	case opts.SBOMDestinationDir != "" || opts.ReportDestinationDir != "":
+		return errors.New("SBOM and report output directories are not supported by the kubernetes executor")
+	}
+
+	// the name of a cache volume is used as the name of its volume claim
+	if cacheImage(opts) == "" && opts.Cache.Build.Source != "" {
+		if errs := validation.IsDNS1123Subdomain(opts.Cache.Build.Source); len(errs) > 0 {
+			return errors.Errorf("build cache volume name %s is not a valid volume claim name: %s", style.Symbol(opts.Cache.Build.Source), strings.Join(errs, "; "))
+		}
+	}
+	return nil
+}
+
+// cacheImage returns the image to keep the build cache in, if it is not kept on a volume.
+func cacheImage(opts build.LifecycleOptions) string {
+	if opts.CacheImage != "" {
+		return opts.CacheImage
+	}
+	if opts.Cache.Build.Format == cache.CacheImage || opts.Cache.Build.Format == cache.CacheRegistry {
+		return opts.Cache.Build.Source
+	}
+	return ""
+}
+
+// cacheClaimName returns the name of the volume claim keeping the build cache of the app image across builds.
+func cacheClaimName(opts build.LifecycleOptions) string {
+	if opts.Cache.Build.Source != "" {
+		return opts.Cache.Build.Source
+	}
+	sum := sha256.Sum256([]byte(opts.Image.Context().Name()))
+	return fmt.Sprintf("pack-cache-%x", sum[:6])
+}
+
+func (e *Executor) ensureCacheClaim(ctx context.Context, name string) error {
+	claims := e.clientset.CoreV1().PersistentVolumeClaims(e.namespace)
+	_, err := claims.Get(ctx, name, metav1.GetOptions{})
+	if err == nil {
+		e.logger.Debugf("Using build cache volume claim %s", style.Symbol(name))
+		return nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "getting build cache volume claim %s", style.Symbol(name))
+	}
+
+	e.logger.Debugf("Creating build cache volume claim %s", style.Symbol(name))
+	if _, err := claims.Create(ctx, volumeClaim(name, defaultVolumeSize), metav1.CreateOptions{}); err != nil {
+		return errors.Wrapf(err, "creating build cache volume claim %s", style.Symbol(name))
+	}
+	return nil
+}
+
+// upload streams the app to the upload container once it runs, and waits for it to be extracted.
+func (e *Executor) upload(ctx context.Context, pod *buildPod) error {
+	if _, err := e.waitForContainer(ctx, pod.name, uploadContainer, started); err != nil {
+		return err
+	}
+
+	e.logger.Debug("Uploading app")
+	appArchive := pod.archive()
+	defer appArchive.Close()
+	if err := e.uploader.Upload(ctx, e.namespace, pod.name, uploadContainer, appArchive); err != nil {
+		return errors.Wrap(err, "uploading app")
+	}
+
+	return e.checkExitCode(ctx, pod.name, uploadContainer)
+}
+
+// runContainer streams the logs of a container of the pod until it exits, and checks it succeeded.
+func (e *Executor) runContainer(ctx context.Context, podName, container string) error {
+	if _, err := e.waitForContainer(ctx, podName, container, started); err != nil {
+		return err
+	}
+
+	logs, err := e.clientset.CoreV1().Pods(e.namespace).GetLogs(podName, &corev1.PodLogOptions{Container: container, Follow: true}).Stream(ctx)
+	if err != nil {
+		return errors.Wrapf(err, "streaming logs of %s", style.Symbol(container))
+	}
+	defer logs.Close()
+
+	out := logging.NewPrefixWriter(logging.GetWriterForLevel(e.logger, logging.InfoLevel), container)
+	defer out.Close()
+	if _, err := io.Copy(out, logs); err != nil {
+		return errors.Wrapf(err, "streaming logs of %s", style.Symbol(container))
+	}
+
+	return e.checkExitCode(ctx, podName, container)
+}
+
+func (e *Executor) checkExitCode(ctx context.Context, podName, container string) error {
+	state, err := e.waitForContainer(ctx, podName, container, terminated)
+	if err != nil {
+		return err
+	}
+	if state.Terminated.ExitCode != 0 {
+		return fmt.Errorf("failed with status code: %d", state.Terminated.ExitCode)
+	}
+	return nil
+}
+
+func started(state corev1.ContainerState) bool {
+	return state.Running != nil || state.Terminated != nil
+}
+
+func terminated(state corev1.ContainerState) bool {
+	return state.Terminated != nil
+}
+
+// waitForContainer polls the pod until the state of the container satisfies done. It fails if the container cannot
+// start, or if the pod fails before it does.
+func (e *Executor) waitForContainer(ctx context.Context, podName, container string, done func(corev1.ContainerState) bool) (corev1.ContainerState, error) {
+	for {
+		pod, err := e.clientset.CoreV1().Pods(e.namespace).Get(ctx, podName, metav1.GetOptions{})
+		if err != nil {
+			return corev1.ContainerState{}, errors.Wrapf(err, "getting pod %s", style.Symbol(podName))
+		}
+
+		status, ok := containerStatus(pod, container)
+		if ok {
+			if done(status.State) {
+				return status.State, nil
+			}
+			if waiting := status.State.Waiting; waiting != nil && stuckReasons[waiting.Reason] {
+				return corev1.ContainerState{}, errors.Errorf("container %s cannot start: %s: %s", style.Symbol(container), waiting.Reason, waiting.Message)
+			}
+		}
+		if pod.Status.Phase == corev1.PodFailed && !(ok && status.State.Terminated != nil) {
+			return corev1.ContainerState{}, errors.Errorf("pod %s failed before container %s ran: %s", style.Symbol(podName), style.Symbol(container), pod.Status.Message)
+		}
+
+		select {
+		case <-ctx.Done():
+			return corev1.ContainerState{}, ctx.Err()
+		case <-time.After(e.pollInterval):
+		}
+	}
+}
+
+// Reasons a container waits that it will not recover from by itself.
+var stuckReasons = map[string]bool{
+	"ErrImagePull":               true,
+	"ImagePullBackOff":           true,
+	"InvalidImageName":           true,
+	"CreateContainerConfigError": true,
+	"CreateContainerError":       true,
+}
+
+func containerStatus(pod *corev1.Pod, container string) (corev1.ContainerStatus, bool) {
+	for _, status := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+		if status.Name == container {
+			return status, true
+		}
+	}
+	return corev1.ContainerStatus{}, false
+}
+
+// cleanup deletes an object created for the build, even if the build was canceled.
+func (e *Executor) cleanup(kind, name string, deleteFn func(context.Context, string, metav1.DeleteOptions) error) {
+	if err := deleteFn(context.Background(), name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		e.logger.Warnf("Unable to delete %s %s: %s", kind, style.Symbol(name), err)
+	}
+}
@@ -0,0 +1,281 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/buildpacks/lifecycle/api"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/buildpacks/pack/internal/build"
+	"github.com/buildpacks/pack/internal/build/fakes"
+	"github.com/buildpacks/pack/internal/builder"
+	"github.com/buildpacks/pack/pkg/archive"
+	"github.com/buildpacks/pack/pkg/cache"
+	"github.com/buildpacks/pack/pkg/events"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestExecutor(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "KubernetesExecutor", testExecutor, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+// fakeUploader records the uploaded archive, and then finishes every container of the pod with the exit code set for it.
+type fakeUploader struct {
+	clientset *fake.Clientset
+	archive   []byte
+	exitCodes map[string]int32
+}
+
+func (u *fakeUploader) Upload(ctx context.Context, namespace, podName, container string, archive io.Reader) error {
+	var err error
+	if u.archive, err = io.ReadAll(archive); err != nil {
+		return err
+	}
+
+	pod, err := u.clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	pod.Status.InitContainerStatuses = finished(pod.Spec.InitContainers, u.exitCodes)
+	pod.Status.ContainerStatuses = finished(pod.Spec.Containers, u.exitCodes)
+	_, err = u.clientset.CoreV1().Pods(namespace).UpdateStatus(ctx, pod, metav1.UpdateOptions{})
+	return err
+}
+
+func finished(containers []corev1.Container, exitCodes map[string]int32) []corev1.ContainerStatus {
+	var statuses []corev1.ContainerStatus
+	for _, c := range containers {
+		statuses = append(statuses, corev1.ContainerStatus{
+			Name:  c.Name,
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: exitCodes[c.Name]}},
+		})
+	}
+	return statuses
+}
+
+func testExecutor(t *testing.T, when spec.G, it spec.S) {
+	var (
+		clientset *fake.Clientset
+		uploader  *fakeUploader
+		outBuf    bytes.Buffer
+		subject   *Executor
+		opts      build.LifecycleOptions
+		created   *corev1.Pod
+	)
+
+	it.Before(func() {
+		clientset = fake.NewSimpleClientset()
+		clientset.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			pod := action.(k8stesting.CreateAction).GetObject().(*corev1.Pod)
+			pod.Status.InitContainerStatuses = []corev1.ContainerStatus{{
+				Name:  uploadContainer,
+				State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+			}}
+			created = pod.DeepCopy()
+			return false, nil, nil
+		})
+		uploader = &fakeUploader{clientset: clientset, exitCodes: map[string]int32{}}
+
+		outBuf.Reset()
+		subject = NewExecutor(logging.NewLogWithWriters(&outBuf, &outBuf), clientset, uploader, "some-namespace")
+		subject.pollInterval = time.Millisecond
+
+		fakeBuilder, err := fakes.NewFakeBuilder(fakes.WithSupportedPlatformAPIs([]*api.Version{api.MustParse("0.12")}))
+		h.AssertNil(t, err)
+		fakeBuilder.ReturnForRunImages = []builder.RunImageMetadata{{Image: "some-registry.io/some-run"}}
+
+		opts = build.LifecycleOptions{
+			AppPath:        filepath.Join("..", "testdata", "fake-app"),
+			Image:          name.MustParseReference("some-registry.io/some-app"),
+			Builder:        fakeBuilder,
+			BuilderImage:   "some-registry.io/some-builder",
+			LifecycleImage: "some-registry.io/some-lifecycle",
+			RunImage:       "some-registry.io/some-run",
+			AdditionalTags: []string{"some-registry.io/some-app:other-tag"},
+			Publish:        true,
+			Keychain:       authn.DefaultKeychain,
+		}
+	})
+
+	containerNames := func(pod *corev1.Pod) []string {
+		var names []string
+		for _, c := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+			names = append(names, c.Name)
+		}
+		return names
+	}
+
+	findContainer := func(pod *corev1.Pod, containerName string) corev1.Container {
+		for _, c := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+			if c.Name == containerName {
+				return c
+			}
+		}
+		t.Fatalf("no container %s in pod", containerName)
+		return corev1.Container{}
+	}
+
+	it("runs each phase as a container of a pod", func() {
+		h.AssertNil(t, subject.Execute(context.TODO(), opts))
+
+		h.AssertEq(t, created.Labels[managedByLabel], managedByValue)
+		h.AssertEq(t, containerNames(created), []string{"upload", "analyzer", "detector", "restorer", "builder", "exporter"})
+
+		analyzer := findContainer(created, "analyzer")
+		h.AssertEq(t, analyzer.Image, "some-registry.io/some-lifecycle")
+		h.AssertEq(t, analyzer.Command, []string{"/cnb/lifecycle/analyzer"})
+		h.AssertSliceContainsInOrder(t, analyzer.Args, "-run", "/layers/run.toml")
+		h.AssertSliceContainsInOrder(t, analyzer.Args, "-tag", "some-registry.io/some-app:other-tag")
+		h.AssertEq(t, analyzer.Args[len(analyzer.Args)-1], "some-registry.io/some-app")
+
+		h.AssertEq(t, findContainer(created, "builder").Image, "some-registry.io/some-builder")
+		h.AssertContains(t, outBuf.String(), "===> BUILDING")
+		h.AssertContains(t, outBuf.String(), "[exporter] fake logs")
+	})
+
+	it("only gives registry credentials to the lifecycle phases", func() {
+		h.AssertNil(t, subject.Execute(context.TODO(), opts))
+
+		hasCredentials := func(c corev1.Container) bool {
+			for _, env := range c.Env {
+				if env.Name == registryAuthKey {
+					return env.ValueFrom.SecretKeyRef.Name == created.Name
+				}
+			}
+			return false
+		}
+		h.AssertEq(t, hasCredentials(findContainer(created, "analyzer")), true)
+		h.AssertEq(t, hasCredentials(findContainer(created, "exporter")), true)
+		h.AssertEq(t, hasCredentials(findContainer(created, "detector")), false)
+		h.AssertEq(t, hasCredentials(findContainer(created, "builder")), false)
+	})
+
+	it("uploads the app and the files read by the lifecycle", func() {
+		h.AssertNil(t, subject.Execute(context.TODO(), opts))
+
+		_, _, err := archive.ReadTarEntry(bytes.NewReader(uploader.archive), "app/fake-app-file")
+		h.AssertNil(t, err)
+		_, contents, err := archive.ReadTarEntry(bytes.NewReader(uploader.archive), "layers/run.toml")
+		h.AssertNil(t, err)
+		h.AssertContains(t, string(contents), `image = "some-registry.io/some-run"`)
+		_, _, err = archive.ReadTarEntry(bytes.NewReader(uploader.archive), "layers/project-metadata.toml")
+		h.AssertNil(t, err)
+	})
+
+	it("deletes the objects created for the build and keeps the build cache", func() {
+		h.AssertNil(t, subject.Execute(context.TODO(), opts))
+
+		pods, err := clientset.CoreV1().Pods("some-namespace").List(context.TODO(), metav1.ListOptions{})
+		h.AssertNil(t, err)
+		h.AssertEq(t, len(pods.Items), 0)
+		secrets, err := clientset.CoreV1().Secrets("some-namespace").List(context.TODO(), metav1.ListOptions{})
+		h.AssertNil(t, err)
+		h.AssertEq(t, len(secrets.Items), 0)
+
+		claims, err := clientset.CoreV1().PersistentVolumeClaims("some-namespace").List(context.TODO(), metav1.ListOptions{})
+		h.AssertNil(t, err)
+		h.AssertEq(t, len(claims.Items), 1)
+		h.AssertEq(t, claims.Items[0].Name, cacheClaimName(opts))
+	})
+
+	it("emits events for each phase", func() {
+		emitter := &fakes.FakeEmitter{}
+		opts.Events = emitter
+
+		h.AssertNil(t, subject.Execute(context.TODO(), opts))
+
+		h.AssertEq(t, len(emitter.Events(events.PhaseStart)), 5)
+		h.AssertEq(t, len(emitter.Events(events.PhaseEnd)), 5)
+	})
+
+	it("fails when a phase fails", func() {
+		uploader.exitCodes["builder"] = 1
+
+		err := subject.Execute(context.TODO(), opts)
+		h.AssertError(t, err, "failed with status code: 1")
+		h.AssertNotContains(t, outBuf.String(), "===> EXPORTING")
+	})
+
+	when("the creator is used", func() {
+		it("runs a single phase", func() {
+			opts.UseCreator = true
+
+			h.AssertNil(t, subject.Execute(context.TODO(), opts))
+
+			h.AssertEq(t, containerNames(created), []string{"upload", "creator"})
+			creator := findContainer(created, "creator")
+			h.AssertEq(t, creator.Image, "some-registry.io/some-builder")
+			h.AssertSliceContainsInOrder(t, creator.Args, "-tag", "some-registry.io/some-app:other-tag")
+			h.AssertEq(t, creator.Args[len(creator.Args)-1], "some-registry.io/some-app")
+		})
+	})
+
+	when("the cache is kept in an image", func() {
+		it("does not claim a cache volume", func() {
+			opts.Cache.Build = cache.CacheInfo{Format: cache.CacheImage, Source: "some-registry.io/some-cache"}
+
+			h.AssertNil(t, subject.Execute(context.TODO(), opts))
+
+			h.AssertSliceContainsInOrder(t, findContainer(created, "exporter").Args, "-cache-image", "some-registry.io/some-cache")
+			claims, err := clientset.CoreV1().PersistentVolumeClaims("some-namespace").List(context.TODO(), metav1.ListOptions{})
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(claims.Items), 0)
+		})
+	})
+
+	when("the cache is kept in a named volume", func() {
+		it("claims a volume with the name of the cache", func() {
+			opts.Cache.Build = cache.CacheInfo{Format: cache.CacheVolume, Source: "some-cache"}
+
+			h.AssertNil(t, subject.Execute(context.TODO(), opts))
+
+			claim, err := clientset.CoreV1().PersistentVolumeClaims("some-namespace").Get(context.TODO(), "some-cache", metav1.GetOptions{})
+			h.AssertNil(t, err)
+			h.AssertEq(t, claim.Name, "some-cache")
+		})
+
+		it("errors when the name is not a valid volume claim name", func() {
+			opts.Cache.Build = cache.CacheInfo{Format: cache.CacheVolume, Source: "Some_Cache"}
+
+			err := subject.Execute(context.TODO(), opts)
+			h.AssertError(t, err, "build cache volume name 'Some_Cache' is not a valid volume claim name")
+		})
+	})
+
+	when("the image is not published", func() {
+		it("errors", func() {
+			opts.Publish = false
+
+			err := subject.Execute(context.TODO(), opts)
+			h.AssertError(t, err, "the kubernetes executor can only publish images")
+		})
+	})
+
+	when("the platform API is older than 0.7", func() {
+		it("errors", func() {
+			fakeBuilder, err := fakes.NewFakeBuilder(fakes.WithSupportedPlatformAPIs([]*api.Version{api.MustParse("0.6")}))
+			h.AssertNil(t, err)
+			opts.Builder = fakeBuilder
+
+			err = subject.Execute(context.TODO(), opts)
+			h.AssertError(t, err, "platform API 0.6 is not supported by the kubernetes executor")
+		})
+	})
+}
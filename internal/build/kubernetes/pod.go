@@ -0,0 +1,254 @@
+package kubernetes
+
+import (
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/buildpacks/lifecycle/api"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/buildpacks/pack/internal/build"
+)
+
+const (
+	layersDir = "/layers"
+	cacheDir  = "/cache"
+
+	workspaceVolume = "workspace"
+	cacheVolume     = "cache"
+
+	uploadContainer = "upload"
+	uploadDir       = "/pack"
+
+	stackFile           = "stack.toml"
+	runFile             = "run.toml"
+	projectMetadataFile = "project-metadata.toml"
+
+	registryAuthKey = "CNB_REGISTRY_AUTH"
+)
+
+// phase is a lifecycle phase run as a container of the build pod.
+type phase struct {
+	name  string
+	step  string
+	image string
+	args  []string
+
+	// whether the phase is given registry credentials, which only phases of the lifecycle itself are
+	registryAccess bool
+
+	// whether the phase sets the creation time of the app image
+	epoch bool
+}
+
+// buildPod describes the pod running a build.
+type buildPod struct {
+	name        string
+	volumeClaim string
+	cacheClaim  string
+	secret      string
+	cacheImage  string
+	appDir      string
+	platformAPI *api.Version
+	opts        build.LifecycleOptions
+}
+
+// phases returns the lifecycle phases to run, in order. Phases that handle registry credentials run in the lifecycle
+// image unless the builder is trusted, like they do with the docker daemon.
+func (p *buildPod) phases() []phase {
+	builderImage := p.opts.BuilderImage
+	lifecycleImage := builderImage
+	if p.opts.LifecycleImage != "" {
+		lifecycleImage = p.opts.LifecycleImage
+	}
+
+	if p.opts.UseCreator {
+		args := addTags([]string{"-app", p.appDir, "-layers", layersDir, "-run-image", p.opts.RunImage}, p.opts.AdditionalTags)
+		args = append(args, p.cacheArgs()...)
+		if p.opts.ClearCache {
+			args = append(args, "-skip-restore")
+		}
+		if p.opts.PreviousImage != "" {
+			args = append(args, "-previous-image", p.opts.PreviousImage)
+		}
+		args = append(args, p.processTypeArgs()...)
+		return []phase{{name: "creator", step: "CREATING", image: builderImage, args: append(args, p.opts.Image.String()), registryAccess: true, epoch: true}}
+	}
+
+	analyzeArgs := addTags(append(p.runArgs(), "-layers", layersDir, "-run-image", p.opts.RunImage), p.opts.AdditionalTags)
+	if p.cacheImage != "" {
+		analyzeArgs = append(analyzeArgs, "-cache-image", p.cacheImage)
+	}
+	if p.opts.ClearCache {
+		analyzeArgs = append(analyzeArgs, "-skip-layers")
+	}
+	if p.opts.PreviousImage != "" {
+		analyzeArgs = append(analyzeArgs, "-previous-image", p.opts.PreviousImage)
+	}
+
+	restoreArgs := append([]string{"-layers", layersDir}, p.cacheArgs()...)
+	if p.opts.ClearCache {
+		restoreArgs = append(restoreArgs, "-skip-layers")
+	}
+
+	exportArgs := append(append(p.runArgs(), "-app", p.appDir, "-layers", layersDir), p.cacheArgs()...)
+	exportArgs = append(exportArgs, p.processTypeArgs()...)
+
+	phases := []phase{
+		{name: "analyzer", step: "ANALYZING", image: lifecycleImage, args: append(analyzeArgs, p.opts.Image.String()), registryAccess: true},
+		{name: "detector", step: "DETECTING", image: builderImage, args: []string{"-app", p.appDir, "-layers", layersDir}},
+	}
+	if !(p.opts.ClearCache && p.platformAPI.LessThan("0.10")) {
+		phases = append(phases, phase{name: "restorer", step: "RESTORING", image: lifecycleImage, args: restoreArgs, registryAccess: p.cacheImage != ""})
+	}
+	return append(phases,
+		phase{name: "builder", step: "BUILDING", image: builderImage, args: []string{"-app", p.appDir, "-layers", layersDir}},
+		phase{name: "exporter", step: "EXPORTING", image: lifecycleImage, args: addTags(exportArgs, p.opts.AdditionalTags), registryAccess: true, epoch: true},
+	)
+}
+
+func (p *buildPod) cacheArgs() []string {
+	if p.cacheImage != "" {
+		return []string{"-cache-image", p.cacheImage}
+	}
+	return []string{"-cache-dir", cacheDir}
+}
+
+// runArgs points the analyzer and exporter, which may not run in the builder image, at the run images of the builder
+// uploaded with the app.
+func (p *buildPod) runArgs() []string {
+	if p.platformAPI.LessThan("0.12") {
+		return []string{"-stack", path.Join(layersDir, stackFile)}
+	}
+	return []string{"-run", path.Join(layersDir, runFile)}
+}
+
+func (p *buildPod) processTypeArgs() []string {
+	if p.opts.DefaultProcessType == "" {
+		return nil
+	}
+	return []string{"-process-type", p.opts.DefaultProcessType}
+}
+
+func addTags(args, additionalTags []string) []string {
+	for _, tag := range additionalTags {
+		args = append(args, "-tag", tag)
+	}
+	return args
+}
+
+// spec returns the pod running the phases. The app is uploaded to the first init container, and each phase but the
+// last runs as a further init container, so that they run one after the other. The upload container extracts the
+// archive with the tar of the builder image.
+func (p *buildPod) spec(phases []phase, verbose bool) *corev1.Pod {
+	uid := int64(p.opts.Builder.UID())
+	gid := int64(p.opts.Builder.GID())
+
+	mounts := []corev1.VolumeMount{
+		{Name: workspaceVolume, MountPath: layersDir, SubPath: "layers"},
+		{Name: workspaceVolume, MountPath: p.appDir, SubPath: "app"},
+	}
+	volumes := []corev1.Volume{{
+		Name: workspaceVolume,
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: p.volumeClaim},
+		},
+	}}
+	if p.cacheClaim != "" {
+		mounts = append(mounts, corev1.VolumeMount{Name: cacheVolume, MountPath: cacheDir})
+		volumes = append(volumes, corev1.Volume{
+			Name: cacheVolume,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: p.cacheClaim},
+			},
+		})
+	}
+
+	env := []corev1.EnvVar{
+		{Name: "CNB_PLATFORM_API", Value: p.platformAPI.String()},
+		{Name: "CNB_USER_ID", Value: strconv.FormatInt(uid, 10)},
+		{Name: "CNB_GROUP_ID", Value: strconv.FormatInt(gid, 10)},
+	}
+	for _, proxy := range []corev1.EnvVar{
+		{Name: "HTTP_PROXY", Value: p.opts.HTTPProxy},
+		{Name: "HTTPS_PROXY", Value: p.opts.HTTPSProxy},
+		{Name: "NO_PROXY", Value: p.opts.NoProxy},
+	} {
+		if proxy.Value != "" {
+			env = append(env, proxy, corev1.EnvVar{Name: strings.ToLower(proxy.Name), Value: proxy.Value})
+		}
+	}
+
+	securityContext := &corev1.SecurityContext{RunAsUser: &uid, RunAsGroup: &gid}
+
+	containers := []corev1.Container{{
+		Name:            uploadContainer,
+		Image:           p.opts.BuilderImage,
+		Command:         []string{"tar", "-xf", "-", "-C", uploadDir},
+		Stdin:           true,
+		StdinOnce:       true,
+		VolumeMounts:    []corev1.VolumeMount{{Name: workspaceVolume, MountPath: uploadDir}},
+		SecurityContext: securityContext,
+	}}
+	for _, ph := range phases {
+		args := ph.args
+		if verbose {
+			args = append([]string{"-log-level", "debug"}, args...)
+		}
+		phaseEnv := append([]corev1.EnvVar{}, env...)
+		if ph.epoch && p.opts.CreationTime != nil && p.platformAPI.AtLeast("0.9") {
+			phaseEnv = append(phaseEnv, corev1.EnvVar{Name: "SOURCE_DATE_EPOCH", Value: strconv.FormatInt(p.opts.CreationTime.Unix(), 10)})
+		}
+		if ph.registryAccess && p.secret != "" {
+			phaseEnv = append(phaseEnv, corev1.EnvVar{
+				Name: registryAuthKey,
+				ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: p.secret},
+					Key:                  registryAuthKey,
+				}},
+			})
+		}
+		containers = append(containers, corev1.Container{
+			Name:            ph.name,
+			Image:           ph.image,
+			Command:         []string{path.Join("/cnb/lifecycle", ph.name)},
+			Args:            args,
+			Env:             phaseEnv,
+			VolumeMounts:    mounts,
+			SecurityContext: securityContext,
+		})
+	}
+
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   p.name,
+			Labels: map[string]string{managedByLabel: managedByValue},
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy:   corev1.RestartPolicyNever,
+			InitContainers:  containers[:len(containers)-1],
+			Containers:      containers[len(containers)-1:],
+			Volumes:         volumes,
+			SecurityContext: &corev1.PodSecurityContext{FSGroup: &gid},
+		},
+	}
+}
+
+// volumeClaim returns a claim for a volume of the given size.
+func volumeClaim(name string, size resource.Quantity) *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{managedByLabel: managedByValue},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: size},
+			},
+		},
+	}
+}
@@ -0,0 +1,43 @@
+package kubernetes
+
+import (
+	"context"
+	"io"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// AppUploader streams the app archive to the standard input of a container of the build pod.
+type AppUploader interface {
+	Upload(ctx context.Context, namespace, pod, container string, archive io.Reader) error
+}
+
+type attachUploader struct {
+	config    *rest.Config
+	clientset kubernetes.Interface
+}
+
+// NewAttachUploader returns an uploader attaching to the container, the way 'kubectl attach' does.
+func NewAttachUploader(config *rest.Config, clientset kubernetes.Interface) AppUploader {
+	return &attachUploader{config: config, clientset: clientset}
+}
+
+func (u *attachUploader) Upload(ctx context.Context, namespace, pod, container string, archive io.Reader) error {
+	req := u.clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(pod).
+		SubResource("attach").
+		VersionedParams(&corev1.PodAttachOptions{Container: container, Stdin: true}, scheme.ParameterCodec)
+
+	exec, err := remotecommand.NewSPDYExecutor(u.config, "POST", req.URL())
+	if err != nil {
+		return errors.Wrap(err, "attaching to container")
+	}
+	return exec.StreamWithContext(ctx, remotecommand.StreamOptions{Stdin: archive})
+}
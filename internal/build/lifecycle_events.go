@@ -0,0 +1,140 @@
+package build
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"io"
+	"sort"
+
+	"github.com/BurntSushi/toml"
+	"github.com/buildpacks/lifecycle/buildpack"
+	"github.com/buildpacks/lifecycle/platform"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/pkg/events"
+)
+
+// cacheMetadataFile is where the lifecycle keeps the metadata of a volume or bind cache, relative to the cache
+// directory.
+var cacheMetadataFile = []string{"committed", "io.buildpacks.lifecycle.cache.metadata"}
+
+// EmitGroup emits the group that passed detection, read from the group.toml written by the phase at groupPath.
+// Nothing is emitted if the phase did not write the group.
+func EmitGroup(emitter events.Emitter, phase, groupPath string) ContainerOperation {
+	return CopyOutMaybe(func(reader io.ReadCloser) error {
+		defer reader.Close()
+
+		data, err := readSingleFile(reader)
+		if err != nil {
+			return errors.Wrap(err, "reading group")
+		}
+
+		var group buildpack.Group
+		if _, err := toml.Decode(string(data), &group); err != nil {
+			return errors.Wrap(err, "decoding group")
+		}
+
+		var modules []events.Module
+		for _, el := range append(group.Group, group.GroupExtensions...) {
+			modules = append(modules, events.Module{ID: el.ID, Version: el.Version})
+		}
+		if len(modules) > 0 {
+			emitter.Emit(events.Event{Type: events.Group, Phase: phase, Group: modules})
+		}
+		return nil
+	}, groupPath)
+}
+
+// cacheEvents emits whether each cached layer was reused from the previous build, by comparing the metadata of a volume
+// or bind cache before and after a phase exporting to it.
+type cacheEvents struct {
+	emitter      events.Emitter
+	phase        string
+	metadataPath string
+	previous     map[string]string
+}
+
+// EmitCacheResults returns the container operations to run before and after a phase exporting to a volume or bind
+// cache, whose metadata is at metadataPath. Once the phase is done, a cache hit is emitted for each cached layer that
+// has the same digest as before, and a cache miss for the others.
+func EmitCacheResults(emitter events.Emitter, phase, metadataPath string) (before, after ContainerOperation) {
+	c := &cacheEvents{emitter: emitter, phase: phase, metadataPath: metadataPath}
+	return c.readPrevious(), c.emit()
+}
+
+// readPrevious reads the cache metadata before the phase runs.
+func (c *cacheEvents) readPrevious() ContainerOperation {
+	return CopyOutMaybe(func(reader io.ReadCloser) error {
+		layers, err := readCachedLayers(reader)
+		if err != nil {
+			return err
+		}
+		c.previous = layers
+		return nil
+	}, c.metadataPath)
+}
+
+// emit reads the cache metadata once the phase is done, and emits the cache results.
+func (c *cacheEvents) emit() ContainerOperation {
+	return CopyOutMaybe(func(reader io.ReadCloser) error {
+		layers, err := readCachedLayers(reader)
+		if err != nil {
+			return err
+		}
+
+		names := make([]string, 0, len(layers))
+		for name := range layers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			previous, ok := c.previous[name]
+			c.emitter.Emit(events.Event{
+				Type:  events.Cache,
+				Phase: c.phase,
+				Cache: &events.CacheResult{Layer: name, Hit: ok && previous == layers[name]},
+			})
+		}
+		return nil
+	}, c.metadataPath)
+}
+
+// readCachedLayers returns the digest of each cached layer in the cache metadata, by '<buildpack ID>:<layer name>'.
+func readCachedLayers(reader io.ReadCloser) (map[string]string, error) {
+	defer reader.Close()
+
+	data, err := readSingleFile(reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading cache metadata")
+	}
+
+	var md platform.CacheMetadata
+	if err := json.Unmarshal(data, &md); err != nil {
+		return nil, errors.Wrap(err, "decoding cache metadata")
+	}
+
+	layers := map[string]string{}
+	for _, bp := range md.Buildpacks {
+		for name, layer := range bp.Layers {
+			if layer.Cache {
+				layers[bp.ID+":"+name] = layer.SHA
+			}
+		}
+	}
+	return layers, nil
+}
+
+// readSingleFile reads the file copied out of a container, which is the first entry of the tar stream.
+func readSingleFile(reader io.Reader) ([]byte, error) {
+	tr := tar.NewReader(reader)
+	for {
+		header, err := tr.Next()
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag == tar.TypeReg {
+			return io.ReadAll(tr)
+		}
+	}
+}
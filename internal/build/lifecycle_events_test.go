@@ -0,0 +1,137 @@
+package build_test
+
+import (
+	"context"
+	"io"
+	"path"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/errdefs"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/build"
+	"github.com/buildpacks/pack/internal/build/fakes"
+	"github.com/buildpacks/pack/pkg/archive"
+	"github.com/buildpacks/pack/pkg/events"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestLifecycleEvents(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "lifecycle-events", testLifecycleEvents, spec.Report(report.Terminal{}), spec.Parallel())
+}
+
+func testLifecycleEvents(t *testing.T, when spec.G, it spec.S) {
+	var (
+		emitter   *fakes.FakeEmitter
+		ctrClient *fakeCopyClient
+	)
+
+	it.Before(func() {
+		emitter = &fakes.FakeEmitter{}
+		ctrClient = &fakeCopyClient{files: map[string]string{}}
+	})
+
+	run := func(op build.ContainerOperation) error {
+		return op(ctrClient, context.Background(), "some-container", io.Discard, io.Discard)
+	}
+
+	when("#EmitGroup", func() {
+		it("emits the group written by the phase", func() {
+			ctrClient.files["/layers/group.toml"] = `
+[[group]]
+  id = "some/buildpack"
+  version = "1.2.3"
+
+[[group]]
+  id = "some/other-buildpack"
+  version = "4.5.6"
+
+[[group-extensions]]
+  id = "some/extension"
+  version = "7.8.9"
+`
+			h.AssertNil(t, run(build.EmitGroup(emitter, "detector", "/layers/group.toml")))
+
+			group := emitter.Events(events.Group)
+			h.AssertEq(t, len(group), 1)
+			h.AssertEq(t, group[0].Phase, "detector")
+			h.AssertEq(t, group[0].Group, []events.Module{
+				{ID: "some/buildpack", Version: "1.2.3"},
+				{ID: "some/other-buildpack", Version: "4.5.6"},
+				{ID: "some/extension", Version: "7.8.9"},
+			})
+		})
+
+		it("emits nothing when the phase wrote no group", func() {
+			h.AssertNil(t, run(build.EmitGroup(emitter, "detector", "/layers/group.toml")))
+			h.AssertEq(t, len(emitter.Events()), 0)
+		})
+	})
+
+	when("#EmitCacheResults", func() {
+		const metadataPath = "/cache/committed/io.buildpacks.lifecycle.cache.metadata"
+
+		it("emits a hit for each cached layer with the same digest as before, and a miss for the others", func() {
+			before, after := build.EmitCacheResults(emitter, "exporter", metadataPath)
+
+			ctrClient.files[metadataPath] = `{"buildpacks": [{"key": "some/buildpack", "layers": {
+				"same-layer": {"sha": "sha256:same", "cache": true},
+				"changed-layer": {"sha": "sha256:old", "cache": true}
+			}}]}`
+			h.AssertNil(t, run(before))
+
+			ctrClient.files[metadataPath] = `{"buildpacks": [{"key": "some/buildpack", "layers": {
+				"same-layer": {"sha": "sha256:same", "cache": true},
+				"changed-layer": {"sha": "sha256:new", "cache": true},
+				"new-layer": {"sha": "sha256:new", "cache": true},
+				"launch-layer": {"sha": "sha256:launch", "launch": true}
+			}}]}`
+			h.AssertNil(t, run(after))
+
+			cache := emitter.Events(events.Cache)
+			h.AssertEq(t, len(cache), 3)
+			h.AssertEq(t, cache[0].Phase, "exporter")
+			h.AssertEq(t, *cache[0].Cache, events.CacheResult{Layer: "some/buildpack:changed-layer", Hit: false})
+			h.AssertEq(t, *cache[1].Cache, events.CacheResult{Layer: "some/buildpack:new-layer", Hit: false})
+			h.AssertEq(t, *cache[2].Cache, events.CacheResult{Layer: "some/buildpack:same-layer", Hit: true})
+		})
+
+		it("emits misses when there was no cache before", func() {
+			before, after := build.EmitCacheResults(emitter, "creator", metadataPath)
+			h.AssertNil(t, run(before))
+
+			ctrClient.files[metadataPath] = `{"buildpacks": [{"key": "some/buildpack", "layers": {"some-layer": {"sha": "sha256:some", "cache": true}}}]}`
+			h.AssertNil(t, run(after))
+
+			cache := emitter.Events(events.Cache)
+			h.AssertEq(t, len(cache), 1)
+			h.AssertEq(t, *cache[0].Cache, events.CacheResult{Layer: "some/buildpack:some-layer", Hit: false})
+		})
+
+		it("errors when the cache metadata is invalid", func() {
+			before, _ := build.EmitCacheResults(emitter, "exporter", metadataPath)
+			ctrClient.files[metadataPath] = "not json"
+			h.AssertError(t, run(before), "decoding cache metadata")
+		})
+	})
+}
+
+// fakeCopyClient copies files out of a container, as tar streams like the daemon does.
+type fakeCopyClient struct {
+	build.DockerClient
+	files map[string]string
+}
+
+func (f *fakeCopyClient) CopyFromContainer(_ context.Context, _, srcPath string) (io.ReadCloser, types.ContainerPathStat, error) {
+	content, ok := f.files[srcPath]
+	if !ok {
+		return nil, types.ContainerPathStat{}, errdefs.NotFound(errors.Errorf("no such file %s", srcPath))
+	}
+	return archive.CreateSingleFileTarReader(path.Base(srcPath), content), types.ContainerPathStat{}, nil
+}
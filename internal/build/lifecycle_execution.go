@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"time"
 
 	"github.com/BurntSushi/toml"
 	"github.com/buildpacks/lifecycle/api"
@@ -20,6 +21,7 @@ import (
 	"github.com/buildpacks/pack/internal/paths"
 	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/pkg/cache"
+	"github.com/buildpacks/pack/pkg/events"
 	"github.com/buildpacks/pack/pkg/logging"
 )
 
@@ -28,6 +30,8 @@ const (
 	overrideGID        = 0
 	overrideUID        = 0
 	sourceDateEpochEnv = "SOURCE_DATE_EPOCH"
+	// serviceBindingRootEnv is read by buildpacks to find the service bindings
+	serviceBindingRootEnv = "SERVICE_BINDING_ROOT"
 )
 
 type LifecycleExecution struct {
@@ -164,6 +168,19 @@ func (l *LifecycleExecution) PrevImageName() string {
 }
 
 func (l *LifecycleExecution) Run(ctx context.Context, phaseFactoryCreator PhaseFactoryCreator) error {
+	if len(l.opts.Secrets) > 0 {
+		switch {
+		case l.os == "windows":
+			return errors.New("secrets are not supported when building Windows images")
+		case l.opts.UseCreator:
+			return errors.New("secrets are not supported by the creator")
+		}
+	}
+
+	if l.opts.BindingsDir != "" && l.os == "windows" {
+		return errors.New("bindings are not supported when building Windows images")
+	}
+
 	phaseFactory := phaseFactoryCreator(l)
 	var buildCache Cache
 	if l.opts.CacheImage != "" || (l.opts.Cache.Build.Format == cache.CacheImage) {
@@ -184,6 +201,13 @@ func (l *LifecycleExecution) Run(ctx context.Context, phaseFactoryCreator PhaseF
 		case cache.CacheBind:
 			buildCache = cache.NewBindCache(l.opts.Cache.Build, l.docker)
 			l.logger.Debugf("Using build cache dir %s", style.Symbol(buildCache.Name()))
+		case cache.CacheRegistry:
+			cacheImage, err := name.ParseReference(l.opts.Cache.Build.Source, name.WeakValidation)
+			if err != nil {
+				return fmt.Errorf("invalid cache image name: %s", err)
+			}
+			buildCache = cache.NewRegistryCache(cacheImage)
+			l.logger.Debugf("Using build cache image %s", style.Symbol(buildCache.Name()))
 		}
 	}
 
@@ -199,22 +223,22 @@ func (l *LifecycleExecution) Run(ctx context.Context, phaseFactoryCreator PhaseF
 	if !l.opts.UseCreator {
 		if l.platformAPI.LessThan("0.7") {
 			l.logger.Info(style.Step("DETECTING"))
-			if err := l.Detect(ctx, phaseFactory); err != nil {
+			if err := l.runPhase("detector", func() error { return l.Detect(ctx, phaseFactory) }); err != nil {
 				return err
 			}
 
 			l.logger.Info(style.Step("ANALYZING"))
-			if err := l.Analyze(ctx, buildCache, launchCache, phaseFactory); err != nil {
+			if err := l.runPhase("analyzer", func() error { return l.Analyze(ctx, buildCache, launchCache, phaseFactory) }); err != nil {
 				return err
 			}
 		} else {
 			l.logger.Info(style.Step("ANALYZING"))
-			if err := l.Analyze(ctx, buildCache, launchCache, phaseFactory); err != nil {
+			if err := l.runPhase("analyzer", func() error { return l.Analyze(ctx, buildCache, launchCache, phaseFactory) }); err != nil {
 				return err
 			}
 
 			l.logger.Info(style.Step("DETECTING"))
-			if err := l.Detect(ctx, phaseFactory); err != nil {
+			if err := l.runPhase("detector", func() error { return l.Detect(ctx, phaseFactory) }); err != nil {
 				return err
 			}
 		}
@@ -254,7 +278,7 @@ func (l *LifecycleExecution) Run(ctx context.Context, phaseFactoryCreator PhaseF
 		l.logger.Info(style.Step("RESTORING"))
 		if l.opts.ClearCache && l.PlatformAPI().LessThan("0.10") {
 			l.logger.Info("Skipping 'restore' due to clearing cache")
-		} else if err := l.Restore(ctx, buildCache, kanikoCache, phaseFactory); err != nil {
+		} else if err := l.runPhase("restorer", func() error { return l.Restore(ctx, buildCache, kanikoCache, phaseFactory) }); err != nil {
 			return err
 		}
 
@@ -268,23 +292,37 @@ func (l *LifecycleExecution) Run(ctx context.Context, phaseFactoryCreator PhaseF
 			}
 		}
 
+		if len(l.opts.Secrets) > 0 && l.platformAPI.AtLeast("0.10") && l.hasExtensionsForBuild() {
+			// the extender runs the build in place of the builder, without the tmpfs the secrets are written to
+			return errors.New("secrets are not supported with extensions extending the build image")
+		}
+
+		if l.opts.BindingsDir != "" && l.platformAPI.AtLeast("0.10") && l.hasExtensionsForBuild() {
+			// the extender snapshots the filesystem of the build image, where the bindings would be written
+			return errors.New("bindings are not supported with extensions extending the build image")
+		}
+
 		group, _ := errgroup.WithContext(context.TODO())
 		if l.platformAPI.AtLeast("0.10") && l.hasExtensionsForBuild() {
 			group.Go(func() error {
 				l.logger.Info(style.Step("EXTENDING (BUILD)"))
-				return l.ExtendBuild(ctx, kanikoCache, phaseFactory, l.extensionsAreExperimental())
+				return l.runPhase("extender (build)", func() error {
+					return l.ExtendBuild(ctx, kanikoCache, phaseFactory, l.extensionsAreExperimental())
+				})
 			})
 		} else {
 			group.Go(func() error {
 				l.logger.Info(style.Step("BUILDING"))
-				return l.Build(ctx, phaseFactory)
+				return l.runPhase("builder", func() error { return l.Build(ctx, phaseFactory) })
 			})
 		}
 
 		if l.platformAPI.AtLeast("0.12") && l.hasExtensionsForRun() {
 			group.Go(func() error {
 				l.logger.Info(style.Step("EXTENDING (RUN)"))
-				return l.ExtendRun(ctx, kanikoCache, phaseFactory, ephemeralRunImage, l.extensionsAreExperimental())
+				return l.runPhase("extender (run)", func() error {
+					return l.ExtendRun(ctx, kanikoCache, phaseFactory, ephemeralRunImage, l.extensionsAreExperimental())
+				})
 			})
 		}
 
@@ -293,13 +331,36 @@ func (l *LifecycleExecution) Run(ctx context.Context, phaseFactoryCreator PhaseF
 		}
 
 		l.logger.Info(style.Step("EXPORTING"))
-		return l.Export(ctx, buildCache, launchCache, kanikoCache, phaseFactory)
+		return l.runPhase("exporter", func() error { return l.Export(ctx, buildCache, launchCache, kanikoCache, phaseFactory) })
 	}
 
 	if l.platformAPI.AtLeast("0.10") && l.hasExtensions() && !l.opts.UseCreatorWithExtensions {
 		return errors.New("builder has an order for extensions which is not supported when using the creator; re-run without '--trust-builder' or re-tag builder to avoid trusting it")
 	}
-	return l.Create(ctx, buildCache, launchCache, phaseFactory)
+	return l.runPhase("creator", func() error { return l.Create(ctx, buildCache, launchCache, phaseFactory) })
+}
+
+// runPhase runs a phase, emitting events when it starts and ends if events were requested.
+func (l *LifecycleExecution) runPhase(phase string, run func() error) error {
+	return RunPhase(l.opts.Events, phase, run)
+}
+
+// RunPhase runs a phase, emitting events to the emitter when it starts and ends, unless the emitter is nil.
+func RunPhase(emitter events.Emitter, phase string, run func() error) error {
+	if emitter == nil {
+		return run()
+	}
+
+	started := time.Now()
+	emitter.Emit(events.Event{Type: events.PhaseStart, Time: started, Phase: phase})
+	err := run()
+
+	end := events.Event{Type: events.PhaseEnd, Phase: phase, DurationMs: time.Since(started).Milliseconds()}
+	if err != nil {
+		end.Error = err.Error()
+	}
+	emitter.Emit(end)
+	return err
 }
 
 func (l *LifecycleExecution) Cleanup() error {
@@ -366,12 +427,14 @@ func (l *LifecycleExecution) Create(ctx context.Context, buildCache, launchCache
 	}
 
 	var cacheBindOp PhaseConfigProviderOperation
+	cacheEventsOp := NullOp()
 	switch buildCache.Type() {
-	case cache.Image:
+	case cache.Image, cache.Registry:
 		flags = append(flags, "-cache-image", buildCache.Name())
 		cacheBindOp = WithBinds(l.opts.Volumes...)
 	case cache.Volume, cache.Bind:
 		cacheBindOp = WithBinds(append(l.opts.Volumes, fmt.Sprintf("%s:%s", buildCache.Name(), l.mountPaths.cacheDir()))...)
+		cacheEventsOp = l.withCacheEvents("creator")
 	}
 
 	withEnv := NullOp()
@@ -380,10 +443,12 @@ func (l *LifecycleExecution) Create(ctx context.Context, buildCache, launchCache
 	}
 
 	opts := []PhaseConfigProviderOperation{
-		WithFlags(l.withLogLevel(flags...)...),
+		WithFlags(l.withDetectLogLevel(flags...)...),
 		WithArgs(l.opts.Image.String()),
 		WithNetwork(l.opts.Network),
 		cacheBindOp,
+		cacheEventsOp,
+		l.withGroupEvent("creator"),
 		WithContainerOperations(WriteProjectMetadata(l.mountPaths.projectPath(), l.opts.ProjectMetadata, l.os)),
 		WithContainerOperations(CopyDir(l.opts.AppPath, l.mountPaths.appDir(), l.opts.Builder.UID(), l.opts.Builder.GID(), l.os, true, l.opts.FileFilter)),
 		If(l.opts.SBOMDestinationDir != "", WithPostContainerRunOperations(
@@ -396,6 +461,7 @@ func (l *LifecycleExecution) Create(ctx context.Context, buildCache, launchCache
 			EnsureVolumeAccess(l.opts.Builder.UID(), l.opts.Builder.GID(), l.os, l.layersVolume, l.appVolume),
 			CopyOut(l.opts.Termui.ReadLayers, l.mountPaths.layersDir(), l.mountPaths.appDir()))),
 		withEnv,
+		l.withBindings(),
 	}
 
 	if l.opts.Layout {
@@ -407,14 +473,20 @@ func (l *LifecycleExecution) Create(ctx context.Context, buildCache, launchCache
 	}
 
 	if l.opts.Publish || l.opts.Layout {
-		authConfig, err := auth.BuildEnvVar(l.opts.Keychain, l.opts.Image.String(), l.opts.RunImage, l.opts.CacheImage, l.opts.PreviousImage)
+		authConfig, err := auth.BuildEnvVar(l.opts.Keychain, append([]string{l.opts.Image.String(), l.opts.RunImage, l.opts.CacheImage, l.opts.PreviousImage}, registryCacheImages(buildCache)...)...)
 		if err != nil {
 			return err
 		}
 
 		opts = append(opts, WithRoot(), WithRegistryAccess(authConfig))
 	} else {
+		registryCacheOp, err := l.withRegistryCacheAccess(buildCache)
+		if err != nil {
+			return err
+		}
+
 		opts = append(opts,
+			registryCacheOp,
 			WithDaemonAccess(l.opts.DockerHost),
 			WithFlags("-daemon", "-launch-cache", l.mountPaths.launchCacheDir()),
 			WithBinds(fmt.Sprintf("%s:%s", launchCache.Name(), l.mountPaths.launchCacheDir())),
@@ -439,7 +511,7 @@ func (l *LifecycleExecution) Detect(ctx context.Context, phaseFactory PhaseFacto
 		l,
 		WithLogPrefix("detector"),
 		WithArgs(
-			l.withLogLevel()...,
+			l.withDetectLogLevel()...,
 		),
 		WithNetwork(l.opts.Network),
 		WithBinds(l.opts.Volumes...),
@@ -453,6 +525,8 @@ func (l *LifecycleExecution) Detect(ctx context.Context, phaseFactory PhaseFacto
 		If(l.hasExtensions(), WithPostContainerRunOperations(
 			CopyOutToMaybe(filepath.Join(l.mountPaths.layersDir(), "generated"), l.tmpDir))),
 		envOp,
+		l.withBindings(),
+		l.withGroupEvent("detector"),
 	)
 
 	detect := phaseFactory.New(configProvider)
@@ -460,6 +534,21 @@ func (l *LifecycleExecution) Detect(ctx context.Context, phaseFactory PhaseFacto
 	return detect.Run(ctx)
 }
 
+// withBindings copies the service bindings into the container of a phase running buildpacks, and points the buildpacks
+// to them.
+func (l *LifecycleExecution) withBindings() PhaseConfigProviderOperation {
+	if l.opts.BindingsDir == "" {
+		return NullOp()
+	}
+
+	return func(provider *PhaseConfigProvider) {
+		WithContainerOperations(
+			CopyDir(l.opts.BindingsDir, l.mountPaths.bindingsDir(), l.opts.Builder.UID(), l.opts.Builder.GID(), l.os, true, nil),
+		)(provider)
+		WithEnv(fmt.Sprintf("%s=%s", serviceBindingRootEnv, l.mountPaths.bindingsDir()))(provider)
+	}
+}
+
 func (l *LifecycleExecution) extensionsAreExperimental() bool {
 	return l.PlatformAPI().AtLeast("0.10") && l.platformAPI.LessThan("0.13")
 }
@@ -475,7 +564,7 @@ func (l *LifecycleExecution) Restore(ctx context.Context, buildCache Cache, kani
 	// for cache
 	cacheBindOp := NullOp()
 	switch buildCache.Type() {
-	case cache.Image:
+	case cache.Image, cache.Registry:
 		flags = append(flags, "-cache-image", buildCache.Name())
 		registryImages = append(registryImages, buildCache.Name())
 	case cache.Volume:
@@ -573,7 +662,7 @@ func (l *LifecycleExecution) Analyze(ctx context.Context, buildCache, launchCach
 		}
 	} else {
 		switch buildCache.Type() {
-		case cache.Image:
+		case cache.Image, cache.Registry:
 			flags = append(flags, "-cache-image", buildCache.Name())
 		case cache.Volume:
 			if platformAPILessThan07 {
@@ -654,7 +743,7 @@ func (l *LifecycleExecution) Analyze(ctx context.Context, buildCache, launchCach
 
 	var analyze RunnerCleaner
 	if l.opts.Publish || l.opts.Layout {
-		authConfig, err := auth.BuildEnvVar(l.opts.Keychain, l.opts.Image.String(), l.opts.RunImage, l.opts.CacheImage, l.opts.PreviousImage)
+		authConfig, err := auth.BuildEnvVar(l.opts.Keychain, append([]string{l.opts.Image.String(), l.opts.RunImage, l.opts.CacheImage, l.opts.PreviousImage}, registryCacheImages(buildCache)...)...)
 		if err != nil {
 			return err
 		}
@@ -678,6 +767,11 @@ func (l *LifecycleExecution) Analyze(ctx context.Context, buildCache, launchCach
 
 		analyze = phaseFactory.New(configProvider)
 	} else {
+		registryCacheOp, err := l.withRegistryCacheAccess(buildCache)
+		if err != nil {
+			return err
+		}
+
 		configProvider := NewPhaseConfigProvider(
 			"analyzer",
 			l,
@@ -688,6 +782,7 @@ func (l *LifecycleExecution) Analyze(ctx context.Context, buildCache, launchCach
 				fmt.Sprintf("%s=%d", builder.EnvGID, l.opts.Builder.GID()),
 			),
 			WithDaemonAccess(l.opts.DockerHost),
+			registryCacheOp,
 			launchCacheBindOp,
 			WithFlags(l.withLogLevel("-daemon")...),
 			WithArgs(args...),
@@ -715,6 +810,8 @@ func (l *LifecycleExecution) Build(ctx context.Context, phaseFactory PhaseFactor
 		WithNetwork(l.opts.Network),
 		WithBinds(l.opts.Volumes...),
 		WithFlags(flags...),
+		If(len(l.opts.Secrets) > 0, WithSecrets(l.mountPaths.secretsDir(), l.opts.Secrets, l.opts.Builder.UID(), l.opts.Builder.GID())),
+		l.withBindings(),
 	)
 
 	build := phaseFactory.New(configProvider)
@@ -809,11 +906,13 @@ func (l *LifecycleExecution) Export(ctx context.Context, buildCache, launchCache
 	}
 
 	cacheBindOp := NullOp()
+	cacheEventsOp := NullOp()
 	switch buildCache.Type() {
-	case cache.Image:
+	case cache.Image, cache.Registry:
 		flags = append(flags, "-cache-image", buildCache.Name())
 	case cache.Volume:
 		cacheBindOp = WithBinds(fmt.Sprintf("%s:%s", buildCache.Name(), l.mountPaths.cacheDir()))
+		cacheEventsOp = l.withCacheEvents("exporter")
 	}
 
 	epochEnv := NullOp()
@@ -835,6 +934,7 @@ func (l *LifecycleExecution) Export(ctx context.Context, buildCache, launchCache
 		WithRoot(),
 		WithNetwork(l.opts.Network),
 		cacheBindOp,
+		cacheEventsOp,
 		kanikoCacheBindOp,
 		WithContainerOperations(WriteStackToml(l.mountPaths.stackPath(), l.opts.Builder.Stack(), l.os)),
 		WithContainerOperations(WriteRunToml(l.mountPaths.runPath(), l.opts.Builder.RunImages(), l.os)),
@@ -863,7 +963,7 @@ func (l *LifecycleExecution) Export(ctx context.Context, buildCache, launchCache
 
 	var export RunnerCleaner
 	if l.opts.Publish || l.opts.Layout {
-		authConfig, err := auth.BuildEnvVar(l.opts.Keychain, l.opts.Image.String(), l.opts.RunImage, l.opts.CacheImage, l.opts.PreviousImage)
+		authConfig, err := auth.BuildEnvVar(l.opts.Keychain, append([]string{l.opts.Image.String(), l.opts.RunImage, l.opts.CacheImage, l.opts.PreviousImage}, registryCacheImages(buildCache)...)...)
 		if err != nil {
 			return err
 		}
@@ -875,8 +975,14 @@ func (l *LifecycleExecution) Export(ctx context.Context, buildCache, launchCache
 		)
 		export = phaseFactory.New(NewPhaseConfigProvider("exporter", l, opts...))
 	} else {
+		registryCacheOp, err := l.withRegistryCacheAccess(buildCache)
+		if err != nil {
+			return err
+		}
+
 		opts = append(
 			opts,
+			registryCacheOp,
 			WithDaemonAccess(l.opts.DockerHost),
 			WithFlags("-daemon", "-launch-cache", l.mountPaths.launchCacheDir()),
 			WithBinds(fmt.Sprintf("%s:%s", launchCache.Name(), l.mountPaths.launchCacheDir())),
@@ -888,6 +994,33 @@ func (l *LifecycleExecution) Export(ctx context.Context, buildCache, launchCache
 	return export.Run(ctx)
 }
 
+// withGroupEvent emits the group that passed detection once a phase running detection is done, if events were
+// requested.
+func (l *LifecycleExecution) withGroupEvent(phase string) PhaseConfigProviderOperation {
+	if !l.emitsEvents() {
+		return NullOp()
+	}
+	return WithPostContainerRunOperations(EmitGroup(l.opts.Events, phase, l.mountPaths.groupPath()))
+}
+
+// withCacheEvents emits whether each cached layer was reused once a phase exporting to a volume or bind cache is done,
+// if events were requested. No cache events are emitted for image and registry caches, as pack does not read them.
+func (l *LifecycleExecution) withCacheEvents(phase string) PhaseConfigProviderOperation {
+	if !l.emitsEvents() {
+		return NullOp()
+	}
+	before, after := EmitCacheResults(l.opts.Events, phase, l.mountPaths.cacheMetadataPath())
+	return func(provider *PhaseConfigProvider) {
+		WithContainerOperations(before)(provider)
+		WithPostContainerRunOperations(after)(provider)
+	}
+}
+
+// emitsEvents returns whether events were requested, which are not emitted in interactive mode.
+func (l *LifecycleExecution) emitsEvents() bool {
+	return l.opts.Events != nil && !l.opts.Interactive
+}
+
 func (l *LifecycleExecution) withLogLevel(args ...string) []string {
 	if l.logger.IsVerbose() {
 		return append([]string{"-log-level", "debug"}, args...)
@@ -895,6 +1028,36 @@ func (l *LifecycleExecution) withLogLevel(args ...string) []string {
 	return args
 }
 
+// withDetectLogLevel is withLogLevel for the phases running detection, which log at debug level when detection is
+// explained, as the results of each group are only logged at that level. The creator has a single log level for all
+// the phases it runs, so they all log at debug level then.
+func (l *LifecycleExecution) withDetectLogLevel(args ...string) []string {
+	if l.opts.ExplainDetect && !l.logger.IsVerbose() {
+		return append([]string{"-log-level", "debug"}, args...)
+	}
+	return l.withLogLevel(args...)
+}
+
+// withRegistryCacheAccess provides registry credentials for a build cache stored in a registry,
+// which is needed even when the app image is exported to the daemon.
+func (l *LifecycleExecution) withRegistryCacheAccess(buildCache Cache) (PhaseConfigProviderOperation, error) {
+	if buildCache.Type() != cache.Registry {
+		return NullOp(), nil
+	}
+	authConfig, err := auth.BuildEnvVar(l.opts.Keychain, buildCache.Name())
+	if err != nil {
+		return nil, err
+	}
+	return WithRegistryAccess(authConfig), nil
+}
+
+func registryCacheImages(buildCache Cache) []string {
+	if buildCache.Type() != cache.Registry {
+		return nil
+	}
+	return []string{buildCache.Name()}
+}
+
 func (l *LifecycleExecution) hasExtensions() bool {
 	return len(l.opts.Builder.OrderExtensions()) > 0
 }
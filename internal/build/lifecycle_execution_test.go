@@ -29,6 +29,7 @@ import (
 	"github.com/buildpacks/pack/internal/paths"
 	"github.com/buildpacks/pack/pkg/cache"
 	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/events"
 	"github.com/buildpacks/pack/pkg/logging"
 	h "github.com/buildpacks/pack/testhelpers"
 )
@@ -294,6 +295,27 @@ func testLifecycleExecution(t *testing.T, when spec.G, it spec.S) {
 			fakePhaseFactory = fakes.NewFakePhaseFactory()
 		})
 
+		when("there are secrets", func() {
+			it("errors when using the creator", func() {
+				opts := build.LifecycleOptions{
+					RunImage:   "test",
+					Image:      imageName,
+					Builder:    fakeBuilder,
+					UseCreator: true,
+					Secrets:    map[string][]byte{"some-secret": []byte("some-value")},
+				}
+
+				lifecycle, err := build.NewLifecycleExecution(logger, docker, "some-temp-dir", opts)
+				h.AssertNil(t, err)
+
+				err = lifecycle.Run(context.Background(), func(execution *build.LifecycleExecution) build.PhaseFactory {
+					return fakePhaseFactory
+				})
+				h.AssertError(t, err, "secrets are not supported by the creator")
+				h.AssertEq(t, len(fakePhaseFactory.NewCalledWithProvider), 0)
+			})
+		})
+
 		when("Run using creator", func() {
 			it("succeeds", func() {
 				opts := build.LifecycleOptions{
@@ -479,6 +501,39 @@ func testLifecycleExecution(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 
+			when("events are requested", func() {
+				it("emits an event when each phase starts and ends", func() {
+					emitter := &fakes.FakeEmitter{}
+					opts := build.LifecycleOptions{
+						RunImage: "test",
+						Image:    imageName,
+						Builder:  fakeBuilder,
+						Termui:   fakeTermui,
+						Events:   emitter,
+					}
+
+					lifecycle, err := build.NewLifecycleExecution(logger, docker, "some-temp-dir", opts)
+					h.AssertNil(t, err)
+
+					err = lifecycle.Run(context.Background(), func(execution *build.LifecycleExecution) build.PhaseFactory {
+						return fakePhaseFactory
+					})
+					h.AssertNil(t, err)
+
+					var phases []string
+					for _, event := range emitter.Events(events.PhaseStart, events.PhaseEnd) {
+						phases = append(phases, string(event.Type)+" "+event.Phase)
+					}
+					h.AssertEq(t, phases, []string{
+						"phaseStart detector", "phaseEnd detector",
+						"phaseStart analyzer", "phaseEnd analyzer",
+						"phaseStart restorer", "phaseEnd restorer",
+						"phaseStart builder", "phaseEnd builder",
+						"phaseStart exporter", "phaseEnd exporter",
+					})
+				})
+			})
+
 			it("succeeds", func() {
 				opts := build.LifecycleOptions{
 					Publish:      false,
@@ -866,6 +921,17 @@ func testLifecycleExecution(t *testing.T, when spec.G, it spec.S) {
 			h.AssertEq(t, configProvider.HostConfig().NetworkMode, container.NetworkMode(providedNetworkMode))
 		})
 
+		when("events are requested", func() {
+			lifecycleOps = append(lifecycleOps, fakes.WithEvents(&fakes.FakeEmitter{}))
+
+			it("reads the cache metadata before and after the phase, and the group once it is done", func() {
+				h.AssertFunctionName(t, configProvider.ContainerOps()[0], "CopyOutMaybe")
+				h.AssertEq(t, len(configProvider.PostContainerRunOps()), 2)
+				h.AssertFunctionName(t, configProvider.PostContainerRunOps()[0], "CopyOutMaybe")
+				h.AssertFunctionName(t, configProvider.PostContainerRunOps()[1], "CopyOutMaybe")
+			})
+		})
+
 		when("clear cache", func() {
 			providedClearCache = true
 
@@ -1356,6 +1422,46 @@ func testLifecycleExecution(t *testing.T, when spec.G, it spec.S) {
 			h.AssertFunctionName(t, configProvider.ContainerOps()[1], "CopyDir")
 		})
 
+		when("detection is explained", func() {
+			it("configures the phase to log at debug level even if the logger is not verbose", func() {
+				lifecycle = newTestLifecycleExec(t, false, tmpDir, append(lifecycleOps, func(opts *build.LifecycleOptions) {
+					opts.ExplainDetect = true
+				})...)
+
+				h.AssertNil(t, lifecycle.Detect(context.Background(), fakePhaseFactory))
+
+				configProvider = fakePhaseFactory.NewCalledWithProvider[len(fakePhaseFactory.NewCalledWithProvider)-1]
+				h.AssertSliceContainsInOrder(t, configProvider.ContainerConfig().Cmd, "-log-level", "debug")
+			})
+		})
+
+		when("there are bindings", func() {
+			it("copies them to the container of the phase", func() {
+				lifecycle = newTestLifecycleExec(t, false, tmpDir, append(lifecycleOps, func(opts *build.LifecycleOptions) {
+					opts.BindingsDir = "some-bindings-dir"
+				})...)
+
+				h.AssertNil(t, lifecycle.Detect(context.Background(), fakePhaseFactory))
+
+				configProvider = fakePhaseFactory.NewCalledWithProvider[len(fakePhaseFactory.NewCalledWithProvider)-1]
+				h.AssertEq(t, len(configProvider.ContainerOps()), 3)
+				h.AssertFunctionName(t, configProvider.ContainerOps()[2], "CopyDir")
+				h.AssertSliceContains(t, configProvider.ContainerConfig().Env, "SERVICE_BINDING_ROOT=/platform/bindings")
+			})
+		})
+
+		when("events are requested", func() {
+			it("reads the group that passed detection once the phase is done", func() {
+				lifecycle = newTestLifecycleExec(t, false, tmpDir, append(lifecycleOps, fakes.WithEvents(&fakes.FakeEmitter{}))...)
+
+				h.AssertNil(t, lifecycle.Detect(context.Background(), fakePhaseFactory))
+
+				configProvider = fakePhaseFactory.NewCalledWithProvider[len(fakePhaseFactory.NewCalledWithProvider)-1]
+				h.AssertEq(t, len(configProvider.PostContainerRunOps()), 1)
+				h.AssertFunctionName(t, configProvider.PostContainerRunOps()[0], "CopyOutMaybe")
+			})
+		})
+
 		when("extensions", func() {
 			platformAPI = api.MustParse("0.10")
 
@@ -2056,6 +2162,43 @@ func testLifecycleExecution(t *testing.T, when spec.G, it spec.S) {
 		it("configures the phase with binds", func() {
 			h.AssertSliceContains(t, configProvider.HostConfig().Binds, providedVolumes...)
 		})
+
+		it("does not mount secrets", func() {
+			h.AssertEq(t, len(configProvider.HostConfig().Tmpfs), 0)
+			h.AssertTrue(t, configProvider.Input() == nil)
+		})
+
+		when("there are secrets", func() {
+			it("mounts them in a tmpfs of the container of the phase", func() {
+				lifecycle = newTestLifecycleExec(t, false, tmpDir, append(lifecycleOps, func(opts *build.LifecycleOptions) {
+					opts.Secrets = map[string][]byte{"some-secret": []byte("some-value")}
+				})...)
+
+				h.AssertNil(t, lifecycle.Build(context.Background(), fakePhaseFactory))
+
+				configProvider = fakePhaseFactory.NewCalledWithProvider[len(fakePhaseFactory.NewCalledWithProvider)-1]
+				h.AssertEq(t, len(configProvider.ContainerOps()), 0)
+				h.AssertEq(t, configProvider.HostConfig().Tmpfs, map[string]string{
+					"/run/secrets": "rw,noexec,nosuid,nodev,mode=0700,uid=2222,gid=3333",
+				})
+				h.AssertNotNil(t, configProvider.Input())
+			})
+		})
+
+		when("there are bindings", func() {
+			it("copies them to the container of the phase", func() {
+				lifecycle = newTestLifecycleExec(t, false, tmpDir, append(lifecycleOps, func(opts *build.LifecycleOptions) {
+					opts.BindingsDir = "some-bindings-dir"
+				})...)
+
+				h.AssertNil(t, lifecycle.Build(context.Background(), fakePhaseFactory))
+
+				configProvider = fakePhaseFactory.NewCalledWithProvider[len(fakePhaseFactory.NewCalledWithProvider)-1]
+				h.AssertEq(t, len(configProvider.ContainerOps()), 1)
+				h.AssertFunctionName(t, configProvider.ContainerOps()[0], "CopyDir")
+				h.AssertSliceContains(t, configProvider.ContainerConfig().Env, "SERVICE_BINDING_ROOT=/platform/bindings")
+			})
+		})
 	})
 
 	when("#ExtendBuild", func() {
@@ -2289,6 +2432,27 @@ func testLifecycleExecution(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("events are requested", func() {
+			lifecycleOps = append(lifecycleOps, fakes.WithEvents(&fakes.FakeEmitter{}))
+
+			it("reads the metadata of the volume cache before and after the phase", func() {
+				h.AssertEq(t, len(configProvider.ContainerOps()), 4)
+				h.AssertFunctionName(t, configProvider.ContainerOps()[0], "CopyOutMaybe")
+				postContainerRunOps := configProvider.PostContainerRunOps()
+				h.AssertEq(t, len(postContainerRunOps), 1)
+				h.AssertFunctionName(t, postContainerRunOps[0], "CopyOutMaybe")
+			})
+
+			when("using cache image", func() {
+				fakeBuildCache = newFakeImageCache()
+
+				it("does not read the cache", func() {
+					h.AssertEq(t, len(configProvider.ContainerOps()), 3)
+					h.AssertEq(t, len(configProvider.PostContainerRunOps()), 0)
+				})
+			})
+		})
+
 		when("using cache image", func() {
 			fakeBuildCache = newFakeImageCache()
 
@@ -2301,6 +2465,23 @@ func testLifecycleExecution(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("using registry cache", func() {
+			fakeBuildCache = newFakeRegistryCache()
+
+			it("configures phase with cache image", func() {
+				h.AssertSliceNotContains(t, configProvider.HostConfig().Binds, ":/cache")
+				h.AssertIncludeAllExpectedPatterns(t,
+					configProvider.ContainerConfig().Cmd,
+					[]string{"-cache-image", "some-registry-cache-image"},
+				)
+			})
+
+			it("configures the phase with daemon and registry access", func() {
+				h.AssertSliceContains(t, configProvider.ContainerConfig().Cmd, "-daemon")
+				h.AssertSliceContains(t, configProvider.ContainerConfig().Env, "CNB_REGISTRY_AUTH={}")
+			})
+		})
+
 		when("publish", func() {
 			providedPublish = true
 
@@ -2640,6 +2821,13 @@ func newFakeImageCache() *fakes.FakeCache {
 	return c
 }
 
+func newFakeRegistryCache() *fakes.FakeCache {
+	c := fakes.NewFakeCache()
+	c.ReturnForType = cache.Registry
+	c.ReturnForName = "some-registry-cache-image"
+	return c
+}
+
 func newFakeFetchRunImageFunc(f *fakeImageFetcher) func(name string) (string, error) {
 	return func(name string) (string, error) {
 		return fmt.Sprintf("ephemeral-%s", name), f.fetchRunImage(name)
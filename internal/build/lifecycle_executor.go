@@ -16,6 +16,7 @@ import (
 	"github.com/buildpacks/pack/internal/container"
 	"github.com/buildpacks/pack/pkg/cache"
 	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/events"
 	"github.com/buildpacks/pack/pkg/logging"
 )
 
@@ -85,6 +86,7 @@ type LifecycleOptions struct {
 	Layout                          bool
 	Termui                          Termui
 	DockerHost                      string
+	RootlessPodman                  bool
 	Cache                           cache.CacheOpts
 	CacheImage                      string
 	HTTPProxy                       string
@@ -93,6 +95,8 @@ type LifecycleOptions struct {
 	Network                         string
 	AdditionalTags                  []string
 	Volumes                         []string
+	Secrets                         map[string][]byte
+	BindingsDir                     string
 	DefaultProcessType              string
 	FileFilter                      func(string) bool
 	Workspace                       string
@@ -103,6 +107,8 @@ type LifecycleOptions struct {
 	SBOMDestinationDir              string
 	CreationTime                    *time.Time
 	Keychain                        authn.Keychain
+	Events                          events.Emitter
+	ExplainDetect                   bool
 }
 
 func NewLifecycleExecutor(logger logging.Logger, docker DockerClient) *LifecycleExecutor {
@@ -46,6 +46,10 @@ func (m mountPaths) projectPath() string {
 	return m.join(m.layersDir(), "project-metadata.toml")
 }
 
+func (m mountPaths) groupPath() string {
+	return m.join(m.layersDir(), "group.toml")
+}
+
 func (m mountPaths) reportPath() string {
 	return m.join(m.layersDir(), "report.toml")
 }
@@ -62,6 +66,10 @@ func (m mountPaths) cacheDir() string {
 	return m.join(m.volume, "cache")
 }
 
+func (m mountPaths) cacheMetadataPath() string {
+	return m.join(append([]string{m.cacheDir()}, cacheMetadataFile...)...)
+}
+
 func (m mountPaths) kanikoCacheDir() string {
 	return m.join(m.volume, "kaniko")
 }
@@ -70,6 +78,14 @@ func (m mountPaths) launchCacheDir() string {
 	return m.join(m.volume, "launch-cache")
 }
 
+func (m mountPaths) secretsDir() string {
+	return m.join(m.volume, "run", "secrets")
+}
+
+func (m mountPaths) bindingsDir() string {
+	return m.join(m.volume, "platform", "bindings")
+}
+
 func (m mountPaths) sbomDir() string {
 	return m.join(m.volume, "layers", "sbom")
 }
@@ -0,0 +1,313 @@
+// Package oci runs the lifecycle with an OCI runtime rather than with the docker daemon, reading the builder from and
+// exporting the app image to OCI layouts.
+package oci
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/buildpacks/lifecycle/api"
+	dockerarchive "github.com/docker/docker/pkg/archive"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/build"
+	"github.com/buildpacks/pack/internal/builder"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/archive"
+	"github.com/buildpacks/pack/pkg/cache"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+const (
+	appDir       = "/workspace"
+	layersDir    = "/layers"
+	cacheDir     = "/cache"
+	layoutDir    = "/layout-repo"
+	lifecycleDir = "/cnb/lifecycle"
+)
+
+// Executor runs the lifecycle in a container of an OCI runtime, from the root filesystem of the builder. All phases
+// run in the creator, since no registry credentials are handed to the lifecycle. The build cache is kept in a
+// directory of the host for each app image.
+type Executor struct {
+	logger   logging.Logger
+	runtime  Runtime
+	cacheDir string
+}
+
+// NewExecutor returns an executor running the lifecycle with the runtime, keeping build caches in cacheDir.
+func NewExecutor(logger logging.Logger, runtime Runtime, cacheDir string) *Executor {
+	return &Executor{logger: logger, runtime: runtime, cacheDir: cacheDir}
+}
+
+func (e *Executor) Execute(ctx context.Context, opts build.LifecycleOptions) error {
+	if err := validate(opts); err != nil {
+		return err
+	}
+
+	descriptor := opts.Builder.LifecycleDescriptor()
+	platformAPI, err := build.FindLatestSupported(append(descriptor.APIs.Platform.Deprecated, descriptor.APIs.Platform.Supported...), opts.LifecycleApis)
+	if err != nil {
+		return err
+	}
+	if platformAPI.LessThan("0.12") {
+		return errors.Errorf("platform API %s is not supported by the oci executor, 0.12 or newer is required", platformAPI)
+	}
+
+	builderImage, ok := opts.Builder.Image().(interface{ UnderlyingImage() v1.Image })
+	if !ok || builderImage.UnderlyingImage() == nil {
+		return errors.Errorf("builder %s must be read from an OCI layout", style.Symbol(opts.BuilderImage))
+	}
+
+	bundle, err := os.MkdirTemp("", "pack.oci")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(bundle)
+
+	e.logger.Debugf("Extracting builder %s", style.Symbol(opts.BuilderImage))
+	if err := extractRootfs(builderImage.UnderlyingImage(), filepath.Join(bundle, rootfsDir)); err != nil {
+		return errors.Wrapf(err, "extracting builder %s", style.Symbol(opts.BuilderImage))
+	}
+
+	binds, err := e.prepareBinds(bundle, opts)
+	if err != nil {
+		return err
+	}
+
+	configFile, err := builderImage.UnderlyingImage().ConfigFile()
+	if err != nil {
+		return errors.Wrapf(err, "reading configuration of builder %s", style.Symbol(opts.BuilderImage))
+	}
+	env := append(append([]string{}, configFile.Config.Env...), lifecycleEnv(opts, platformAPI)...)
+	if err := writeSpec(bundle, containerSpec(creatorArgs(opts, e.logger.IsVerbose()), env, binds)); err != nil {
+		return err
+	}
+
+	e.logger.Info(style.Step("CREATING"))
+	return build.RunPhase(opts.Events, "creator", func() error {
+		stdout := logging.NewPrefixWriter(logging.GetWriterForLevel(e.logger, logging.InfoLevel), "creator")
+		defer stdout.Close()
+		stderr := logging.NewPrefixWriter(logging.GetWriterForLevel(e.logger, logging.ErrorLevel), "creator")
+		defer stderr.Close()
+
+		return e.runtime.Run(ctx, filepath.Base(bundle), bundle, stdout, stderr)
+	})
+}
+
+func validate(opts build.LifecycleOptions) error {
+	switch {
+	case !opts.Layout:
+		return errors.New("the oci executor can only export images to OCI layout")
+	case opts.Publish:
+		return errors.New("the oci executor cannot publish images")
+	case opts.Interactive:
+		return errors.New("interactive mode is not supported by the oci executor")
+	case len(opts.Builder.OrderExtensions()) > 0:
+		return errors.New("builders with image extensions are not supported by the oci executor")
+	case opts.CacheImage != "" || opts.Cache.Build.Format == cache.CacheImage || opts.Cache.Build.Format == cache.CacheRegistry:
+		return errors.New("cache images are not supported by the oci executor")
+	case opts.SBOMDestinationDir != "" || opts.ReportDestinationDir != "":
+		return errors.New("SBOM and report output directories are not supported by the oci executor")
+	}
+
+	for _, volume := range opts.Volumes {
+		b, err := parseBind(volume)
+		if err != nil {
+			return err
+		}
+		if !filepath.IsAbs(b.source) {
+			return errors.Errorf("volume %s must mount a directory of the host with the oci executor", style.Symbol(volume))
+		}
+	}
+	return nil
+}
+
+// prepareBinds writes the app and the files the lifecycle reads from the layers directory to the bundle, and returns
+// them with the build cache and the OCI layouts of the images as directories to mount in the container.
+func (e *Executor) prepareBinds(bundle string, opts build.LifecycleOptions) ([]bind, error) {
+	uid, gid := opts.Builder.UID(), opts.Builder.GID()
+
+	if err := untar(appArchive(opts, uid, gid), bundle); err != nil {
+		return nil, errors.Wrap(err, "copying app")
+	}
+
+	layers := filepath.Join(bundle, "layers")
+	if err := mkdirOwned(layers, uid, gid); err != nil {
+		return nil, err
+	}
+	if err := writeToml(filepath.Join(layers, "project-metadata.toml"), opts.ProjectMetadata, uid, gid); err != nil {
+		return nil, err
+	}
+	if err := writeToml(filepath.Join(layers, "run.toml"), builder.RunImages{Images: opts.Builder.RunImages()}, uid, gid); err != nil {
+		return nil, err
+	}
+
+	buildCache := e.buildCacheDir(opts)
+	if opts.ClearCache {
+		if err := os.RemoveAll(buildCache); err != nil {
+			return nil, errors.Wrapf(err, "clearing build cache %s", style.Symbol(buildCache))
+		}
+	}
+	if err := mkdirOwned(buildCache, uid, gid); err != nil {
+		return nil, errors.Wrapf(err, "creating build cache %s", style.Symbol(buildCache))
+	}
+
+	binds := []bind{
+		{source: filepath.Join(bundle, "app"), target: workspace(opts)},
+		{source: layers, target: layersDir},
+		{source: buildCache, target: cacheDir},
+	}
+	for _, volume := range opts.Volumes {
+		b, err := parseBind(volume)
+		if err != nil {
+			return nil, err
+		}
+		binds = append(binds, b)
+	}
+	return binds, nil
+}
+
+// buildCacheDir returns the directory of the host keeping the build cache of the app image across builds.
+func (e *Executor) buildCacheDir(opts build.LifecycleOptions) string {
+	switch {
+	case opts.Cache.Build.Format == cache.CacheBind:
+		return opts.Cache.Build.Source
+	case opts.Cache.Build.Source != "":
+		return filepath.Join(e.cacheDir, opts.Cache.Build.Source)
+	}
+	sum := sha256.Sum256([]byte(opts.Image.Context().Name()))
+	return filepath.Join(e.cacheDir, fmt.Sprintf("pack-cache-%x", sum[:6]))
+}
+
+func workspace(opts build.LifecycleOptions) string {
+	if opts.Workspace != "" {
+		return path.Join("/", opts.Workspace)
+	}
+	return appDir
+}
+
+func creatorArgs(opts build.LifecycleOptions, verbose bool) []string {
+	args := []string{path.Join(lifecycleDir, "creator")}
+	if verbose {
+		args = append(args, "-log-level", "debug")
+	}
+	args = append(args,
+		"-app", workspace(opts),
+		"-layers", layersDir,
+		"-cache-dir", cacheDir,
+		"-run", path.Join(layersDir, "run.toml"),
+		"-run-image", opts.RunImage,
+	)
+	for _, tag := range opts.AdditionalTags {
+		args = append(args, "-tag", tag)
+	}
+	if opts.ClearCache {
+		args = append(args, "-skip-restore")
+	}
+	if opts.PreviousImage != "" {
+		args = append(args, "-previous-image", opts.PreviousImage)
+	}
+	if opts.DefaultProcessType != "" {
+		args = append(args, "-process-type", opts.DefaultProcessType)
+	}
+	return append(args, opts.Image.String())
+}
+
+func lifecycleEnv(opts build.LifecycleOptions, platformAPI *api.Version) []string {
+	env := []string{
+		"CNB_PLATFORM_API=" + platformAPI.String(),
+		"CNB_USER_ID=" + strconv.Itoa(opts.Builder.UID()),
+		"CNB_GROUP_ID=" + strconv.Itoa(opts.Builder.GID()),
+		"CNB_USE_LAYOUT=true",
+		"CNB_LAYOUT_DIR=" + layoutDir,
+		"CNB_EXPERIMENTAL_MODE=warn",
+	}
+	if opts.CreationTime != nil {
+		env = append(env, "SOURCE_DATE_EPOCH="+strconv.FormatInt(opts.CreationTime.Unix(), 10))
+	}
+	for _, proxy := range [][2]string{{"HTTP_PROXY", opts.HTTPProxy}, {"HTTPS_PROXY", opts.HTTPSProxy}, {"NO_PROXY", opts.NoProxy}} {
+		if name, value := proxy[0], proxy[1]; value != "" {
+			env = append(env, name+"="+value, strings.ToLower(name)+"="+value)
+		}
+	}
+	return env
+}
+
+// appArchive returns the app as a tar of the 'app' directory, owned by the builder user.
+func appArchive(opts build.LifecycleOptions, uid, gid int) io.ReadCloser {
+	if fi, err := os.Stat(opts.AppPath); err == nil && !fi.IsDir() {
+		return archive.GenerateTar(func(tw archive.TarWriter) error {
+			if err := tw.WriteHeader(dirHeader("app", uid, gid)); err != nil {
+				return err
+			}
+			return archive.WriteZipToTar(tw, opts.AppPath, "app", uid, gid, -1, false, opts.FileFilter)
+		})
+	}
+	return archive.ReadDirAsTar(opts.AppPath, "app", uid, gid, -1, false, true, opts.FileFilter)
+}
+
+// extractRootfs extracts the filesystem of the image to dir.
+func extractRootfs(img v1.Image, dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	rc := mutate.Extract(img)
+	return untar(rc, dir)
+}
+
+// untar extracts the archive to dir, keeping the owners of its files if run as root.
+func untar(rc io.ReadCloser, dir string) error {
+	defer rc.Close()
+	return dockerarchive.Untar(rc, dir, &dockerarchive.TarOptions{NoLchown: os.Geteuid() != 0})
+}
+
+func mkdirOwned(dir string, uid, gid int) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	return chown(dir, uid, gid)
+}
+
+func writeToml(path string, data interface{}, uid, gid int) error {
+	buf := &bytes.Buffer{}
+	if err := toml.NewEncoder(buf).Encode(data); err != nil {
+		return errors.Wrapf(err, "marshaling data to %s", path)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return err
+	}
+	return chown(path, uid, gid)
+}
+
+// chown gives the file to the builder user when run as root. Runtimes running containers rootless are expected to
+// map the builder user to the user running pack.
+func chown(path string, uid, gid int) error {
+	if os.Geteuid() != 0 {
+		return nil
+	}
+	return os.Lchown(path, uid, gid)
+}
+
+func dirHeader(name string, uid, gid int) *tar.Header {
+	return &tar.Header{
+		Typeflag: tar.TypeDir,
+		Name:     name,
+		Mode:     0755,
+		Uid:      uid,
+		Gid:      gid,
+		ModTime:  archive.NormalizedDateTime,
+	}
+}
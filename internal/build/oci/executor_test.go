@@ -0,0 +1,247 @@
+package oci
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/buildpacks/lifecycle/api"
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/heroku/color"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/build"
+	bfakes "github.com/buildpacks/pack/internal/build/fakes"
+	"github.com/buildpacks/pack/internal/builder"
+	"github.com/buildpacks/pack/internal/container"
+	"github.com/buildpacks/pack/pkg/cache"
+	"github.com/buildpacks/pack/pkg/events"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestExecutor(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "OCIExecutor", testExecutor, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+// fakeRuntime records the bundle of the container it is asked to run before it is deleted.
+type fakeRuntime struct {
+	spec  specs.Spec
+	files map[string]string
+	err   error
+}
+
+func (r *fakeRuntime) Run(_ context.Context, _, bundle string, stdout, _ io.Writer) error {
+	data, err := os.ReadFile(filepath.Join(bundle, configFile))
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, &r.spec); err != nil {
+		return err
+	}
+
+	r.files = map[string]string{}
+	for _, file := range []string{"rootfs/cnb/lifecycle/creator", "app/fake-app-file", "layers/run.toml", "layers/project-metadata.toml"} {
+		if contents, err := os.ReadFile(filepath.Join(bundle, file)); err == nil {
+			r.files[file] = string(contents)
+		}
+	}
+
+	_, _ = stdout.Write([]byte("fake output\n"))
+	return r.err
+}
+
+// builderImage is a builder image with a filesystem.
+type builderImage struct {
+	*fakes.Image
+	underlying v1.Image
+}
+
+func (b *builderImage) UnderlyingImage() v1.Image {
+	return b.underlying
+}
+
+func testExecutor(t *testing.T, when spec.G, it spec.S) {
+	var (
+		runtime  *fakeRuntime
+		outBuf   bytes.Buffer
+		cacheDir string
+		subject  *Executor
+		opts     build.LifecycleOptions
+	)
+
+	it.Before(func() {
+		runtime = &fakeRuntime{}
+		outBuf.Reset()
+		cacheDir = t.TempDir()
+		subject = NewExecutor(logging.NewLogWithWriters(&outBuf, &outBuf), runtime, cacheDir)
+
+		layer, err := crane.Layer(map[string][]byte{"cnb/lifecycle/creator": []byte("some-creator")})
+		h.AssertNil(t, err)
+		img, err := mutate.AppendLayers(empty.Image, layer)
+		h.AssertNil(t, err)
+		img, err = mutate.Config(img, v1.Config{Env: []string{"PATH=/cnb/process:/usr/bin"}})
+		h.AssertNil(t, err)
+
+		fakeBuilder, err := bfakes.NewFakeBuilder(
+			bfakes.WithSupportedPlatformAPIs([]*api.Version{api.MustParse("0.12")}),
+			bfakes.WithImage(&builderImage{Image: fakes.NewImage("some-builder", "", nil), underlying: img}),
+		)
+		h.AssertNil(t, err)
+		fakeBuilder.ReturnForRunImages = []builder.RunImageMetadata{{Image: "some-run"}}
+
+		opts = build.LifecycleOptions{
+			AppPath:        filepath.Join("..", "testdata", "fake-app"),
+			Image:          name.MustParseReference("some-app"),
+			Builder:        fakeBuilder,
+			BuilderImage:   "some-builder",
+			RunImage:       "some-run",
+			AdditionalTags: []string{"some-app:other-tag"},
+			Layout:         true,
+			Volumes:        []string{"/some/layout/run:/layout-repo/index.docker.io/library/some-run/latest:ro"},
+		}
+	})
+
+	it("runs the creator from the filesystem of the builder", func() {
+		h.AssertNil(t, subject.Execute(context.TODO(), opts))
+
+		h.AssertEq(t, runtime.files["rootfs/cnb/lifecycle/creator"], "some-creator")
+		h.AssertEq(t, runtime.spec.Root.Path, "rootfs")
+		h.AssertEq(t, runtime.spec.Process.Args[0], "/cnb/lifecycle/creator")
+		h.AssertSliceContainsInOrder(t, runtime.spec.Process.Args, "-run-image", "some-run")
+		h.AssertSliceContainsInOrder(t, runtime.spec.Process.Args, "-tag", "some-app:other-tag")
+		h.AssertEq(t, runtime.spec.Process.Args[len(runtime.spec.Process.Args)-1], "some-app")
+		h.AssertContains(t, outBuf.String(), "===> CREATING")
+		h.AssertContains(t, outBuf.String(), "[creator] fake output")
+	})
+
+	it("exports to the OCI layouts mounted in the container", func() {
+		h.AssertNil(t, subject.Execute(context.TODO(), opts))
+
+		h.AssertSliceContains(t, runtime.spec.Process.Env, "PATH=/cnb/process:/usr/bin", "CNB_USE_LAYOUT=true", "CNB_LAYOUT_DIR=/layout-repo", "CNB_PLATFORM_API=0.12")
+		h.AssertEq(t, findMount(t, runtime.spec, "/layout-repo/index.docker.io/library/some-run/latest"), specs.Mount{
+			Destination: "/layout-repo/index.docker.io/library/some-run/latest",
+			Type:        "bind",
+			Source:      "/some/layout/run",
+			Options:     []string{"rbind", "ro"},
+		})
+	})
+
+	it("mounts the volumes of the host", func() {
+		opts.Volumes = append(opts.Volumes, "/some/host/dir:/some/target:rw")
+
+		h.AssertNil(t, subject.Execute(context.TODO(), opts))
+
+		h.AssertEq(t, findMount(t, runtime.spec, "/some/target"), specs.Mount{
+			Destination: "/some/target",
+			Type:        "bind",
+			Source:      "/some/host/dir",
+			Options:     []string{"rbind", "rw"},
+		})
+	})
+
+	it("copies the app and the files read by the lifecycle to the bundle", func() {
+		h.AssertNil(t, subject.Execute(context.TODO(), opts))
+
+		_, ok := runtime.files["app/fake-app-file"]
+		h.AssertEq(t, ok, true)
+		h.AssertContains(t, runtime.files["layers/run.toml"], `image = "some-run"`)
+		_, ok = runtime.files["layers/project-metadata.toml"]
+		h.AssertEq(t, ok, true)
+		h.AssertEq(t, findMount(t, runtime.spec, "/workspace").Type, "bind")
+		h.AssertEq(t, findMount(t, runtime.spec, "/layers").Type, "bind")
+	})
+
+	it("keeps the build cache in a directory for the app image", func() {
+		h.AssertNil(t, subject.Execute(context.TODO(), opts))
+
+		cache := findMount(t, runtime.spec, "/cache")
+		h.AssertEq(t, filepath.Dir(cache.Source), cacheDir)
+		h.AssertEq(t, cache.Source, subject.buildCacheDir(opts))
+		_, err := os.Stat(cache.Source)
+		h.AssertNil(t, err)
+	})
+
+	it("emits events for the creator", func() {
+		emitter := &bfakes.FakeEmitter{}
+		opts.Events = emitter
+
+		h.AssertNil(t, subject.Execute(context.TODO(), opts))
+
+		h.AssertEq(t, len(emitter.Events(events.PhaseStart, events.PhaseEnd)), 2)
+	})
+
+	it("fails when the container fails", func() {
+		runtime.err = container.StatusError{StatusCode: 1}
+
+		h.AssertError(t, subject.Execute(context.TODO(), opts), "failed with status code: 1")
+	})
+
+	when("the image is not exported to OCI layout", func() {
+		it("errors", func() {
+			opts.Layout = false
+
+			h.AssertError(t, subject.Execute(context.TODO(), opts), "the oci executor can only export images to OCI layout")
+		})
+	})
+
+	when("a volume is not a directory of the host", func() {
+		it("errors", func() {
+			opts.Volumes = append(opts.Volumes, "some-volume:/some/target:ro")
+
+			h.AssertError(t, subject.Execute(context.TODO(), opts), "volume 'some-volume:/some/target:ro' must mount a directory of the host with the oci executor")
+		})
+	})
+
+	when("the cache is kept in an image", func() {
+		it("errors", func() {
+			opts.Cache.Build = cache.CacheInfo{Format: cache.CacheImage, Source: "some-cache"}
+
+			h.AssertError(t, subject.Execute(context.TODO(), opts), "cache images are not supported by the oci executor")
+		})
+	})
+
+	when("the platform API is older than 0.12", func() {
+		it("errors", func() {
+			fakeBuilder, err := bfakes.NewFakeBuilder(bfakes.WithSupportedPlatformAPIs([]*api.Version{api.MustParse("0.11")}))
+			h.AssertNil(t, err)
+			opts.Builder = fakeBuilder
+
+			h.AssertError(t, subject.Execute(context.TODO(), opts), "platform API 0.11 is not supported by the oci executor")
+		})
+	})
+
+	when("the builder has no filesystem", func() {
+		it("errors", func() {
+			fakeBuilder, err := bfakes.NewFakeBuilder(bfakes.WithSupportedPlatformAPIs([]*api.Version{api.MustParse("0.12")}))
+			h.AssertNil(t, err)
+			opts.Builder = fakeBuilder
+
+			h.AssertError(t, subject.Execute(context.TODO(), opts), "builder 'some-builder' must be read from an OCI layout")
+		})
+	})
+}
+
+func findMount(t *testing.T, s specs.Spec, destination string) specs.Mount {
+	t.Helper()
+	for _, m := range s.Mounts {
+		if m.Destination == destination {
+			return m
+		}
+	}
+	t.Fatalf("no mount at %s", destination)
+	return specs.Mount{}
+}
@@ -0,0 +1,49 @@
+package oci
+
+import (
+	"context"
+	"io"
+	"os/exec"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/container"
+)
+
+// Runtime runs a container from an OCI runtime bundle: a directory holding the root filesystem of the container and
+// its config.json.
+type Runtime interface {
+	Run(ctx context.Context, id, bundle string, stdout, stderr io.Writer) error
+}
+
+type commandRuntime struct {
+	path string
+}
+
+// NewCommandRuntime returns a runtime running containers with a command line tool compatible with runc, such as runc
+// itself, crun, or a shim running them rootless.
+func NewCommandRuntime(path string) Runtime {
+	return &commandRuntime{path: path}
+}
+
+func (r *commandRuntime) Run(ctx context.Context, id, bundle string, stdout, stderr io.Writer) error {
+	cmd := exec.CommandContext(ctx, r.path, "run", "--bundle", bundle, id)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	err := cmd.Run()
+	if ctx.Err() != nil {
+		// the container outlives the runtime killed when the build is canceled
+		_ = exec.Command(r.path, "delete", "--force", id).Run()
+		return ctx.Err()
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return container.StatusError{StatusCode: int64(exitErr.ExitCode())}
+	}
+	if err != nil {
+		return errors.Wrapf(err, "running container with %s", r.path)
+	}
+	return nil
+}
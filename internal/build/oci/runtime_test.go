@@ -0,0 +1,43 @@
+package oci
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os/exec"
+	"runtime"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/container"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestCommandRuntime(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "CommandRuntime", testCommandRuntime, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testCommandRuntime(t *testing.T, when spec.G, it spec.S) {
+	it.Before(func() {
+		h.SkipIf(t, runtime.GOOS == "windows", "the oci executor runs on linux")
+	})
+
+	when("the container exits with a non-zero status", func() {
+		it("returns the status code", func() {
+			path, err := exec.LookPath("false")
+			h.AssertNil(t, err)
+
+			var out bytes.Buffer
+			err = NewCommandRuntime(path).Run(context.TODO(), "some-id", t.TempDir(), &out, &out)
+
+			var statusErr container.StatusError
+			h.AssertTrue(t, errors.As(err, &statusErr))
+			h.AssertEq(t, statusErr.StatusCode, int64(1))
+		})
+	})
+}
@@ -3,15 +3,19 @@ package build
 import (
 	"context"
 	"io"
+	"time"
 
 	dcontainer "github.com/docker/docker/api/types/container"
 	"github.com/pkg/errors"
 
 	"github.com/buildpacks/pack/internal/container"
+	"github.com/buildpacks/pack/pkg/events"
 )
 
 type Phase struct {
 	name                string
+	phase               string
+	events              events.Emitter
 	infoWriter          io.Writer
 	errorWriter         io.Writer
 	docker              DockerClient
@@ -23,20 +27,33 @@ type Phase struct {
 	appPath             string
 	containerOps        []ContainerOperation
 	postContainerRunOps []ContainerOperation
+	input               func() io.ReadCloser
 	fileFilter          func(string) bool
 }
 
 func (p *Phase) Run(ctx context.Context) error {
-	var err error
-	p.ctr, err = p.docker.ContainerCreate(ctx, p.ctrConf, p.hostConf, nil, nil, "")
+	err := p.timeStep(events.StepCreate, func() error {
+		var err error
+		p.ctr, err = p.docker.ContainerCreate(ctx, p.ctrConf, p.hostConf, nil, nil, "")
+		if err != nil {
+			return errors.Wrapf(err, "failed to create '%s' container", p.name)
+		}
+		return nil
+	})
 	if err != nil {
-		return errors.Wrapf(err, "failed to create '%s' container", p.name)
+		return err
 	}
 
-	for _, containerOp := range p.containerOps {
-		if err := containerOp(p.docker, ctx, p.ctr.ID, p.infoWriter, p.errorWriter); err != nil {
-			return err
+	err = p.timeStep(events.StepCopyIn, func() error {
+		for _, containerOp := range p.containerOps {
+			if err := containerOp(p.docker, ctx, p.ctr.ID, p.infoWriter, p.errorWriter); err != nil {
+				return err
+			}
 		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
 	handler := container.DefaultHandler(p.infoWriter, p.errorWriter)
@@ -44,22 +61,43 @@ func (p *Phase) Run(ctx context.Context) error {
 		handler = p.handler
 	}
 
-	err = container.RunWithHandler(
-		ctx,
-		p.docker,
-		p.ctr.ID,
-		handler)
+	err = p.timeStep(events.StepRun, func() error {
+		if p.input == nil {
+			return container.RunWithHandler(
+				ctx,
+				p.docker,
+				p.ctr.ID,
+				handler)
+		}
+
+		input := p.input()
+		defer input.Close()
+		return container.RunWithInput(ctx, p.docker, p.ctr.ID, input, handler)
+	})
 	if err != nil {
 		return err
 	}
 
-	for _, containerOp := range p.postContainerRunOps {
-		if err := containerOp(p.docker, ctx, p.ctr.ID, p.infoWriter, p.errorWriter); err != nil {
-			return err
+	return p.timeStep(events.StepCopyOut, func() error {
+		for _, containerOp := range p.postContainerRunOps {
+			if err := containerOp(p.docker, ctx, p.ctr.ID, p.infoWriter, p.errorWriter); err != nil {
+				return err
+			}
 		}
+		return nil
+	})
+}
+
+// timeStep runs a step of the phase, emitting an event with how long it took if events were requested.
+func (p *Phase) timeStep(step string, run func() error) error {
+	if p.events == nil {
+		return run()
 	}
 
-	return nil
+	started := time.Now()
+	err := run()
+	p.events.Emit(events.Event{Type: events.PhaseStep, Phase: p.phase, Step: step, DurationMs: time.Since(started).Milliseconds()})
+	return err
 }
 
 func (p *Phase) Cleanup() error {
@@ -1,15 +1,18 @@
 package build
 
 import (
+	"archive/tar"
 	"fmt"
 	"io"
 	"os"
+	"sort"
 	"strings"
 
 	"github.com/docker/docker/api/types/container"
 
 	pcontainer "github.com/buildpacks/pack/internal/container"
 	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/archive"
 	"github.com/buildpacks/pack/pkg/logging"
 )
 
@@ -25,9 +28,11 @@ type PhaseConfigProvider struct {
 	ctrConf             *container.Config
 	hostConf            *container.HostConfig
 	name                string
+	phase               string
 	os                  string
 	containerOps        []ContainerOperation
 	postContainerRunOps []ContainerOperation
+	input               func() io.ReadCloser
 	infoWriter          io.Writer
 	errorWriter         io.Writer
 	handler             pcontainer.Handler
@@ -38,6 +43,7 @@ func NewPhaseConfigProvider(name string, lifecycleExec *LifecycleExecution, ops
 		ctrConf:     new(container.Config),
 		hostConf:    new(container.HostConfig),
 		name:        name,
+		phase:       name,
 		os:          lifecycleExec.os,
 		infoWriter:  logging.GetWriterForLevel(lifecycleExec.logger, logging.InfoLevel),
 		errorWriter: logging.GetWriterForLevel(lifecycleExec.logger, logging.ErrorLevel),
@@ -50,6 +56,12 @@ func NewPhaseConfigProvider(name string, lifecycleExec *LifecycleExecution, ops
 		provider.hostConf.Isolation = container.IsolationProcess
 	}
 
+	if lifecycleExec.opts.RootlessPodman {
+		// the user running podman owns the volumes and the podman socket, and is mapped to the builder user so that
+		// the phases can write to the volumes and reach the socket
+		provider.hostConf.UsernsMode = container.UsernsMode(fmt.Sprintf("keep-id:uid=%d,gid=%d", lifecycleExec.opts.Builder.UID(), lifecycleExec.opts.Builder.GID()))
+	}
+
 	ops = append(ops,
 		WithEnv(fmt.Sprintf("%s=%s", platformAPIEnvVar, lifecycleExec.platformAPI.String())),
 		WithLifecycleProxy(lifecycleExec),
@@ -63,7 +75,9 @@ func NewPhaseConfigProvider(name string, lifecycleExec *LifecycleExecution, ops
 		op(provider)
 	}
 
-	provider.ctrConf.Entrypoint = []string{""} // override entrypoint in case it is set
+	if provider.ctrConf.Entrypoint == nil {
+		provider.ctrConf.Entrypoint = []string{""} // override entrypoint in case it is set
+	}
 	provider.ctrConf.Cmd = append([]string{"/cnb/lifecycle/" + name}, provider.ctrConf.Cmd...)
 
 	lifecycleExec.logger.Debugf("Running the %s on OS %s from image %s with:", style.Symbol(provider.Name()), style.Symbol(provider.os), style.Symbol(provider.ctrConf.Image))
@@ -77,9 +91,14 @@ func NewPhaseConfigProvider(name string, lifecycleExec *LifecycleExecution, ops
 	lifecycleExec.logger.Debug("Host Settings:")
 	lifecycleExec.logger.Debugf("  Binds: %s", style.Symbol(strings.Join(provider.hostConf.Binds, " ")))
 	lifecycleExec.logger.Debugf("  Network Mode: %s", style.Symbol(string(provider.hostConf.NetworkMode)))
+	if provider.hostConf.UsernsMode != "" {
+		lifecycleExec.logger.Debugf("  Userns Mode: %s", style.Symbol(string(provider.hostConf.UsernsMode)))
+	}
 
 	if lifecycleExec.opts.Interactive {
 		provider.handler = lifecycleExec.opts.Termui.Handler()
+	} else if lifecycleExec.opts.Events != nil {
+		provider.handler = eventHandler(lifecycleExec.opts.Events, provider.phase, provider.infoWriter, provider.errorWriter)
 	}
 
 	return provider
@@ -109,6 +128,11 @@ func (p *PhaseConfigProvider) PostContainerRunOps() []ContainerOperation {
 	return p.postContainerRunOps
 }
 
+// Input returns the input written to the standard input of the container once it starts, or nil if there is none.
+func (p *PhaseConfigProvider) Input() func() io.ReadCloser {
+	return p.input
+}
+
 func (p *PhaseConfigProvider) HostConfig() *container.HostConfig {
 	return p.hostConf
 }
@@ -200,6 +224,8 @@ func WithImage(image string) PhaseConfigProviderOperation {
 func WithLogPrefix(prefix string) PhaseConfigProviderOperation {
 	return func(provider *PhaseConfigProvider) {
 		if prefix != "" {
+			// the prefix tells apart phases run from the same lifecycle binary, such as the build and run extenders
+			provider.phase = prefix
 			provider.infoWriter = logging.NewPrefixWriter(provider.infoWriter, prefix)
 			provider.errorWriter = logging.NewPrefixWriter(provider.errorWriter, prefix)
 		}
@@ -256,6 +282,44 @@ func WithPostContainerRunOperations(operations ...ContainerOperation) PhaseConfi
 	}
 }
 
+// WithSecrets makes each secret available in a file named after its ID in the destination directory, readable by the
+// given UID/GID only. The directory is a tmpfs mount of the container, so that the secrets are never written to disk:
+// they are written to the standard input of the container once it starts, and extracted by a shell wrapping the
+// lifecycle binary. The image must provide sh and tar.
+func WithSecrets(dstDir string, secrets map[string][]byte, uid, gid int) PhaseConfigProviderOperation {
+	return func(provider *PhaseConfigProvider) {
+		if provider.hostConf.Tmpfs == nil {
+			provider.hostConf.Tmpfs = map[string]string{}
+		}
+		provider.hostConf.Tmpfs[dstDir] = fmt.Sprintf("rw,noexec,nosuid,nodev,mode=0700,uid=%d,gid=%d", uid, gid)
+
+		provider.ctrConf.OpenStdin = true
+		provider.ctrConf.StdinOnce = true
+		provider.ctrConf.AttachStdin = true
+		provider.ctrConf.Entrypoint = []string{"/bin/sh", "-c", fmt.Sprintf(`tar -x -f - -C %s && exec "$@"`, dstDir), "sh"}
+
+		ids := make([]string, 0, len(secrets))
+		for id := range secrets {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+
+		provider.input = func() io.ReadCloser {
+			return archive.GenerateTar(func(tw archive.TarWriter) error {
+				for _, id := range ids {
+					if err := tw.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: id, Size: int64(len(secrets[id])), Mode: 0400, Uid: uid, Gid: gid, ModTime: archive.NormalizedDateTime}); err != nil {
+						return err
+					}
+					if _, err := tw.Write(secrets[id]); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+		}
+	}
+}
+
 func If(expression bool, operation PhaseConfigProviderOperation) PhaseConfigProviderOperation {
 	if expression {
 		return operation
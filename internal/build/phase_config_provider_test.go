@@ -9,6 +9,7 @@ import (
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/strslice"
 	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/heroku/color"
 	"github.com/pkg/errors"
 	"github.com/sclevine/spec"
@@ -16,6 +17,8 @@ import (
 
 	"github.com/buildpacks/pack/internal/build"
 	"github.com/buildpacks/pack/internal/build/fakes"
+	"github.com/buildpacks/pack/pkg/archive"
+	"github.com/buildpacks/pack/pkg/events"
 	"github.com/buildpacks/pack/pkg/logging"
 	h "github.com/buildpacks/pack/testhelpers"
 )
@@ -75,6 +78,20 @@ func testPhaseConfigProvider(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("building with rootless Podman", func() {
+			it("maps the user running podman to the builder user", func() {
+				fakeBuilder, err := fakes.NewFakeBuilder(fakes.WithUID(1234), fakes.WithGID(5678))
+				h.AssertNil(t, err)
+				lifecycle := newTestLifecycleExec(t, false, "some-temp-dir", fakes.WithBuilder(fakeBuilder), func(opts *build.LifecycleOptions) {
+					opts.RootlessPodman = true
+				})
+
+				phaseConfigProvider := build.NewPhaseConfigProvider("some-name", lifecycle)
+
+				h.AssertEq(t, phaseConfigProvider.HostConfig().UsernsMode, container.UsernsMode("keep-id:uid=1234,gid=5678"))
+			})
+		})
+
 		when("building with interactive mode", func() {
 			it("returns a phase config provider with interactive args", func() {
 				handler := func(bodyChan <-chan container.WaitResponse, errChan <-chan error, reader io.Reader) error {
@@ -89,6 +106,56 @@ func testPhaseConfigProvider(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("building with events", func() {
+			var emitter *fakes.FakeEmitter
+
+			runHandler := func(provider *build.PhaseConfigProvider, stdout, stderr string) error {
+				var stream bytes.Buffer
+				_, err := stdcopy.NewStdWriter(&stream, stdcopy.Stdout).Write([]byte(stdout))
+				h.AssertNil(t, err)
+				if stderr != "" {
+					_, err = stdcopy.NewStdWriter(&stream, stdcopy.Stderr).Write([]byte(stderr))
+					h.AssertNil(t, err)
+				}
+
+				bodyChan := make(chan container.WaitResponse, 1)
+				bodyChan <- container.WaitResponse{StatusCode: 0}
+				return provider.Handler()(bodyChan, make(chan error), &stream)
+			}
+
+			it.Before(func() {
+				emitter = &fakes.FakeEmitter{}
+			})
+
+			it("emits an event for each line of output, tagged with the phase and buildpack", func() {
+				lifecycle := newTestLifecycleExec(t, false, "some-temp-dir", fakes.WithEvents(emitter))
+				provider := build.NewPhaseConfigProvider("builder", lifecycle, build.WithLogPrefix("builder"))
+
+				err := runHandler(provider,
+					"Running build for buildpack some/buildpack@1.2.3\nsome output\n", "some error\n")
+				h.AssertNil(t, err)
+
+				output := emitter.Events(events.Output)
+				h.AssertEq(t, len(output), 3)
+				h.AssertEq(t, output[1], events.Event{
+					Type: events.Output, Phase: "builder", Buildpack: "some/buildpack@1.2.3", Stream: "stdout", Line: "some output",
+				})
+				h.AssertEq(t, output[2].Stream, "stderr")
+				h.AssertEq(t, output[2].Line, "some error")
+			})
+
+			it("leaves the handler to the terminal UI in interactive mode", func() {
+				handler := func(bodyChan <-chan container.WaitResponse, errChan <-chan error, reader io.Reader) error {
+					return errors.New("i was called")
+				}
+
+				lifecycle := newTestLifecycleExec(t, false, "some-temp-dir", fakes.WithEvents(emitter), fakes.WithTermui(&fakes.FakeTermui{HandlerFunc: handler}))
+				provider := build.NewPhaseConfigProvider("some-name", lifecycle)
+
+				h.AssertError(t, provider.Handler()(nil, nil, nil), "i was called")
+			})
+		})
+
 		when("called with WithArgs", func() {
 			it("sets args on the config", func() {
 				lifecycle := newTestLifecycleExec(t, false, "some-temp-dir")
@@ -273,6 +340,42 @@ func testPhaseConfigProvider(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("called with WithSecrets", func() {
+			it("mounts a tmpfs for the secrets and writes them to the standard input of the container", func() {
+				lifecycle := newTestLifecycleExec(t, false, "some-temp-dir")
+
+				phaseConfigProvider := build.NewPhaseConfigProvider(
+					"builder",
+					lifecycle,
+					build.WithSecrets("/run/secrets", map[string][]byte{"some-secret": []byte("some-value")}, 123, 456),
+				)
+
+				h.AssertEq(t, phaseConfigProvider.HostConfig().Tmpfs, map[string]string{
+					"/run/secrets": "rw,noexec,nosuid,nodev,mode=0700,uid=123,gid=456",
+				})
+				h.AssertTrue(t, phaseConfigProvider.ContainerConfig().OpenStdin)
+				h.AssertTrue(t, phaseConfigProvider.ContainerConfig().StdinOnce)
+				h.AssertEq(t, phaseConfigProvider.ContainerConfig().Entrypoint, strslice.StrSlice{"/bin/sh", "-c", `tar -x -f - -C /run/secrets && exec "$@"`, "sh"})
+				h.AssertEq(t, phaseConfigProvider.ContainerConfig().Cmd[0], "/cnb/lifecycle/builder")
+
+				input := phaseConfigProvider.Input()()
+				defer input.Close()
+				header, contents, err := archive.ReadTarEntry(input, "some-secret")
+				h.AssertNil(t, err)
+				h.AssertEq(t, string(contents), "some-value")
+				h.AssertEq(t, header.Mode, int64(0400))
+			})
+
+			it("has no input otherwise", func() {
+				lifecycle := newTestLifecycleExec(t, false, "some-temp-dir")
+
+				phaseConfigProvider := build.NewPhaseConfigProvider("builder", lifecycle)
+
+				h.AssertTrue(t, phaseConfigProvider.Input() == nil)
+				h.AssertEq(t, phaseConfigProvider.ContainerConfig().Entrypoint, strslice.StrSlice{""})
+			})
+		})
+
 		when("called with WithLogPrefix", func() {
 			it("sets prefix writers", func() {
 				lifecycle := newTestLifecycleExec(t, false, "some-temp-dir")
@@ -26,6 +26,8 @@ func (m *DefaultPhaseFactory) New(provider *PhaseConfigProvider) RunnerCleaner {
 		ctrConf:             provider.ContainerConfig(),
 		hostConf:            provider.HostConfig(),
 		name:                provider.Name(),
+		phase:               provider.phase,
+		events:              m.lifecycleExec.opts.Events,
 		docker:              m.lifecycleExec.docker,
 		infoWriter:          provider.InfoWriter(),
 		errorWriter:         provider.ErrorWriter(),
@@ -35,6 +37,7 @@ func (m *DefaultPhaseFactory) New(provider *PhaseConfigProvider) RunnerCleaner {
 		appPath:             m.lifecycleExec.opts.AppPath,
 		containerOps:        provider.containerOps,
 		postContainerRunOps: provider.postContainerRunOps,
+		input:               provider.input,
 		fileFilter:          m.lifecycleExec.opts.FileFilter,
 	}
 }
@@ -421,6 +421,18 @@ func testPhase(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 
+			when("#WithSecrets", func() {
+				it("writes the secrets to a tmpfs once the container starts", func() {
+					configProvider := build.NewPhaseConfigProvider(phaseName, lifecycleExec,
+						build.WithArgs("read", "/run/secrets/some-secret"),
+						build.WithSecrets("/run/secrets", map[string][]byte{"some-secret": []byte("some-value")}, 111, 222),
+					)
+					phase := phaseFactory.New(configProvider)
+					assertRunSucceeds(t, phase, &outBuf, &errBuf)
+					h.AssertContains(t, outBuf.String(), "file contents: some-value")
+				})
+			})
+
 			when("#WithPostContainerRunOperations", func() {
 				it("runs the operation after the container command", func() {
 					tarDestinationPath, err := os.CreateTemp("", "pack.phase.test.")
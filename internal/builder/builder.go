@@ -380,6 +380,7 @@ func (b *Builder) SetEnv(env map[string]string) {
 // SetBuildConfigEnv sets an environment variable to a value that will take action on platform environment variables basedon filename suffix
 func (b *Builder) SetBuildConfigEnv(env map[string]string) {
 	b.buildConfigEnv = env
+	b.metadata.BuildConfigEnv = env
 }
 
 // SetOrder sets the order of the builder
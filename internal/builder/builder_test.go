@@ -1683,6 +1683,19 @@ func testBuilder(t *testing.T, when spec.G, it spec.S) {
 					h.HasModTime(archive.NormalizedDateTime),
 				)
 			})
+
+			it("records the env vars in the builder metadata", func() {
+				label, err := baseImage.Label("io.buildpacks.builder.metadata")
+				h.AssertNil(t, err)
+
+				var metadata builder.Metadata
+				h.AssertNil(t, json.Unmarshal([]byte(label), &metadata))
+				h.AssertEq(t, metadata.BuildConfigEnv, map[string]string{
+					"SOME_KEY":         "some-val",
+					"OTHER_KEY.append": "other-val",
+					"OTHER_KEY.delim":  ":",
+				})
+			})
 		})
 
 		when("#SetEnv", func() {
@@ -6,6 +6,14 @@ type FakeInspectable struct {
 	ErrorForLabel error
 
 	ReceivedName string
+
+	ReturnForLabels map[string]string
+
+	ErrorForLabels error
+
+	ReturnForOS           string
+	ReturnForArchitecture string
+	ReturnForVariant      string
 }
 
 func (f *FakeInspectable) Label(name string) (string, error) {
@@ -13,3 +21,19 @@ func (f *FakeInspectable) Label(name string) (string, error) {
 
 	return f.ReturnForLabel, f.ErrorForLabel
 }
+
+func (f *FakeInspectable) Labels() (map[string]string, error) {
+	return f.ReturnForLabels, f.ErrorForLabels
+}
+
+func (f *FakeInspectable) OS() (string, error) {
+	return f.ReturnForOS, nil
+}
+
+func (f *FakeInspectable) Architecture() (string, error) {
+	return f.ReturnForArchitecture, nil
+}
+
+func (f *FakeInspectable) Variant() (string, error) {
+	return f.ReturnForVariant, nil
+}
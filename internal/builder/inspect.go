@@ -6,6 +6,8 @@ import (
 	"sort"
 	"strings"
 
+	lifecycleplatform "github.com/buildpacks/lifecycle/platform"
+
 	pubbldr "github.com/buildpacks/pack/builder"
 	"github.com/buildpacks/pack/pkg/dist"
 	"github.com/buildpacks/pack/pkg/image"
@@ -23,10 +25,17 @@ type Info struct {
 	CreatedBy       CreatorMetadata
 	Extensions      []dist.ModuleInfo
 	OrderExtensions pubbldr.DetectionOrder
+	BuildConfigEnv  map[string]string
+	Labels          map[string]string
+	Targets         []dist.Target
 }
 
 type Inspectable interface {
 	Label(name string) (string, error)
+	Labels() (map[string]string, error)
+	OS() (string, error)
+	Architecture() (string, error)
+	Variant() (string, error)
 }
 
 type InspectableFetcher interface {
@@ -142,6 +151,16 @@ func (i *Inspector) Inspect(name string, daemon bool, orderDetectionDepth int) (
 		})
 	}
 
+	labels, err := inspectable.Labels()
+	if err != nil {
+		return Info{}, fmt.Errorf("reading image labels: %w", err)
+	}
+
+	target, err := inspectTarget(inspectable, labels)
+	if err != nil {
+		return Info{}, fmt.Errorf("reading image target: %w", err)
+	}
+
 	return Info{
 		Description:     metadata.Description,
 		StackID:         stackID,
@@ -154,9 +173,33 @@ func (i *Inspector) Inspect(name string, daemon bool, orderDetectionDepth int) (
 		CreatedBy:       metadata.CreatedBy,
 		Extensions:      metadata.Extensions,
 		OrderExtensions: detectionOrderExtensions,
+		BuildConfigEnv:  metadata.BuildConfigEnv,
+		Labels:          labels,
+		Targets:         []dist.Target{target},
 	}, nil
 }
 
+// inspectTarget returns the target the builder image is for, from its platform and distro labels.
+func inspectTarget(inspectable Inspectable, labels map[string]string) (dist.Target, error) {
+	var (
+		target dist.Target
+		err    error
+	)
+	if target.OS, err = inspectable.OS(); err != nil {
+		return dist.Target{}, err
+	}
+	if target.Arch, err = inspectable.Architecture(); err != nil {
+		return dist.Target{}, err
+	}
+	if target.ArchVariant, err = inspectable.Variant(); err != nil {
+		return dist.Target{}, err
+	}
+	if name := labels[lifecycleplatform.OSDistroNameLabel]; name != "" {
+		target.Distributions = []dist.Distribution{{Name: name, Version: labels[lifecycleplatform.OSDistroVersionLabel]}}
+	}
+	return target, nil
+}
+
 func orderExttoPubbldrDetectionOrderExt(orderExt dist.Order) pubbldr.DetectionOrder {
 	var detectionOrderExt pubbldr.DetectionOrder
 
@@ -237,6 +237,39 @@ func testInspect(t *testing.T, when spec.G, it spec.S) {
 			assert.Equal(info.CreatedBy, testCreatorData)
 		})
 
+		it("returns the labels, target and build config env of the builder", func() {
+			fetcher := &fakes.FakeInspectableFetcher{
+				InspectableToReturn: &fakes.FakeInspectable{
+					ReturnForLabels: map[string]string{
+						"io.buildpacks.base.distro.name":    "ubuntu",
+						"io.buildpacks.base.distro.version": "22.04",
+					},
+					ReturnForOS:           "linux",
+					ReturnForArchitecture: "arm64",
+					ReturnForVariant:      "v8",
+				},
+			}
+			metadata := testMetadata
+			metadata.BuildConfigEnv = map[string]string{"SOME_KEY.override": "some-val"}
+
+			inspector := builder.NewInspector(
+				fetcher,
+				newLabelManagerFactory(newLabelManager(returnForMetadata(metadata))),
+				newDefaultDetectionCalculator(),
+			)
+			info, err := inspector.Inspect(testBuilderName, true, pubbldr.OrderDetectionNone)
+			assert.Nil(err)
+
+			assert.Equal(info.Labels["io.buildpacks.base.distro.name"], "ubuntu")
+			assert.Equal(info.Targets, []dist.Target{{
+				OS:            "linux",
+				Arch:          "arm64",
+				ArchVariant:   "v8",
+				Distributions: []dist.Distribution{{Name: "ubuntu", Version: "22.04"}},
+			}})
+			assert.Equal(info.BuildConfigEnv, map[string]string{"SOME_KEY.override": "some-val"})
+		})
+
 		it("sorts buildPacks by ID then Version", func() {
 			metadata := builder.Metadata{
 				Description: testBuilderDescription,
@@ -15,6 +15,8 @@ type Metadata struct {
 	Lifecycle   LifecycleMetadata  `json:"lifecycle"`
 	CreatedBy   CreatorMetadata    `json:"createdBy"`
 	RunImages   []RunImageMetadata `json:"images"`
+	// BuildConfigEnv is recorded as the files of /cnb/build-config/env can only be read from the layers of the image
+	BuildConfigEnv map[string]string `json:"buildConfigEnv,omitempty"`
 }
 
 type CreatorMetadata struct {
@@ -0,0 +1,292 @@
+package writer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	pubbldr "github.com/buildpacks/pack/builder"
+	"github.com/buildpacks/pack/internal/builder"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/dist"
+)
+
+// Kinds of change between two builders.
+const (
+	ChangeAdded   = "added"
+	ChangeRemoved = "removed"
+	ChangeChanged = "changed"
+)
+
+// Labels of the builder image that are compared by other sections of the diff.
+var diffedMetadataLabels = map[string]bool{
+	"io.buildpacks.builder.metadata":           true,
+	"io.buildpacks.buildpack.layers":           true,
+	"io.buildpacks.buildpack.order":            true,
+	"io.buildpacks.buildpack.order-extensions": true,
+	"io.buildpacks.extension.layers":           true,
+	"io.buildpacks.lifecycle.apis":             true,
+	"io.buildpacks.lifecycle.version":          true,
+}
+
+type ValueChange struct {
+	Name   string `json:"name" yaml:"name" toml:"name"`
+	Change string `json:"change" yaml:"change" toml:"change"`
+	From   string `json:"from,omitempty" yaml:"from,omitempty" toml:"from,omitempty"`
+	To     string `json:"to,omitempty" yaml:"to,omitempty" toml:"to,omitempty"`
+}
+
+type RunImageChange struct {
+	Image       string   `json:"image" yaml:"image" toml:"image"`
+	Change      string   `json:"change" yaml:"change" toml:"change"`
+	FromMirrors []string `json:"from_mirrors,omitempty" yaml:"from_mirrors,omitempty" toml:"from_mirrors,omitempty"`
+	ToMirrors   []string `json:"to_mirrors,omitempty" yaml:"to_mirrors,omitempty" toml:"to_mirrors,omitempty"`
+}
+
+type ModuleChange struct {
+	ID           string   `json:"id" yaml:"id" toml:"id"`
+	Change       string   `json:"change" yaml:"change" toml:"change"`
+	FromVersions []string `json:"from_versions,omitempty" yaml:"from_versions,omitempty" toml:"from_versions,omitempty"`
+	ToVersions   []string `json:"to_versions,omitempty" yaml:"to_versions,omitempty" toml:"to_versions,omitempty"`
+}
+
+type OrderChange struct {
+	Group  int    `json:"group" yaml:"group" toml:"group"`
+	Change string `json:"change" yaml:"change" toml:"change"`
+	From   string `json:"from,omitempty" yaml:"from,omitempty" toml:"from,omitempty"`
+	To     string `json:"to,omitempty" yaml:"to,omitempty" toml:"to,omitempty"`
+}
+
+type TargetChange struct {
+	Target string `json:"target" yaml:"target" toml:"target"`
+	Change string `json:"change" yaml:"change" toml:"change"`
+}
+
+// DiffDisplay lists what changed from one builder to another. Lists are empty when nothing changed.
+type DiffDisplay struct {
+	From            string           `json:"from" yaml:"from" toml:"from"`
+	To              string           `json:"to" yaml:"to" toml:"to"`
+	Lifecycle       []ValueChange    `json:"lifecycle" yaml:"lifecycle" toml:"lifecycle"`
+	RunImages       []RunImageChange `json:"run_images" yaml:"run_images" toml:"run_images"`
+	Buildpacks      []ModuleChange   `json:"buildpacks" yaml:"buildpacks" toml:"buildpacks"`
+	Extensions      []ModuleChange   `json:"extensions" yaml:"extensions" toml:"extensions"`
+	Order           []OrderChange    `json:"detection_order" yaml:"detection_order" toml:"detection_order"`
+	OrderExtensions []OrderChange    `json:"order_extensions" yaml:"order_extensions" toml:"order_extensions"`
+	BuildConfigEnv  []ValueChange    `json:"build_config_env" yaml:"build_config_env" toml:"build_config_env"`
+	Labels          []ValueChange    `json:"labels" yaml:"labels" toml:"labels"`
+	Targets         []TargetChange   `json:"targets" yaml:"targets" toml:"targets"`
+}
+
+// Empty returns true if the builders do not differ.
+func (d *DiffDisplay) Empty() bool {
+	return len(d.Lifecycle) == 0 && len(d.RunImages) == 0 && len(d.Buildpacks) == 0 && len(d.Extensions) == 0 &&
+		len(d.Order) == 0 && len(d.OrderExtensions) == 0 && len(d.BuildConfigEnv) == 0 && len(d.Labels) == 0 &&
+		len(d.Targets) == 0
+}
+
+// NewDiffDisplay compares two builders, whose detection orders are expected to be inspected without depth, as
+// changes to composite buildpacks are reported as buildpack changes.
+func NewDiffDisplay(fromName string, from *client.BuilderInfo, toName string, to *client.BuilderInfo) *DiffDisplay {
+	return &DiffDisplay{
+		From:            fromName,
+		To:              toName,
+		Lifecycle:       diffValues(lifecycleValues(from.Lifecycle), lifecycleValues(to.Lifecycle)),
+		RunImages:       diffRunImages(from.RunImages, to.RunImages),
+		Buildpacks:      diffModules(from.Buildpacks, to.Buildpacks),
+		Extensions:      diffModules(from.Extensions, to.Extensions),
+		Order:           diffOrder(from.Order, to.Order),
+		OrderExtensions: diffOrder(from.OrderExtensions, to.OrderExtensions),
+		BuildConfigEnv:  diffValues(from.BuildConfigEnv, to.BuildConfigEnv),
+		Labels:          diffValues(userLabels(from.Labels), userLabels(to.Labels)),
+		Targets:         diffTargets(from.Targets, to.Targets),
+	}
+}
+
+//
+// private functions
+//
+
+func lifecycleValues(lifecycle builder.LifecycleDescriptor) map[string]string {
+	values := map[string]string{
+		"buildpack_apis.deprecated": strings.Join(lifecycle.APIs.Buildpack.Deprecated.AsStrings(), ", "),
+		"buildpack_apis.supported":  strings.Join(lifecycle.APIs.Buildpack.Supported.AsStrings(), ", "),
+		"platform_apis.deprecated":  strings.Join(lifecycle.APIs.Platform.Deprecated.AsStrings(), ", "),
+		"platform_apis.supported":   strings.Join(lifecycle.APIs.Platform.Supported.AsStrings(), ", "),
+	}
+	if lifecycle.Info.Version != nil {
+		values["version"] = lifecycle.Info.Version.String()
+	}
+	return values
+}
+
+// userLabels returns the labels not compared by other sections of the diff.
+func userLabels(labels map[string]string) map[string]string {
+	result := map[string]string{}
+	for name, value := range labels {
+		if !diffedMetadataLabels[name] {
+			result[name] = value
+		}
+	}
+	return result
+}
+
+func diffValues(from, to map[string]string) []ValueChange {
+	result := []ValueChange{}
+	for _, name := range sortedKeys(from, to) {
+		fromValue, inFrom := from[name]
+		toValue, inTo := to[name]
+		if change := changeOf(inFrom, inTo, fromValue == toValue); change != "" {
+			result = append(result, ValueChange{Name: name, Change: change, From: fromValue, To: toValue})
+		}
+	}
+	return result
+}
+
+func diffRunImages(from, to []pubbldr.RunImageConfig) []RunImageChange {
+	mirrors := func(runImages []pubbldr.RunImageConfig) map[string][]string {
+		result := map[string][]string{}
+		for _, runImage := range runImages {
+			result[runImage.Image] = runImage.Mirrors
+		}
+		return result
+	}
+	fromMirrors := mirrors(from)
+	toMirrors := mirrors(to)
+
+	result := []RunImageChange{}
+	for _, image := range sortedKeys(fromMirrors, toMirrors) {
+		fromImageMirrors, inFrom := fromMirrors[image]
+		toImageMirrors, inTo := toMirrors[image]
+		same := strings.Join(fromImageMirrors, ",") == strings.Join(toImageMirrors, ",")
+		if change := changeOf(inFrom, inTo, same); change != "" {
+			result = append(result, RunImageChange{Image: image, Change: change, FromMirrors: fromImageMirrors, ToMirrors: toImageMirrors})
+		}
+	}
+	return result
+}
+
+// diffModules compares the versions of each module, as a builder may have several versions of the same one.
+func diffModules(from, to []dist.ModuleInfo) []ModuleChange {
+	versions := func(modules []dist.ModuleInfo) map[string][]string {
+		result := map[string][]string{}
+		for _, module := range modules {
+			result[module.ID] = append(result[module.ID], module.Version)
+		}
+		for _, moduleVersions := range result {
+			sort.Strings(moduleVersions)
+		}
+		return result
+	}
+	fromVersions := versions(from)
+	toVersions := versions(to)
+
+	result := []ModuleChange{}
+	for _, id := range sortedKeys(fromVersions, toVersions) {
+		fromModuleVersions, inFrom := fromVersions[id]
+		toModuleVersions, inTo := toVersions[id]
+		same := strings.Join(fromModuleVersions, ",") == strings.Join(toModuleVersions, ",")
+		if change := changeOf(inFrom, inTo, same); change != "" {
+			result = append(result, ModuleChange{ID: id, Change: change, FromVersions: fromModuleVersions, ToVersions: toModuleVersions})
+		}
+	}
+	return result
+}
+
+// diffOrder compares the groups of the orders by position.
+func diffOrder(from, to pubbldr.DetectionOrder) []OrderChange {
+	result := []OrderChange{}
+	for i := 0; i < len(from) || i < len(to); i++ {
+		var fromGroup, toGroup string
+		if i < len(from) {
+			fromGroup = groupString(from[i])
+		}
+		if i < len(to) {
+			toGroup = groupString(to[i])
+		}
+		if change := changeOf(i < len(from), i < len(to), fromGroup == toGroup); change != "" {
+			result = append(result, OrderChange{Group: i + 1, Change: change, From: fromGroup, To: toGroup})
+		}
+	}
+	return result
+}
+
+// groupString returns the modules of a group of the order, which holds the module itself for the order of extensions.
+func groupString(entry pubbldr.DetectionOrderEntry) string {
+	refs := entry.GroupDetectionOrder
+	if len(refs) == 0 {
+		refs = pubbldr.DetectionOrder{entry}
+	}
+
+	var modules []string
+	for _, ref := range refs {
+		module := ref.FullName()
+		if ref.Optional {
+			module += " (optional)"
+		}
+		modules = append(modules, module)
+	}
+	return strings.Join(modules, ", ")
+}
+
+func diffTargets(from, to []dist.Target) []TargetChange {
+	targets := func(targets []dist.Target) map[string]bool {
+		result := map[string]bool{}
+		for _, target := range targets {
+			result[targetString(target)] = true
+		}
+		return result
+	}
+	fromTargets := targets(from)
+	toTargets := targets(to)
+
+	result := []TargetChange{}
+	for _, target := range sortedKeys(fromTargets, toTargets) {
+		if change := changeOf(fromTargets[target], toTargets[target], true); change != "" {
+			result = append(result, TargetChange{Target: target, Change: change})
+		}
+	}
+	return result
+}
+
+func targetString(target dist.Target) string {
+	parts := []string{target.OS, target.Arch}
+	if target.ArchVariant != "" {
+		parts = append(parts, target.ArchVariant)
+	}
+	result := strings.Join(parts, "/")
+	for _, distro := range target.Distributions {
+		result += fmt.Sprintf(" %s@%s", distro.Name, distro.Version)
+	}
+	return result
+}
+
+// changeOf returns how an item changed given whether it is in each builder and whether it is the same in both, or an
+// empty string if it did not.
+func changeOf(inFrom, inTo, same bool) string {
+	switch {
+	case inFrom && !inTo:
+		return ChangeRemoved
+	case !inFrom && inTo:
+		return ChangeAdded
+	case !same:
+		return ChangeChanged
+	default:
+		return ""
+	}
+}
+
+// sortedKeys returns the keys of both maps, sorted.
+func sortedKeys[V any](from, to map[string]V) []string {
+	seen := map[string]bool{}
+	var keys []string
+	for _, m := range []map[string]V{from, to} {
+		for k := range m {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,138 @@
+package writer
+
+import (
+	"bytes"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	strs "github.com/buildpacks/pack/internal/strings"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type HumanReadableDiff struct{}
+
+func NewHumanReadableDiff() *HumanReadableDiff {
+	return &HumanReadableDiff{}
+}
+
+func (h *HumanReadableDiff) Print(logger logging.Logger, diff *DiffDisplay) error {
+	tpl := template.Must(template.New("diff").
+		Funcs(template.FuncMap{
+			"StringsValueOrDefault": strs.ValueOrDefault,
+			"JoinOrDefault": func(values []string, def string) string {
+				return strs.ValueOrDefault(strings.Join(values, ", "), def)
+			},
+		}).
+		Parse(diffTemplate))
+
+	buf := bytes.NewBuffer(nil)
+	tw := tabwriter.NewWriter(buf, 0, 0, 4, ' ', 0)
+	if err := tpl.Execute(tw, diff); err != nil {
+		return err
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	logger.Info(buf.String())
+	return nil
+}
+
+var diffTemplate = `Comparing builder '{{ .From }}' to '{{ .To }}'
+{{- if .Empty }}
+
+No differences
+{{- else }}
+
+Lifecycle:
+{{- if .Lifecycle }}
+  NAME	CHANGE	FROM	TO
+{{- range $_, $c := .Lifecycle }}
+  {{ $c.Name }}	{{ $c.Change }}	{{ StringsValueOrDefault $c.From "-" }}	{{ StringsValueOrDefault $c.To "-" }}
+{{- end }}
+{{- else }}
+  (no changes)
+{{- end }}
+
+Run Images:
+{{- if .RunImages }}
+  IMAGE	CHANGE	FROM MIRRORS	TO MIRRORS
+{{- range $_, $c := .RunImages }}
+  {{ $c.Image }}	{{ $c.Change }}	{{ JoinOrDefault $c.FromMirrors "-" }}	{{ JoinOrDefault $c.ToMirrors "-" }}
+{{- end }}
+{{- else }}
+  (no changes)
+{{- end }}
+
+Buildpacks:
+{{- if .Buildpacks }}
+  ID	CHANGE	FROM	TO
+{{- range $_, $c := .Buildpacks }}
+  {{ $c.ID }}	{{ $c.Change }}	{{ JoinOrDefault $c.FromVersions "-" }}	{{ JoinOrDefault $c.ToVersions "-" }}
+{{- end }}
+{{- else }}
+  (no changes)
+{{- end }}
+
+Extensions:
+{{- if .Extensions }}
+  ID	CHANGE	FROM	TO
+{{- range $_, $c := .Extensions }}
+  {{ $c.ID }}	{{ $c.Change }}	{{ JoinOrDefault $c.FromVersions "-" }}	{{ JoinOrDefault $c.ToVersions "-" }}
+{{- end }}
+{{- else }}
+  (no changes)
+{{- end }}
+
+Detection Order:
+{{- if .Order }}
+  GROUP	CHANGE	FROM	TO
+{{- range $_, $c := .Order }}
+  Group #{{ $c.Group }}	{{ $c.Change }}	{{ StringsValueOrDefault $c.From "-" }}	{{ StringsValueOrDefault $c.To "-" }}
+{{- end }}
+{{- else }}
+  (no changes)
+{{- end }}
+
+Detection Order (Extensions):
+{{- if .OrderExtensions }}
+  GROUP	CHANGE	FROM	TO
+{{- range $_, $c := .OrderExtensions }}
+  Group #{{ $c.Group }}	{{ $c.Change }}	{{ StringsValueOrDefault $c.From "-" }}	{{ StringsValueOrDefault $c.To "-" }}
+{{- end }}
+{{- else }}
+  (no changes)
+{{- end }}
+
+Build Config Env:
+{{- if .BuildConfigEnv }}
+  NAME	CHANGE	FROM	TO
+{{- range $_, $c := .BuildConfigEnv }}
+  {{ $c.Name }}	{{ $c.Change }}	{{ StringsValueOrDefault $c.From "-" }}	{{ StringsValueOrDefault $c.To "-" }}
+{{- end }}
+{{- else }}
+  (no changes)
+{{- end }}
+
+Labels:
+{{- if .Labels }}
+  NAME	CHANGE	FROM	TO
+{{- range $_, $c := .Labels }}
+  {{ $c.Name }}	{{ $c.Change }}	{{ StringsValueOrDefault $c.From "-" }}	{{ StringsValueOrDefault $c.To "-" }}
+{{- end }}
+{{- else }}
+  (no changes)
+{{- end }}
+
+Targets:
+{{- if .Targets }}
+  TARGET	CHANGE
+{{- range $_, $c := .Targets }}
+  {{ $c.Target }}	{{ $c.Change }}
+{{- end }}
+{{- else }}
+  (no changes)
+{{- end }}
+{{- end }}
+`
@@ -0,0 +1,273 @@
+package writer_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/buildpacks/lifecycle/api"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	pubbldr "github.com/buildpacks/pack/builder"
+	"github.com/buildpacks/pack/internal/builder"
+	"github.com/buildpacks/pack/internal/builder/writer"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestDiff(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Builder Diff Writers", testDiff, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testDiff(t *testing.T, when spec.G, it spec.S) {
+	var (
+		assert = h.NewAssertionManager(t)
+		outBuf bytes.Buffer
+
+		fromInfo *client.BuilderInfo
+		toInfo   *client.BuilderInfo
+		diff     *writer.DiffDisplay
+	)
+
+	group := func(refs ...dist.ModuleRef) pubbldr.DetectionOrderEntry {
+		var entry pubbldr.DetectionOrderEntry
+		for _, ref := range refs {
+			entry.GroupDetectionOrder = append(entry.GroupDetectionOrder, pubbldr.DetectionOrderEntry{ModuleRef: ref})
+		}
+		return entry
+	}
+	ref := func(id, version string, optional bool) dist.ModuleRef {
+		return dist.ModuleRef{ModuleInfo: dist.ModuleInfo{ID: id, Version: version}, Optional: optional}
+	}
+
+	it.Before(func() {
+		fromInfo = &client.BuilderInfo{
+			Lifecycle: builder.LifecycleDescriptor{
+				Info: builder.LifecycleInfo{Version: builder.VersionMustParse("0.19.6")},
+				APIs: builder.LifecycleAPIs{
+					Buildpack: builder.APIVersions{Supported: builder.APISet{api.MustParse("0.10")}},
+					Platform:  builder.APIVersions{Supported: builder.APISet{api.MustParse("0.12"), api.MustParse("0.13")}},
+				},
+			},
+			RunImages: []pubbldr.RunImageConfig{
+				{Image: "some/run-image:jammy", Mirrors: []string{"gcr.io/some/run-image:jammy"}},
+				{Image: "some/run-image:bionic"},
+			},
+			Buildpacks: []dist.ModuleInfo{
+				{ID: "test.bp.one", Version: "1.0.0"},
+				{ID: "test.bp.two", Version: "2.0.0"},
+				{ID: "test.bp.two", Version: "2.1.0"},
+			},
+			Order: pubbldr.DetectionOrder{
+				group(ref("test.bp.one", "1.0.0", false)),
+				group(ref("test.bp.two", "2.1.0", false), ref("test.bp.one", "1.0.0", true)),
+			},
+			BuildConfigEnv: map[string]string{"BP_LOG_LEVEL.default": "INFO"},
+			Labels: map[string]string{
+				"io.buildpacks.builder.metadata": `{"description": "old"}`,
+				"org.opencontainers.image.title": "some-builder",
+			},
+			Targets: []dist.Target{{OS: "linux", Arch: "amd64", Distributions: []dist.Distribution{{Name: "ubuntu", Version: "22.04"}}}},
+		}
+		toInfo = &client.BuilderInfo{
+			Lifecycle: builder.LifecycleDescriptor{
+				Info: builder.LifecycleInfo{Version: builder.VersionMustParse("0.20.0")},
+				APIs: builder.LifecycleAPIs{
+					Buildpack: builder.APIVersions{Supported: builder.APISet{api.MustParse("0.10")}},
+					Platform:  builder.APIVersions{Supported: builder.APISet{api.MustParse("0.12"), api.MustParse("0.13"), api.MustParse("0.14")}},
+				},
+			},
+			RunImages: []pubbldr.RunImageConfig{
+				{Image: "some/run-image:jammy", Mirrors: []string{"ghcr.io/some/run-image:jammy"}},
+			},
+			Buildpacks: []dist.ModuleInfo{
+				{ID: "test.bp.one", Version: "1.1.0"},
+				{ID: "test.bp.two", Version: "2.0.0"},
+				{ID: "test.bp.two", Version: "2.1.0"},
+				{ID: "test.bp.three", Version: "3.0.0"},
+			},
+			Order: pubbldr.DetectionOrder{
+				group(ref("test.bp.one", "1.1.0", false)),
+				group(ref("test.bp.two", "2.1.0", false), ref("test.bp.one", "1.1.0", true)),
+				group(ref("test.bp.three", "3.0.0", false)),
+			},
+			BuildConfigEnv: map[string]string{"BP_LOG_LEVEL.default": "DEBUG"},
+			Labels: map[string]string{
+				"io.buildpacks.builder.metadata": `{"description": "new"}`,
+				"org.opencontainers.image.title": "some-builder",
+			},
+			Targets: []dist.Target{{OS: "linux", Arch: "arm64", Distributions: []dist.Distribution{{Name: "ubuntu", Version: "22.04"}}}},
+		}
+		diff = writer.NewDiffDisplay("some/builder:v1", fromInfo, "some/builder:v2", toInfo)
+		outBuf.Reset()
+	})
+
+	when("NewDiffDisplay", func() {
+		it("reports lifecycle changes", func() {
+			assert.Equal(diff.Lifecycle, []writer.ValueChange{
+				{Name: "platform_apis.supported", Change: writer.ChangeChanged, From: "0.12, 0.13", To: "0.12, 0.13, 0.14"},
+				{Name: "version", Change: writer.ChangeChanged, From: "0.19.6", To: "0.20.0"},
+			})
+		})
+
+		it("reports run image and mirror changes", func() {
+			assert.Equal(diff.RunImages, []writer.RunImageChange{
+				{Image: "some/run-image:bionic", Change: writer.ChangeRemoved},
+				{Image: "some/run-image:jammy", Change: writer.ChangeChanged, FromMirrors: []string{"gcr.io/some/run-image:jammy"}, ToMirrors: []string{"ghcr.io/some/run-image:jammy"}},
+			})
+		})
+
+		it("reports changed, added and removed buildpack versions", func() {
+			assert.Equal(diff.Buildpacks, []writer.ModuleChange{
+				{ID: "test.bp.one", Change: writer.ChangeChanged, FromVersions: []string{"1.0.0"}, ToVersions: []string{"1.1.0"}},
+				{ID: "test.bp.three", Change: writer.ChangeAdded, ToVersions: []string{"3.0.0"}},
+			})
+			assert.Equal(diff.Extensions, []writer.ModuleChange{})
+		})
+
+		it("reports detection order changes by group", func() {
+			assert.Equal(diff.Order, []writer.OrderChange{
+				{Group: 1, Change: writer.ChangeChanged, From: "test.bp.one@1.0.0", To: "test.bp.one@1.1.0"},
+				{Group: 2, Change: writer.ChangeChanged, From: "test.bp.two@2.1.0, test.bp.one@1.0.0 (optional)", To: "test.bp.two@2.1.0, test.bp.one@1.1.0 (optional)"},
+				{Group: 3, Change: writer.ChangeAdded, To: "test.bp.three@3.0.0"},
+			})
+		})
+
+		it("reports build config env changes", func() {
+			assert.Equal(diff.BuildConfigEnv, []writer.ValueChange{
+				{Name: "BP_LOG_LEVEL.default", Change: writer.ChangeChanged, From: "INFO", To: "DEBUG"},
+			})
+		})
+
+		it("ignores the labels compared by other sections", func() {
+			assert.Equal(diff.Labels, []writer.ValueChange{})
+		})
+
+		it("reports target changes", func() {
+			assert.Equal(diff.Targets, []writer.TargetChange{
+				{Target: "linux/amd64 ubuntu@22.04", Change: writer.ChangeRemoved},
+				{Target: "linux/arm64 ubuntu@22.04", Change: writer.ChangeAdded},
+			})
+		})
+
+		it("is empty when comparing a builder to itself", func() {
+			assert.TrueWithMessage(writer.NewDiffDisplay("a", fromInfo, "b", fromInfo).Empty(), "expected no differences")
+		})
+	})
+
+	when("HumanReadableDiff", func() {
+		it("prints each section of the diff", func() {
+			logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+			assert.Nil(writer.NewHumanReadableDiff().Print(logger, diff))
+
+			out := outBuf.String()
+			assert.Contains(out, "Comparing builder 'some/builder:v1' to 'some/builder:v2'")
+			h.AssertContainsMatch(t, out, `version\s+changed\s+0.19.6\s+0.20.0`)
+			h.AssertContainsMatch(t, out, `some/run-image:jammy\s+changed\s+gcr.io/some/run-image:jammy\s+ghcr.io/some/run-image:jammy`)
+			h.AssertContainsMatch(t, out, `test.bp.three\s+added\s+-\s+3.0.0`)
+			h.AssertContainsMatch(t, out, `Group #3\s+added\s+-\s+test.bp.three@3.0.0`)
+			h.AssertContainsMatch(t, out, `BP_LOG_LEVEL.default\s+changed\s+INFO\s+DEBUG`)
+			h.AssertContainsMatch(t, out, `linux/arm64 ubuntu@22.04\s+added`)
+			h.AssertContainsMatch(t, out, `Extensions:\n\s+\(no changes\)`)
+		})
+
+		it("says when there are no differences", func() {
+			logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+			assert.Nil(writer.NewHumanReadableDiff().Print(logger, writer.NewDiffDisplay("a", fromInfo, "b", fromInfo)))
+
+			assert.Contains(outBuf.String(), "No differences")
+		})
+	})
+
+	when("JSONDiff", func() {
+		it("prints the diff as JSON", func() {
+			logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+			assert.Nil(writer.NewJSONDiff().Print(logger, writer.NewDiffDisplay("a", fromInfo, "b", fromInfo)))
+
+			assert.ContainsJSON(outBuf.String(), `{
+  "from": "a",
+  "to": "b",
+  "lifecycle": [],
+  "run_images": [],
+  "buildpacks": [],
+  "extensions": [],
+  "detection_order": [],
+  "order_extensions": [],
+  "build_config_env": [],
+  "labels": [],
+  "targets": []
+}`)
+		})
+	})
+
+	when("YAMLDiff", func() {
+		it("prints the diff as YAML", func() {
+			logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+			assert.Nil(writer.NewYAMLDiff().Print(logger, diff))
+
+			assert.ContainsYAML(outBuf.String(), `---
+from: some/builder:v1
+to: some/builder:v2
+lifecycle:
+- name: platform_apis.supported
+  change: changed
+  from: 0.12, 0.13
+  to: 0.12, 0.13, 0.14
+- name: version
+  change: changed
+  from: 0.19.6
+  to: 0.20.0
+run_images:
+- image: some/run-image:bionic
+  change: removed
+- image: some/run-image:jammy
+  change: changed
+  from_mirrors:
+  - gcr.io/some/run-image:jammy
+  to_mirrors:
+  - ghcr.io/some/run-image:jammy
+buildpacks:
+- id: test.bp.one
+  change: changed
+  from_versions:
+  - 1.0.0
+  to_versions:
+  - 1.1.0
+- id: test.bp.three
+  change: added
+  to_versions:
+  - 3.0.0
+extensions: []
+detection_order:
+- group: 1
+  change: changed
+  from: test.bp.one@1.0.0
+  to: test.bp.one@1.1.0
+- group: 2
+  change: changed
+  from: test.bp.two@2.1.0, test.bp.one@1.0.0 (optional)
+  to: test.bp.two@2.1.0, test.bp.one@1.1.0 (optional)
+- group: 3
+  change: added
+  to: test.bp.three@3.0.0
+order_extensions: []
+build_config_env:
+- name: BP_LOG_LEVEL.default
+  change: changed
+  from: INFO
+  to: DEBUG
+labels: []
+targets:
+- target: linux/amd64 ubuntu@22.04
+  change: removed
+- target: linux/arm64 ubuntu@22.04
+  change: added
+`)
+		})
+	})
+}
@@ -0,0 +1,25 @@
+package writer
+
+import (
+	"bytes"
+
+	"gopkg.in/yaml.v3"
+)
+
+type YAMLDiff struct {
+	StructuredDiffFormat
+}
+
+func NewYAMLDiff() *YAMLDiff {
+	return &YAMLDiff{
+		StructuredDiffFormat: StructuredDiffFormat{
+			MarshalFunc: func(i interface{}) ([]byte, error) {
+				buf := bytes.NewBuffer(nil)
+				if err := yaml.NewEncoder(buf).Encode(i); err != nil {
+					return []byte{}, err
+				}
+				return buf.Bytes(), nil
+			},
+		},
+	}
+}
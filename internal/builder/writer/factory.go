@@ -22,6 +22,10 @@ type BuilderWriter interface {
 	) error
 }
 
+type BuilderDiffWriter interface {
+	Print(logger logging.Logger, diff *DiffDisplay) error
+}
+
 type SharedBuilderInfo struct {
 	Name      string `json:"builder_name" yaml:"builder_name" toml:"builder_name"`
 	Trusted   bool   `json:"trusted" yaml:"trusted" toml:"trusted"`
@@ -32,6 +36,10 @@ type BuilderWriterFactory interface {
 	Writer(kind string) (BuilderWriter, error)
 }
 
+type BuilderDiffWriterFactory interface {
+	DiffWriter(kind string) (BuilderDiffWriter, error)
+}
+
 func NewFactory() *Factory {
 	return &Factory{}
 }
@@ -50,3 +58,16 @@ func (f *Factory) Writer(kind string) (BuilderWriter, error) {
 
 	return nil, fmt.Errorf("output format %s is not supported", style.Symbol(kind))
 }
+
+func (f *Factory) DiffWriter(kind string) (BuilderDiffWriter, error) {
+	switch kind {
+	case "human-readable":
+		return NewHumanReadableDiff(), nil
+	case "json":
+		return NewJSONDiff(), nil
+	case "yaml":
+		return NewYAMLDiff(), nil
+	}
+
+	return nil, fmt.Errorf("output format %s is not supported when comparing builders", style.Symbol(kind))
+}
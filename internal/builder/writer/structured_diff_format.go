@@ -0,0 +1,19 @@
+package writer
+
+import (
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type StructuredDiffFormat struct {
+	MarshalFunc func(interface{}) ([]byte, error)
+}
+
+func (w *StructuredDiffFormat) Print(logger logging.Logger, diff *DiffDisplay) error {
+	out, err := w.MarshalFunc(diff)
+	if err != nil {
+		return err
+	}
+
+	_, err = logger.Writer().Write(out)
+	return err
+}
@@ -13,9 +13,12 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
+	"github.com/buildpacks/pack/internal/build/kubernetes"
+	"github.com/buildpacks/pack/internal/build/oci"
 	"github.com/buildpacks/pack/internal/config"
 	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/events"
 	"github.com/buildpacks/pack/pkg/image"
 	"github.com/buildpacks/pack/pkg/logging"
 	"github.com/buildpacks/pack/pkg/project"
@@ -25,9 +28,16 @@ import (
 type BuildFlags struct {
 	Publish              bool
 	ClearCache           bool
+	AttachProvenance     bool
 	TrustBuilder         bool
 	Interactive          bool
 	Sparse               bool
+	ExplainDetect        bool
+	Timings              bool
+	Watch                bool
+	WatchRun             bool
+	WatchDebounce        time.Duration
+	WatchRunPorts        []string
 	DockerHost           string
 	CacheImage           string
 	Cache                cache.CacheOpts
@@ -46,6 +56,8 @@ type BuildFlags struct {
 	Buildpacks           []string
 	Extensions           []string
 	Volumes              []string
+	Secrets              []string
+	Bindings             []string
 	AdditionalTags       []string
 	Workspace            string
 	GID                  int
@@ -53,9 +65,41 @@ type BuildFlags struct {
 	PreviousImage        string
 	SBOMDestinationDir   string
 	ReportDestinationDir string
+	ProvenanceDir        string
+	SignKey              string
+	VerifyKeys           []string
+	OutputFormat         string
 	DateTime             string
 	PreBuildpacks        []string
 	PostBuildpacks       []string
+	Executor             string
+	KubernetesNamespace  string
+	OCIRuntime           string
+}
+
+const (
+	outputFormatHumanReadable = "human-readable"
+	outputFormatJSON          = "json"
+)
+
+const (
+	executorDocker     = "docker"
+	executorKubernetes = "kubernetes"
+	executorOCI        = "oci"
+
+	defaultOCIRuntime = "runc"
+)
+
+const defaultWatchDebounce = 500 * time.Millisecond
+
+// quietable is implemented by loggers that can be told to only log warnings and errors.
+type quietable interface {
+	WantQuiet(f bool)
+}
+
+// warningRedirectable is implemented by loggers that can be told to log warnings to stderr.
+type warningRedirectable interface {
+	WantWarningsOnStderr(f bool)
 }
 
 // Build an image from source code
@@ -78,6 +122,16 @@ func Build(logger logging.Logger, cfg config.Config, packClient PackClient) *cob
 				return err
 			}
 
+			if flags.OutputFormat == outputFormatJSON {
+				// only warnings and errors are logged, to stderr, keeping stdout for the events
+				if l, ok := logger.(quietable); ok {
+					l.WantQuiet(true)
+				}
+				if l, ok := logger.(warningRedirectable); ok {
+					l.WantWarningsOnStderr(true)
+				}
+			}
+
 			inputPreviousImage := client.ParseInputImageReference(flags.PreviousImage)
 
 			descriptor, actualDescriptorPath, err := parseProjectToml(flags.AppPath, flags.DescriptorPath, logger)
@@ -109,7 +163,24 @@ func Build(logger logging.Logger, cfg config.Config, packClient PackClient) *cob
 				return err
 			}
 
+			secrets, err := parseSecrets(flags.Secrets)
+			if err != nil {
+				return err
+			}
+
+			bindings, err := parseBindings(flags.Bindings)
+			if err != nil {
+				return err
+			}
+
 			trustBuilder := isTrustedBuilder(cfg, builder) || flags.TrustBuilder
+			var trustPolicy *client.TrustedBuilderPolicy
+			if !flags.TrustBuilder {
+				if trustPolicy, err = trustedBuilderPolicy(cfg, builder); err != nil {
+					return err
+				}
+			}
+
 			if trustBuilder {
 				logger.Debugf("Builder %s is trusted", style.Symbol(builder))
 				if flags.LifecycleImage != "" {
@@ -157,7 +228,36 @@ func Build(logger logging.Logger, cfg config.Config, packClient PackClient) *cob
 			if err != nil {
 				return errors.Wrapf(err, "parsing creation time %s", flags.DateTime)
 			}
-			if err := packClient.Build(cmd.Context(), client.BuildOptions{
+
+			signer, err := loadSigner(flags.SignKey)
+			if err != nil {
+				return err
+			}
+
+			verifier, err := loadVerifier(flags.VerifyKeys)
+			if err != nil {
+				return err
+			}
+
+			var buildEvents events.Emitter
+			if flags.OutputFormat == outputFormatJSON {
+				buildEvents = events.NewJSONWriter(logger.Writer())
+			}
+
+			var executor client.LifecycleExecutor
+			switch flags.Executor {
+			case executorKubernetes:
+				if executor, err = kubernetes.NewExecutorFromKubeconfig(logger, flags.KubernetesNamespace); err != nil {
+					return err
+				}
+			case executorOCI:
+				packHome, err := config.PackHome()
+				if err != nil {
+					return err
+				}
+				executor = oci.NewExecutor(logger, oci.NewCommandRuntime(flags.OCIRuntime), filepath.Join(packHome, "build-cache"))
+			}
+			buildOpts := client.BuildOptions{
 				AppPath:           flags.AppPath,
 				Builder:           builder,
 				Registry:          flags.Registry,
@@ -165,6 +265,8 @@ func Build(logger logging.Logger, cfg config.Config, packClient PackClient) *cob
 				AdditionalTags:    flags.AdditionalTags,
 				RunImage:          flags.RunImage,
 				Env:               env,
+				Secrets:           secrets,
+				Bindings:          bindings,
 				Image:             inputImageName.Name(),
 				Publish:           flags.Publish,
 				DockerHost:        flags.DockerHost,
@@ -193,16 +295,36 @@ func Build(logger logging.Logger, cfg config.Config, packClient PackClient) *cob
 				Interactive:              flags.Interactive,
 				SBOMDestinationDir:       flags.SBOMDestinationDir,
 				ReportDestinationDir:     flags.ReportDestinationDir,
+				ProvenanceDestinationDir: flags.ProvenanceDir,
+				AttachProvenance:         flags.AttachProvenance,
+				Signer:                   signer,
+				Verifier:                 verifier,
+				TrustedBuilderPolicy:     trustPolicy,
+				Events:                   buildEvents,
+				ExplainDetect:            flags.ExplainDetect,
+				Timings:                  flags.Timings,
 				CreationTime:             dateTime,
 				PreBuildpacks:            flags.PreBuildpacks,
 				PostBuildpacks:           flags.PostBuildpacks,
+				Executor:                 executor,
 				LayoutConfig: &client.LayoutConfig{
 					Sparse:             flags.Sparse,
 					InputImage:         inputImageName,
 					PreviousInputImage: inputPreviousImage,
 					LayoutRepoDir:      cfg.LayoutRepositoryDir,
 				},
-			}); err != nil {
+			}
+
+			if flags.Watch {
+				return packClient.Watch(cmd.Context(), client.WatchOptions{
+					BuildOptions: buildOpts,
+					Debounce:     flags.WatchDebounce,
+					RunContainer: flags.WatchRun,
+					RunPorts:     flags.WatchRunPorts,
+				})
+			}
+
+			if err := packClient.Build(cmd.Context(), buildOpts); err != nil {
 				return errors.Wrap(err, "failed to build")
 			}
 			logger.Infof("Successfully built image %s", style.Symbol(inputImageName.Name()))
@@ -240,6 +362,7 @@ func buildCommandFlags(cmd *cobra.Command, buildFlags *BuildFlags, cfg config.Co
 		`Cache options used to define cache techniques for build process.
 - Cache as bind: 'type=<build/launch>;format=bind;source=<path to directory>'
 - Cache as image (requires --publish): 'type=<build/launch>;format=image;name=<registry image name>'
+- Cache as registry image (daemon or published builds): 'type=build;format=registry;name=<registry image name>'
 - Cache as volume: 'type=<build/launch>;format=volume;[name=<volume name>]'
     - If no name is provided, a random name will be generated.
 `)
@@ -256,25 +379,41 @@ func buildCommandFlags(cmd *cobra.Command, buildFlags *BuildFlags, cfg config.Co
 	cmd.Flags().BoolVar(&buildFlags.Publish, "publish", false, "Publish the application image directly to the container registry specified in <image-name>, instead of the daemon. The run image must also reside in the registry.")
 	cmd.Flags().StringVar(&buildFlags.DockerHost, "docker-host", "",
 		`Address to docker daemon that will be exposed to the build container.
-If not set (or set to empty string) the standard socket location will be used.
+If not set (or set to empty string) the standard socket location will be used, or the socket of the Podman service in use.
 Special value 'inherit' may be used in which case DOCKER_HOST environment variable will be used.
 This option may set DOCKER_HOST environment variable for the build container if needed.
 `)
+	cmd.Flags().StringVar(&buildFlags.Executor, "executor", executorDocker, "Where to run the lifecycle (docker, kubernetes, oci).\nWith kubernetes, the lifecycle runs in a pod of the cluster of the current kubeconfig context, without a docker daemon. Requires --publish\nWith oci, the lifecycle runs in a container of the OCI runtime set by --oci-runtime, without a docker daemon. The builder and run image are read from the OCI layout repository, and the image must be exported to OCI layout")
+	cmd.Flags().StringVar(&buildFlags.KubernetesNamespace, "kubernetes-namespace", "", "Namespace to run the lifecycle in with --executor kubernetes (defaults to the namespace of the current kubeconfig context)")
+	cmd.Flags().StringVar(&buildFlags.OCIRuntime, "oci-runtime", defaultOCIRuntime, "OCI runtime to run the lifecycle with --executor oci, such as runc, crun or a shim running containers rootless")
 	cmd.Flags().StringVar(&buildFlags.LifecycleImage, "lifecycle-image", cfg.LifecycleImage, `Custom lifecycle image to use for analysis, restore, and export when builder is untrusted.`)
-	cmd.Flags().StringVar(&buildFlags.Platform, "platform", "", `Platform to build on (e.g., "linux/amd64").`)
+	cmd.Flags().StringVar(&buildFlags.Platform, "platform", "", `Platform to build on (e.g., "linux/amd64"). Provide a comma separated list of platforms to build for each of them and publish an image index (requires --publish, and doesn't support --sign-key or --attach-provenance).`)
 	cmd.Flags().StringVar(&buildFlags.Policy, "pull-policy", "", `Pull policy to use. Accepted values are always, never, and if-not-present. (default "always")`)
 	cmd.Flags().StringVarP(&buildFlags.Registry, "buildpack-registry", "r", cfg.DefaultRegistryName, "Buildpack Registry by name")
 	cmd.Flags().StringVar(&buildFlags.RunImage, "run-image", "", "Run image (defaults to default stack's run image)")
 	cmd.Flags().StringSliceVarP(&buildFlags.AdditionalTags, "tag", "t", nil, "Additional tags to push the output image to.\nTags should be in the format 'image:tag' or 'repository/image:tag'."+stringSliceHelp("tag"))
 	cmd.Flags().BoolVar(&buildFlags.TrustBuilder, "trust-builder", false, "Trust the provided builder.\nAll lifecycle phases will be run in a single container.\nFor more on trusted builders, and when to trust or untrust a builder, check out our docs here: https://buildpacks.io/docs/tools/pack/concepts/trusted_builders")
 	cmd.Flags().StringArrayVar(&buildFlags.Volumes, "volume", nil, "Mount host volume into the build container, in the form '<host path>:<target path>[:<options>]'.\n- 'host path': Name of the volume or absolute directory path to mount.\n- 'target path': The path where the file or directory is available in the container.\n- 'options' (default \"ro\"): An optional comma separated list of mount options.\n    - \"ro\", volume contents are read-only.\n    - \"rw\", volume contents are readable and writeable.\n    - \"volume-opt=<key>=<value>\", can be specified more than once, takes a key-value pair consisting of the option name and its value."+stringArrayHelp("volume"))
+	cmd.Flags().StringArrayVar(&buildFlags.Secrets, "secret", nil, "Secret available to buildpacks during the build phase only, in the form 'id=<id>,src=<path>' or 'id=<id>,env=<variable>'.\nThe secret is readable by the builder user at /run/secrets/<id>, a tmpfs mount of the build phase's container, and is never written to disk, the image, the cache or report.toml.\nThe lifecycle phases run in separate containers when secrets are used, and the build image must provide sh and tar."+stringArrayHelp("secret"))
+	cmd.Flags().StringArrayVar(&buildFlags.Bindings, "binding", nil, "Service binding available to buildpacks during the detect and build phases, in the form '<name>=<type>:<path>', where <path> is a directory holding a file per entry of the binding.\nThe binding is copied to /platform/bindings/<name>, following the Kubernetes service binding specification, and replaces the binding of project.toml with the same name."+stringArrayHelp("binding"))
 	cmd.Flags().StringVar(&buildFlags.Workspace, "workspace", "", "Location at which to mount the app dir in the build image")
 	cmd.Flags().IntVar(&buildFlags.GID, "gid", 0, `Override GID of user's group in the stack's build and run images. The provided value must be a positive number`)
 	cmd.Flags().IntVar(&buildFlags.UID, "uid", 0, `Override UID of user in the stack's build and run images. The provided value must be a positive number`)
 	cmd.Flags().StringVar(&buildFlags.PreviousImage, "previous-image", "", "Set previous image to a particular tag reference, digest reference, or (when performing a daemon build) image ID")
 	cmd.Flags().StringVar(&buildFlags.SBOMDestinationDir, "sbom-output-dir", "", "Path to export SBoM contents.\nOmitting the flag will yield no SBoM content.")
-	cmd.Flags().StringVar(&buildFlags.ReportDestinationDir, "report-output-dir", "", "Path to export build report.toml.\nOmitting the flag yield no report file.")
+	cmd.Flags().StringVar(&buildFlags.ReportDestinationDir, "report-output-dir", "", "Path to export build report.toml, and timings.toml when --timings is set.\nOmitting the flag yield no report file.")
+	cmd.Flags().StringVar(&buildFlags.ProvenanceDir, "provenance-output-dir", "", "Path to export a SLSA provenance attestation (provenance.json) describing how the image was built.\nImages in the daemon are recorded by image ID, under the 'dockerImageId' digest key.\nOmitting the flag will yield no provenance file.")
+	cmd.Flags().BoolVar(&buildFlags.AttachProvenance, "attach-provenance", false, "Attach a SLSA provenance attestation to the published image as an OCI referrer. Requires --publish")
+	cmd.Flags().StringVar(&buildFlags.SignKey, "sign-key", "", "Path to a cosign-compatible private key to sign the published image with. Encrypted keys are decrypted with the password in the COSIGN_PASSWORD environment variable. Requires --publish")
+	cmd.Flags().StringArrayVar(&buildFlags.VerifyKeys, "verify-key", nil, "Path to a cosign-compatible public key. When set, the builder and run image must be signed with one of the provided keys."+stringArrayHelp("verify-key"))
+	cmd.Flags().StringVar(&buildFlags.OutputFormat, "output-format", outputFormatHumanReadable, "Output format of the build (human-readable, json).\nWith json, a stream of JSON lines describing the phases, their output, cache hits and misses, and the built image is written instead of logs.")
+	cmd.Flags().BoolVar(&buildFlags.ExplainDetect, "explain-detect", false, "Explain why each group of the order of the builder passed or failed detection: the status of each buildpack, the requires and provides that could not be resolved, and the group selected.\nThe detect phase logs at debug level, as do all the phases when they run in a single container with a trusted builder. With --output-format json, the explanation is written as a detect event.")
+	cmd.Flags().BoolVar(&buildFlags.Timings, "timings", false, "Summarize the time spent in each lifecycle phase after the build, splitting the creator by the phases it runs.\nThe summary is also written to timings.toml in the --report-output-dir directory.")
 	cmd.Flags().BoolVar(&buildFlags.Interactive, "interactive", false, "Launch a terminal UI to depict the build process")
+	cmd.Flags().BoolVar(&buildFlags.Watch, "watch", false, "Rebuild the image each time files of the app change, until interrupted.\nFiles excluded by the project descriptor are ignored.")
+	cmd.Flags().DurationVar(&buildFlags.WatchDebounce, "watch-debounce", defaultWatchDebounce, "How long the app must go without changes before it is rebuilt. Requires --watch")
+	cmd.Flags().BoolVar(&buildFlags.WatchRun, "watch-run", false, "Run a container from the image after each successful build, replacing the container of the previous build. Requires --watch")
+	cmd.Flags().StringArrayVar(&buildFlags.WatchRunPorts, "watch-run-port", nil, "Port the container run with --watch-run publishes, in the form '[<host ip>:][<host port>:]<container port>[/<protocol>]'."+stringArrayHelp("watch-run-port"))
 	cmd.Flags().BoolVar(&buildFlags.Sparse, "sparse", false, "Use this flag to avoid saving on disk the run-image layers when the application image is exported to OCI layout format")
 	if !cfg.Experimental {
 		cmd.Flags().MarkHidden("interactive")
@@ -291,10 +430,18 @@ func validateBuildFlags(flags *BuildFlags, cfg config.Config, inputImageRef clie
 		logger.Warn("cache definition: 'launch' cache in format 'image' is not supported.")
 	}
 
+	if flags.Cache.Launch.Format == cache.CacheRegistry {
+		logger.Warn("cache definition: 'launch' cache in format 'registry' is not supported.")
+	}
+
 	if flags.Cache.Build.Format == cache.CacheImage && flags.CacheImage != "" {
 		return errors.New("'cache' flag with 'image' format cannot be used with 'cache-image' flag.")
 	}
 
+	if flags.Cache.Build.Format == cache.CacheRegistry && flags.CacheImage != "" {
+		return errors.New("'cache' flag with 'registry' format cannot be used with 'cache-image' flag.")
+	}
+
 	if flags.Cache.Build.Format == cache.CacheImage && !flags.Publish {
 		return errors.New("image cache format requires the 'publish' flag")
 	}
@@ -303,6 +450,14 @@ func validateBuildFlags(flags *BuildFlags, cfg config.Config, inputImageRef clie
 		return errors.New("cache-image flag requires the publish flag")
 	}
 
+	if flags.AttachProvenance && !flags.Publish {
+		return errors.New("attach-provenance flag requires the publish flag")
+	}
+
+	if flags.SignKey != "" && !flags.Publish {
+		return errors.New("sign-key flag requires the publish flag")
+	}
+
 	if flags.GID < 0 {
 		return errors.New("gid flag must be in the range of 0-2147483647")
 	}
@@ -315,6 +470,72 @@ func validateBuildFlags(flags *BuildFlags, cfg config.Config, inputImageRef clie
 		return client.NewExperimentError("Interactive mode is currently experimental.")
 	}
 
+	if !flags.Watch && (flags.WatchRun || len(flags.WatchRunPorts) > 0) {
+		return errors.New("watch-run and watch-run-port flags require the watch flag")
+	}
+
+	if !flags.Watch && flags.WatchDebounce != defaultWatchDebounce {
+		return errors.New("watch-debounce flag requires the watch flag")
+	}
+
+	if flags.WatchRun && flags.Publish {
+		return errors.New("watch-run flag cannot be used with the publish flag")
+	}
+
+	if len(flags.WatchRunPorts) > 0 && !flags.WatchRun {
+		return errors.New("watch-run-port flag requires the watch-run flag")
+	}
+
+	if flags.Watch && flags.Interactive {
+		return errors.New("watch flag cannot be used in interactive mode")
+	}
+
+	if flags.KubernetesNamespace != "" && flags.Executor != executorKubernetes {
+		return errors.New("kubernetes-namespace flag requires the kubernetes executor")
+	}
+
+	if flags.OCIRuntime != defaultOCIRuntime && flags.Executor != executorOCI {
+		return errors.New("oci-runtime flag requires the oci executor")
+	}
+
+	switch flags.Executor {
+	case executorDocker:
+	case executorKubernetes:
+		if !flags.Publish {
+			return errors.New("kubernetes executor requires the publish flag")
+		}
+		if flags.DockerHost != "" || len(flags.Volumes) > 0 || flags.Interactive {
+			return errors.New("docker-host, volume and interactive flags cannot be used with the kubernetes executor")
+		}
+	case executorOCI:
+		if !inputImageRef.Layout() || flags.Publish {
+			return errors.New("oci executor requires the image to be exported to OCI layout")
+		}
+		if flags.DockerHost != "" || flags.Interactive {
+			return errors.New("docker-host and interactive flags cannot be used with the oci executor")
+		}
+	default:
+		return errors.Errorf("executor must be one of %s, %s or %s", style.Symbol(executorDocker), style.Symbol(executorKubernetes), style.Symbol(executorOCI))
+	}
+
+	switch flags.OutputFormat {
+	case outputFormatHumanReadable:
+	case outputFormatJSON:
+		if flags.Interactive {
+			return errors.New("output-format flag cannot be json in interactive mode")
+		}
+	default:
+		return errors.Errorf("output-format must be one of %s or %s", style.Symbol(outputFormatHumanReadable), style.Symbol(outputFormatJSON))
+	}
+
+	if flags.ExplainDetect && flags.Interactive {
+		return errors.New("explain-detect flag cannot be used in interactive mode")
+	}
+
+	if flags.Timings && flags.Interactive {
+		return errors.New("timings flag cannot be used in interactive mode")
+	}
+
 	if inputImageRef.Layout() && !cfg.Experimental {
 		return client.NewExperimentError("Exporting to OCI layout is currently experimental.")
 	}
@@ -367,6 +588,91 @@ func addEnvVar(env map[string]string, item string) map[string]string {
 	return env
 }
 
+// parseSecrets reads the secrets given in the form 'id=<id>,src=<path>' or 'id=<id>,env=<variable>'. The ID defaults to
+// the name of the file.
+func parseSecrets(secrets []string) (map[string][]byte, error) {
+	out := make(map[string][]byte)
+	for _, secret := range secrets {
+		var id, src, env string
+		for _, field := range strings.Split(secret, ",") {
+			key, value, _ := strings.Cut(field, "=")
+			switch key {
+			case "id":
+				id = value
+			case "src", "source":
+				src = value
+			case "env":
+				env = value
+			default:
+				return nil, errors.Errorf("invalid secret %s: unknown field %s", style.Symbol(secret), style.Symbol(key))
+			}
+		}
+
+		if id == "" && src != "" {
+			id = filepath.Base(src)
+		}
+		switch {
+		case id == "" || id == "." || id == ".." || strings.ContainsAny(id, `/\`):
+			return nil, errors.Errorf("invalid secret %s: id must be a file name", style.Symbol(secret))
+		case (src == "") == (env == ""):
+			return nil, errors.Errorf("invalid secret %s: exactly one of src or env must be set", style.Symbol(secret))
+		}
+		if _, ok := out[id]; ok {
+			return nil, errors.Errorf("secret %s is set more than once", style.Symbol(id))
+		}
+
+		if env != "" {
+			value, ok := os.LookupEnv(env)
+			if !ok {
+				return nil, errors.Errorf("environment variable %s of secret %s is not set", style.Symbol(env), style.Symbol(id))
+			}
+			out[id] = []byte(value)
+			continue
+		}
+
+		if rest, ok := strings.CutPrefix(src, "~/"); ok {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return nil, errors.Wrap(err, "determining user home directory")
+			}
+			src = filepath.Join(home, rest)
+		}
+		data, err := os.ReadFile(filepath.Clean(src))
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading secret %s", style.Symbol(id))
+		}
+		out[id] = data
+	}
+	return out, nil
+}
+
+// parseBindings reads the service bindings given in the form '<name>=<type>:<path>'.
+func parseBindings(bindings []string) ([]projectTypes.Binding, error) {
+	var out []projectTypes.Binding
+	names := map[string]bool{}
+	for _, binding := range bindings {
+		name, rest, _ := strings.Cut(binding, "=")
+		bindingType, path, _ := strings.Cut(rest, ":")
+		if name == "" || bindingType == "" || path == "" {
+			return nil, errors.Errorf("invalid binding %s: must be in the form '<name>=<type>:<path>'", style.Symbol(binding))
+		}
+		if names[name] {
+			return nil, errors.Errorf("binding %s is set more than once", style.Symbol(name))
+		}
+		names[name] = true
+
+		if rest, ok := strings.CutPrefix(path, "~/"); ok {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return nil, errors.Wrap(err, "determining user home directory")
+			}
+			path = filepath.Join(home, rest)
+		}
+		out = append(out, projectTypes.Binding{Name: name, Type: bindingType, Path: filepath.Clean(path)})
+	}
+	return out, nil
+}
+
 func parseProjectToml(appPath, descriptorPath string, logger logging.Logger) (projectTypes.Descriptor, string, error) {
 	actualPath := descriptorPath
 	computePath := descriptorPath == ""
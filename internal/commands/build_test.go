@@ -2,6 +2,13 @@ package commands_test
 
 import (
 	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -23,6 +30,7 @@ import (
 	"github.com/buildpacks/pack/internal/commands/testmocks"
 	"github.com/buildpacks/pack/internal/config"
 	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/events"
 	"github.com/buildpacks/pack/pkg/image"
 	"github.com/buildpacks/pack/pkg/logging"
 	projectTypes "github.com/buildpacks/pack/pkg/project/types"
@@ -114,6 +122,38 @@ func testBuildCommand(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 
+			when("the trusted builder is pinned", func() {
+				it("forwards the trusted builder policy onto the client", func() {
+					_, publicKeyPath := writeSigningKeys(t, t.TempDir())
+					mockClient.EXPECT().
+						Build(gomock.Any(), EqBuildOptionsWithTrustedBuilderPolicy("sha256:abc", true)).
+						Return(nil)
+
+					cfg := config.Config{TrustedBuilders: []config.TrustedBuilder{{Name: "my-builder", Digest: "sha256:abc", SigningKeys: []string{publicKeyPath}}}}
+					command = commands.Build(logger, cfg, mockClient)
+					command.SetArgs([]string{"image", "--builder", "my-builder"})
+					h.AssertNil(t, command.Execute())
+				})
+
+				it("does not apply the policy when the builder is trusted with --trust-builder", func() {
+					mockClient.EXPECT().
+						Build(gomock.Any(), EqBuildOptionsWithTrustedBuilderPolicy("", false)).
+						Return(nil)
+
+					cfg := config.Config{TrustedBuilders: []config.TrustedBuilder{{Name: "my-builder", Digest: "sha256:abc"}}}
+					command = commands.Build(logger, cfg, mockClient)
+					command.SetArgs([]string{"image", "--builder", "my-builder", "--trust-builder"})
+					h.AssertNil(t, command.Execute())
+				})
+
+				it("errors when a signing key cannot be loaded", func() {
+					cfg := config.Config{TrustedBuilders: []config.TrustedBuilder{{Name: "my-builder", SigningKeys: []string{filepath.Join(t.TempDir(), "missing.pub")}}}}
+					command = commands.Build(logger, cfg, mockClient)
+					command.SetArgs([]string{"image", "--builder", "my-builder"})
+					h.AssertError(t, command.Execute(), "reading trusted builder policy for 'my-builder'")
+				})
+			})
+
 			when("the builder is suggested", func() {
 				it("sets the trust builder option", func() {
 					mockClient.EXPECT().
@@ -405,6 +445,37 @@ func testBuildCommand(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("cache flag with 'format=registry' is passed", func() {
+			when("--publish is not used", func() {
+				it("succeeds", func() {
+					mockClient.EXPECT().
+						Build(gomock.Any(), EqBuildOptionsWithCacheFlags("type=build;format=registry;name=myorg/myimage:cache;type=launch;format=volume;")).
+						Return(nil)
+
+					command.SetArgs([]string{"--builder", "my-builder", "image", "--cache", "type=build;format=registry;name=myorg/myimage:cache"})
+					h.AssertNil(t, command.Execute())
+				})
+			})
+			when("used together with --cache-image", func() {
+				it("errors", func() {
+					command.SetArgs([]string{"--builder", "my-builder", "image", "--cache-image", "some-cache-image", "--cache", "type=build;format=registry;name=myorg/myimage:cache", "--publish"})
+					err := command.Execute()
+					h.AssertError(t, err, "'cache' flag with 'registry' format cannot be used with 'cache-image' flag")
+				})
+			})
+			when("'type=launch;format=registry' is used", func() {
+				it("warns", func() {
+					mockClient.EXPECT().
+						Build(gomock.Any(), EqBuildOptionsWithCacheFlags("type=build;format=volume;type=launch;format=registry;name=myorg/myimage:cache;")).
+						Return(nil)
+
+					command.SetArgs([]string{"--builder", "my-builder", "image", "--cache", "type=launch;format=registry;name=myorg/myimage:cache"})
+					h.AssertNil(t, command.Execute())
+					h.AssertContains(t, outBuf.String(), "Warning: cache definition: 'launch' cache in format 'registry' is not supported.")
+				})
+			})
+		})
+
 		when("a valid lifecycle-image is provided", func() {
 			when("only the image repo is provided", func() {
 				it("uses the provided lifecycle-image and parses it correctly", func() {
@@ -844,6 +915,451 @@ builder = "my-builder"
 			})
 		})
 
+		when("provenance destination directory is provided", func() {
+			it("forwards the directory onto the client", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithProvenance("some-output-dir", false)).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--provenance-output-dir", "some-output-dir"})
+				h.AssertNil(t, command.Execute())
+			})
+		})
+
+		when("--attach-provenance", func() {
+			when("--publish is not used", func() {
+				it("errors", func() {
+					command.SetArgs([]string{"image", "--builder", "my-builder", "--attach-provenance"})
+					h.AssertError(t, command.Execute(), "attach-provenance flag requires the publish flag")
+				})
+			})
+
+			when("--publish is used", func() {
+				it("forwards the option onto the client", func() {
+					mockClient.EXPECT().
+						Build(gomock.Any(), EqBuildOptionsWithProvenance("", true)).
+						Return(nil)
+
+					command.SetArgs([]string{"image", "--builder", "my-builder", "--attach-provenance", "--publish"})
+					h.AssertNil(t, command.Execute())
+				})
+			})
+		})
+
+		when("--sign-key", func() {
+			var privateKeyPath string
+
+			it.Before(func() {
+				privateKeyPath, _ = writeSigningKeys(t, t.TempDir())
+			})
+
+			when("--publish is not used", func() {
+				it("errors", func() {
+					command.SetArgs([]string{"image", "--builder", "my-builder", "--sign-key", privateKeyPath})
+					h.AssertError(t, command.Execute(), "sign-key flag requires the publish flag")
+				})
+			})
+
+			when("--publish is used", func() {
+				it("forwards the signer onto the client", func() {
+					mockClient.EXPECT().
+						Build(gomock.Any(), EqBuildOptionsWithSigning(true, false)).
+						Return(nil)
+
+					command.SetArgs([]string{"image", "--builder", "my-builder", "--sign-key", privateKeyPath, "--publish"})
+					h.AssertNil(t, command.Execute())
+				})
+			})
+
+			when("the key cannot be loaded", func() {
+				it("errors", func() {
+					command.SetArgs([]string{"image", "--builder", "my-builder", "--sign-key", filepath.Join(t.TempDir(), "missing.key"), "--publish"})
+					h.AssertError(t, command.Execute(), "loading signing key")
+				})
+			})
+		})
+
+		when("--verify-key", func() {
+			it("forwards the verifier onto the client", func() {
+				_, publicKeyPath := writeSigningKeys(t, t.TempDir())
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithSigning(false, true)).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--verify-key", publicKeyPath})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("errors when the key is not a public key", func() {
+				privateKeyPath, _ := writeSigningKeys(t, t.TempDir())
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--verify-key", privateKeyPath})
+				h.AssertError(t, command.Execute(), "loading verification keys")
+			})
+		})
+
+		when("--output-format", func() {
+			when("json", func() {
+				it("writes build events as JSON lines instead of logs", func() {
+					mockClient.EXPECT().
+						Build(gomock.Any(), EqBuildOptionsWithEvents(true)).
+						DoAndReturn(func(_ context.Context, opts client.BuildOptions) error {
+							opts.Events.Emit(events.Event{Type: events.Image, Image: "image", Digest: "sha256:some-digest"})
+							return nil
+						})
+
+					command.SetArgs([]string{"image", "--builder", "my-builder", "--output-format", "json"})
+					h.AssertNil(t, command.Execute())
+
+					var event events.Event
+					h.AssertNil(t, json.Unmarshal(outBuf.Bytes(), &event))
+					h.AssertEq(t, event.Type, events.Image)
+					h.AssertEq(t, event.Digest, "sha256:some-digest")
+					h.AssertNotContains(t, outBuf.String(), "Successfully built image")
+				})
+
+				it("logs warnings to stderr, keeping stdout for the events", func() {
+					var errBuf bytes.Buffer
+					outBuf.Reset()
+					logger = logging.NewLogWithWriters(&outBuf, &errBuf)
+					command = commands.Build(logger, cfg, mockClient)
+
+					mockClient.EXPECT().
+						Build(gomock.Any(), EqBuildOptionsWithEvents(true)).
+						DoAndReturn(func(_ context.Context, opts client.BuildOptions) error {
+							opts.Events.Emit(events.Event{Type: events.Image, Image: "image", Digest: "sha256:some-digest"})
+							return nil
+						})
+
+					command.SetArgs([]string{"image", "--builder", "my-builder", "--output-format", "json", "--volume", "a:b"})
+					h.AssertNil(t, command.Execute())
+
+					var event events.Event
+					h.AssertNil(t, json.Unmarshal(outBuf.Bytes(), &event))
+					h.AssertEq(t, event.Type, events.Image)
+					h.AssertContains(t, errBuf.String(), "Warning: Using untrusted builder with volume mounts")
+				})
+
+				it("errors in interactive mode", func() {
+					cfg := config.Config{Experimental: true}
+					command = commands.Build(logger, cfg, mockClient)
+
+					command.SetArgs([]string{"image", "--builder", "my-builder", "--output-format", "json", "--interactive"})
+					h.AssertError(t, command.Execute(), "output-format flag cannot be json in interactive mode")
+				})
+			})
+
+			when("not provided", func() {
+				it("does not request events", func() {
+					mockClient.EXPECT().
+						Build(gomock.Any(), EqBuildOptionsWithEvents(false)).
+						Return(nil)
+
+					command.SetArgs([]string{"image", "--builder", "my-builder"})
+					h.AssertNil(t, command.Execute())
+				})
+			})
+
+			when("unknown", func() {
+				it("errors", func() {
+					command.SetArgs([]string{"image", "--builder", "my-builder", "--output-format", "yaml"})
+					h.AssertError(t, command.Execute(), "output-format must be one of 'human-readable' or 'json'")
+				})
+			})
+		})
+
+		when("--explain-detect", func() {
+			it("requests an explanation of detection", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithExplainDetect(true)).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--explain-detect"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("errors in interactive mode", func() {
+				cfg := config.Config{Experimental: true}
+				command = commands.Build(logger, cfg, mockClient)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--explain-detect", "--interactive"})
+				h.AssertError(t, command.Execute(), "explain-detect flag cannot be used in interactive mode")
+			})
+		})
+
+		when("--timings", func() {
+			it("requests a summary of the phase timings", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithTimings(true)).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--timings"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("doesn't request the timings by default", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithTimings(false)).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("errors in interactive mode", func() {
+				cfg := config.Config{Experimental: true}
+				command = commands.Build(logger, cfg, mockClient)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--timings", "--interactive"})
+				h.AssertError(t, command.Execute(), "timings flag cannot be used in interactive mode")
+			})
+		})
+
+		when("--watch", func() {
+			it("watches the app instead of building once", func() {
+				mockClient.EXPECT().
+					Watch(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, opts client.WatchOptions) error {
+						h.AssertEq(t, opts.BuildOptions.Image, "image")
+						h.AssertEq(t, opts.BuildOptions.Builder, "my-builder")
+						h.AssertEq(t, opts.Debounce, 2*time.Second)
+						h.AssertEq(t, opts.RunContainer, true)
+						h.AssertEq(t, opts.RunPorts, []string{"8080:8080"})
+						return nil
+					})
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--watch", "--watch-debounce", "2s", "--watch-run", "--watch-run-port", "8080:8080"})
+				h.AssertNil(t, command.Execute())
+				h.AssertNotContains(t, outBuf.String(), "Successfully built image")
+			})
+
+			it("defaults the debounce to 500ms", func() {
+				mockClient.EXPECT().
+					Watch(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, opts client.WatchOptions) error {
+						h.AssertEq(t, opts.Debounce, 500*time.Millisecond)
+						h.AssertEq(t, opts.RunContainer, false)
+						return nil
+					})
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--watch"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("errors when --watch-run is used without --watch", func() {
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--watch-run"})
+				h.AssertError(t, command.Execute(), "watch-run and watch-run-port flags require the watch flag")
+			})
+
+			it("errors when --watch-debounce is used without --watch", func() {
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--watch-debounce", "2s"})
+				h.AssertError(t, command.Execute(), "watch-debounce flag requires the watch flag")
+			})
+
+			it("errors when --watch-run-port is used without --watch-run", func() {
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--watch", "--watch-run-port", "8080"})
+				h.AssertError(t, command.Execute(), "watch-run-port flag requires the watch-run flag")
+			})
+
+			it("errors when --watch-run is used with --publish", func() {
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--watch", "--watch-run", "--publish"})
+				h.AssertError(t, command.Execute(), "watch-run flag cannot be used with the publish flag")
+			})
+
+			it("errors in interactive mode", func() {
+				cfg := config.Config{Experimental: true}
+				command = commands.Build(logger, cfg, mockClient)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--watch", "--interactive"})
+				h.AssertError(t, command.Execute(), "watch flag cannot be used in interactive mode")
+			})
+		})
+
+		when("--secret", func() {
+			var secretPath string
+
+			it.Before(func() {
+				secretPath = filepath.Join(t.TempDir(), ".npmrc")
+				h.AssertNil(t, os.WriteFile(secretPath, []byte("some-token"), 0600))
+			})
+
+			it("reads secrets from files and environment variables", func() {
+				h.AssertNil(t, os.Setenv("SOME_SECRET_VAR", "some-value"))
+				defer os.Unsetenv("SOME_SECRET_VAR")
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithSecrets(map[string]string{
+						"npmrc":       "some-token",
+						".npmrc":      "some-token",
+						"some-secret": "some-value",
+					})).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder",
+					"--secret", "id=npmrc,src=" + secretPath,
+					"--secret", "src=" + secretPath,
+					"--secret", "id=some-secret,env=SOME_SECRET_VAR",
+				})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("errors when the file doesn't exist", func() {
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--secret", "id=npmrc,src=/does/not/exist"})
+				h.AssertError(t, command.Execute(), "reading secret 'npmrc'")
+			})
+
+			it("errors when the environment variable isn't set", func() {
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--secret", "id=npmrc,env=SOME_UNSET_SECRET_VAR"})
+				h.AssertError(t, command.Execute(), "environment variable 'SOME_UNSET_SECRET_VAR' of secret 'npmrc' is not set")
+			})
+
+			it("errors when the id is not a file name", func() {
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--secret", "id=../npmrc,src=" + secretPath})
+				h.AssertError(t, command.Execute(), "id must be a file name")
+			})
+
+			it("errors without a source", func() {
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--secret", "id=npmrc"})
+				h.AssertError(t, command.Execute(), "exactly one of src or env must be set")
+			})
+
+			it("errors with an unknown field", func() {
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--secret", "id=npmrc,target=/some/path"})
+				h.AssertError(t, command.Execute(), "unknown field 'target'")
+			})
+
+			it("errors when a secret is set more than once", func() {
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--secret", "id=npmrc,src=" + secretPath, "--secret", "id=npmrc,src=" + secretPath})
+				h.AssertError(t, command.Execute(), "secret 'npmrc' is set more than once")
+			})
+		})
+
+		when("--binding", func() {
+			it("passes the bindings to the client", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithBindings([]projectTypes.Binding{
+						{Name: "db", Type: "postgresql", Path: filepath.Join("some", "bindings", "db")},
+						{Name: "cache", Type: "redis", Path: filepath.Join("some", "bindings", "cache")},
+					})).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder",
+					"--binding", "db=postgresql:" + filepath.Join("some", "bindings", "db"),
+					"--binding", "cache=redis:" + filepath.Join("some", "bindings", "cache"),
+				})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("errors when the binding has no type", func() {
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--binding", "db=some/bindings/db"})
+				h.AssertError(t, command.Execute(), "invalid binding 'db=some/bindings/db': must be in the form '<name>=<type>:<path>'")
+			})
+
+			it("errors when a binding is set more than once", func() {
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--binding", "db=postgresql:some/db", "--binding", "db=mysql:some/other-db"})
+				h.AssertError(t, command.Execute(), "binding 'db' is set more than once")
+			})
+		})
+
+		when("--executor", func() {
+			it("uses the docker daemon by default", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithExecutor(false)).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			when("kubernetes", func() {
+				it.Before(func() {
+					kubeconfig := filepath.Join(t.TempDir(), "kubeconfig")
+					h.AssertNil(t, os.WriteFile(kubeconfig, []byte(`apiVersion: v1
+kind: Config
+clusters:
+- name: some-cluster
+  cluster:
+    server: https://some-cluster.example.com
+contexts:
+- name: some-context
+  context:
+    cluster: some-cluster
+    user: some-user
+    namespace: some-namespace
+current-context: some-context
+users:
+- name: some-user
+  user:
+    token: some-token
+`), 0600))
+					h.AssertNil(t, os.Setenv("KUBECONFIG", kubeconfig))
+				})
+
+				it.After(func() {
+					h.AssertNil(t, os.Unsetenv("KUBECONFIG"))
+				})
+
+				it("forwards an executor for the cluster of the kubeconfig onto the client", func() {
+					mockClient.EXPECT().
+						Build(gomock.Any(), EqBuildOptionsWithExecutor(true)).
+						Return(nil)
+
+					command.SetArgs([]string{"image", "--builder", "my-builder", "--executor", "kubernetes", "--publish"})
+					h.AssertNil(t, command.Execute())
+				})
+
+				it("errors without --publish", func() {
+					command.SetArgs([]string{"image", "--builder", "my-builder", "--executor", "kubernetes"})
+					h.AssertError(t, command.Execute(), "kubernetes executor requires the publish flag")
+				})
+
+				it("errors with --volume", func() {
+					command.SetArgs([]string{"image", "--builder", "my-builder", "--executor", "kubernetes", "--publish", "--volume", "/a:/b"})
+					h.AssertError(t, command.Execute(), "docker-host, volume and interactive flags cannot be used with the kubernetes executor")
+				})
+			})
+
+			when("oci", func() {
+				it.Before(func() {
+					cfg = config.Config{Experimental: true}
+					command = commands.Build(logger, cfg, mockClient)
+				})
+
+				it("forwards an executor for the OCI runtime onto the client", func() {
+					mockClient.EXPECT().
+						Build(gomock.Any(), EqBuildOptionsWithExecutor(true)).
+						Return(nil)
+
+					command.SetArgs([]string{"oci:image", "--builder", "my-builder", "--executor", "oci", "--oci-runtime", "crun"})
+					h.AssertNil(t, command.Execute())
+				})
+
+				it("errors when the image is not exported to OCI layout", func() {
+					command.SetArgs([]string{"image", "--builder", "my-builder", "--executor", "oci"})
+					h.AssertError(t, command.Execute(), "oci executor requires the image to be exported to OCI layout")
+				})
+
+				it("errors with --publish", func() {
+					command.SetArgs([]string{"oci:image", "--builder", "my-builder", "--executor", "oci", "--publish"})
+					h.AssertError(t, command.Execute(), "oci executor requires the image to be exported to OCI layout")
+				})
+			})
+
+			it("errors with an unknown executor", func() {
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--executor", "some-executor"})
+				h.AssertError(t, command.Execute(), "executor must be one of 'docker', 'kubernetes' or 'oci'")
+			})
+
+			it("errors when --oci-runtime is used without the oci executor", func() {
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--oci-runtime", "crun"})
+				h.AssertError(t, command.Execute(), "oci-runtime flag requires the oci executor")
+			})
+
+			it("errors when --kubernetes-namespace is used without the kubernetes executor", func() {
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--kubernetes-namespace", "some-namespace"})
+				h.AssertError(t, command.Execute(), "kubernetes-namespace flag requires the kubernetes executor")
+			})
+		})
+
 		when("--creation-time", func() {
 			when("provided as 'now'", func() {
 				it("passes it to the builder", func() {
@@ -1068,6 +1584,32 @@ func EqBuildOptionsWithProjectDescriptor(descriptor projectTypes.Descriptor) gom
 	}
 }
 
+func EqBuildOptionsWithSecrets(secrets map[string]string) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("Secrets=%+v", secrets),
+		equals: func(o client.BuildOptions) bool {
+			if len(o.Secrets) != len(secrets) {
+				return false
+			}
+			for id, value := range secrets {
+				if string(o.Secrets[id]) != value {
+					return false
+				}
+			}
+			return true
+		},
+	}
+}
+
+func EqBuildOptionsWithBindings(bindings []projectTypes.Binding) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("Bindings=%+v", bindings),
+		equals: func(o client.BuildOptions) bool {
+			return reflect.DeepEqual(o.Bindings, bindings)
+		},
+	}
+}
+
 func EqBuildOptionsWithEnv(env map[string]string) gomock.Matcher {
 	return buildOptionsMatcher{
 		description: fmt.Sprintf("Env=%+v", env),
@@ -1114,6 +1656,72 @@ func EqBuildOptionsWithSBOMOutputDir(s string) interface{} {
 	}
 }
 
+func EqBuildOptionsWithProvenance(dir string, attach bool) interface{} {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("provenance-destination-dir=%s attach-provenance=%t", dir, attach),
+		equals: func(o client.BuildOptions) bool {
+			return o.ProvenanceDestinationDir == dir && o.AttachProvenance == attach
+		},
+	}
+}
+
+func EqBuildOptionsWithSigning(signer, verifier bool) interface{} {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("signer=%t verifier=%t", signer, verifier),
+		equals: func(o client.BuildOptions) bool {
+			return (o.Signer != nil) == signer && (o.Verifier != nil) == verifier
+		},
+	}
+}
+
+func EqBuildOptionsWithExecutor(set bool) interface{} {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("executor=%t", set),
+		equals: func(o client.BuildOptions) bool {
+			return (o.Executor != nil) == set
+		},
+	}
+}
+
+func EqBuildOptionsWithEvents(requested bool) interface{} {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("events=%t", requested),
+		equals: func(o client.BuildOptions) bool {
+			return (o.Events != nil) == requested
+		},
+	}
+}
+
+func EqBuildOptionsWithExplainDetect(explain bool) interface{} {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("explain-detect=%t", explain),
+		equals: func(o client.BuildOptions) bool {
+			return o.ExplainDetect == explain
+		},
+	}
+}
+
+func EqBuildOptionsWithTimings(timings bool) interface{} {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("timings=%t", timings),
+		equals: func(o client.BuildOptions) bool {
+			return o.Timings == timings
+		},
+	}
+}
+
+func EqBuildOptionsWithTrustedBuilderPolicy(digest string, verifier bool) interface{} {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("trusted-builder-policy digest=%s verifier=%t", digest, verifier),
+		equals: func(o client.BuildOptions) bool {
+			if o.TrustedBuilderPolicy == nil {
+				return digest == "" && !verifier
+			}
+			return o.TrustedBuilderPolicy.Digest == digest && (o.TrustedBuilderPolicy.Verifier != nil) == verifier
+		},
+	}
+}
+
 func EqBuildOptionsWithDateTime(t *time.Time) interface{} {
 	return buildOptionsMatcher{
 		description: fmt.Sprintf("CreationTime=%s", t),
@@ -1157,3 +1765,22 @@ func (m buildOptionsMatcher) Matches(x interface{}) bool {
 func (m buildOptionsMatcher) String() string {
 	return "is a BuildOptions with " + m.description
 }
+
+func writeSigningKeys(t *testing.T, dir string) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	h.AssertNil(t, err)
+
+	privateDER, err := x509.MarshalPKCS8PrivateKey(key)
+	h.AssertNil(t, err)
+	privateKeyPath := filepath.Join(dir, "cosign.key")
+	h.AssertNil(t, os.WriteFile(privateKeyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER}), 0600))
+
+	publicDER, err := x509.MarshalPKIXPublicKey(key.Public())
+	h.AssertNil(t, err)
+	publicKeyPath := filepath.Join(dir, "cosign.pub")
+	h.AssertNil(t, os.WriteFile(publicKeyPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}), 0600))
+
+	return privateKeyPath, publicKeyPath
+}
@@ -25,6 +25,7 @@ type BuilderCreateFlags struct {
 	Flatten         []string
 	Targets         []string
 	Label           map[string]string
+	SignKey         string
 }
 
 // CreateBuilder creates a builder image, based on a builder config
@@ -97,6 +98,11 @@ Creating a custom builder allows you to control what buildpacks are used and wha
 				logger.Infof("Pro tip: use --targets flag OR [[targets]] in builder.toml to specify the desired platform")
 			}
 
+			signer, err := loadSigner(flags.SignKey)
+			if err != nil {
+				return err
+			}
+
 			imageName := args[0]
 			if err := pack.CreateBuilder(cmd.Context(), client.CreateBuilderOptions{
 				RelativeBaseDir: relativeBaseDir,
@@ -109,6 +115,7 @@ Creating a custom builder allows you to control what buildpacks are used and wha
 				Flatten:         toFlatten,
 				Labels:          flags.Label,
 				Targets:         multiArchCfg.Targets(),
+				Signer:          signer,
 			}); err != nil {
 				return err
 			}
@@ -127,6 +134,7 @@ Creating a custom builder allows you to control what buildpacks are used and wha
 	cmd.Flags().StringVar(&flags.Policy, "pull-policy", "", "Pull policy to use. Accepted values are always, never, and if-not-present. The default is always")
 	cmd.Flags().StringArrayVar(&flags.Flatten, "flatten", nil, "List of buildpacks to flatten together into a single layer (format: '<buildpack-id>@<buildpack-version>,<buildpack-id>@<buildpack-version>'")
 	cmd.Flags().StringToStringVarP(&flags.Label, "label", "l", nil, "Labels to add to the builder image, in the form of '<name>=<value>'")
+	cmd.Flags().StringVar(&flags.SignKey, "sign-key", "", "Path to a cosign-compatible private key to sign the published builder with. Encrypted keys are decrypted with the password in the COSIGN_PASSWORD environment variable. Requires --publish")
 	cmd.Flags().StringSliceVarP(&flags.Targets, "target", "t", nil,
 		`Target platforms to build for.\nTargets should be in the format '[os][/arch][/variant]:[distroname@osversion@anotherversion];[distroname@osversion]'.
 - To specify two different architectures:  '--target "linux/amd64" --target "linux/arm64"'
@@ -155,5 +163,9 @@ func validateCreateFlags(flags *BuilderCreateFlags, cfg config.Config) error {
 		return errors.Errorf("Please provide a builder config path, using --config.")
 	}
 
+	if flags.SignKey != "" && !flags.Publish {
+		return errors.New("sign-key flag requires the publish flag")
+	}
+
 	return nil
 }
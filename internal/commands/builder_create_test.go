@@ -223,6 +223,17 @@ func testCreateCommand(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("--sign-key is specified without --publish", func() {
+			it("errors with a descriptive message", func() {
+				command.SetArgs([]string{
+					"some/builder",
+					"--config", "some-config-path",
+					"--sign-key", "some-key-path",
+				})
+				h.AssertError(t, command.Execute(), "sign-key flag requires the publish flag")
+			})
+		})
+
 		when("--pull-policy", func() {
 			it("returns error for unknown policy", func() {
 				command.SetArgs([]string{
@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/builder"
+	"github.com/buildpacks/pack/internal/builder/writer"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type BuilderDiffFlags struct {
+	OutputFormat string
+}
+
+// BuilderDiff compares two builders
+func BuilderDiff(logger logging.Logger, inspector BuilderInspector, writerFactory writer.BuilderDiffWriterFactory) *cobra.Command {
+	var flags BuilderDiffFlags
+	cmd := &cobra.Command{
+		Use:     "diff <old-builder-image-name> <new-builder-image-name>",
+		Args:    cobra.ExactArgs(2),
+		Short:   "Show what changed from one builder to another",
+		Example: "pack builder diff cnbs/sample-builder:jammy-v1 cnbs/sample-builder:jammy-v2",
+		Long: "Compare two builders, reporting changes to the lifecycle version and APIs, run images and their mirrors, " +
+			"buildpack and extension versions, detection order, build config env, labels and targets.\n" +
+			"Builders are read from the daemon if present there, otherwise from the registry. The build config env is only " +
+			"known for builders created by versions of pack recording it.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			w, err := writerFactory.DiffWriter(flags.OutputFormat)
+			if err != nil {
+				return err
+			}
+
+			from, err := inspectBuilderForDiff(inspector, args[0])
+			if err != nil {
+				return err
+			}
+			to, err := inspectBuilderForDiff(inspector, args[1])
+			if err != nil {
+				return err
+			}
+
+			return w.Print(logger, writer.NewDiffDisplay(args[0], from, args[1], to))
+		}),
+	}
+
+	cmd.Flags().StringVarP(&flags.OutputFormat, "output", "o", "human-readable", "Output format to display the differences (json, yaml, human-readable).\nOmission of this flag will display as human-readable.")
+	AddHelpFlag(cmd, "diff")
+	return cmd
+}
+
+// inspectBuilderForDiff inspects the builder in the daemon, or in the registry if the daemon does not have it. Its
+// detection order is not expanded, as changes to composite buildpacks are reported as buildpack changes.
+func inspectBuilderForDiff(inspector BuilderInspector, name string) (*client.BuilderInfo, error) {
+	depth := client.WithDetectionOrderDepth(builder.OrderDetectionNone)
+	info, err := inspector.InspectBuilder(name, true, depth)
+	if err != nil {
+		return nil, errors.Wrapf(err, "inspecting builder %s", style.Symbol(name))
+	}
+	if info != nil {
+		return info, nil
+	}
+
+	info, err = inspector.InspectBuilder(name, false, depth)
+	if err != nil {
+		return nil, errors.Wrapf(err, "inspecting builder %s", style.Symbol(name))
+	}
+	if info == nil {
+		return nil, errors.Errorf("unable to find builder %s locally or remotely", style.Symbol(name))
+	}
+	return info, nil
+}
@@ -0,0 +1,125 @@
+package commands_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/builder/writer"
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestBuilderDiffCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "BuilderDiffCommand", testBuilderDiffCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testBuilderDiffCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		logger         logging.Logger
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+
+		oldInfo = &client.BuilderInfo{
+			Lifecycle:  minimalLifecycleDescriptor,
+			Buildpacks: []dist.ModuleInfo{{ID: "test.bp.one", Version: "1.0.0"}},
+		}
+		newInfo = &client.BuilderInfo{
+			Lifecycle:  minimalLifecycleDescriptor,
+			Buildpacks: []dist.ModuleInfo{{ID: "test.bp.one", Version: "1.1.0"}},
+		}
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		command = commands.BuilderDiff(logger, mockClient, writer.NewFactory())
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#BuilderDiff", func() {
+		when("both builders are in the daemon", func() {
+			it.Before(func() {
+				mockClient.EXPECT().InspectBuilder("some/builder:v1", true, gomock.Any()).Return(oldInfo, nil)
+				mockClient.EXPECT().InspectBuilder("some/builder:v2", true, gomock.Any()).Return(newInfo, nil)
+			})
+
+			it("prints the differences", func() {
+				command.SetArgs([]string{"some/builder:v1", "some/builder:v2"})
+				h.AssertNil(t, command.Execute())
+
+				h.AssertContains(t, outBuf.String(), "Comparing builder 'some/builder:v1' to 'some/builder:v2'")
+				h.AssertContainsMatch(t, outBuf.String(), `test.bp.one\s+changed\s+1.0.0\s+1.1.0`)
+			})
+
+			when("--output is json", func() {
+				it("prints the differences as JSON", func() {
+					command.SetArgs([]string{"some/builder:v1", "some/builder:v2", "--output", "json"})
+					h.AssertNil(t, command.Execute())
+
+					h.AssertContainsMatch(t, outBuf.String(), `"id":\s+"test.bp.one",\s+"change":\s+"changed"`)
+				})
+			})
+		})
+
+		when("a builder is not in the daemon", func() {
+			it("inspects it in the registry", func() {
+				mockClient.EXPECT().InspectBuilder("some/builder:v1", true, gomock.Any()).Return(oldInfo, nil)
+				mockClient.EXPECT().InspectBuilder("some/builder:v2", true, gomock.Any()).Return(nil, nil)
+				mockClient.EXPECT().InspectBuilder("some/builder:v2", false, gomock.Any()).Return(newInfo, nil)
+
+				command.SetArgs([]string{"some/builder:v1", "some/builder:v2"})
+				h.AssertNil(t, command.Execute())
+
+				h.AssertContainsMatch(t, outBuf.String(), `test.bp.one\s+changed\s+1.0.0\s+1.1.0`)
+			})
+		})
+
+		when("a builder cannot be found", func() {
+			it("returns an error", func() {
+				mockClient.EXPECT().InspectBuilder("some/builder:v1", true, gomock.Any()).Return(nil, nil)
+				mockClient.EXPECT().InspectBuilder("some/builder:v1", false, gomock.Any()).Return(nil, nil)
+
+				command.SetArgs([]string{"some/builder:v1", "some/builder:v2"})
+				err := command.Execute()
+				h.AssertError(t, err, "unable to find builder 'some/builder:v1' locally or remotely")
+			})
+		})
+
+		when("inspecting a builder fails", func() {
+			it("returns an error", func() {
+				mockClient.EXPECT().InspectBuilder("some/builder:v1", true, gomock.Any()).Return(nil, errors.New("some error"))
+
+				command.SetArgs([]string{"some/builder:v1", "some/builder:v2"})
+				err := command.Execute()
+				h.AssertError(t, err, "inspecting builder 'some/builder:v1': some error")
+			})
+		})
+
+		when("the output format is not supported", func() {
+			it("returns an error", func() {
+				command.SetArgs([]string{"some/builder:v1", "some/builder:v2", "--output", "toml"})
+				err := command.Execute()
+				h.AssertError(t, err, "output format 'toml' is not supported when comparing builders")
+			})
+		})
+	})
+}
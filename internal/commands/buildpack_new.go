@@ -22,9 +22,11 @@ type BuildpackNewFlags struct {
 	API  string
 	Path string
 	// Deprecated: Stacks are deprecated
-	Stacks  []string
-	Targets []string
-	Version string
+	Stacks   []string
+	Targets  []string
+	Version  string
+	Template string
+	Package  bool
 }
 
 // BuildpackCreator creates buildpacks
@@ -40,7 +42,9 @@ func BuildpackNew(logger logging.Logger, creator BuildpackCreator) *cobra.Comman
 		Short:   "Creates basic scaffolding of a buildpack.",
 		Args:    cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
 		Example: "pack buildpack new sample/my-buildpack",
-		Long:    "buildpack new generates the basic scaffolding of a buildpack repository. It creates a new directory `name` in the current directory (or at `path`, if passed as a flag), and initializes a buildpack.toml, and two executable bash scripts, `bin/detect` and `bin/build`. ",
+		Long: "buildpack new generates the basic scaffolding of a buildpack repository. It creates a new directory `name` in the current directory (or at `path`, if passed as a flag), and initializes a buildpack.toml, and the files of `template`: " +
+			"two executable bash scripts, `bin/detect` and `bin/build`, by default. The `go` template lays out a libcnb buildpack built by `scripts/build.sh`, and the `exec.d` template contributes exec.d and profile.d scripts to the app image. " +
+			"`template` may also be a local directory or a git repository URL, whose files ending in .tmpl are rendered as Go templates given the .ID, .Version, .API, .Name and .GoPackage of the buildpack.",
 		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
 			id := args[0]
 			idParts := strings.Split(id, "/")
@@ -83,12 +87,14 @@ func BuildpackNew(logger logging.Logger, creator BuildpackCreator) *cobra.Comman
 			}
 
 			if err := creator.NewBuildpack(cmd.Context(), client.NewBuildpackOptions{
-				API:     flags.API,
-				ID:      id,
-				Path:    path,
-				Stacks:  stacks,
-				Targets: targets,
-				Version: flags.Version,
+				API:      flags.API,
+				ID:       id,
+				Path:     path,
+				Stacks:   stacks,
+				Targets:  targets,
+				Version:  flags.Version,
+				Template: flags.Template,
+				Package:  flags.Package,
 			}); err != nil {
 				return err
 			}
@@ -101,6 +107,8 @@ func BuildpackNew(logger logging.Logger, creator BuildpackCreator) *cobra.Comman
 	cmd.Flags().StringVarP(&flags.API, "api", "a", "0.8", "Buildpack API compatibility of the generated buildpack")
 	cmd.Flags().StringVarP(&flags.Path, "path", "p", "", "Path to generate the buildpack")
 	cmd.Flags().StringVarP(&flags.Version, "version", "V", "1.0.0", "Version of the generated buildpack")
+	cmd.Flags().StringVarP(&flags.Template, "template", "T", client.DefaultScaffoldTemplate, "Template of the generated buildpack: bash, go, exec.d, a directory or a git repository URL")
+	cmd.Flags().BoolVar(&flags.Package, "package", false, "Generate a package.toml packaging the buildpack for its targets")
 	cmd.Flags().StringSliceVarP(&flags.Stacks, "stacks", "s", nil, "Stack(s) this buildpack will be compatible with"+stringSliceHelp("stack"))
 	cmd.Flags().MarkDeprecated("stacks", "prefer `--targets` instead: https://github.com/buildpacks/rfcs/blob/main/text/0096-remove-stacks-mixins.md")
 	cmd.Flags().StringSliceVarP(&flags.Targets, "targets", "t", nil,
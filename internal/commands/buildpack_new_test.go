@@ -61,11 +61,12 @@ func testBuildpackNewCommand(t *testing.T, when spec.G, it spec.S) {
 	when("BuildpackNew#Execute", func() {
 		it("uses the args to generate artifacts", func() {
 			mockClient.EXPECT().NewBuildpack(gomock.Any(), client.NewBuildpackOptions{
-				API:     "0.8",
-				ID:      "example/some-cnb",
-				Path:    filepath.Join(tmpDir, "some-cnb"),
-				Version: "1.0.0",
-				Targets: targets,
+				API:      "0.8",
+				ID:       "example/some-cnb",
+				Path:     filepath.Join(tmpDir, "some-cnb"),
+				Version:  "1.0.0",
+				Template: "bash",
+				Targets:  targets,
 			}).Return(nil).MaxTimes(1)
 
 			path := filepath.Join(tmpDir, "some-cnb")
@@ -75,6 +76,24 @@ func testBuildpackNewCommand(t *testing.T, when spec.G, it spec.S) {
 			h.AssertNil(t, err)
 		})
 
+		it("passes the template and package flags", func() {
+			mockClient.EXPECT().NewBuildpack(gomock.Any(), client.NewBuildpackOptions{
+				API:      "0.8",
+				ID:       "example/some-cnb",
+				Path:     filepath.Join(tmpDir, "some-cnb"),
+				Version:  "1.0.0",
+				Targets:  targets,
+				Template: "go",
+				Package:  true,
+			}).Return(nil).MaxTimes(1)
+
+			path := filepath.Join(tmpDir, "some-cnb")
+			command.SetArgs([]string{"--path", path, "example/some-cnb", "--template", "go", "--package"})
+
+			err := command.Execute()
+			h.AssertNil(t, err)
+		})
+
 		it("stops if the directory already exists", func() {
 			err := os.MkdirAll(tmpDir, 0600)
 			h.AssertNil(t, err)
@@ -88,10 +107,11 @@ func testBuildpackNewCommand(t *testing.T, when spec.G, it spec.S) {
 		when("target flag is specified, ", func() {
 			it("it uses target to generate artifacts", func() {
 				mockClient.EXPECT().NewBuildpack(gomock.Any(), client.NewBuildpackOptions{
-					API:     "0.8",
-					ID:      "example/targets",
-					Path:    filepath.Join(tmpDir, "targets"),
-					Version: "1.0.0",
+					API:      "0.8",
+					ID:       "example/targets",
+					Path:     filepath.Join(tmpDir, "targets"),
+					Version:  "1.0.0",
+					Template: "bash",
 					Targets: []dist.Target{{
 						OS:          "linux",
 						Arch:        "arm",
@@ -111,10 +131,11 @@ func testBuildpackNewCommand(t *testing.T, when spec.G, it spec.S) {
 			})
 			it("it should show error when invalid [os]/[arch] passed", func() {
 				mockClient.EXPECT().NewBuildpack(gomock.Any(), client.NewBuildpackOptions{
-					API:     "0.8",
-					ID:      "example/targets",
-					Path:    filepath.Join(tmpDir, "targets"),
-					Version: "1.0.0",
+					API:      "0.8",
+					ID:       "example/targets",
+					Path:     filepath.Join(tmpDir, "targets"),
+					Version:  "1.0.0",
+					Template: "bash",
 					Targets: []dist.Target{{
 						OS:          "os",
 						Arch:        "arm",
@@ -138,10 +159,11 @@ func testBuildpackNewCommand(t *testing.T, when spec.G, it spec.S) {
 			when("it should", func() {
 				it("support format [os][/arch][/variant]:[name@version];[some-name@version]", func() {
 					mockClient.EXPECT().NewBuildpack(gomock.Any(), client.NewBuildpackOptions{
-						API:     "0.8",
-						ID:      "example/targets",
-						Path:    filepath.Join(tmpDir, "targets"),
-						Version: "1.0.0",
+						API:      "0.8",
+						ID:       "example/targets",
+						Path:     filepath.Join(tmpDir, "targets"),
+						Version:  "1.0.0",
+						Template: "bash",
 						Targets: []dist.Target{
 							{
 								OS:          "linux",
@@ -181,10 +203,11 @@ func testBuildpackNewCommand(t *testing.T, when spec.G, it spec.S) {
 			when("stacks ", func() {
 				it("flag should show deprecated message when used", func() {
 					mockClient.EXPECT().NewBuildpack(gomock.Any(), client.NewBuildpackOptions{
-						API:     "0.8",
-						ID:      "example/stacks",
-						Path:    filepath.Join(tmpDir, "stacks"),
-						Version: "1.0.0",
+						API:      "0.8",
+						ID:       "example/stacks",
+						Path:     filepath.Join(tmpDir, "stacks"),
+						Version:  "1.0.0",
+						Template: "bash",
 						Stacks: []dist.Stack{{
 							ID:     "io.buildpacks.stacks.jammy",
 							Mixins: []string{},
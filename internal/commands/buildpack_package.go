@@ -25,6 +25,7 @@ type BuildpackPackageFlags struct {
 	Policy            string
 	BuildpackRegistry string
 	Path              string
+	SignKey           string
 	FlattenExclude    []string
 	Targets           []string
 	Label             map[string]string
@@ -130,6 +131,11 @@ func BuildpackPackage(logger logging.Logger, cfg config.Config, packager Buildpa
 				defer clean(filesToClean)
 			}
 
+			signer, err := loadSigner(flags.SignKey)
+			if err != nil {
+				return err
+			}
+
 			if err := packager.PackageBuildpack(cmd.Context(), client.PackageBuildpackOptions{
 				RelativeBaseDir: relativeBaseDir,
 				Name:            name,
@@ -142,6 +148,7 @@ func BuildpackPackage(logger logging.Logger, cfg config.Config, packager Buildpa
 				FlattenExclude:  flags.FlattenExclude,
 				Labels:          flags.Label,
 				Targets:         multiArchCfg.Targets(),
+				Signer:          signer,
 			}); err != nil {
 				return err
 			}
@@ -169,6 +176,7 @@ func BuildpackPackage(logger logging.Logger, cfg config.Config, packager Buildpa
 	cmd.Flags().BoolVar(&flags.Flatten, "flatten", false, "Flatten the buildpack into a single layer")
 	cmd.Flags().StringSliceVarP(&flags.FlattenExclude, "flatten-exclude", "e", nil, "Buildpacks to exclude from flattening, in the form of '<buildpack-id>@<buildpack-version>'")
 	cmd.Flags().StringToStringVarP(&flags.Label, "label", "l", nil, "Labels to add to packaged Buildpack, in the form of '<name>=<value>'")
+	cmd.Flags().StringVar(&flags.SignKey, "sign-key", "", "Path to a cosign-compatible private key to sign the published package with. Encrypted keys are decrypted with the password in the COSIGN_PASSWORD environment variable. Requires --publish")
 	cmd.Flags().StringSliceVarP(&flags.Targets, "target", "t", nil,
 		`Target platforms to build for.
 Targets should be in the format '[os][/arch][/variant]:[distroname@osversion@anotherversion];[distroname@osversion]'.
@@ -191,6 +199,9 @@ func validateBuildpackPackageFlags(cfg config.Config, p *BuildpackPackageFlags)
 	if p.PackageTomlPath != "" && p.Path != "" {
 		return errors.Errorf("--config and --path cannot be used together. Please specify the relative path to the Buildpack directory in the package config file.")
 	}
+	if p.SignKey != "" && (!p.Publish || p.Format == client.FormatFile) {
+		return errors.Errorf("--sign-key requires --publish and cannot be used with --format=file")
+	}
 
 	if p.Flatten {
 		if !cfg.Experimental {
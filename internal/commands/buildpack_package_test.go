@@ -337,6 +337,18 @@ func testPackageCommand(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("--sign-key is specified without --publish", func() {
+			it("errors with a descriptive message", func() {
+				cmd := packageCommand()
+				cmd.SetArgs([]string{
+					"some-image-name", "--config", "/path/to/some/file",
+					"--sign-key", "some-key-path",
+				})
+
+				h.AssertError(t, cmd.Execute(), "--sign-key requires --publish and cannot be used with --format=file")
+			})
+		})
+
 		it("logs an error and exits when package toml is invalid", func() {
 			expectedErr := errors.New("it went wrong")
 
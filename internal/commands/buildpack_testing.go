@@ -0,0 +1,217 @@
+package commands
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// Name of the test spec looked up in each fixture when none is provided
+const buildpackTestSpecFileName = "buildpack-test.toml"
+
+// BuildpackTestFlags define flags provided to the BuildpackTest command
+type BuildpackTestFlags struct {
+	Path         string
+	Fixtures     []string
+	Spec         string
+	Builder      string
+	RunImage     string
+	Env          []string
+	Policy       string
+	TrustBuilder bool
+	JUnitReport  string
+}
+
+// BuildpackTest builds fixture apps with a buildpack and checks the results against test specs
+func BuildpackTest(logger logging.Logger, cfg config.Config, packClient PackClient) *cobra.Command {
+	var flags BuildpackTestFlags
+	cmd := &cobra.Command{
+		Use:     "test --fixture <fixture-path>",
+		Short:   "Test a buildpack against fixture apps",
+		Args:    cobra.NoArgs,
+		Example: "pack buildpack test --path ./my-buildpack --fixture ./testdata/app --junit-report report.xml",
+		Long: "buildpack test packages the buildpack at `path` on the fly, and builds each fixture app with it alone " +
+			"through the lifecycle of the builder. The results are checked against the test spec of the fixture, " +
+			"'" + buildpackTestSpecFileName + "' in its directory unless `spec` is provided, which sets whether the " +
+			"buildpack is expected to pass detection, and the processes, launch layers and bill of materials entries " +
+			"it is expected to contribute. Without a test spec, the buildpack is expected to pass detection and build.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			builder := flags.Builder
+			if builder == "" {
+				suggestSettingBuilder(logger, packClient)
+				return client.NewSoftError()
+			}
+
+			stringPolicy := flags.Policy
+			if stringPolicy == "" {
+				stringPolicy = cfg.PullPolicy
+			}
+			pullPolicy, err := image.ParsePullPolicy(stringPolicy)
+			if err != nil {
+				return errors.Wrap(err, "parsing pull policy")
+			}
+
+			env, err := parseEnv(nil, flags.Env)
+			if err != nil {
+				return err
+			}
+
+			var results []*client.BuildpackTestResult
+			for _, fixture := range flags.Fixtures {
+				spec, err := readBuildpackTestSpec(flags.Spec, fixture)
+				if err != nil {
+					return err
+				}
+
+				logger.Infof("Testing buildpack with fixture %s", style.Symbol(fixture))
+				result, err := packClient.TestBuildpack(cmd.Context(), client.TestBuildpackOptions{
+					BuildpackPath: flags.Path,
+					FixturePath:   fixture,
+					Builder:       builder,
+					RunImage:      flags.RunImage,
+					Env:           env,
+					PullPolicy:    pullPolicy,
+					TrustBuilder:  isTrustedBuilder(cfg, builder) || flags.TrustBuilder,
+					Spec:          spec,
+				})
+				if err != nil {
+					return err
+				}
+				logBuildpackTestResult(logger, result)
+				results = append(results, result)
+			}
+
+			if flags.JUnitReport != "" {
+				if err := writeJUnitReport(flags.JUnitReport, flags.Path, results); err != nil {
+					return errors.Wrap(err, "writing JUnit report")
+				}
+			}
+
+			failed := 0
+			for _, result := range results {
+				if !result.Passed() {
+					failed++
+				}
+			}
+			if failed > 0 {
+				return errors.Errorf("%d of %d fixtures failed", failed, len(results))
+			}
+			logger.Infof("All %d fixtures passed", len(results))
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVarP(&flags.Path, "path", "p", ".", "Path of the directory of the buildpack")
+	cmd.Flags().StringArrayVarP(&flags.Fixtures, "fixture", "f", nil, "Path of a fixture app to build with the buildpack"+stringArrayHelp("fixture"))
+	cmd.Flags().StringVarP(&flags.Spec, "spec", "s", "", "Path of the test spec of every fixture, instead of the "+buildpackTestSpecFileName+" of each fixture")
+	cmd.Flags().StringVarP(&flags.Builder, "builder", "B", cfg.DefaultBuilder, "Builder image")
+	cmd.Flags().StringVar(&flags.RunImage, "run-image", "", "Run image (defaults to default stack's run image)")
+	cmd.Flags().StringArrayVarP(&flags.Env, "env", "e", []string{}, "Build-time environment variable, in the form 'VAR=VALUE' or 'VAR'."+stringArrayHelp("env"))
+	cmd.Flags().StringVar(&flags.Policy, "pull-policy", "", `Pull policy to use. Accepted values are always, never, and if-not-present. (default "always")`)
+	cmd.Flags().BoolVar(&flags.TrustBuilder, "trust-builder", false, "Trust the provided builder.\nAll lifecycle phases will be run in a single container.")
+	cmd.Flags().StringVar(&flags.JUnitReport, "junit-report", "", "Path of the JUnit XML report of the tests")
+	cmd.MarkFlagRequired("fixture")
+
+	AddHelpFlag(cmd, "test")
+	return cmd
+}
+
+// readBuildpackTestSpec reads the test spec at specPath or, when not set, the one of the fixture, if any.
+func readBuildpackTestSpec(specPath, fixture string) (client.BuildpackTestSpec, error) {
+	if specPath != "" {
+		return client.ReadBuildpackTestSpec(specPath)
+	}
+
+	specPath = filepath.Join(fixture, buildpackTestSpecFileName)
+	if _, err := os.Stat(specPath); os.IsNotExist(err) {
+		return client.BuildpackTestSpec{}, nil
+	}
+	return client.ReadBuildpackTestSpec(specPath)
+}
+
+func logBuildpackTestResult(logger logging.Logger, result *client.BuildpackTestResult) {
+	duration := result.Duration.Round(time.Millisecond)
+	switch {
+	case result.Error != nil:
+		logger.Errorf("Fixture %s errored after %s: %s", style.Symbol(result.Fixture), duration, result.Error)
+	case len(result.Failures) > 0:
+		logger.Errorf("Fixture %s failed after %s:", style.Symbol(result.Fixture), duration)
+		for _, failure := range result.Failures {
+			logger.Errorf("  %s", failure)
+		}
+	default:
+		logger.Infof("Fixture %s passed in %s", style.Symbol(result.Fixture), duration)
+	}
+}
+
+type junitTestSuite struct {
+	XMLName   xml.Name        `xml:"testsuite"`
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Time      string          `xml:"time,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// writeJUnitReport writes the results as a JUnit test suite, with a test case per fixture.
+func writeJUnitReport(path, buildpackPath string, results []*client.BuildpackTestResult) error {
+	suite := junitTestSuite{Name: buildpackPath, Tests: len(results)}
+	var total time.Duration
+	for _, result := range results {
+		total += result.Duration
+		testCase := junitTestCase{
+			Name:      result.Fixture,
+			ClassName: buildpackPath,
+			Time:      junitSeconds(result.Duration),
+		}
+		switch {
+		case result.Error != nil:
+			suite.Errors++
+			testCase.Error = &junitMessage{Message: "build failed", Text: result.Error.Error()}
+		case len(result.Failures) > 0:
+			suite.Failures++
+			testCase.Failure = &junitMessage{
+				Message: fmt.Sprintf("%d expectations not met", len(result.Failures)),
+				Text:    strings.Join(result.Failures, "\n"),
+			}
+		}
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+	suite.Time = junitSeconds(total)
+
+	out, err := xml.MarshalIndent(suite, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append([]byte(xml.Header), append(out, '\n')...), 0644)
+}
+
+func junitSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
@@ -0,0 +1,123 @@
+package commands_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestBuildpackTestCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "BuildpackTestCommand", testBuildpackTestCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testBuildpackTestCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		logger         logging.Logger
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+		cfg            config.Config
+		fixture        string
+	)
+
+	it.Before(func() {
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		cfg = config.Config{DefaultBuilder: "some/builder"}
+		fixture = filepath.Join(t.TempDir(), "some-app")
+		h.AssertNil(t, os.MkdirAll(fixture, 0755))
+
+		command = commands.BuildpackTest(logger, cfg, mockClient)
+	})
+
+	when("#BuildpackTest", func() {
+		it("tests the buildpack with each fixture", func() {
+			otherFixture := filepath.Join(t.TempDir(), "other-app")
+			h.AssertNil(t, os.MkdirAll(otherFixture, 0755))
+
+			for _, path := range []string{fixture, otherFixture} {
+				mockClient.EXPECT().
+					TestBuildpack(gomock.Any(), client.TestBuildpackOptions{
+						BuildpackPath: "some-buildpack",
+						FixturePath:   path,
+						Builder:       "some/builder",
+						Env:           map[string]string{"SOME_VAR": "some-value"},
+						PullPolicy:    image.PullAlways,
+					}).
+					Return(&client.BuildpackTestResult{Fixture: filepath.Base(path)}, nil)
+			}
+
+			command.SetArgs([]string{"--path", "some-buildpack", "--fixture", fixture, "--fixture", otherFixture, "--env", "SOME_VAR=some-value"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "Fixture 'some-app' passed")
+			h.AssertContains(t, outBuf.String(), "All 2 fixtures passed")
+		})
+
+		it("reads the test spec of the fixture", func() {
+			h.AssertNil(t, os.WriteFile(filepath.Join(fixture, "buildpack-test.toml"), []byte(`
+[[processes]]
+type = "web"
+`), 0600))
+			mockClient.EXPECT().
+				TestBuildpack(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ interface{}, opts client.TestBuildpackOptions) (*client.BuildpackTestResult, error) {
+					h.AssertEq(t, opts.Spec.Processes, []client.BuildpackTestProcess{{Type: "web"}})
+					return &client.BuildpackTestResult{Fixture: "some-app"}, nil
+				})
+
+			command.SetArgs([]string{"--fixture", fixture})
+			h.AssertNil(t, command.Execute())
+		})
+
+		it("fails when a fixture fails", func() {
+			mockClient.EXPECT().
+				TestBuildpack(gomock.Any(), gomock.Any()).
+				Return(&client.BuildpackTestResult{Fixture: "some-app", Failures: []string{"process 'web' is missing"}}, nil)
+
+			command.SetArgs([]string{"--fixture", fixture})
+			h.AssertError(t, command.Execute(), "1 of 1 fixtures failed")
+			h.AssertContains(t, outBuf.String(), "process 'web' is missing")
+		})
+
+		it("writes a JUnit report", func() {
+			reportPath := filepath.Join(t.TempDir(), "report.xml")
+			mockClient.EXPECT().
+				TestBuildpack(gomock.Any(), gomock.Any()).
+				Return(&client.BuildpackTestResult{Fixture: "some-app", Duration: 1500 * time.Millisecond, Error: errors.New("some-error")}, nil)
+
+			command.SetArgs([]string{"--path", "some-buildpack", "--fixture", fixture, "--junit-report", reportPath})
+			h.AssertError(t, command.Execute(), "1 of 1 fixtures failed")
+
+			contents, err := os.ReadFile(reportPath)
+			h.AssertNil(t, err)
+			h.AssertContains(t, string(contents), `<testsuite name="some-buildpack" tests="1" failures="0" errors="1" time="1.500">`)
+			h.AssertContains(t, string(contents), `<testcase name="some-app" classname="some-buildpack" time="1.500">`)
+			h.AssertContains(t, string(contents), `<error message="build failed">some-error</error>`)
+		})
+
+		it("errors without a fixture", func() {
+			h.AssertError(t, command.Execute(), `required flag(s) "fixture" not set`)
+		})
+	})
+}
@@ -0,0 +1,35 @@
+package commands
+
+import (
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+func NewCacheCommand(logger logging.Logger, client PackClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Interact with build caches",
+		Long: `'pack cache' commands list, inspect, prune, export and import the caches created by 'pack build'.
+
+Caches used by builds are recorded at '$PACK_HOME/caches.toml' so that bind and image caches, and the app image a cache belongs to, can be reported.`,
+		RunE: nil,
+	}
+
+	cmd.AddCommand(CacheList(logger, client))
+	cmd.AddCommand(CacheInspect(logger, client))
+	cmd.AddCommand(CachePrune(logger, client))
+	cmd.AddCommand(CacheExport(logger, client))
+	cmd.AddCommand(CacheImport(logger, client))
+
+	AddHelpFlag(cmd, "cache")
+	return cmd
+}
+
+func cacheSize(size int64) string {
+	if size < 0 {
+		return "unknown"
+	}
+	return humanize.Bytes(uint64(size))
+}
@@ -0,0 +1,39 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/style"
+	cpkg "github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// CacheExport writes a volume cache to a tarball
+func CacheExport(logger logging.Logger, client PackClient) *cobra.Command {
+	var helperImage string
+
+	cmd := &cobra.Command{
+		Use:     "export <cache-name> <tarball-path>",
+		Args:    cobra.ExactArgs(2),
+		Short:   "Export a volume cache to a tarball",
+		Long:    "Export the contents of a volume cache to a tarball, which can be restored on another machine with 'pack cache import'.",
+		Example: "pack cache export pack-cache-library_my-app_latest-a1b2c3d4e5f6.build my-app-cache.tar",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if err := client.ExportCache(cmd.Context(), cpkg.ExportCacheOptions{
+				Name:        args[0],
+				Path:        args[1],
+				HelperImage: helperImage,
+			}); err != nil {
+				return err
+			}
+
+			logger.Infof("Exported cache %s to %s", style.Symbol(args[0]), style.Symbol(args[1]))
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVar(&helperImage, "helper-image", "", "Image used for the container the cache volume is mounted into (defaults to the lifecycle image)")
+
+	AddHelpFlag(cmd, "export")
+	return cmd
+}
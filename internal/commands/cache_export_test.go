@@ -0,0 +1,84 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	cpkg "github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestCacheExportCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "CacheExportCommand", testCacheExportCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testCacheExportCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		logger         logging.Logger
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#CacheExport", func() {
+		var command *cobra.Command
+
+		it.Before(func() {
+			command = commands.CacheExport(logger, mockClient)
+		})
+
+		it("exports the cache", func() {
+			mockClient.EXPECT().ExportCache(gomock.Any(), cpkg.ExportCacheOptions{
+				Name:        "some-cache",
+				Path:        "some-cache.tar",
+				HelperImage: "some-helper",
+			}).Return(nil)
+
+			command.SetArgs([]string{"some-cache", "some-cache.tar", "--helper-image", "some-helper"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "Exported cache 'some-cache' to 'some-cache.tar'")
+		})
+	})
+
+	when("#CacheImport", func() {
+		var command *cobra.Command
+
+		it.Before(func() {
+			command = commands.CacheImport(logger, mockClient)
+		})
+
+		it("imports the cache", func() {
+			mockClient.EXPECT().ImportCache(gomock.Any(), cpkg.ImportCacheOptions{
+				Name:  "some-cache",
+				Path:  "some-cache.tar",
+				Image: "my-app",
+				Kind:  "build",
+			}).Return(nil)
+
+			command.SetArgs([]string{"some-cache", "some-cache.tar", "--image", "my-app"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "Imported 'some-cache.tar' into cache 'some-cache'")
+		})
+	})
+}
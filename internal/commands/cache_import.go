@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/style"
+	cpkg "github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type CacheImportFlags struct {
+	Image       string
+	Kind        string
+	HelperImage string
+}
+
+// CacheImport restores a tarball created by 'pack cache export' into a volume cache
+func CacheImport(logger logging.Logger, client PackClient) *cobra.Command {
+	var flags CacheImportFlags
+
+	cmd := &cobra.Command{
+		Use:   "import <cache-name> <tarball-path>",
+		Args:  cobra.ExactArgs(2),
+		Short: "Import a volume cache from a tarball",
+		Long: `Import a tarball created by 'pack cache export' into a volume cache, creating the volume if needed.
+
+To have 'pack build' use the imported cache, either import into the volume name reported by 'pack cache ls' on the original machine,
+or pass '--cache "type=build;format=volume;name=<cache-name>"' to 'pack build'.`,
+		Example: "pack cache import pack-cache-library_my-app_latest-a1b2c3d4e5f6.build my-app-cache.tar --image my-app",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if err := client.ImportCache(cmd.Context(), cpkg.ImportCacheOptions{
+				Name:        args[0],
+				Path:        args[1],
+				Image:       flags.Image,
+				Kind:        flags.Kind,
+				HelperImage: flags.HelperImage,
+			}); err != nil {
+				return err
+			}
+
+			logger.Infof("Imported %s into cache %s", style.Symbol(args[1]), style.Symbol(args[0]))
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVar(&flags.Image, "image", "", "App image the cache belongs to")
+	cmd.Flags().StringVar(&flags.Kind, "kind", "build", "Kind of cache, one of build, launch or kaniko")
+	cmd.Flags().StringVar(&flags.HelperImage, "helper-image", "", "Image used for the container the cache volume is mounted into (defaults to the lifecycle image)")
+
+	AddHelpFlag(cmd, "import")
+	return cmd
+}
@@ -0,0 +1,40 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// CacheInspect shows details of a cache created by pack
+func CacheInspect(logger logging.Logger, client PackClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "inspect <cache-name>",
+		Args:    cobra.ExactArgs(1),
+		Short:   "Show information about a build cache",
+		Example: "pack cache inspect pack-cache-library_my-app_latest-a1b2c3d4e5f6.build",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			info, err := client.InspectCache(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+
+			img := info.Image
+			if img == "" {
+				img = "(unknown)"
+			}
+
+			logger.Infof("Name:      %s", style.Symbol(info.Name))
+			logger.Infof("Format:    %s", info.Format)
+			logger.Infof("Kind:      %s", info.Kind)
+			logger.Infof("Image:     %s", img)
+			logger.Infof("Size:      %s", cacheSize(info.Size))
+			logger.Infof("Last Used: %s", info.LastUsed.Format("2006-01-02 15:04:05 MST"))
+			return nil
+		}),
+	}
+
+	AddHelpFlag(cmd, "inspect")
+	return cmd
+}
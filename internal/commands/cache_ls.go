@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// CacheList lists the caches created by pack
+func CacheList(logger logging.Logger, client PackClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "ls",
+		Aliases: []string{"list"},
+		Args:    cobra.NoArgs,
+		Short:   "List build caches",
+		Long:    "List the volume, bind and image caches created by pack, most recently used first.",
+		Example: "pack cache ls",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			caches, err := client.ListCaches(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			if len(caches) == 0 {
+				logger.Info("No caches found")
+				return nil
+			}
+
+			buf := &bytes.Buffer{}
+			tw := tabwriter.NewWriter(buf, 0, 0, 3, ' ', 0)
+			fmt.Fprintln(tw, "NAME\tFORMAT\tKIND\tIMAGE\tSIZE\tLAST USED")
+			for _, c := range caches {
+				img := c.Image
+				if img == "" {
+					img = "-"
+				}
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", c.Name, c.Format, c.Kind, img, cacheSize(c.Size), humanize.Time(c.LastUsed))
+			}
+			if err := tw.Flush(); err != nil {
+				return err
+			}
+
+			logger.Info(buf.String())
+			return nil
+		}),
+	}
+
+	AddHelpFlag(cmd, "ls")
+	return cmd
+}
@@ -0,0 +1,78 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	cpkg "github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestCacheListCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "CacheListCommand", testCacheListCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testCacheListCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		logger         logging.Logger
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		command = commands.CacheList(logger, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#CacheList", func() {
+		it("lists caches with their image and size", func() {
+			mockClient.EXPECT().ListCaches(gomock.Any()).Return([]cpkg.CacheInfo{
+				{Name: "pack-cache-my-app.build", Format: "volume", Kind: "build", Image: "my-app:latest", Size: 2048, LastUsed: time.Now()},
+				{Name: "/some/bind/build-cache", Format: "bind", Kind: "build", Size: -1, LastUsed: time.Now()},
+			}, nil)
+
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "NAME")
+			h.AssertContainsMatch(t, outBuf.String(), `pack-cache-my-app.build\s+volume\s+build\s+my-app:latest\s+2.0 kB`)
+			h.AssertContainsMatch(t, outBuf.String(), `/some/bind/build-cache\s+bind\s+build\s+-\s+unknown`)
+		})
+
+		when("there are no caches", func() {
+			it("says so", func() {
+				mockClient.EXPECT().ListCaches(gomock.Any()).Return(nil, nil)
+
+				h.AssertNil(t, command.Execute())
+				h.AssertContains(t, outBuf.String(), "No caches found")
+			})
+		})
+
+		when("listing fails", func() {
+			it("returns the error", func() {
+				mockClient.EXPECT().ListCaches(gomock.Any()).Return(nil, errors.New("some-error"))
+
+				h.AssertError(t, command.Execute(), "some-error")
+			})
+		})
+	})
+}
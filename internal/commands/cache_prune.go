@@ -0,0 +1,107 @@
+package commands
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/style"
+	cpkg "github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type CachePruneFlags struct {
+	OlderThan string
+	MaxSize   string
+	DryRun    bool
+}
+
+// CachePrune removes caches by age or total size
+func CachePrune(logger logging.Logger, client PackClient) *cobra.Command {
+	var flags CachePruneFlags
+
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Args:  cobra.NoArgs,
+		Short: "Remove unused build caches",
+		Long: `Remove caches that have not been used recently, or the least recently used caches until the total size is under a limit.
+
+Registry caches are never removed since they do not reside on this machine.`,
+		Example: "pack cache prune --older-than 168h --max-size 10GB",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			opts, err := cachePruneOptions(flags)
+			if err != nil {
+				return err
+			}
+
+			pruned, err := client.PruneCaches(cmd.Context(), opts)
+			if err != nil {
+				return err
+			}
+
+			verb := "Removed"
+			if flags.DryRun {
+				verb = "Would remove"
+			}
+
+			var total int64
+			for _, c := range pruned {
+				logger.Infof("%s cache %s (%s)", verb, style.Symbol(c.Name), cacheSize(c.Size))
+				if c.Size > 0 {
+					total += c.Size
+				}
+			}
+			logger.Infof("%s %d cache(s), reclaiming %s", verb, len(pruned), humanize.Bytes(uint64(total)))
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVar(&flags.OlderThan, "older-than", "", "Remove caches not used within this duration (e.g. '72h' or '7d')")
+	cmd.Flags().StringVar(&flags.MaxSize, "max-size", "", "Remove least recently used caches until their total size is at most this size (e.g. '10GB')")
+	cmd.Flags().BoolVar(&flags.DryRun, "dry-run", false, "Show the caches that would be removed without removing them")
+
+	AddHelpFlag(cmd, "prune")
+	return cmd
+}
+
+func cachePruneOptions(flags CachePruneFlags) (cpkg.PruneCachesOptions, error) {
+	var opts cpkg.PruneCachesOptions
+	if flags.OlderThan == "" && flags.MaxSize == "" {
+		return opts, errors.New("at least one of 'older-than' or 'max-size' must be provided")
+	}
+
+	if flags.OlderThan != "" {
+		olderThan, err := parseDuration(flags.OlderThan)
+		if err != nil {
+			return opts, errors.Wrapf(err, "parsing 'older-than' value %s", style.Symbol(flags.OlderThan))
+		}
+		opts.OlderThan = olderThan
+	}
+
+	if flags.MaxSize != "" {
+		maxSize, err := humanize.ParseBytes(flags.MaxSize)
+		if err != nil {
+			return opts, errors.Wrapf(err, "parsing 'max-size' value %s", style.Symbol(flags.MaxSize))
+		}
+		opts.MaxSize = int64(maxSize)
+	}
+
+	opts.DryRun = flags.DryRun
+	return opts, nil
+}
+
+// parseDuration extends time.ParseDuration with a 'd' suffix for whole days.
+func parseDuration(value string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(value)
+}
@@ -0,0 +1,93 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	cpkg "github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestCachePruneCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "CachePruneCommand", testCachePruneCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testCachePruneCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		logger         logging.Logger
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		command = commands.CachePrune(logger, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#CachePrune", func() {
+		it("prunes by age and size", func() {
+			mockClient.EXPECT().PruneCaches(gomock.Any(), cpkg.PruneCachesOptions{
+				OlderThan: 7 * 24 * time.Hour,
+				MaxSize:   10 * 1000 * 1000 * 1000,
+			}).Return([]cpkg.CacheInfo{{Name: "some-cache", Size: 1000}}, nil)
+
+			command.SetArgs([]string{"--older-than", "7d", "--max-size", "10GB"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "Removed cache 'some-cache' (1.0 kB)")
+			h.AssertContains(t, outBuf.String(), "Removed 1 cache(s), reclaiming 1.0 kB")
+		})
+
+		when("--dry-run", func() {
+			it("reports what would be removed", func() {
+				mockClient.EXPECT().PruneCaches(gomock.Any(), cpkg.PruneCachesOptions{
+					OlderThan: 72 * time.Hour,
+					DryRun:    true,
+				}).Return([]cpkg.CacheInfo{{Name: "some-cache", Size: -1}}, nil)
+
+				command.SetArgs([]string{"--older-than", "72h", "--dry-run"})
+				h.AssertNil(t, command.Execute())
+				h.AssertContains(t, outBuf.String(), "Would remove cache 'some-cache' (unknown)")
+			})
+		})
+
+		when("no criteria are provided", func() {
+			it("errors", func() {
+				h.AssertError(t, command.Execute(), "at least one of 'older-than' or 'max-size' must be provided")
+			})
+		})
+
+		when("older-than is invalid", func() {
+			it("errors", func() {
+				command.SetArgs([]string{"--older-than", "soon"})
+				h.AssertError(t, command.Execute(), "parsing 'older-than' value 'soon'")
+			})
+		})
+
+		when("max-size is invalid", func() {
+			it("errors", func() {
+				command.SetArgs([]string{"--max-size", "lots"})
+				h.AssertError(t, command.Execute(), "parsing 'max-size' value 'lots'")
+			})
+		})
+	})
+}
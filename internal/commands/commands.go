@@ -23,13 +23,19 @@ import (
 //go:generate mockgen -package testmocks -destination testmocks/mock_pack_client.go github.com/buildpacks/pack/internal/commands PackClient
 type PackClient interface {
 	InspectBuilder(string, bool, ...client.BuilderInspectionModifier) (*client.BuilderInfo, error)
-	InspectImage(string, bool) (*client.ImageInfo, error)
+	InspectImage(string, bool, ...client.ImageInspectionModifier) (*client.ImageInfo, error)
 	Rebase(context.Context, client.RebaseOptions) error
+	PlanRebase(context.Context, client.RebaseOptions) (*client.RebasePlan, error)
+	RebaseMany(context.Context, client.RebaseManyOptions) ([]client.RebaseResult, error)
 	CreateBuilder(context.Context, client.CreateBuilderOptions) error
 	NewBuildpack(context.Context, client.NewBuildpackOptions) error
+	NewExtension(context.Context, client.NewExtensionOptions) error
+	TestBuildpack(context.Context, client.TestBuildpackOptions) (*client.BuildpackTestResult, error)
+	Lint(client.LintOptions) (*client.LintResult, error)
 	PackageBuildpack(ctx context.Context, opts client.PackageBuildpackOptions) error
 	PackageExtension(ctx context.Context, opts client.PackageBuildpackOptions) error
 	Build(context.Context, client.BuildOptions) error
+	Watch(context.Context, client.WatchOptions) error
 	RegisterBuildpack(context.Context, client.RegisterBuildpackOptions) error
 	YankBuildpack(client.YankBuildpackOptions) error
 	InspectBuildpack(client.InspectBuildpackOptions) (*client.BuildpackInfo, error)
@@ -43,6 +49,11 @@ type PackClient interface {
 	RemoveManifest(name string, images []string) error
 	PushManifest(client.PushManifestOptions) error
 	InspectManifest(string) error
+	ListCaches(context.Context) ([]client.CacheInfo, error)
+	InspectCache(context.Context, string) (*client.CacheInfo, error)
+	PruneCaches(context.Context, client.PruneCachesOptions) ([]client.CacheInfo, error)
+	ExportCache(context.Context, client.ExportCacheOptions) error
+	ImportCache(context.Context, client.ImportCacheOptions) error
 }
 
 func AddHelpFlag(cmd *cobra.Command, commandName string) {
@@ -115,6 +126,23 @@ func isTrustedBuilder(cfg config.Config, builder string) bool {
 	return isSuggestedBuilder(builder)
 }
 
+// trustedBuilderPolicy returns the digest and signing keys the trusted builder is pinned to, if any.
+func trustedBuilderPolicy(cfg config.Config, builder string) (*client.TrustedBuilderPolicy, error) {
+	for _, trustedBuilder := range cfg.TrustedBuilders {
+		if builder != trustedBuilder.Name || (trustedBuilder.Digest == "" && len(trustedBuilder.SigningKeys) == 0) {
+			continue
+		}
+
+		verifier, err := loadVerifier(trustedBuilder.SigningKeys)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading trusted builder policy for %s", style.Symbol(builder))
+		}
+		return &client.TrustedBuilderPolicy{Digest: trustedBuilder.Digest, Verifier: verifier}, nil
+	}
+
+	return nil, nil
+}
+
 func deprecationWarning(logger logging.Logger, oldCmd, replacementCmd string) {
 	logger.Warnf("Command %s has been deprecated, please use %s instead", style.Symbol("pack "+oldCmd), style.Symbol("pack "+replacementCmd))
 }
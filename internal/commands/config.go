@@ -18,6 +18,7 @@ func NewConfigCommand(logger logging.Logger, cfg config.Config, cfgPath string,
 
 	cmd.AddCommand(ConfigDefaultBuilder(logger, cfg, cfgPath, client))
 	cmd.AddCommand(ConfigExperimental(logger, cfg, cfgPath))
+	cmd.AddCommand(ConfigOffline(logger, cfg, cfgPath))
 	cmd.AddCommand(ConfigPullPolicy(logger, cfg, cfgPath))
 	cmd.AddCommand(ConfigRegistries(logger, cfg, cfgPath))
 	cmd.AddCommand(ConfigRunImagesMirrors(logger, cfg, cfgPath))
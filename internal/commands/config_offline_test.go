@@ -0,0 +1,98 @@
+package commands_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestConfigOffline(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ConfigOfflineCommand", testConfigOffline, spec.Random(), spec.Report(report.Terminal{}))
+}
+
+func testConfigOffline(t *testing.T, when spec.G, it spec.S) {
+	var (
+		cmd          *cobra.Command
+		logger       logging.Logger
+		outBuf       bytes.Buffer
+		tempPackHome string
+		configPath   string
+	)
+
+	it.Before(func() {
+		var err error
+
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		tempPackHome, err = os.MkdirTemp("", "pack-home")
+		h.AssertNil(t, err)
+		configPath = filepath.Join(tempPackHome, "config.toml")
+
+		cmd = commands.ConfigOffline(logger, config.Config{}, configPath)
+		cmd.SetOut(logging.GetWriterForLevel(logger, logging.InfoLevel))
+	})
+
+	it.After(func() {
+		h.AssertNil(t, os.RemoveAll(tempPackHome))
+	})
+
+	when("#ConfigOffline", func() {
+		when("list values", func() {
+			it("prints a clear message if false", func() {
+				cmd.SetArgs([]string{})
+				h.AssertNil(t, cmd.Execute())
+				h.AssertContains(t, outBuf.String(), "Offline mode isn't currently enabled")
+			})
+
+			it("prints a clear message if true", func() {
+				cmd = commands.ConfigOffline(logger, config.Config{Offline: true}, configPath)
+				cmd.SetArgs([]string{})
+				h.AssertNil(t, cmd.Execute())
+				h.AssertContains(t, outBuf.String(), "Offline mode is enabled!")
+			})
+		})
+
+		when("set", func() {
+			it("sets true if provided", func() {
+				cmd.SetArgs([]string{"true"})
+				h.AssertNil(t, cmd.Execute())
+				h.AssertContains(t, outBuf.String(), "Offline mode enabled")
+				cfg, err := config.Read(configPath)
+				h.AssertNil(t, err)
+				h.AssertEq(t, cfg.Offline, true)
+			})
+
+			it("sets false if provided", func() {
+				cmd = commands.ConfigOffline(logger, config.Config{Offline: true}, configPath)
+				cmd.SetArgs([]string{"false"})
+				h.AssertNil(t, cmd.Execute())
+				h.AssertContains(t, outBuf.String(), "Offline mode disabled")
+				cfg, err := config.Read(configPath)
+				h.AssertNil(t, err)
+				h.AssertEq(t, cfg.Offline, false)
+			})
+
+			it("returns error if invalid value provided", func() {
+				cmd.SetArgs([]string{"sometimes"})
+				h.AssertError(t, cmd.Execute(), fmt.Sprintf("invalid value %s provided", style.Symbol("sometimes")))
+				cfg, err := config.Read(configPath)
+				h.AssertNil(t, err)
+				h.AssertEq(t, cfg.Offline, false)
+			})
+		})
+	})
+}
@@ -1,8 +1,12 @@
 package commands
 
 import (
+	"fmt"
+	"path/filepath"
 	"sort"
+	"strings"
 
+	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
@@ -33,9 +37,16 @@ func ConfigTrustedBuilder(logger logging.Logger, cfg config.Config, cfgPath stri
 	listCmd.Example = "pack config trusted-builders list"
 	cmd.AddCommand(listCmd)
 
-	addCmd := generateAdd("trusted-builders", logger, cfg, cfgPath, addTrustedBuilder)
-	addCmd.Long = "Trust builder.\n\nWhen building with this builder, all lifecycle phases will be run in a single container using the builder image."
-	addCmd.Example = "pack config trusted-builders add cnbs/sample-stack-run:bionic"
+	var flags trustedBuilderFlags
+	addCmd := generateAdd("trusted-builders", logger, cfg, cfgPath, func(args []string, logger logging.Logger, cfg config.Config, cfgPath string) error {
+		return addTrustedBuilder(args, flags, logger, cfg, cfgPath)
+	})
+	addCmd.Long = "Trust builder.\n\nWhen building with this builder, all lifecycle phases will be run in a single container using the builder image.\n\n" +
+		"Trust can be restricted to a builder image digest, or to builder images signed with one of a set of keys. " +
+		"Builder images that do not meet these restrictions are treated as untrusted."
+	addCmd.Example = "pack config trusted-builders add cnbs/sample-stack-run:bionic --signing-key cosign.pub"
+	addCmd.Flags().StringVar(&flags.Digest, "digest", "", "Only trust the builder image with this digest, in the form 'sha256:<hex>'")
+	addCmd.Flags().StringArrayVar(&flags.SigningKeys, "signing-key", nil, "Path to a cosign-compatible public key. Only trust builder images signed with one of the provided keys."+stringArrayHelp("signing-key"))
 	cmd.AddCommand(addCmd)
 
 	rmCmd := generateRemove("trusted-builders", logger, cfg, cfgPath, removeTrustedBuilder)
@@ -47,24 +58,70 @@ func ConfigTrustedBuilder(logger logging.Logger, cfg config.Config, cfgPath stri
 	return cmd
 }
 
-func addTrustedBuilder(args []string, logger logging.Logger, cfg config.Config, cfgPath string) error {
+type trustedBuilderFlags struct {
+	Digest      string
+	SigningKeys []string
+}
+
+func addTrustedBuilder(args []string, flags trustedBuilderFlags, logger logging.Logger, cfg config.Config, cfgPath string) error {
 	imageName := args[0]
 	builderToTrust := config.TrustedBuilder{Name: imageName}
 
-	if isTrustedBuilder(cfg, imageName) {
+	if flags.Digest != "" {
+		if _, err := v1.NewHash(flags.Digest); err != nil {
+			return errors.Wrapf(err, "invalid digest %s", style.Symbol(flags.Digest))
+		}
+		builderToTrust.Digest = flags.Digest
+	}
+
+	for _, keyPath := range flags.SigningKeys {
+		absPath, err := filepath.Abs(keyPath)
+		if err != nil {
+			return errors.Wrapf(err, "getting absolute path for %s", style.Symbol(keyPath))
+		}
+		builderToTrust.SigningKeys = append(builderToTrust.SigningKeys, absPath)
+	}
+	if _, err := loadVerifier(builderToTrust.SigningKeys); err != nil {
+		return err
+	}
+
+	pinned := builderToTrust.Digest != "" || len(builderToTrust.SigningKeys) > 0
+	if !pinned && isTrustedBuilder(cfg, imageName) {
 		logger.Infof("Builder %s is already trusted", style.Symbol(imageName))
 		return nil
 	}
 
-	cfg.TrustedBuilders = append(cfg.TrustedBuilders, builderToTrust)
+	var trustedBuilders []config.TrustedBuilder
+	for _, trustedBuilder := range cfg.TrustedBuilders {
+		if trustedBuilder.Name != imageName {
+			trustedBuilders = append(trustedBuilders, trustedBuilder)
+		}
+	}
+	cfg.TrustedBuilders = append(trustedBuilders, builderToTrust)
 	if err := config.Write(cfg, cfgPath); err != nil {
 		return errors.Wrap(err, "writing config")
 	}
+
+	if pinned {
+		logger.Infof("Builder %s is now trusted when it %s", style.Symbol(imageName), describeTrustPolicy(builderToTrust))
+		return nil
+	}
 	logger.Infof("Builder %s is now trusted", style.Symbol(imageName))
 
 	return nil
 }
 
+func describeTrustPolicy(trustedBuilder config.TrustedBuilder) string {
+	var conditions []string
+	if trustedBuilder.Digest != "" {
+		conditions = append(conditions, fmt.Sprintf("has digest %s", style.Symbol(trustedBuilder.Digest)))
+	}
+	if len(trustedBuilder.SigningKeys) > 0 {
+		conditions = append(conditions, fmt.Sprintf("is signed with one of %d key(s)", len(trustedBuilder.SigningKeys)))
+	}
+	return strings.Join(conditions, " and ")
+}
+
 func removeTrustedBuilder(args []string, logger logging.Logger, cfg config.Config, cfgPath string) error {
 	builder := args[0]
 
@@ -109,6 +166,10 @@ func listTrustedBuilders(args []string, logger logging.Logger, cfg config.Config
 	}
 
 	for _, builder := range cfg.TrustedBuilders {
+		if builder.Digest != "" || len(builder.SigningKeys) > 0 {
+			trustedBuilders = append(trustedBuilders, fmt.Sprintf("%s (when it %s)", builder.Name, describeTrustPolicy(builder)))
+			continue
+		}
 		trustedBuilders = append(trustedBuilders, builder.Name)
 	}
 
@@ -122,6 +122,17 @@ func testTrustedBuilderCommand(t *testing.T, when spec.G, it spec.S) {
 		})
 	})
 
+	when("list", func() {
+		when("a trusted builder is pinned", func() {
+			it("shows its policy", func() {
+				command = commands.ConfigTrustedBuilder(logger, config.Config{TrustedBuilders: []config.TrustedBuilder{{Name: "some-builder", Digest: "sha256:abc"}}}, configPath)
+				command.SetArgs([]string{"list"})
+				h.AssertNil(t, command.Execute())
+				h.AssertContains(t, outBuf.String(), "some-builder (when it has digest 'sha256:abc')")
+			})
+		})
+	})
+
 	when("add", func() {
 		var args = []string{"add"}
 		when("no builder is provided", func() {
@@ -171,6 +182,62 @@ func testTrustedBuilderCommand(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 
+			when("--digest is provided", func() {
+				it("pins the builder to the digest", func() {
+					command.SetArgs(append(args, "some-builder", "--digest", "sha256:363c754893f0efe22480b4359a5956cf3bd3ce22742fc576973c61348308c2e4"))
+					h.AssertNil(t, command.Execute())
+
+					b, err := os.ReadFile(configPath)
+					h.AssertNil(t, err)
+					h.AssertContains(t, string(b), `[[trusted-builders]]
+  name = "some-builder"
+  digest = "sha256:363c754893f0efe22480b4359a5956cf3bd3ce22742fc576973c61348308c2e4"`)
+					h.AssertContains(t, outBuf.String(), "Builder 'some-builder' is now trusted when it has digest 'sha256:363c754893f0efe22480b4359a5956cf3bd3ce22742fc576973c61348308c2e4'")
+				})
+
+				it("fails for an invalid digest", func() {
+					command.SetArgs(append(args, "some-builder", "--digest", "not-a-digest"))
+					h.AssertError(t, command.Execute(), "invalid digest 'not-a-digest'")
+				})
+
+				it("replaces the entry of an already trusted builder", func() {
+					command = commands.ConfigTrustedBuilder(logger, config.Config{TrustedBuilders: []config.TrustedBuilder{{Name: "some-builder"}}}, configPath)
+					command.SetArgs(append(args, "some-builder", "--digest", "sha256:363c754893f0efe22480b4359a5956cf3bd3ce22742fc576973c61348308c2e4"))
+					h.AssertNil(t, command.Execute())
+
+					cfg, err := config.Read(configPath)
+					h.AssertNil(t, err)
+					h.AssertEq(t, len(cfg.TrustedBuilders), 1)
+					h.AssertEq(t, cfg.TrustedBuilders[0].Digest, "sha256:363c754893f0efe22480b4359a5956cf3bd3ce22742fc576973c61348308c2e4")
+				})
+			})
+
+			when("--signing-key is provided", func() {
+				it("pins the builder to the absolute key paths", func() {
+					keyDir := t.TempDir()
+					_, publicKeyPath := writeSigningKeys(t, keyDir)
+					wd, err := os.Getwd()
+					h.AssertNil(t, err)
+					relPath, err := filepath.Rel(wd, publicKeyPath)
+					h.AssertNil(t, err)
+
+					command.SetArgs(append(args, "some-builder", "--signing-key", relPath))
+					h.AssertNil(t, command.Execute())
+
+					cfg, err := config.Read(configPath)
+					h.AssertNil(t, err)
+					h.AssertEq(t, cfg.TrustedBuilders[0].SigningKeys, []string{publicKeyPath})
+					h.AssertContains(t, outBuf.String(), "is now trusted when it is signed with one of 1 key(s)")
+				})
+
+				it("fails when the key is not a public key", func() {
+					privateKeyPath, _ := writeSigningKeys(t, t.TempDir())
+
+					command.SetArgs(append(args, "some-builder", "--signing-key", privateKeyPath))
+					h.AssertError(t, command.Execute(), "loading verification keys")
+				})
+			})
+
 			when("builder is a suggested builder", func() {
 				it("does nothing", func() {
 					h.AssertNil(t, os.WriteFile(configPath, []byte(""), os.ModePerm))
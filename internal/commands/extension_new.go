@@ -1,35 +1,105 @@
 package commands
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
 	"github.com/spf13/cobra"
 
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/internal/target"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/dist"
 	"github.com/buildpacks/pack/pkg/logging"
 )
 
 // ExtensionNewFlags define flags provided to the ExtensionNew command
 type ExtensionNewFlags struct {
-	API     string
-	Path    string
-	Stacks  []string
-	Version string
+	API      string
+	Path     string
+	Targets  []string
+	Version  string
+	Template string
+	Package  bool
 }
 
-// extensioncreator type to be added here and argument also to be added in the function
+// ExtensionCreator creates extensions
+type ExtensionCreator interface {
+	NewExtension(ctx context.Context, options client.NewExtensionOptions) error
+}
 
 // ExtensionNew generates the scaffolding of an extension
-func ExtensionNew(logger logging.Logger) *cobra.Command {
+func ExtensionNew(logger logging.Logger, creator ExtensionCreator) *cobra.Command {
+	var flags ExtensionNewFlags
 	cmd := &cobra.Command{
 		Use:     "new <id>",
 		Short:   "Creates basic scaffolding of an extension",
 		Args:    cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
-		Example: "pack extension new <example-extension>",
+		Example: "pack extension new sample/my-extension",
+		Long: "extension new generates the basic scaffolding of an extension repository. It creates a new directory `name` in the current directory (or at `path`, if passed as a flag), and initializes an extension.toml, and the files of `template`: " +
+			"two executable bash scripts by default, `bin/detect` and `bin/generate`, which outputs a build.Dockerfile extending the build image. " +
+			"`template` may also be a local directory or a git repository URL, whose files ending in .tmpl are rendered as Go templates given the .ID, .Version, .API, .Name and .GoPackage of the extension.",
 		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
-			// logic will go here
+			id := args[0]
+			idParts := strings.Split(id, "/")
+			dirName := idParts[len(idParts)-1]
+
+			var path string
+			if len(flags.Path) == 0 {
+				cwd, err := os.Getwd()
+				if err != nil {
+					return err
+				}
+				path = filepath.Join(cwd, dirName)
+			} else {
+				path = flags.Path
+			}
+
+			_, err := os.Stat(path)
+			if !os.IsNotExist(err) {
+				return fmt.Errorf("directory %s exists", style.Symbol(path))
+			}
+
+			var targets []dist.Target
+			if len(flags.Targets) == 0 {
+				targets = []dist.Target{{
+					OS:   runtime.GOOS,
+					Arch: runtime.GOARCH,
+				}}
+			} else {
+				if targets, err = target.ParseTargets(flags.Targets, logger); err != nil {
+					return err
+				}
+			}
+
+			if err := creator.NewExtension(cmd.Context(), client.NewExtensionOptions{
+				API:      flags.API,
+				ID:       id,
+				Path:     path,
+				Targets:  targets,
+				Version:  flags.Version,
+				Template: flags.Template,
+				Package:  flags.Package,
+			}); err != nil {
+				return err
+			}
+
+			logger.Infof("Successfully created %s", style.Symbol(id))
 			return nil
 		}),
 	}
 
-	// flags will go here
+	cmd.Flags().StringVarP(&flags.API, "api", "a", "0.10", "Buildpack API compatibility of the generated extension")
+	cmd.Flags().StringVarP(&flags.Path, "path", "p", "", "Path to generate the extension")
+	cmd.Flags().StringVarP(&flags.Version, "version", "V", "1.0.0", "Version of the generated extension")
+	cmd.Flags().StringVarP(&flags.Template, "template", "T", client.DefaultScaffoldTemplate, "Template of the generated extension: bash, a directory or a git repository URL")
+	cmd.Flags().BoolVar(&flags.Package, "package", false, "Generate a package.toml packaging the extension for its targets")
+	cmd.Flags().StringSliceVarP(&flags.Targets, "targets", "t", nil,
+		`Target platforms of the package.toml of the extension, in format [os][/arch][/variant]:[distroname@osversion@anotherversion];[distroname@osversion]`+stringSliceHelp("target"))
 
 	AddHelpFlag(cmd, "new")
 	return cmd
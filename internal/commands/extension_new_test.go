@@ -0,0 +1,89 @@
+package commands_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestExtensionNewCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ExtensionNewCommand", testExtensionNewCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testExtensionNewCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		logger         *logging.LogWithWriters
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+		tmpDir         string
+	)
+
+	it.Before(func() {
+		tmpDir = t.TempDir()
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+
+		command = commands.ExtensionNew(logger, mockClient)
+	})
+
+	when("ExtensionNew#Execute", func() {
+		it("uses the args to generate artifacts", func() {
+			mockClient.EXPECT().NewExtension(gomock.Any(), client.NewExtensionOptions{
+				API:      "0.10",
+				ID:       "example/some-extension",
+				Path:     filepath.Join(tmpDir, "some-extension"),
+				Version:  "1.0.0",
+				Targets:  []dist.Target{{OS: runtime.GOOS, Arch: runtime.GOARCH}},
+				Template: "bash",
+			}).Return(nil)
+
+			command.SetArgs([]string{"--path", filepath.Join(tmpDir, "some-extension"), "example/some-extension"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "Successfully created 'example/some-extension'")
+		})
+
+		it("passes the targets, template and package flags", func() {
+			mockClient.EXPECT().NewExtension(gomock.Any(), client.NewExtensionOptions{
+				API:      "0.10",
+				ID:       "example/some-extension",
+				Path:     filepath.Join(tmpDir, "some-extension"),
+				Version:  "1.0.0",
+				Targets:  []dist.Target{{OS: "linux", Arch: "arm64"}},
+				Template: "https://example.com/templates.git",
+				Package:  true,
+			}).Return(nil)
+
+			command.SetArgs([]string{"--path", filepath.Join(tmpDir, "some-extension"), "example/some-extension",
+				"--targets", "linux/arm64", "--template", "https://example.com/templates.git", "--package"})
+			h.AssertNil(t, command.Execute())
+		})
+
+		it("stops if the directory already exists", func() {
+			h.AssertNil(t, os.MkdirAll(filepath.Join(tmpDir, "some-extension"), 0755))
+
+			command.SetArgs([]string{"--path", filepath.Join(tmpDir, "some-extension"), "example/some-extension"})
+			h.AssertNotNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "ERROR: directory")
+		})
+	})
+}
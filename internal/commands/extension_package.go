@@ -21,6 +21,7 @@ type ExtensionPackageFlags struct {
 	Format          string
 	Publish         bool
 	Policy          string
+	SignKey         string
 }
 
 // ExtensionPackager packages extensions
@@ -74,6 +75,11 @@ func ExtensionPackage(logger logging.Logger, cfg config.Config, packager Extensi
 				}
 			}
 
+			signer, err := loadSigner(flags.SignKey)
+			if err != nil {
+				return err
+			}
+
 			if err := packager.PackageExtension(cmd.Context(), client.PackageBuildpackOptions{
 				RelativeBaseDir: relativeBaseDir,
 				Name:            name,
@@ -81,6 +87,7 @@ func ExtensionPackage(logger logging.Logger, cfg config.Config, packager Extensi
 				Config:          exPackageCfg,
 				Publish:         flags.Publish,
 				PullPolicy:      pullPolicy,
+				Signer:          signer,
 			}); err != nil {
 				return err
 			}
@@ -104,6 +111,7 @@ func ExtensionPackage(logger logging.Logger, cfg config.Config, packager Extensi
 	cmd.Flags().StringVarP(&flags.Format, "format", "f", "", `Format to save package as ("image" or "file")`)
 	cmd.Flags().BoolVar(&flags.Publish, "publish", false, `Publish the extension directly to the container registry specified in <name>, instead of the daemon (applies to "--format=image" only).`)
 	cmd.Flags().StringVar(&flags.Policy, "pull-policy", "", "Pull policy to use. Accepted values are always, never, and if-not-present. The default is always")
+	cmd.Flags().StringVar(&flags.SignKey, "sign-key", "", "Path to a cosign-compatible private key to sign the published package with. Encrypted keys are decrypted with the password in the COSIGN_PASSWORD environment variable. Requires --publish")
 	AddHelpFlag(cmd, "package")
 	return cmd
 }
@@ -112,5 +120,8 @@ func validateExtensionPackageFlags(p *ExtensionPackageFlags) error {
 	if p.Publish && p.Policy == image.PullNever.String() {
 		return errors.Errorf("--publish and --pull-policy=never cannot be used together. The --publish flag requires the use of remote images.")
 	}
+	if p.SignKey != "" && (!p.Publish || p.Format == client.FormatFile) {
+		return errors.Errorf("--sign-key requires --publish and cannot be used with --format=file")
+	}
 	return nil
 }
@@ -0,0 +1,18 @@
+package fakes
+
+import (
+	"github.com/buildpacks/pack/internal/inspectimage"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type FakeInspectImageDiffWriter struct {
+	ErrorForPrint error
+
+	ReceivedDiff *inspectimage.DiffDisplay
+}
+
+func (w *FakeInspectImageDiffWriter) Print(logger logging.Logger, diff *inspectimage.DiffDisplay) error {
+	w.ReceivedDiff = diff
+
+	return w.ErrorForPrint
+}
@@ -8,6 +8,9 @@ type FakeInspectImageWriterFactory struct {
 	ReturnForWriter writer.InspectImageWriter
 	ErrorForWriter  error
 
+	ReturnForDiffWriter writer.InspectImageDiffWriter
+	ErrorForDiffWriter  error
+
 	ReceivedForKind string
 	ReceivedForBOM  bool
 }
@@ -18,3 +21,9 @@ func (f *FakeInspectImageWriterFactory) Writer(kind string, bom bool) (writer.In
 
 	return f.ReturnForWriter, f.ErrorForWriter
 }
+
+func (f *FakeInspectImageWriterFactory) DiffWriter(kind string) (writer.InspectImageDiffWriter, error) {
+	f.ReceivedForKind = kind
+
+	return f.ReturnForDiffWriter, f.ErrorForDiffWriter
+}
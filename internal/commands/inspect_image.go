@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
 	"github.com/buildpacks/pack/internal/inspectimage"
@@ -8,24 +9,29 @@ import (
 	"github.com/buildpacks/pack/internal/inspectimage/writer"
 
 	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
 	"github.com/buildpacks/pack/pkg/logging"
 )
 
 //go:generate mockgen -package testmocks -destination testmocks/mock_inspect_image_writer_factory.go github.com/buildpacks/pack/internal/commands InspectImageWriterFactory
 type InspectImageWriterFactory interface {
 	Writer(kind string, BOM bool) (writer.InspectImageWriter, error)
+	DiffWriter(kind string) (writer.InspectImageDiffWriter, error)
 }
 
 type InspectImageFlags struct {
 	BOM          bool
 	OutputFormat string
+	Diff         string
+	Layers       bool
 }
 
 func InspectImage(
 	logger logging.Logger,
 	writerFactory InspectImageWriterFactory,
 	cfg config.Config,
-	client PackClient,
+	packClient PackClient,
 ) *cobra.Command {
 	var flags InspectImageFlags
 	cmd := &cobra.Command{
@@ -37,9 +43,17 @@ func InspectImage(
 		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
 			img := args[0]
 
+			if flags.Diff != "" {
+				if flags.BOM {
+					return errors.New("bom flag cannot be used with the diff flag")
+				}
+				return diffImages(logger, writerFactory, packClient, flags.OutputFormat, img, flags.Diff)
+			}
+
 			sharedImageInfo := inspectimage.GeneralInfo{
 				Name:            img,
 				RunImageMirrors: cfg.RunImages,
+				ShowLayers:      flags.Layers,
 			}
 
 			w, err := writerFactory.Writer(flags.OutputFormat, flags.BOM)
@@ -47,8 +61,12 @@ func InspectImage(
 				return err
 			}
 
-			remote, remoteErr := client.InspectImage(img, false)
-			local, localErr := client.InspectImage(img, true)
+			var modifiers []client.ImageInspectionModifier
+			if flags.Layers {
+				modifiers = append(modifiers, client.WithLayers())
+			}
+			remote, remoteErr := packClient.InspectImage(img, false, modifiers...)
+			local, localErr := packClient.InspectImage(img, true, modifiers...)
 
 			if flags.BOM {
 				logger.Warn("Using the '--bom' flag with 'pack inspect-image <image-name>' is deprecated. Users are encouraged to use 'pack sbom download <image-name>'.")
@@ -63,5 +81,45 @@ func InspectImage(
 	AddHelpFlag(cmd, "inspect")
 	cmd.Flags().BoolVar(&flags.BOM, "bom", false, "print bill of materials")
 	cmd.Flags().StringVarP(&flags.OutputFormat, "output", "o", "human-readable", "Output format to display builder detail (json, yaml, toml, human-readable).\nOmission of this flag will display as human-readable.")
+	cmd.Flags().BoolVar(&flags.Layers, "layers", false, "List every layer of the image with its size, diff ID and what added it")
+	cmd.Flags().StringVar(&flags.Diff, "diff", "", "Compare the image to another one, reporting changed buildpacks, BOM entries, processes, run image and layers.\nImages are read from the daemon if present there, otherwise from the registry. Supports the json, yaml and human-readable output formats.")
 	return cmd
 }
+
+func diffImages(logger logging.Logger, writerFactory InspectImageWriterFactory, packClient PackClient, outputFormat, from, to string) error {
+	w, err := writerFactory.DiffWriter(outputFormat)
+	if err != nil {
+		return err
+	}
+
+	fromInfo, err := inspectImageForDiff(packClient, from)
+	if err != nil {
+		return err
+	}
+	toInfo, err := inspectImageForDiff(packClient, to)
+	if err != nil {
+		return err
+	}
+
+	return w.Print(logger, inspectimage.NewDiffDisplay(from, fromInfo, to, toInfo))
+}
+
+// inspectImageForDiff inspects the image in the daemon, or in the registry if the daemon does not have it.
+func inspectImageForDiff(packClient PackClient, img string) (*client.ImageInfo, error) {
+	info, err := packClient.InspectImage(img, true)
+	if err != nil {
+		return nil, errors.Wrapf(err, "inspecting image %s", style.Symbol(img))
+	}
+	if info != nil {
+		return info, nil
+	}
+
+	info, err = packClient.InspectImage(img, false)
+	if err != nil {
+		return nil, errors.Wrapf(err, "inspecting image %s", style.Symbol(img))
+	}
+	if info == nil {
+		return nil, errors.Errorf("unable to find image %s locally or remotely", style.Symbol(img))
+	}
+	return info, nil
+}
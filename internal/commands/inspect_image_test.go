@@ -199,6 +199,82 @@ func testInspectImageCommand(t *testing.T, when spec.G, it spec.S) {
 			assert.Equal(inspectImageWriter.RecievedGeneralInfo.RunImageMirrors, cfg.RunImages)
 		})
 
+		it("asks the writer to show layers when --layers is set", func() {
+			inspectImageWriter := newDefaultInspectImageWriter()
+			inspectImageWriterFactory := newImageWriterFactory(inspectImageWriter)
+
+			var inspectionConfigs []client.ImageInspectionConfig
+			recordConfig := func(info *client.ImageInfo) func(string, bool, ...client.ImageInspectionModifier) (*client.ImageInfo, error) {
+				return func(_ string, _ bool, modifiers ...client.ImageInspectionModifier) (*client.ImageInfo, error) {
+					var config client.ImageInspectionConfig
+					for _, mod := range modifiers {
+						mod(&config)
+					}
+					inspectionConfigs = append(inspectionConfigs, config)
+					return info, nil
+				}
+			}
+			mockClient.EXPECT().InspectImage("some/image", true, gomock.Any()).DoAndReturn(recordConfig(expectedLocalImageInfo))
+			mockClient.EXPECT().InspectImage("some/image", false, gomock.Any()).DoAndReturn(recordConfig(expectedRemoteImageInfo))
+
+			command := commands.InspectImage(logger, inspectImageWriterFactory, cfg, mockClient)
+			command.SetArgs([]string{"some/image", "--layers"})
+			assert.Nil(command.Execute())
+
+			assert.Equal(inspectImageWriter.RecievedGeneralInfo.ShowLayers, true)
+			assert.Equal(inspectionConfigs, []client.ImageInspectionConfig{{Layers: true}, {Layers: true}})
+		})
+
+		when("--diff", func() {
+			var (
+				diffWriter    *fakes.FakeInspectImageDiffWriter
+				writerFactory *fakes.FakeInspectImageWriterFactory
+			)
+
+			it.Before(func() {
+				diffWriter = &fakes.FakeInspectImageDiffWriter{}
+				writerFactory = &fakes.FakeInspectImageWriterFactory{ReturnForDiffWriter: diffWriter}
+			})
+
+			it("compares the images, preferring those in the daemon", func() {
+				mockClient.EXPECT().InspectImage("some/image", true).Return(expectedLocalImageInfo, nil)
+				mockClient.EXPECT().InspectImage("other/image", true).Return(nil, nil)
+				mockClient.EXPECT().InspectImage("other/image", false).Return(expectedRemoteImageInfo, nil)
+
+				command := commands.InspectImage(logger, writerFactory, cfg, mockClient)
+				command.SetArgs([]string{"some/image", "--diff", "other/image", "--output", "json"})
+				assert.Nil(command.Execute())
+
+				assert.Equal(writerFactory.ReceivedForKind, "json")
+				assert.Equal(diffWriter.ReceivedDiff.From, "some/image")
+				assert.Equal(diffWriter.ReceivedDiff.To, "other/image")
+			})
+
+			it("errors when an image is not found", func() {
+				mockClient.EXPECT().InspectImage("some/image", true).Return(expectedLocalImageInfo, nil)
+				mockClient.EXPECT().InspectImage("other/image", true).Return(nil, nil)
+				mockClient.EXPECT().InspectImage("other/image", false).Return(nil, nil)
+
+				command := commands.InspectImage(logger, writerFactory, cfg, mockClient)
+				command.SetArgs([]string{"some/image", "--diff", "other/image"})
+				assert.ErrorWithMessage(command.Execute(), "unable to find image 'other/image' locally or remotely")
+			})
+
+			it("errors when inspecting an image fails", func() {
+				mockClient.EXPECT().InspectImage("some/image", true).Return(nil, errors.New("some-error"))
+
+				command := commands.InspectImage(logger, writerFactory, cfg, mockClient)
+				command.SetArgs([]string{"some/image", "--diff", "other/image"})
+				assert.ErrorWithMessage(command.Execute(), "inspecting image 'some/image': some-error")
+			})
+
+			it("errors with --bom", func() {
+				command := commands.InspectImage(logger, writerFactory, cfg, mockClient)
+				command.SetArgs([]string{"some/image", "--diff", "other/image", "--bom"})
+				assert.ErrorWithMessage(command.Execute(), "bom flag cannot be used with the diff flag")
+			})
+		})
+
 		when("error cases", func() {
 			when("client returns an error when inspecting", func() {
 				it("passes errors to the Writer", func() {
@@ -0,0 +1,108 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// LintFlags define flags provided to the Lint command
+type LintFlags struct {
+	Kind         string
+	OutputFormat string
+}
+
+// Lint statically validates buildpack.toml, builder.toml and project.toml files
+func Lint(logger logging.Logger, packClient PackClient) *cobra.Command {
+	var flags LintFlags
+	cmd := &cobra.Command{
+		Use:     "lint [<path>...]",
+		Short:   "Validate buildpack.toml, builder.toml and project.toml files",
+		Args:    cobra.ArbitraryArgs,
+		Example: "pack lint ./my-buildpack ./builder-jammy.toml --kind builder",
+		Long: "lint statically validates buildpack.toml, builder.toml and project.toml files, or those of the given directories " +
+			"(the current directory by default), before they are used to package a buildpack, create a builder or build an app. " +
+			"It reports unknown keys, missing or non-executable bin scripts, order groups referencing undeclared buildpacks, " +
+			"targets not matching the stack or the buildpacks, unsupported Buildpack APIs and deprecated fields. " +
+			"It fails when errors are found, but not for warnings.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				args = []string{"."}
+			}
+
+			result, err := packClient.Lint(client.LintOptions{Paths: args, Kind: flags.Kind})
+			if err != nil {
+				return err
+			}
+
+			switch flags.OutputFormat {
+			case "human-readable":
+				logLintResult(logger, result)
+			case "json", "yaml":
+				if err := writeLintResult(logger, flags.OutputFormat, result); err != nil {
+					return err
+				}
+				if result.Count(client.LintSeverityError) > 0 {
+					return client.NewSoftError()
+				}
+				return nil
+			default:
+				return fmt.Errorf("output format %s is not supported", style.Symbol(flags.OutputFormat))
+			}
+
+			if errorCount := result.Count(client.LintSeverityError); errorCount > 0 {
+				return fmt.Errorf("%d errors found", errorCount)
+			}
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVarP(&flags.Kind, "kind", "k", "", "Kind of the files, one of buildpack, builder or project, inferred from their names by default")
+	cmd.Flags().StringVarP(&flags.OutputFormat, "output", "o", "human-readable", "Output format of the issues (json, yaml, human-readable)")
+
+	AddHelpFlag(cmd, "lint")
+	return cmd
+}
+
+func logLintResult(logger logging.Logger, result *client.LintResult) {
+	for _, issue := range result.Issues {
+		location := issue.File
+		if issue.Key != "" {
+			location += ": " + issue.Key
+		}
+		if issue.Severity == client.LintSeverityError {
+			logger.Errorf("%s: %s (%s)", location, issue.Message, issue.Rule)
+		} else {
+			logger.Warnf("%s: %s (%s)", location, issue.Message, issue.Rule)
+		}
+	}
+	logger.Infof("Linted %d files: %d errors, %d warnings", len(result.Files),
+		result.Count(client.LintSeverityError), result.Count(client.LintSeverityWarning))
+}
+
+func writeLintResult(logger logging.Logger, format string, result *client.LintResult) error {
+	if result.Issues == nil {
+		result.Issues = []client.LintIssue{}
+	}
+
+	buf := bytes.NewBuffer(nil)
+	if format == "json" {
+		encoder := json.NewEncoder(buf)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(result); err != nil {
+			return err
+		}
+	} else if err := yaml.NewEncoder(buf).Encode(result); err != nil {
+		return err
+	}
+
+	_, err := logger.Writer().Write(buf.Bytes())
+	return err
+}
@@ -0,0 +1,110 @@
+package commands_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestLintCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "LintCommand", testLintCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testLintCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		logger         logging.Logger
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+		result         *client.LintResult
+	)
+
+	it.Before(func() {
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		result = &client.LintResult{
+			Files: []string{"buildpack.toml", "builder.toml"},
+			Issues: []client.LintIssue{
+				{File: "buildpack.toml", Severity: client.LintSeverityWarning, Rule: client.LintRuleDeprecated, Key: "stacks", Message: "'stacks' are deprecated, prefer 'targets'"},
+				{File: "builder.toml", Severity: client.LintSeverityError, Rule: client.LintRuleOrder, Key: "order[0].group[0]", Message: "'example/bp' is not declared in 'buildpacks'"},
+			},
+		}
+
+		command = commands.Lint(logger, mockClient)
+	})
+
+	when("#Lint", func() {
+		it("lints the current directory by default", func() {
+			mockClient.EXPECT().
+				Lint(client.LintOptions{Paths: []string{"."}}).
+				Return(&client.LintResult{Files: []string{"buildpack.toml"}}, nil)
+
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "Linted 1 files: 0 errors, 0 warnings")
+		})
+
+		it("logs the issues and fails with errors", func() {
+			mockClient.EXPECT().
+				Lint(client.LintOptions{Paths: []string{"some-buildpack", "builder-jammy.toml"}, Kind: "builder"}).
+				Return(result, nil)
+
+			command.SetArgs([]string{"some-buildpack", "builder-jammy.toml", "--kind", "builder"})
+			h.AssertError(t, command.Execute(), "1 errors found")
+			h.AssertContains(t, outBuf.String(), "Warning: buildpack.toml: stacks: 'stacks' are deprecated, prefer 'targets' (deprecated)")
+			h.AssertContains(t, outBuf.String(), "ERROR: builder.toml: order[0].group[0]: 'example/bp' is not declared in 'buildpacks' (order)")
+			h.AssertContains(t, outBuf.String(), "Linted 2 files: 1 errors, 1 warnings")
+		})
+
+		it("doesn't fail with warnings only", func() {
+			result.Issues = result.Issues[:1]
+			mockClient.EXPECT().Lint(gomock.Any()).Return(result, nil)
+
+			h.AssertNil(t, command.Execute())
+		})
+
+		it("writes the issues as json", func() {
+			mockClient.EXPECT().Lint(gomock.Any()).Return(result, nil)
+
+			command.SetArgs([]string{"--output", "json"})
+			err := command.Execute()
+			h.AssertNotNil(t, err)
+			h.AssertEq(t, errors.Is(err, client.SoftError{}), true)
+
+			var written client.LintResult
+			h.AssertNil(t, json.Unmarshal(outBuf.Bytes(), &written))
+			h.AssertEq(t, written, *result)
+		})
+
+		it("writes the issues as yaml", func() {
+			mockClient.EXPECT().Lint(gomock.Any()).Return(&client.LintResult{Files: []string{"project.toml"}}, nil)
+
+			command.SetArgs([]string{"--output", "yaml"})
+			h.AssertNil(t, command.Execute())
+			h.AssertEq(t, outBuf.String(), "files:\n    - project.toml\nissues: []\n")
+		})
+
+		it("errors with an unknown output format", func() {
+			mockClient.EXPECT().Lint(gomock.Any()).Return(result, nil)
+
+			command.SetArgs([]string{"--output", "xml"})
+			h.AssertError(t, command.Execute(), "output format 'xml' is not supported")
+		})
+	})
+}
@@ -1,6 +1,10 @@
 package commands
 
 import (
+	"bufio"
+	"os"
+	"strings"
+
 	"github.com/pkg/errors"
 
 	"github.com/spf13/cobra"
@@ -9,6 +13,7 @@ import (
 	"github.com/buildpacks/pack/pkg/image"
 
 	"github.com/buildpacks/pack/internal/config"
+	strs "github.com/buildpacks/pack/internal/strings"
 	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/pkg/logging"
 )
@@ -16,16 +21,31 @@ import (
 func Rebase(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
 	var opts client.RebaseOptions
 	var policy string
+	var signKey string
+	var dryRun, check bool
+	var fromFile string
+	var parallelism int
 
 	cmd := &cobra.Command{
 		Use:     "rebase <image-name>",
-		Args:    cobra.ExactArgs(1),
+		Args:    cobra.MaximumNArgs(1),
 		Short:   "Rebase app image with latest run image",
 		Example: "pack rebase buildpacksio/pack",
 		Long: "Rebase allows you to quickly swap out the underlying OS layers (run image) of an app image generated by `pack build` " +
 			"with a newer version of the run image, without re-building the application.",
 		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
-			opts.RepoName = args[0]
+			if fromFile == "" && len(args) == 0 {
+				return errors.New("an image name or the from-file flag is required")
+			}
+			if fromFile != "" && len(args) > 0 {
+				return errors.New("image name cannot be provided with the from-file flag")
+			}
+			if fromFile == "" && cmd.Flags().Changed("parallelism") {
+				return errors.New("parallelism flag requires the from-file flag")
+			}
+			if len(args) > 0 {
+				opts.RepoName = args[0]
+			}
 			opts.AdditionalMirrors = getMirrors(cfg)
 
 			var err error
@@ -38,6 +58,27 @@ func Rebase(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Co
 				return errors.Wrapf(err, "parsing pull policy %s", stringPolicy)
 			}
 
+			if signKey != "" && !opts.Publish {
+				return errors.New("sign-key flag requires the publish flag")
+			}
+			if fromFile != "" {
+				if signKey != "" || opts.ReportDestinationDir != "" || opts.PreviousImage != "" || dryRun || check {
+					return errors.New("sign-key, report-output-dir, previous-image, dry-run and check flags cannot be used with the from-file flag")
+				}
+				return rebaseMany(cmd, logger, pack, opts, fromFile, parallelism)
+			}
+			if dryRun || check {
+				if signKey != "" || opts.ReportDestinationDir != "" {
+					return errors.New("sign-key and report-output-dir flags cannot be used with the dry-run or check flags")
+				}
+				return planRebase(cmd, logger, pack, opts, dryRun, check)
+			}
+
+			opts.Signer, err = loadSigner(signKey)
+			if err != nil {
+				return err
+			}
+
 			if err := pack.Rebase(cmd.Context(), opts); err != nil {
 				return err
 			}
@@ -52,7 +93,118 @@ func Rebase(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Co
 	cmd.Flags().StringVar(&opts.PreviousImage, "previous-image", "", "Image to rebase. Set to a particular tag reference, digest reference, or (when performing a daemon build) image ID. Use this flag in combination with <image-name> to avoid replacing the original image.")
 	cmd.Flags().StringVar(&opts.ReportDestinationDir, "report-output-dir", "", "Path to export build report.toml.\nOmitting the flag yield no report file.")
 	cmd.Flags().BoolVar(&opts.Force, "force", false, "Perform rebase operation without target validation (only available for API >= 0.12)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Report the run image the app image would be rebased on, and whether rebasing would change it, without rebasing")
+	cmd.Flags().BoolVar(&check, "check", false, "Exit with code 2 if a newer compatible run image is available, and 0 if the app image is up to date, without rebasing")
+	cmd.Flags().StringVar(&fromFile, "from-file", "", "Path to a file listing the images to rebase, one per line, instead of <image-name>. Blank lines and lines starting with '#' are ignored")
+	cmd.Flags().IntVar(&parallelism, "parallelism", client.DefaultRebaseParallelism, "Maximum number of images to rebase at once. Requires --from-file")
+	cmd.Flags().StringVar(&signKey, "sign-key", "", "Path to a cosign-compatible private key to sign the rebased image with. Encrypted keys are decrypted with the password in the COSIGN_PASSWORD environment variable. Requires --publish")
 
 	AddHelpFlag(cmd, "rebase")
 	return cmd
 }
+
+func planRebase(cmd *cobra.Command, logger logging.Logger, pack PackClient, opts client.RebaseOptions, dryRun, check bool) error {
+	plan, err := pack.PlanRebase(cmd.Context(), opts)
+	if err != nil {
+		return err
+	}
+
+	if dryRun {
+		logger.Infof("Rebase plan for %s:", style.Symbol(plan.AppImage))
+		logger.Infof("  Run image:      %s", style.Symbol(plan.RunImage))
+		logger.Infof("  Current:        %s (top layer %s)", strs.ValueOrDefault(plan.CurrentRunImageReference, "unknown"), plan.CurrentTopLayer)
+		logger.Infof("  New:            %s (top layer %s)", plan.NewRunImageReference, plan.NewTopLayer)
+		if plan.Compatible {
+			logger.Info("  Compatible:     yes")
+		} else {
+			logger.Infof("  Compatible:     no, %s", plan.IncompatibleReason)
+		}
+		logger.Infof("  Rebase needed:  %s", yesNo(plan.RebaseNeeded))
+	}
+
+	if !check {
+		return nil
+	}
+
+	switch {
+	case !plan.RebaseNeeded:
+		logger.Infof("%s is up to date with run image %s", style.Symbol(plan.AppImage), style.Symbol(plan.RunImage))
+		return nil
+	case !plan.Compatible:
+		return errors.Errorf("run image %s cannot be used to rebase %s: %s", style.Symbol(plan.RunImage), style.Symbol(plan.AppImage), plan.IncompatibleReason)
+	default:
+		logger.Infof("%s can be rebased on a newer run image %s", style.Symbol(plan.AppImage), style.Symbol(plan.RunImage))
+		return client.NewSoftError()
+	}
+}
+
+func rebaseMany(cmd *cobra.Command, logger logging.Logger, pack PackClient, opts client.RebaseOptions, fromFile string, parallelism int) error {
+	repoNames, err := readImageList(fromFile)
+	if err != nil {
+		return err
+	}
+	if len(repoNames) == 0 {
+		return errors.Errorf("no images listed in %s", style.Symbol(fromFile))
+	}
+
+	results, err := pack.RebaseMany(cmd.Context(), client.RebaseManyOptions{
+		RepoNames:         repoNames,
+		Parallelism:       parallelism,
+		Publish:           opts.Publish,
+		PullPolicy:        opts.PullPolicy,
+		RunImage:          opts.RunImage,
+		AdditionalMirrors: opts.AdditionalMirrors,
+		Force:             opts.Force,
+	})
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	logger.Info("")
+	logger.Info("Rebase summary:")
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+			logger.Infof("  %s  %s: %s", style.Error("FAILED"), style.Symbol(result.RepoName), result.Err)
+			continue
+		}
+		logger.Infof("  %s  %s", style.Complete("OK    "), style.Symbol(result.RepoName))
+	}
+
+	if failed > 0 {
+		return errors.Errorf("failed to rebase %d of %d images", failed, len(results))
+	}
+	logger.Infof("Successfully rebased %d images", len(results))
+	return nil
+}
+
+// readImageList reads the image names listed in a file, one per line, ignoring blank lines and comments.
+func readImageList(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "opening %s", style.Symbol(path))
+	}
+	defer f.Close()
+
+	var names []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		names = append(names, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrapf(err, "reading %s", style.Symbol(path))
+	}
+	return names, nil
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
@@ -2,6 +2,9 @@ package commands_test
 
 import (
 	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/heroku/color"
@@ -51,7 +54,74 @@ func testRebaseCommand(t *testing.T, when spec.G, it spec.S) {
 		when("no image is provided", func() {
 			it("fails to run", func() {
 				err := command.Execute()
-				h.AssertError(t, err, "accepts 1 arg")
+				h.AssertError(t, err, "an image name or the from-file flag is required")
+			})
+		})
+
+		when("--from-file", func() {
+			var (
+				imagesFile string
+				opts       client.RebaseManyOptions
+			)
+
+			it.Before(func() {
+				imagesFile = filepath.Join(t.TempDir(), "images.txt")
+				h.AssertNil(t, os.WriteFile(imagesFile, []byte("# apps\nsome/app-one\n\n  some/app-two  \n"), 0600))
+
+				opts = client.RebaseManyOptions{
+					RepoNames:         []string{"some/app-one", "some/app-two"},
+					Parallelism:       client.DefaultRebaseParallelism,
+					PullPolicy:        image.PullAlways,
+					AdditionalMirrors: map[string][]string{},
+				}
+			})
+
+			it("rebases every image listed in the file", func() {
+				opts.Parallelism = 8
+				mockClient.EXPECT().
+					RebaseMany(gomock.Any(), opts).
+					Return([]client.RebaseResult{{RepoName: "some/app-one"}, {RepoName: "some/app-two"}}, nil)
+
+				command.SetArgs([]string{"--from-file", imagesFile, "--parallelism", "8"})
+				h.AssertNil(t, command.Execute())
+				h.AssertContainsMatch(t, outBuf.String(), `OK\s+'some/app-one'`)
+				h.AssertContainsMatch(t, outBuf.String(), `OK\s+'some/app-two'`)
+				h.AssertContains(t, outBuf.String(), "Successfully rebased 2 images")
+			})
+
+			it("summarizes failures", func() {
+				mockClient.EXPECT().
+					RebaseMany(gomock.Any(), opts).
+					Return([]client.RebaseResult{{RepoName: "some/app-one", Err: errors.New("some-error")}, {RepoName: "some/app-two"}}, nil)
+
+				command.SetArgs([]string{"--from-file", imagesFile})
+				h.AssertError(t, command.Execute(), "failed to rebase 1 of 2 images")
+				h.AssertContains(t, outBuf.String(), "FAILED  'some/app-one': some-error")
+				h.AssertContainsMatch(t, outBuf.String(), `OK\s+'some/app-two'`)
+			})
+
+			it("errors when the file lists no images", func() {
+				h.AssertNil(t, os.WriteFile(imagesFile, []byte("# nothing\n"), 0600))
+
+				command.SetArgs([]string{"--from-file", imagesFile})
+				h.AssertError(t, command.Execute(), "no images listed in")
+			})
+
+			it("errors with an image name", func() {
+				command.SetArgs([]string{"some/app", "--from-file", imagesFile})
+				h.AssertError(t, command.Execute(), "image name cannot be provided with the from-file flag")
+			})
+
+			it("errors with --previous-image", func() {
+				command.SetArgs([]string{"--from-file", imagesFile, "--previous-image", "some/previous"})
+				h.AssertError(t, command.Execute(), "cannot be used with the from-file flag")
+			})
+		})
+
+		when("--parallelism without --from-file", func() {
+			it("fails to run", func() {
+				command.SetArgs([]string{"some/app", "--parallelism", "2"})
+				h.AssertError(t, command.Execute(), "parallelism flag requires the from-file flag")
 			})
 		})
 
@@ -116,6 +186,108 @@ func testRebaseCommand(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 
+			when("--sign-key", func() {
+				var privateKeyPath string
+
+				it.Before(func() {
+					privateKeyPath, _ = writeSigningKeys(t, t.TempDir())
+				})
+
+				it("errors when --publish is not used", func() {
+					command.SetArgs([]string{repoName, "--sign-key", privateKeyPath})
+					h.AssertError(t, command.Execute(), "sign-key flag requires the publish flag")
+				})
+
+				it("forwards the signer onto the client", func() {
+					mockClient.EXPECT().
+						Rebase(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(_ interface{}, o client.RebaseOptions) error {
+							h.AssertNotNil(t, o.Signer)
+							h.AssertEq(t, o.Publish, true)
+							return nil
+						})
+
+					command.SetArgs([]string{repoName, "--sign-key", privateKeyPath, "--publish"})
+					h.AssertNil(t, command.Execute())
+				})
+			})
+
+			when("--dry-run", func() {
+				it("reports the plan without rebasing", func() {
+					mockClient.EXPECT().
+						PlanRebase(gomock.Any(), opts).
+						Return(&client.RebasePlan{
+							AppImage:                 repoName,
+							RunImage:                 "test/image",
+							CurrentRunImageReference: "test/image@sha256:old",
+							CurrentTopLayer:          "sha256:old-top",
+							NewRunImageReference:     "test/image@sha256:new",
+							NewTopLayer:              "sha256:new-top",
+							Compatible:               true,
+							RebaseNeeded:             true,
+						}, nil)
+
+					command.SetArgs([]string{repoName, "--dry-run"})
+					h.AssertNil(t, command.Execute())
+
+					h.AssertContains(t, outBuf.String(), "Rebase plan for 'test/repo-image':")
+					h.AssertContainsMatch(t, outBuf.String(), `Current:\s+test/image@sha256:old \(top layer sha256:old-top\)`)
+					h.AssertContainsMatch(t, outBuf.String(), `New:\s+test/image@sha256:new \(top layer sha256:new-top\)`)
+					h.AssertContainsMatch(t, outBuf.String(), `Compatible:\s+yes`)
+					h.AssertContainsMatch(t, outBuf.String(), `Rebase needed:\s+yes`)
+					h.AssertNotContains(t, outBuf.String(), "Successfully rebased")
+				})
+
+				it("reports why the run image is incompatible", func() {
+					mockClient.EXPECT().
+						PlanRebase(gomock.Any(), opts).
+						Return(&client.RebasePlan{AppImage: repoName, IncompatibleReason: "some-reason"}, nil)
+
+					command.SetArgs([]string{repoName, "--dry-run"})
+					h.AssertNil(t, command.Execute())
+
+					h.AssertContainsMatch(t, outBuf.String(), `Compatible:\s+no, some-reason`)
+				})
+
+				it("errors with --report-output-dir", func() {
+					command.SetArgs([]string{repoName, "--dry-run", "--report-output-dir", "some-dir"})
+					h.AssertError(t, command.Execute(), "sign-key and report-output-dir flags cannot be used with the dry-run or check flags")
+				})
+			})
+
+			when("--check", func() {
+				it("succeeds when the image is up to date", func() {
+					mockClient.EXPECT().
+						PlanRebase(gomock.Any(), opts).
+						Return(&client.RebasePlan{AppImage: repoName, RunImage: "test/image", Compatible: true}, nil)
+
+					command.SetArgs([]string{repoName, "--check"})
+					h.AssertNil(t, command.Execute())
+					h.AssertContains(t, outBuf.String(), "'test/repo-image' is up to date with run image 'test/image'")
+				})
+
+				it("returns a soft error when a rebase is needed", func() {
+					mockClient.EXPECT().
+						PlanRebase(gomock.Any(), opts).
+						Return(&client.RebasePlan{AppImage: repoName, RunImage: "test/image", Compatible: true, RebaseNeeded: true}, nil)
+
+					command.SetArgs([]string{repoName, "--check"})
+					err := command.Execute()
+					_, isSoftError := err.(client.SoftError)
+					h.AssertTrue(t, isSoftError)
+					h.AssertContains(t, outBuf.String(), "'test/repo-image' can be rebased on a newer run image 'test/image'")
+				})
+
+				it("errors when the newer run image is incompatible", func() {
+					mockClient.EXPECT().
+						PlanRebase(gomock.Any(), opts).
+						Return(&client.RebasePlan{AppImage: repoName, RunImage: "test/image", RebaseNeeded: true, IncompatibleReason: "some-reason"}, nil)
+
+					command.SetArgs([]string{repoName, "--check"})
+					h.AssertError(t, command.Execute(), "run image 'test/image' cannot be used to rebase 'test/repo-image': some-reason")
+				})
+			})
+
 			when("--pull-policy unknown-policy", func() {
 				it("fails to run", func() {
 					command.SetArgs([]string{repoName, "--pull-policy", "unknown-policy"})
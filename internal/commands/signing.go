@@ -0,0 +1,36 @@
+package commands
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/pkg/signing"
+)
+
+// loadSigner loads the private key at keyPath, if any, decrypting it with the password in the COSIGN_PASSWORD
+// environment variable when it is encrypted.
+func loadSigner(keyPath string) (*signing.Signer, error) {
+	if keyPath == "" {
+		return nil, nil
+	}
+
+	signer, err := signing.LoadSigner(keyPath, []byte(os.Getenv(signing.PasswordEnvVar)))
+	if err != nil {
+		return nil, errors.Wrap(err, "loading signing key")
+	}
+	return signer, nil
+}
+
+// loadVerifier loads the public keys at keyPaths, if any.
+func loadVerifier(keyPaths []string) (*signing.Verifier, error) {
+	if len(keyPaths) == 0 {
+		return nil, nil
+	}
+
+	verifier, err := signing.LoadVerifier(keyPaths...)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading verification keys")
+	}
+	return verifier, nil
+}
@@ -134,6 +134,34 @@ func (mr *MockPackClientMockRecorder) DownloadSBOM(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadSBOM", reflect.TypeOf((*MockPackClient)(nil).DownloadSBOM), arg0, arg1)
 }
 
+// ExportCache mocks base method.
+func (m *MockPackClient) ExportCache(arg0 context.Context, arg1 client.ExportCacheOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportCache", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportCache indicates an expected call of ExportCache.
+func (mr *MockPackClientMockRecorder) ExportCache(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportCache", reflect.TypeOf((*MockPackClient)(nil).ExportCache), arg0, arg1)
+}
+
+// ImportCache mocks base method.
+func (m *MockPackClient) ImportCache(arg0 context.Context, arg1 client.ImportCacheOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportCache", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportCache indicates an expected call of ImportCache.
+func (mr *MockPackClientMockRecorder) ImportCache(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportCache", reflect.TypeOf((*MockPackClient)(nil).ImportCache), arg0, arg1)
+}
+
 // InspectBuilder mocks base method.
 func (m *MockPackClient) InspectBuilder(arg0 string, arg1 bool, arg2 ...client.BuilderInspectionModifier) (*client.BuilderInfo, error) {
 	m.ctrl.T.Helper()
@@ -169,6 +197,21 @@ func (mr *MockPackClientMockRecorder) InspectBuildpack(arg0 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InspectBuildpack", reflect.TypeOf((*MockPackClient)(nil).InspectBuildpack), arg0)
 }
 
+// InspectCache mocks base method.
+func (m *MockPackClient) InspectCache(arg0 context.Context, arg1 string) (*client.CacheInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InspectCache", arg0, arg1)
+	ret0, _ := ret[0].(*client.CacheInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InspectCache indicates an expected call of InspectCache.
+func (mr *MockPackClientMockRecorder) InspectCache(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InspectCache", reflect.TypeOf((*MockPackClient)(nil).InspectCache), arg0, arg1)
+}
+
 // InspectExtension mocks base method.
 func (m *MockPackClient) InspectExtension(arg0 client.InspectExtensionOptions) (*client.ExtensionInfo, error) {
 	m.ctrl.T.Helper()
@@ -185,18 +228,23 @@ func (mr *MockPackClientMockRecorder) InspectExtension(arg0 interface{}) *gomock
 }
 
 // InspectImage mocks base method.
-func (m *MockPackClient) InspectImage(arg0 string, arg1 bool) (*client.ImageInfo, error) {
+func (m *MockPackClient) InspectImage(arg0 string, arg1 bool, arg2 ...client.ImageInspectionModifier) (*client.ImageInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InspectImage", arg0, arg1)
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "InspectImage", varargs...)
 	ret0, _ := ret[0].(*client.ImageInfo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // InspectImage indicates an expected call of InspectImage.
-func (mr *MockPackClientMockRecorder) InspectImage(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockPackClientMockRecorder) InspectImage(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InspectImage", reflect.TypeOf((*MockPackClient)(nil).InspectImage), arg0, arg1)
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InspectImage", reflect.TypeOf((*MockPackClient)(nil).InspectImage), varargs...)
 }
 
 // InspectManifest mocks base method.
@@ -213,6 +261,36 @@ func (mr *MockPackClientMockRecorder) InspectManifest(arg0 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InspectManifest", reflect.TypeOf((*MockPackClient)(nil).InspectManifest), arg0)
 }
 
+// Lint mocks base method.
+func (m *MockPackClient) Lint(arg0 client.LintOptions) (*client.LintResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lint", arg0)
+	ret0, _ := ret[0].(*client.LintResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Lint indicates an expected call of Lint.
+func (mr *MockPackClientMockRecorder) Lint(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lint", reflect.TypeOf((*MockPackClient)(nil).Lint), arg0)
+}
+
+// ListCaches mocks base method.
+func (m *MockPackClient) ListCaches(arg0 context.Context) ([]client.CacheInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCaches", arg0)
+	ret0, _ := ret[0].([]client.CacheInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCaches indicates an expected call of ListCaches.
+func (mr *MockPackClientMockRecorder) ListCaches(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCaches", reflect.TypeOf((*MockPackClient)(nil).ListCaches), arg0)
+}
+
 // NewBuildpack mocks base method.
 func (m *MockPackClient) NewBuildpack(arg0 context.Context, arg1 client.NewBuildpackOptions) error {
 	m.ctrl.T.Helper()
@@ -227,6 +305,20 @@ func (mr *MockPackClientMockRecorder) NewBuildpack(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewBuildpack", reflect.TypeOf((*MockPackClient)(nil).NewBuildpack), arg0, arg1)
 }
 
+// NewExtension mocks base method.
+func (m *MockPackClient) NewExtension(arg0 context.Context, arg1 client.NewExtensionOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewExtension", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NewExtension indicates an expected call of NewExtension.
+func (mr *MockPackClientMockRecorder) NewExtension(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewExtension", reflect.TypeOf((*MockPackClient)(nil).NewExtension), arg0, arg1)
+}
+
 // PackageBuildpack mocks base method.
 func (m *MockPackClient) PackageBuildpack(arg0 context.Context, arg1 client.PackageBuildpackOptions) error {
 	m.ctrl.T.Helper()
@@ -255,6 +347,36 @@ func (mr *MockPackClientMockRecorder) PackageExtension(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PackageExtension", reflect.TypeOf((*MockPackClient)(nil).PackageExtension), arg0, arg1)
 }
 
+// PlanRebase mocks base method.
+func (m *MockPackClient) PlanRebase(arg0 context.Context, arg1 client.RebaseOptions) (*client.RebasePlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PlanRebase", arg0, arg1)
+	ret0, _ := ret[0].(*client.RebasePlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PlanRebase indicates an expected call of PlanRebase.
+func (mr *MockPackClientMockRecorder) PlanRebase(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlanRebase", reflect.TypeOf((*MockPackClient)(nil).PlanRebase), arg0, arg1)
+}
+
+// PruneCaches mocks base method.
+func (m *MockPackClient) PruneCaches(arg0 context.Context, arg1 client.PruneCachesOptions) ([]client.CacheInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PruneCaches", arg0, arg1)
+	ret0, _ := ret[0].([]client.CacheInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PruneCaches indicates an expected call of PruneCaches.
+func (mr *MockPackClientMockRecorder) PruneCaches(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneCaches", reflect.TypeOf((*MockPackClient)(nil).PruneCaches), arg0, arg1)
+}
+
 // PullBuildpack mocks base method.
 func (m *MockPackClient) PullBuildpack(arg0 context.Context, arg1 client.PullBuildpackOptions) error {
 	m.ctrl.T.Helper()
@@ -297,6 +419,21 @@ func (mr *MockPackClientMockRecorder) Rebase(arg0, arg1 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rebase", reflect.TypeOf((*MockPackClient)(nil).Rebase), arg0, arg1)
 }
 
+// RebaseMany mocks base method.
+func (m *MockPackClient) RebaseMany(arg0 context.Context, arg1 client.RebaseManyOptions) ([]client.RebaseResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RebaseMany", arg0, arg1)
+	ret0, _ := ret[0].([]client.RebaseResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RebaseMany indicates an expected call of RebaseMany.
+func (mr *MockPackClientMockRecorder) RebaseMany(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RebaseMany", reflect.TypeOf((*MockPackClient)(nil).RebaseMany), arg0, arg1)
+}
+
 // RegisterBuildpack mocks base method.
 func (m *MockPackClient) RegisterBuildpack(arg0 context.Context, arg1 client.RegisterBuildpackOptions) error {
 	m.ctrl.T.Helper()
@@ -325,6 +462,35 @@ func (mr *MockPackClientMockRecorder) RemoveManifest(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveManifest", reflect.TypeOf((*MockPackClient)(nil).RemoveManifest), arg0, arg1)
 }
 
+// TestBuildpack mocks base method.
+func (m *MockPackClient) TestBuildpack(arg0 context.Context, arg1 client.TestBuildpackOptions) (*client.BuildpackTestResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TestBuildpack", arg0, arg1)
+	ret0, _ := ret[0].(*client.BuildpackTestResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TestBuildpack indicates an expected call of TestBuildpack.
+func (mr *MockPackClientMockRecorder) TestBuildpack(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TestBuildpack", reflect.TypeOf((*MockPackClient)(nil).TestBuildpack), arg0, arg1)
+}
+
+// Watch mocks base method.
+func (m *MockPackClient) Watch(arg0 context.Context, arg1 client.WatchOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Watch", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Watch indicates an expected call of Watch.
+func (mr *MockPackClientMockRecorder) Watch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Watch", reflect.TypeOf((*MockPackClient)(nil).Watch), arg0, arg1)
+}
+
 // YankBuildpack mocks base method.
 func (m *MockPackClient) YankBuildpack(arg0 client.YankBuildpackOptions) error {
 	m.ctrl.T.Helper()
@@ -18,7 +18,7 @@ func TrustBuilder(logger logging.Logger, cfg config.Config, cfgPath string) *cob
 		Hidden:  true,
 		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
 			deprecationWarning(logger, "trust-builder", "config trusted-builders add")
-			return addTrustedBuilder(args, logger, cfg, cfgPath)
+			return addTrustedBuilder(args, trustedBuilderFlags{}, logger, cfg, cfgPath)
 		}),
 	}
 
@@ -17,6 +17,7 @@ type Config struct {
 	DefaultBuilder      string            `toml:"default-builder-image,omitempty"`
 	PullPolicy          string            `toml:"pull-policy,omitempty"`
 	Experimental        bool              `toml:"experimental,omitempty"`
+	Offline             bool              `toml:"offline,omitempty"`
 	RunImages           []RunImage        `toml:"run-images"`
 	TrustedBuilders     []TrustedBuilder  `toml:"trusted-builders,omitempty"`
 	Registries          []Registry        `toml:"registries,omitempty"`
@@ -38,6 +39,10 @@ type RunImage struct {
 
 type TrustedBuilder struct {
 	Name string `toml:"name"`
+	// Digest, when set, is the only builder image digest that is trusted.
+	Digest string `toml:"digest,omitempty"`
+	// SigningKeys, when set, are paths to public keys one of which must have signed the builder image for it to be trusted.
+	SigningKeys []string `toml:"signing-keys,omitempty"`
 }
 
 const OfficialRegistryName = "official"
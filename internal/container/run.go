@@ -11,6 +11,15 @@ import (
 	"github.com/pkg/errors"
 )
 
+// StatusError is returned when a container exits with a non-zero status code.
+type StatusError struct {
+	StatusCode int64
+}
+
+func (e StatusError) Error() string {
+	return fmt.Sprintf("failed with status code: %d", e.StatusCode)
+}
+
 type Handler func(bodyChan <-chan dcontainer.WaitResponse, errChan <-chan error, reader io.Reader) error
 
 type DockerClient interface {
@@ -44,10 +53,18 @@ func ContainerWaitWrapper(ctx context.Context, docker DockerClient, container st
 }
 
 func RunWithHandler(ctx context.Context, docker DockerClient, ctrID string, handler Handler) error {
+	return RunWithInput(ctx, docker, ctrID, nil, handler)
+}
+
+// RunWithInput runs the container like RunWithHandler, writing stdin to the standard input of the container once it
+// starts, if not nil. The container must be created with its standard input open once. Errors writing the input are
+// not returned, as the container then fails reading it.
+func RunWithInput(ctx context.Context, docker DockerClient, ctrID string, stdin io.Reader, handler Handler) error {
 	bodyChan, errChan := ContainerWaitWrapper(ctx, docker, ctrID, dcontainer.WaitConditionNextExit)
 
 	resp, err := docker.ContainerAttach(ctx, ctrID, dcontainer.AttachOptions{
 		Stream: true,
+		Stdin:  stdin != nil,
 		Stdout: true,
 		Stderr: true,
 	})
@@ -60,6 +77,13 @@ func RunWithHandler(ctx context.Context, docker DockerClient, ctrID string, hand
 		return errors.Wrap(err, "container start")
 	}
 
+	if stdin != nil {
+		go func() {
+			_, _ = io.Copy(resp.Conn, stdin)
+			_ = resp.CloseWrite()
+		}()
+	}
+
 	return handler(bodyChan, errChan, resp.Reader)
 }
 
@@ -77,7 +101,7 @@ func DefaultHandler(out, errOut io.Writer) Handler {
 		select {
 		case body := <-bodyChan:
 			if body.StatusCode != 0 {
-				return fmt.Errorf("failed with status code: %d", body.StatusCode)
+				return StatusError{StatusCode: body.StatusCode}
 			}
 		case err := <-errChan:
 			return err
@@ -4,13 +4,25 @@ import (
 	"context"
 
 	"github.com/buildpacks/pack/internal/build"
+	"github.com/buildpacks/pack/pkg/events"
 )
 
 type FakeLifecycle struct {
 	Opts build.LifecycleOptions
+
+	// Events are emitted to the events emitter of the options on Execute.
+	Events []events.Event
+
+	// ExecuteErr is returned by Execute.
+	ExecuteErr error
 }
 
 func (f *FakeLifecycle) Execute(ctx context.Context, opts build.LifecycleOptions) error {
 	f.Opts = opts
-	return nil
+	if opts.Events != nil {
+		for _, event := range f.Events {
+			opts.Events.Emit(event)
+		}
+	}
+	return f.ExecuteErr
 }
@@ -0,0 +1,253 @@
+package inspectimage
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/buildpacks/lifecycle/buildpack"
+
+	"github.com/buildpacks/pack/pkg/client"
+)
+
+// Kinds of change between two images.
+const (
+	ChangeAdded   = "added"
+	ChangeRemoved = "removed"
+	ChangeChanged = "changed"
+)
+
+type ModuleChange struct {
+	ID          string `json:"id" yaml:"id" toml:"id"`
+	Change      string `json:"change" yaml:"change" toml:"change"`
+	FromVersion string `json:"from_version,omitempty" yaml:"from_version,omitempty" toml:"from_version,omitempty"`
+	ToVersion   string `json:"to_version,omitempty" yaml:"to_version,omitempty" toml:"to_version,omitempty"`
+}
+
+type BOMChange struct {
+	Name        string `json:"name" yaml:"name" toml:"name"`
+	Buildpack   string `json:"buildpack" yaml:"buildpack" toml:"buildpack"`
+	Change      string `json:"change" yaml:"change" toml:"change"`
+	FromVersion string `json:"from_version,omitempty" yaml:"from_version,omitempty" toml:"from_version,omitempty"`
+	ToVersion   string `json:"to_version,omitempty" yaml:"to_version,omitempty" toml:"to_version,omitempty"`
+}
+
+type ProcessChange struct {
+	Type        string `json:"type" yaml:"type" toml:"type"`
+	Change      string `json:"change" yaml:"change" toml:"change"`
+	FromCommand string `json:"from_command,omitempty" yaml:"from_command,omitempty" toml:"from_command,omitempty"`
+	ToCommand   string `json:"to_command,omitempty" yaml:"to_command,omitempty" toml:"to_command,omitempty"`
+}
+
+type RunImageChange struct {
+	FromImage     string `json:"from_image,omitempty" yaml:"from_image,omitempty" toml:"from_image,omitempty"`
+	ToImage       string `json:"to_image,omitempty" yaml:"to_image,omitempty" toml:"to_image,omitempty"`
+	FromReference string `json:"from_reference,omitempty" yaml:"from_reference,omitempty" toml:"from_reference,omitempty"`
+	ToReference   string `json:"to_reference,omitempty" yaml:"to_reference,omitempty" toml:"to_reference,omitempty"`
+	FromTopLayer  string `json:"from_top_layer,omitempty" yaml:"from_top_layer,omitempty" toml:"from_top_layer,omitempty"`
+	ToTopLayer    string `json:"to_top_layer,omitempty" yaml:"to_top_layer,omitempty" toml:"to_top_layer,omitempty"`
+}
+
+type LayerChange struct {
+	Buildpack  string `json:"buildpack" yaml:"buildpack" toml:"buildpack"`
+	Layer      string `json:"layer" yaml:"layer" toml:"layer"`
+	Change     string `json:"change" yaml:"change" toml:"change"`
+	FromDigest string `json:"from_digest,omitempty" yaml:"from_digest,omitempty" toml:"from_digest,omitempty"`
+	ToDigest   string `json:"to_digest,omitempty" yaml:"to_digest,omitempty" toml:"to_digest,omitempty"`
+}
+
+// DiffDisplay lists what changed from one image to another. Lists are empty when nothing changed.
+type DiffDisplay struct {
+	From       string          `json:"from" yaml:"from" toml:"from"`
+	To         string          `json:"to" yaml:"to" toml:"to"`
+	Buildpacks []ModuleChange  `json:"buildpacks" yaml:"buildpacks" toml:"buildpacks"`
+	BOM        []BOMChange     `json:"bom" yaml:"bom" toml:"bom"`
+	Processes  []ProcessChange `json:"processes" yaml:"processes" toml:"processes"`
+	RunImage   *RunImageChange `json:"run_image,omitempty" yaml:"run_image,omitempty" toml:"run_image,omitempty"`
+	Layers     []LayerChange   `json:"layers" yaml:"layers" toml:"layers"`
+}
+
+// Empty returns true if the images do not differ.
+func (d *DiffDisplay) Empty() bool {
+	return len(d.Buildpacks) == 0 && len(d.BOM) == 0 && len(d.Processes) == 0 && d.RunImage == nil && len(d.Layers) == 0
+}
+
+func NewDiffDisplay(fromName string, from *client.ImageInfo, toName string, to *client.ImageInfo) *DiffDisplay {
+	return &DiffDisplay{
+		From:       fromName,
+		To:         toName,
+		Buildpacks: diffBuildpacks(from.Buildpacks, to.Buildpacks),
+		BOM:        diffBOM(from.BOM, to.BOM),
+		Processes:  diffProcesses(from.Processes, to.Processes),
+		RunImage:   diffRunImage(from, to),
+		Layers:     diffLayers(from.BuildpackLayers, to.BuildpackLayers),
+	}
+}
+
+//
+// private functions
+//
+
+func diffBuildpacks(from, to []buildpack.GroupElement) []ModuleChange {
+	fromVersions := map[string]string{}
+	for _, bp := range from {
+		fromVersions[bp.ID] = bp.Version
+	}
+	toVersions := map[string]string{}
+	for _, bp := range to {
+		toVersions[bp.ID] = bp.Version
+	}
+
+	result := []ModuleChange{}
+	for _, id := range sortedKeys(fromVersions, toVersions) {
+		fromVersion, inFrom := fromVersions[id]
+		toVersion, inTo := toVersions[id]
+		if change := changeOf(inFrom, inTo, fromVersion == toVersion); change != "" {
+			result = append(result, ModuleChange{ID: id, Change: change, FromVersion: fromVersion, ToVersion: toVersion})
+		}
+	}
+	return result
+}
+
+func diffBOM(from, to []buildpack.BOMEntry) []BOMChange {
+	key := func(entry buildpack.BOMEntry) string {
+		return entry.Buildpack.ID + "\x00" + entry.Name
+	}
+	fromEntries := map[string]buildpack.BOMEntry{}
+	for _, entry := range from {
+		fromEntries[key(entry)] = entry
+	}
+	toEntries := map[string]buildpack.BOMEntry{}
+	for _, entry := range to {
+		toEntries[key(entry)] = entry
+	}
+
+	result := []BOMChange{}
+	for _, k := range sortedKeys(fromEntries, toEntries) {
+		fromEntry, inFrom := fromEntries[k]
+		toEntry, inTo := toEntries[k]
+		same := bomVersion(fromEntry) == bomVersion(toEntry) && reflect.DeepEqual(fromEntry.Metadata, toEntry.Metadata)
+		if change := changeOf(inFrom, inTo, same); change != "" {
+			entry := toEntry
+			if !inTo {
+				entry = fromEntry
+			}
+			result = append(result, BOMChange{
+				Name:        entry.Name,
+				Buildpack:   entry.Buildpack.ID,
+				Change:      change,
+				FromVersion: bomVersion(fromEntry),
+				ToVersion:   bomVersion(toEntry),
+			})
+		}
+	}
+	return result
+}
+
+// bomVersion returns the version of the entry, which older buildpacks set in its metadata.
+func bomVersion(entry buildpack.BOMEntry) string {
+	if entry.Version != "" {
+		return entry.Version
+	}
+	if version, ok := entry.Metadata["version"].(string); ok {
+		return version
+	}
+	return ""
+}
+
+func diffProcesses(from, to client.ProcessDetails) []ProcessChange {
+	fromCommands := processCommands(from)
+	toCommands := processCommands(to)
+
+	result := []ProcessChange{}
+	for _, processType := range sortedKeys(fromCommands, toCommands) {
+		fromCommand, inFrom := fromCommands[processType]
+		toCommand, inTo := toCommands[processType]
+		if change := changeOf(inFrom, inTo, fromCommand == toCommand); change != "" {
+			result = append(result, ProcessChange{Type: processType, Change: change, FromCommand: fromCommand, ToCommand: toCommand})
+		}
+	}
+	return result
+}
+
+// processCommands returns the command line of each process, by type.
+func processCommands(details client.ProcessDetails) map[string]string {
+	result := map[string]string{}
+	for _, process := range displayProcesses(details) {
+		result[process.Type] = strings.Join(append([]string{process.Command}, process.Args...), " ")
+	}
+	return result
+}
+
+func diffRunImage(from, to *client.ImageInfo) *RunImageChange {
+	if from.Stack.RunImage.Image == to.Stack.RunImage.Image &&
+		from.Base.Reference == to.Base.Reference &&
+		from.Base.TopLayer == to.Base.TopLayer {
+		return nil
+	}
+
+	return &RunImageChange{
+		FromImage:     from.Stack.RunImage.Image,
+		ToImage:       to.Stack.RunImage.Image,
+		FromReference: from.Base.Reference,
+		ToReference:   to.Base.Reference,
+		FromTopLayer:  from.Base.TopLayer,
+		ToTopLayer:    to.Base.TopLayer,
+	}
+}
+
+func diffLayers(from, to []buildpack.LayersMetadata) []LayerChange {
+	layerDigests := func(buildpacks []buildpack.LayersMetadata) map[string]string {
+		result := map[string]string{}
+		for _, bp := range buildpacks {
+			for name, layer := range bp.Layers {
+				result[bp.ID+"\x00"+name] = layer.SHA
+			}
+		}
+		return result
+	}
+	fromDigests := layerDigests(from)
+	toDigests := layerDigests(to)
+
+	result := []LayerChange{}
+	for _, k := range sortedKeys(fromDigests, toDigests) {
+		fromDigest, inFrom := fromDigests[k]
+		toDigest, inTo := toDigests[k]
+		if change := changeOf(inFrom, inTo, fromDigest == toDigest); change != "" {
+			bp, layer, _ := strings.Cut(k, "\x00")
+			result = append(result, LayerChange{Buildpack: bp, Layer: layer, Change: change, FromDigest: fromDigest, ToDigest: toDigest})
+		}
+	}
+	return result
+}
+
+// changeOf returns how an item changed given whether it is in each image and whether it is the same in both, or an
+// empty string if it did not.
+func changeOf(inFrom, inTo, same bool) string {
+	switch {
+	case inFrom && !inTo:
+		return ChangeRemoved
+	case !inFrom && inTo:
+		return ChangeAdded
+	case !same:
+		return ChangeChanged
+	default:
+		return ""
+	}
+}
+
+// sortedKeys returns the keys of both maps, sorted.
+func sortedKeys[V any](from, to map[string]V) []string {
+	seen := map[string]bool{}
+	var keys []string
+	for _, m := range []map[string]V{from, to} {
+		for k := range m {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -13,6 +13,7 @@ import (
 type GeneralInfo struct {
 	Name            string
 	RunImageMirrors []config.RunImage
+	ShowLayers      bool
 }
 
 type RunImageMirrorDisplay struct {
@@ -34,6 +35,13 @@ type ProcessDisplay struct {
 	WorkDir string   `json:"working-dir" yaml:"working-dir" toml:"working-dir"`
 }
 
+type LayerDisplay struct {
+	DiffID string `json:"diff_id" yaml:"diff_id" toml:"diff_id"`
+	Size   int64  `json:"size" yaml:"size" toml:"size"`
+	Group  string `json:"group" yaml:"group" toml:"group"`
+	Owner  string `json:"owner,omitempty" yaml:"owner,omitempty" toml:"owner,omitempty"`
+}
+
 type BaseDisplay struct {
 	TopLayer  string `json:"top_layer" yaml:"top_layer" toml:"top_layer"`
 	Reference string `json:"reference" yaml:"reference" toml:"reference"`
@@ -47,6 +55,7 @@ type InfoDisplay struct {
 	Extensions      []dist.ModuleInfo       `json:"extensions" yaml:"extensions" toml:"extensions"`
 	Processes       []ProcessDisplay        `json:"processes" yaml:"processes" toml:"processes"`
 	Rebasable       bool                    `json:"rebasable" yaml:"rebasable" toml:"rebasable"`
+	Layers          []LayerDisplay          `json:"layers,omitempty" yaml:"layers,omitempty" toml:"layers,omitempty"`
 }
 
 type InspectOutput struct {
@@ -68,6 +77,7 @@ func NewInfoDisplay(info *client.ImageInfo, generalInfo GeneralInfo) *InfoDispla
 			Extensions:      displayExtensions(info.Extensions),
 			Processes:       displayProcesses(info.Processes),
 			Rebasable:       info.Rebasable,
+			Layers:          displayLayers(info.Layers, generalInfo),
 		}
 	}
 	return &InfoDisplay{
@@ -77,6 +87,7 @@ func NewInfoDisplay(info *client.ImageInfo, generalInfo GeneralInfo) *InfoDispla
 		Buildpacks:      displayBuildpacks(info.Buildpacks),
 		Processes:       displayProcesses(info.Processes),
 		Rebasable:       info.Rebasable,
+		Layers:          displayLayers(info.Layers, generalInfo),
 	}
 }
 
@@ -142,6 +153,23 @@ func displayMirrors(info *client.ImageInfo, generalInfo GeneralInfo) []RunImageM
 	return result
 }
 
+func displayLayers(layers []client.LayerInfo, generalInfo GeneralInfo) []LayerDisplay {
+	if !generalInfo.ShowLayers {
+		return nil
+	}
+
+	var result []LayerDisplay
+	for _, layer := range layers {
+		result = append(result, LayerDisplay{
+			DiffID: layer.DiffID,
+			Size:   layer.Size,
+			Group:  layer.Group,
+			Owner:  layer.Owner,
+		})
+	}
+	return result
+}
+
 func displayBuildpacks(buildpacks []buildpack.GroupElement) []dist.ModuleInfo {
 	var result []dist.ModuleInfo
 	for _, buildpack := range buildpacks {
@@ -0,0 +1,92 @@
+package writer
+
+import (
+	"bytes"
+	"text/tabwriter"
+	"text/template"
+
+	"github.com/buildpacks/pack/internal/inspectimage"
+	strs "github.com/buildpacks/pack/internal/strings"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type HumanReadableDiff struct{}
+
+func NewHumanReadableDiff() *HumanReadableDiff {
+	return &HumanReadableDiff{}
+}
+
+func (h *HumanReadableDiff) Print(logger logging.Logger, diff *inspectimage.DiffDisplay) error {
+	tpl := template.Must(template.New("diff").
+		Funcs(template.FuncMap{"StringsValueOrDefault": strs.ValueOrDefault}).
+		Parse(diffTemplate))
+
+	buf := bytes.NewBuffer(nil)
+	tw := tabwriter.NewWriter(buf, 0, 0, 4, ' ', 0)
+	if err := tpl.Execute(tw, diff); err != nil {
+		return err
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	logger.Info(buf.String())
+	return nil
+}
+
+var diffTemplate = `Comparing image '{{ .From }}' to '{{ .To }}'
+{{- if .Empty }}
+
+No differences
+{{- else }}
+
+Buildpacks:
+{{- if .Buildpacks }}
+  ID	CHANGE	FROM	TO
+{{- range $_, $c := .Buildpacks }}
+  {{ $c.ID }}	{{ $c.Change }}	{{ StringsValueOrDefault $c.FromVersion "-" }}	{{ StringsValueOrDefault $c.ToVersion "-" }}
+{{- end }}
+{{- else }}
+  (no changes)
+{{- end }}
+
+BOM:
+{{- if .BOM }}
+  BUILDPACK	NAME	CHANGE	FROM	TO
+{{- range $_, $c := .BOM }}
+  {{ $c.Buildpack }}	{{ $c.Name }}	{{ $c.Change }}	{{ StringsValueOrDefault $c.FromVersion "-" }}	{{ StringsValueOrDefault $c.ToVersion "-" }}
+{{- end }}
+{{- else }}
+  (no changes)
+{{- end }}
+
+Processes:
+{{- if .Processes }}
+  TYPE	CHANGE	FROM	TO
+{{- range $_, $c := .Processes }}
+  {{ $c.Type }}	{{ $c.Change }}	{{ StringsValueOrDefault $c.FromCommand "-" }}	{{ StringsValueOrDefault $c.ToCommand "-" }}
+{{- end }}
+{{- else }}
+  (no changes)
+{{- end }}
+
+Run Image:
+{{- if .RunImage }}
+  Image:	{{ StringsValueOrDefault .RunImage.FromImage "-" }}	->	{{ StringsValueOrDefault .RunImage.ToImage "-" }}
+  Reference:	{{ StringsValueOrDefault .RunImage.FromReference "-" }}	->	{{ StringsValueOrDefault .RunImage.ToReference "-" }}
+  Top Layer:	{{ StringsValueOrDefault .RunImage.FromTopLayer "-" }}	->	{{ StringsValueOrDefault .RunImage.ToTopLayer "-" }}
+{{- else }}
+  (no changes)
+{{- end }}
+
+Layers:
+{{- if .Layers }}
+  BUILDPACK	LAYER	CHANGE	FROM	TO
+{{- range $_, $c := .Layers }}
+  {{ $c.Buildpack }}	{{ $c.Layer }}	{{ $c.Change }}	{{ StringsValueOrDefault $c.FromDigest "-" }}	{{ StringsValueOrDefault $c.ToDigest "-" }}
+{{- end }}
+{{- else }}
+  (no changes)
+{{- end }}
+{{- end }}
+`
@@ -0,0 +1,226 @@
+package writer_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/buildpacks/lifecycle/buildpack"
+	"github.com/buildpacks/lifecycle/launch"
+	"github.com/buildpacks/lifecycle/platform/files"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/inspectimage"
+	"github.com/buildpacks/pack/internal/inspectimage/writer"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestDiff(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Diff Writers", testDiff, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testDiff(t *testing.T, when spec.G, it spec.S) {
+	var (
+		assert = h.NewAssertionManager(t)
+		outBuf bytes.Buffer
+
+		fromInfo *client.ImageInfo
+		toInfo   *client.ImageInfo
+		diff     *inspectimage.DiffDisplay
+	)
+
+	it.Before(func() {
+		fromInfo = &client.ImageInfo{
+			Buildpacks: []buildpack.GroupElement{
+				{ID: "test.bp.one", Version: "1.0.0"},
+				{ID: "test.bp.two", Version: "2.0.0"},
+			},
+			BOM: []buildpack.BOMEntry{
+				{Require: buildpack.Require{Name: "node", Version: "18.0.0"}, Buildpack: buildpack.GroupElement{ID: "test.bp.one"}},
+				{Require: buildpack.Require{Name: "yarn", Metadata: map[string]interface{}{"version": "1.22.0"}}, Buildpack: buildpack.GroupElement{ID: "test.bp.one"}},
+			},
+			Processes: client.ProcessDetails{
+				DefaultProcess: &launch.Process{Type: "web", Command: launch.NewRawCommand([]string{"node"}), Args: []string{"server.js"}},
+				OtherProcesses: []launch.Process{
+					{Type: "worker", Command: launch.NewRawCommand([]string{"node"}), Args: []string{"worker.js"}},
+				},
+			},
+			Base:  files.RunImageForRebase{TopLayer: "some-top-layer", Reference: "some-run-image@sha256:old"},
+			Stack: files.Stack{RunImage: files.RunImageForExport{Image: "some-run-image"}},
+			BuildpackLayers: []buildpack.LayersMetadata{
+				{ID: "test.bp.one", Layers: map[string]buildpack.LayerMetadata{
+					"node_modules": {SHA: "sha256:modules-old"},
+					"node":         {SHA: "sha256:node"},
+				}},
+			},
+		}
+		toInfo = &client.ImageInfo{
+			Buildpacks: []buildpack.GroupElement{
+				{ID: "test.bp.one", Version: "1.1.0"},
+				{ID: "test.bp.three", Version: "3.0.0"},
+			},
+			BOM: []buildpack.BOMEntry{
+				{Require: buildpack.Require{Name: "node", Version: "20.0.0"}, Buildpack: buildpack.GroupElement{ID: "test.bp.one"}},
+				{Require: buildpack.Require{Name: "yarn", Metadata: map[string]interface{}{"version": "1.22.0"}}, Buildpack: buildpack.GroupElement{ID: "test.bp.one"}},
+			},
+			Processes: client.ProcessDetails{
+				DefaultProcess: &launch.Process{Type: "web", Command: launch.NewRawCommand([]string{"node"}), Args: []string{"--enable-source-maps", "server.js"}},
+				OtherProcesses: []launch.Process{
+					{Type: "worker", Command: launch.NewRawCommand([]string{"node"}), Args: []string{"worker.js"}},
+				},
+			},
+			Base:  files.RunImageForRebase{TopLayer: "other-top-layer", Reference: "some-run-image@sha256:new"},
+			Stack: files.Stack{RunImage: files.RunImageForExport{Image: "some-run-image"}},
+			BuildpackLayers: []buildpack.LayersMetadata{
+				{ID: "test.bp.one", Layers: map[string]buildpack.LayerMetadata{
+					"node_modules": {SHA: "sha256:modules-new"},
+					"node":         {SHA: "sha256:node"},
+				}},
+				{ID: "test.bp.three", Layers: map[string]buildpack.LayerMetadata{
+					"cache": {SHA: "sha256:cache"},
+				}},
+			},
+		}
+		diff = inspectimage.NewDiffDisplay("some/image:v1", fromInfo, "some/image:v2", toInfo)
+		outBuf.Reset()
+	})
+
+	when("NewDiffDisplay", func() {
+		it("reports changed, added and removed buildpacks", func() {
+			assert.Equal(diff.Buildpacks, []inspectimage.ModuleChange{
+				{ID: "test.bp.one", Change: inspectimage.ChangeChanged, FromVersion: "1.0.0", ToVersion: "1.1.0"},
+				{ID: "test.bp.three", Change: inspectimage.ChangeAdded, ToVersion: "3.0.0"},
+				{ID: "test.bp.two", Change: inspectimage.ChangeRemoved, FromVersion: "2.0.0"},
+			})
+		})
+
+		it("reports changed BOM entries only", func() {
+			assert.Equal(diff.BOM, []inspectimage.BOMChange{
+				{Name: "node", Buildpack: "test.bp.one", Change: inspectimage.ChangeChanged, FromVersion: "18.0.0", ToVersion: "20.0.0"},
+			})
+		})
+
+		it("reports changed process commands", func() {
+			assert.Equal(diff.Processes, []inspectimage.ProcessChange{
+				{Type: "web", Change: inspectimage.ChangeChanged, FromCommand: "node server.js", ToCommand: "node --enable-source-maps server.js"},
+			})
+		})
+
+		it("reports run image changes", func() {
+			assert.Equal(diff.RunImage, &inspectimage.RunImageChange{
+				FromImage:     "some-run-image",
+				ToImage:       "some-run-image",
+				FromReference: "some-run-image@sha256:old",
+				ToReference:   "some-run-image@sha256:new",
+				FromTopLayer:  "some-top-layer",
+				ToTopLayer:    "other-top-layer",
+			})
+		})
+
+		it("reports layer digest changes by buildpack", func() {
+			assert.Equal(diff.Layers, []inspectimage.LayerChange{
+				{Buildpack: "test.bp.one", Layer: "node_modules", Change: inspectimage.ChangeChanged, FromDigest: "sha256:modules-old", ToDigest: "sha256:modules-new"},
+				{Buildpack: "test.bp.three", Layer: "cache", Change: inspectimage.ChangeAdded, ToDigest: "sha256:cache"},
+			})
+		})
+
+		it("is empty when comparing an image to itself", func() {
+			assert.TrueWithMessage(inspectimage.NewDiffDisplay("a", fromInfo, "b", fromInfo).Empty(), "expected no differences")
+		})
+	})
+
+	when("HumanReadableDiff", func() {
+		it("prints each section of the diff", func() {
+			logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+			assert.Nil(writer.NewHumanReadableDiff().Print(logger, diff))
+
+			out := outBuf.String()
+			assert.Contains(out, "Comparing image 'some/image:v1' to 'some/image:v2'")
+			h.AssertContainsMatch(t, out, `test.bp.one\s+changed\s+1.0.0\s+1.1.0`)
+			h.AssertContainsMatch(t, out, `test.bp.three\s+added\s+-\s+3.0.0`)
+			h.AssertContainsMatch(t, out, `test.bp.one\s+node\s+changed\s+18.0.0\s+20.0.0`)
+			h.AssertContainsMatch(t, out, `web\s+changed\s+node server.js\s+node --enable-source-maps server.js`)
+			h.AssertContainsMatch(t, out, `Top Layer:\s+some-top-layer\s+->\s+other-top-layer`)
+			h.AssertContainsMatch(t, out, `test.bp.one\s+node_modules\s+changed\s+sha256:modules-old\s+sha256:modules-new`)
+		})
+
+		it("says when there are no differences", func() {
+			logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+			assert.Nil(writer.NewHumanReadableDiff().Print(logger, inspectimage.NewDiffDisplay("a", fromInfo, "b", fromInfo)))
+
+			assert.Contains(outBuf.String(), "No differences")
+		})
+	})
+
+	when("JSONDiff", func() {
+		it("prints the diff as JSON", func() {
+			logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+			assert.Nil(writer.NewJSONDiff().Print(logger, inspectimage.NewDiffDisplay("a", fromInfo, "b", fromInfo)))
+
+			assert.ContainsJSON(outBuf.String(), `{
+  "from": "a",
+  "to": "b",
+  "buildpacks": [],
+  "bom": [],
+  "processes": [],
+  "layers": []
+}`)
+		})
+	})
+
+	when("YAMLDiff", func() {
+		it("prints the diff as YAML", func() {
+			logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+			assert.Nil(writer.NewYAMLDiff().Print(logger, diff))
+
+			assert.ContainsYAML(outBuf.String(), `---
+from: some/image:v1
+to: some/image:v2
+buildpacks:
+- id: test.bp.one
+  change: changed
+  from_version: 1.0.0
+  to_version: 1.1.0
+- id: test.bp.three
+  change: added
+  to_version: 3.0.0
+- id: test.bp.two
+  change: removed
+  from_version: 2.0.0
+bom:
+- name: node
+  buildpack: test.bp.one
+  change: changed
+  from_version: 18.0.0
+  to_version: 20.0.0
+processes:
+- type: web
+  change: changed
+  from_command: node server.js
+  to_command: node --enable-source-maps server.js
+run_image:
+  from_image: some-run-image
+  to_image: some-run-image
+  from_reference: some-run-image@sha256:old
+  to_reference: some-run-image@sha256:new
+  from_top_layer: some-top-layer
+  to_top_layer: other-top-layer
+layers:
+- buildpack: test.bp.one
+  layer: node_modules
+  change: changed
+  from_digest: sha256:modules-old
+  to_digest: sha256:modules-new
+- buildpack: test.bp.three
+  layer: cache
+  change: added
+  to_digest: sha256:cache
+`)
+		})
+	})
+}
@@ -22,6 +22,10 @@ type InspectImageWriter interface {
 	) error
 }
 
+type InspectImageDiffWriter interface {
+	Print(logger logging.Logger, diff *inspectimage.DiffDisplay) error
+}
+
 func NewFactory() *Factory {
 	return &Factory{}
 }
@@ -49,3 +53,16 @@ func (f *Factory) Writer(kind string, bom bool) (InspectImageWriter, error) {
 
 	return nil, fmt.Errorf("output format %s is not supported", style.Symbol(kind))
 }
+
+func (f *Factory) DiffWriter(kind string) (InspectImageDiffWriter, error) {
+	switch kind {
+	case "human-readable":
+		return NewHumanReadableDiff(), nil
+	case "json":
+		return NewJSONDiff(), nil
+	case "yaml":
+		return NewYAMLDiff(), nil
+	}
+
+	return nil, fmt.Errorf("output format %s is not supported when comparing images", style.Symbol(kind))
+}
@@ -145,4 +145,35 @@ func testFactory(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 	})
+
+	when("DiffWriter", func() {
+		for kind, expected := range map[string]interface{}{
+			"human-readable": &writer.HumanReadableDiff{},
+			"json":           &writer.JSONDiff{},
+			"yaml":           &writer.YAMLDiff{},
+		} {
+			kind, expected := kind, expected
+			when(fmt.Sprintf("output format is %s", kind), func() {
+				it(fmt.Sprintf("returns a %T writer", expected), func() {
+					factory := writer.NewFactory()
+
+					returnedWriter, err := factory.DiffWriter(kind)
+					assert.Nil(err)
+					assert.TrueWithMessage(
+						fmt.Sprintf("%T", returnedWriter) == fmt.Sprintf("%T", expected),
+						fmt.Sprintf("expected %T to be of type `%T`", returnedWriter, expected),
+					)
+				})
+			})
+		}
+
+		when("output format is not supported", func() {
+			it("returns an error", func() {
+				factory := writer.NewFactory()
+
+				_, err := factory.DiffWriter("toml")
+				assert.ErrorWithMessage(err, "output format 'toml' is not supported when comparing images")
+			})
+		})
+	})
 }
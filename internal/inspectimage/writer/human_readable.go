@@ -7,6 +7,8 @@ import (
 	"text/tabwriter"
 	"text/template"
 
+	"github.com/dustin/go-humanize"
+
 	"github.com/buildpacks/pack/internal/inspectimage"
 	"github.com/buildpacks/pack/pkg/client"
 
@@ -115,6 +117,7 @@ func getImageTemplate(info *inspectimage.InfoDisplay) *template.Template {
 	imgTpl := template.Must(template.New("runImages").
 		Funcs(template.FuncMap{"StringsJoin": strings.Join}).
 		Funcs(template.FuncMap{"StringsValueOrDefault": strs.ValueOrDefault}).
+		Funcs(template.FuncMap{"LayerSize": layerSize}).
 		Parse(runImagesTemplate))
 	imgTpl = template.Must(imgTpl.New("buildpacks").Parse(buildpacksTemplate))
 
@@ -122,6 +125,8 @@ func getImageTemplate(info *inspectimage.InfoDisplay) *template.Template {
 
 	imgTpl = template.Must(imgTpl.New("rebasable").Parse(rebasableTemplate))
 
+	imgTpl = template.Must(imgTpl.New("layers").Parse(layersTemplate))
+
 	if info != nil && info.Extensions != nil {
 		imgTpl = template.Must(imgTpl.New("extensions").Parse(extensionsTemplate))
 		imgTpl = template.Must(imgTpl.New("image").Parse(imageWithExtensionTemplate))
@@ -201,6 +206,16 @@ Processes:
   {{- end }}
 {{- end }}`
 
+var layersTemplate = `
+{{- if .Info.Layers }}
+
+Layers:
+  GROUP	OWNER	SIZE	DIFF ID
+  {{- range $_, $l := .Info.Layers }}
+  {{ $l.Group }}	{{ StringsValueOrDefault $l.Owner "-" }}	{{ LayerSize $l.Size }}	{{ $l.DiffID }}
+  {{- end }}
+{{- end }}`
+
 var rebasableTemplate = `
 
 Rebasable: 
@@ -218,7 +233,7 @@ Base Image:
   Top Layer: {{ .Info.Base.TopLayer }}
 {{ template "runImages" . }}
 {{- template "rebasable" . }}
-{{ template "buildpacks" . }}{{ template "processes" . }}`
+{{ template "buildpacks" . }}{{ template "processes" . }}{{ template "layers" . }}`
 
 var imageWithExtensionTemplate = `
 Stack: {{ .Info.StackID }}
@@ -232,4 +247,12 @@ Base Image:
 {{- template "rebasable" . }}
 {{ template "buildpacks" . }}
 {{ template "extensions" . -}}
-{{ template "processes" . }}`
+{{ template "processes" . }}{{ template "layers" . }}`
+
+// layerSize returns the size of a layer for humans, or '-' if it is not known.
+func layerSize(size int64) string {
+	if size < 0 {
+		return "-"
+	}
+	return humanize.Bytes(uint64(size))
+}
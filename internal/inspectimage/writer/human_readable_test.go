@@ -288,6 +288,41 @@ Processes:
 			})
 		})
 
+		when("layers are requested", func() {
+			it("lists the layers of the image", func() {
+				localInfo.Layers = []client.LayerInfo{
+					{DiffID: "sha256:run-layer", Size: 80_000_000, Group: client.LayerGroupRunImage},
+					{DiffID: "sha256:bp-layer", Size: 1_500_000, Group: client.LayerGroupBuildpack, Owner: "test.bp.one.local:some-layer"},
+					{DiffID: "sha256:app-layer", Size: -1, Group: client.LayerGroupApp, Owner: "app"},
+				}
+				sharedImageInfo := inspectimage.GeneralInfo{
+					Name:       "test-image",
+					ShowLayers: true,
+				}
+				humanReadableWriter := writer.NewHumanReadable()
+
+				logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+				err := humanReadableWriter.Print(logger, sharedImageInfo, localInfo, nil, nil, nil)
+				assert.Nil(err)
+
+				h.AssertContainsMatch(t, outBuf.String(), `Layers:\n\s+GROUP\s+OWNER\s+SIZE\s+DIFF ID\n`)
+				h.AssertContainsMatch(t, outBuf.String(), `run-image\s+-\s+80 MB\s+sha256:run-layer\n`)
+				h.AssertContainsMatch(t, outBuf.String(), `buildpack\s+test.bp.one.local:some-layer\s+1.5 MB\s+sha256:bp-layer\n`)
+				h.AssertContainsMatch(t, outBuf.String(), `app\s+app\s+-\s+sha256:app-layer`)
+			})
+
+			it("does not list layers unless requested", func() {
+				localInfo.Layers = []client.LayerInfo{{DiffID: "sha256:run-layer", Group: client.LayerGroupRunImage}}
+				humanReadableWriter := writer.NewHumanReadable()
+
+				logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+				err := humanReadableWriter.Print(logger, inspectimage.GeneralInfo{Name: "test-image"}, localInfo, nil, nil, nil)
+				assert.Nil(err)
+
+				assert.NotContains(outBuf.String(), "Layers:")
+			})
+		})
+
 		when("only local image exists", func() {
 			it("prints local image info in a human readable format", func() {
 				runImageMirrors := []config.RunImage{
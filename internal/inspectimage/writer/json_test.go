@@ -578,6 +578,31 @@ func testJSON(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("layers are requested", func() {
+			it("lists the layers of the image", func() {
+				localInfo.Layers = []client.LayerInfo{
+					{DiffID: "sha256:run-layer", Size: 1000, Group: client.LayerGroupRunImage},
+					{DiffID: "sha256:bp-layer", Size: 20, Group: client.LayerGroupBuildpack, Owner: "test.bp.one.local:some-layer"},
+				}
+				sharedImageInfo := inspectimage.GeneralInfo{
+					Name:       "test-image",
+					ShowLayers: true,
+				}
+				jsonWriter := writer.NewJSON()
+
+				logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+				err := jsonWriter.Print(logger, sharedImageInfo, localInfo, nil, nil, nil)
+				assert.Nil(err)
+
+				assert.ContainsJSON(outBuf.String(), `{
+  "layers": [
+    {"diff_id": "sha256:run-layer", "size": 1000, "group": "run-image"},
+    {"diff_id": "sha256:bp-layer", "size": 20, "group": "buildpack", "owner": "test.bp.one.local:some-layer"}
+  ]
+}`)
+			})
+		})
+
 		when("only local image exists", func() {
 			it("prints local image info in JSON format", func() {
 				runImageMirrors := []config.RunImage{
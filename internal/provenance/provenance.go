@@ -0,0 +1,200 @@
+package provenance
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/archive"
+)
+
+const (
+	// FileName is the name of the file the statement is written to in the output directory.
+	FileName = "provenance.json"
+
+	// MediaType is the media type of an in-toto statement, used as the artifact type of attached attestations.
+	MediaType = "application/vnd.in-toto+json"
+
+	StatementType = "https://in-toto.io/Statement/v1"
+	PredicateType = "https://slsa.dev/provenance/v1"
+	BuildType     = "https://buildpacks.io/pack/build/v1"
+	BuilderID     = "https://github.com/buildpacks/pack"
+
+	// DockerImageIDKey is the digest set key of images only known to the docker daemon, recorded with their image
+	// ID, the digest of their config, as the daemon does not know the digest of their manifest.
+	DockerImageIDKey = "dockerImageId"
+)
+
+// Statement is an in-toto statement carrying a SLSA provenance predicate.
+type Statement struct {
+	Type          string    `json:"_type"`
+	Subject       []Subject `json:"subject"`
+	PredicateType string    `json:"predicateType"`
+	Predicate     Predicate `json:"predicate"`
+}
+
+// Subject is the artifact the statement is about.
+type Subject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+type Predicate struct {
+	BuildDefinition BuildDefinition `json:"buildDefinition"`
+	RunDetails      RunDetails      `json:"runDetails"`
+}
+
+type BuildDefinition struct {
+	BuildType            string               `json:"buildType"`
+	ExternalParameters   ExternalParameters   `json:"externalParameters"`
+	InternalParameters   InternalParameters   `json:"internalParameters"`
+	ResolvedDependencies []ResourceDescriptor `json:"resolvedDependencies"`
+}
+
+// ExternalParameters are the parameters of the build under control of the user.
+type ExternalParameters struct {
+	Image    string   `json:"image"`
+	Builder  string   `json:"builder"`
+	RunImage string   `json:"runImage"`
+	Publish  bool     `json:"publish"`
+	Env      []string `json:"env,omitempty"`
+}
+
+// InternalParameters are the parameters of the build chosen by pack and the builder.
+type InternalParameters struct {
+	LifecycleVersion string `json:"lifecycleVersion"`
+	LifecycleImage   string `json:"lifecycleImage,omitempty"`
+	PlatformAPI      string `json:"platformAPI"`
+}
+
+// ResourceDescriptor describes an artifact the build depended on.
+type ResourceDescriptor struct {
+	Name   string            `json:"name,omitempty"`
+	URI    string            `json:"uri,omitempty"`
+	Digest map[string]string `json:"digest,omitempty"`
+}
+
+type RunDetails struct {
+	Builder  Builder  `json:"builder"`
+	Metadata Metadata `json:"metadata"`
+}
+
+type Builder struct {
+	ID      string            `json:"id"`
+	Version map[string]string `json:"version,omitempty"`
+}
+
+type Metadata struct {
+	StartedOn  time.Time `json:"startedOn"`
+	FinishedOn time.Time `json:"finishedOn"`
+}
+
+// DigestSet returns a digest set for a digest of the form 'algorithm:hex'. Digests without an algorithm are assumed to be sha256.
+func DigestSet(digest string) map[string]string {
+	if digest == "" {
+		return nil
+	}
+	if h, err := v1.NewHash(digest); err == nil {
+		return map[string]string{h.Algorithm: h.Hex}
+	}
+	return map[string]string{"sha256": digest}
+}
+
+// Write writes the statement to FileName in dir.
+func Write(dir string, statement Statement) error {
+	contents, err := json.MarshalIndent(statement, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "encoding provenance")
+	}
+
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return errors.Wrapf(err, "creating directory %s", style.Symbol(dir))
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, FileName), contents, 0644); err != nil {
+		return errors.Wrapf(err, "writing %s", style.Symbol(FileName))
+	}
+	return nil
+}
+
+// Attach pushes the statement to the registry as an OCI artifact whose subject is the given image,
+// so that it is discoverable through the referrers API of the image. The digest of the attestation
+// manifest is returned.
+func Attach(ctx context.Context, imageRef name.Digest, keychain authn.Keychain, statement Statement) (v1.Hash, error) {
+	contents, err := json.Marshal(statement)
+	if err != nil {
+		return v1.Hash{}, errors.Wrap(err, "encoding provenance")
+	}
+
+	opts := []remote.Option{remote.WithContext(ctx), remote.WithAuthFromKeychain(keychain)}
+	subject, err := remote.Head(imageRef, opts...)
+	if err != nil {
+		return v1.Hash{}, errors.Wrapf(err, "reading manifest of %s", style.Symbol(imageRef.String()))
+	}
+
+	attestation, err := mutate.Append(empty.Image, mutate.Addendum{
+		Layer:     static.NewLayer(contents, MediaType),
+		MediaType: MediaType,
+	})
+	if err != nil {
+		return v1.Hash{}, err
+	}
+	attestation = mutate.MediaType(attestation, types.OCIManifestSchema1)
+	attestation = mutate.ConfigMediaType(attestation, MediaType)
+	attestation = mutate.Annotations(attestation, map[string]string{
+		"in-toto.io/predicate-type": PredicateType,
+	}).(v1.Image)
+	attestation = mutate.Subject(attestation, *subject).(v1.Image)
+
+	digest, err := attestation.Digest()
+	if err != nil {
+		return v1.Hash{}, err
+	}
+
+	if err := remote.Write(imageRef.Context().Digest(digest.String()), attestation, opts...); err != nil {
+		return v1.Hash{}, errors.Wrap(err, "pushing provenance")
+	}
+	return digest, nil
+}
+
+// SourceDigest returns the sha256 digest of the application source at path. Directories are hashed as a
+// normalized tar archive of the files matching fileFilter, and files by their contents.
+func SourceDigest(path string, fileFilter func(string) bool) (string, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+
+	var reader io.ReadCloser
+	if fi.IsDir() {
+		reader = archive.ReadDirAsTar(path, "", 0, 0, -1, true, false, fileFilter)
+	} else {
+		reader, err = os.Open(filepath.Clean(path))
+		if err != nil {
+			return "", err
+		}
+	}
+	defer reader.Close()
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, reader); err != nil {
+		return "", errors.Wrapf(err, "hashing %s", style.Symbol(path))
+	}
+	return "sha256:" + hex.EncodeToString(hasher.Sum(nil)), nil
+}
@@ -0,0 +1,158 @@
+package provenance_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/provenance"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestProvenance(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Provenance", testProvenance, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testProvenance(t *testing.T, when spec.G, it spec.S) {
+	var tmpDir string
+
+	it.Before(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "provenance")
+		h.AssertNil(t, err)
+	})
+
+	it.After(func() {
+		h.AssertNil(t, os.RemoveAll(tmpDir))
+	})
+
+	statement := provenance.Statement{
+		Type:          provenance.StatementType,
+		Subject:       []provenance.Subject{{Name: "some/app", Digest: provenance.DigestSet("sha256:abc")}},
+		PredicateType: provenance.PredicateType,
+	}
+
+	when("#DigestSet", func() {
+		it("splits the algorithm from the digest", func() {
+			h.AssertEq(t, provenance.DigestSet("sha256:363c754893f0efe22480b4359a5956cf3bd3ce22742fc576973c61348308c2e4"),
+				map[string]string{"sha256": "363c754893f0efe22480b4359a5956cf3bd3ce22742fc576973c61348308c2e4"})
+		})
+
+		it("returns no digests for an empty digest", func() {
+			h.AssertEq(t, len(provenance.DigestSet("")), 0)
+		})
+	})
+
+	when("#Write", func() {
+		it("writes the statement as json", func() {
+			outputDir := filepath.Join(tmpDir, "output")
+			h.AssertNil(t, provenance.Write(outputDir, statement))
+
+			contents, err := os.ReadFile(filepath.Join(outputDir, provenance.FileName))
+			h.AssertNil(t, err)
+
+			var written provenance.Statement
+			h.AssertNil(t, json.Unmarshal(contents, &written))
+			h.AssertEq(t, written.Type, "https://in-toto.io/Statement/v1")
+			h.AssertEq(t, written.Subject[0].Name, "some/app")
+		})
+	})
+
+	when("#SourceDigest", func() {
+		var appDir string
+
+		it.Before(func() {
+			appDir = filepath.Join(tmpDir, "app")
+			h.AssertNil(t, os.MkdirAll(filepath.Join(appDir, "sub"), 0755))
+			h.AssertNil(t, os.WriteFile(filepath.Join(appDir, "main.go"), []byte("package main"), 0644))
+			h.AssertNil(t, os.WriteFile(filepath.Join(appDir, "sub", "README"), []byte("readme"), 0644))
+		})
+
+		it("is stable across calls", func() {
+			first, err := provenance.SourceDigest(appDir, nil)
+			h.AssertNil(t, err)
+			second, err := provenance.SourceDigest(appDir, nil)
+			h.AssertNil(t, err)
+			h.AssertEq(t, first, second)
+			h.AssertContains(t, first, "sha256:")
+		})
+
+		it("changes when the contents change", func() {
+			before, err := provenance.SourceDigest(appDir, nil)
+			h.AssertNil(t, err)
+
+			h.AssertNil(t, os.WriteFile(filepath.Join(appDir, "main.go"), []byte("package other"), 0644))
+			after, err := provenance.SourceDigest(appDir, nil)
+			h.AssertNil(t, err)
+			h.AssertNotEq(t, before, after)
+		})
+
+		it("ignores files excluded by the filter", func() {
+			before, err := provenance.SourceDigest(appDir, func(path string) bool { return filepath.Base(path) != "README" })
+			h.AssertNil(t, err)
+
+			h.AssertNil(t, os.WriteFile(filepath.Join(appDir, "sub", "README"), []byte("changed"), 0644))
+			after, err := provenance.SourceDigest(appDir, func(path string) bool { return filepath.Base(path) != "README" })
+			h.AssertNil(t, err)
+			h.AssertEq(t, before, after)
+		})
+	})
+
+	when("#Attach", func() {
+		var (
+			server   *httptest.Server
+			imageRef name.Reference
+		)
+
+		it.Before(func() {
+			server = httptest.NewServer(registry.New(registry.WithReferrersSupport(true), registry.Logger(log.New(io.Discard, "", 0))))
+			u, err := url.Parse(server.URL)
+			h.AssertNil(t, err)
+
+			imageRef, err = name.ParseReference(u.Host + "/some/app:latest")
+			h.AssertNil(t, err)
+
+			img, err := random.Image(1024, 1)
+			h.AssertNil(t, err)
+			h.AssertNil(t, remote.Write(imageRef, img))
+		})
+
+		it.After(func() {
+			server.Close()
+		})
+
+		it("pushes the statement as a referrer of the image", func() {
+			desc, err := remote.Head(imageRef)
+			h.AssertNil(t, err)
+			imageDigest := imageRef.Context().Digest(desc.Digest.String())
+
+			digest, err := provenance.Attach(context.TODO(), imageDigest, authn.DefaultKeychain, statement)
+			h.AssertNil(t, err)
+
+			referrers, err := remote.Referrers(imageDigest)
+			h.AssertNil(t, err)
+			manifest, err := referrers.IndexManifest()
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(manifest.Manifests), 1)
+			h.AssertEq(t, manifest.Manifests[0].Digest, digest)
+			h.AssertEq(t, manifest.Manifests[0].ArtifactType, provenance.MediaType)
+		})
+	})
+}
@@ -33,6 +33,17 @@ type Cache struct {
 	url         *url.URL
 	Root        string
 	RegistryDir string
+	offline     bool
+}
+
+// CacheOption configures a registry cache
+type CacheOption func(c *Cache)
+
+// WithOffline makes the cache use the existing local clone of the registry index without fetching updates
+func WithOffline(offline bool) CacheOption {
+	return func(c *Cache) {
+		c.offline = offline
+	}
 }
 
 const GithubIssueTitleTemplate = "{{ if .Yanked }}YANK{{ else }}ADD{{ end }} {{.Namespace}}/{{.Name}}@{{.Version}}"
@@ -49,12 +60,12 @@ type Entry struct {
 }
 
 // NewDefaultRegistryCache creates a new registry cache with default options
-func NewDefaultRegistryCache(logger logging.Logger, home string) (Cache, error) {
-	return NewRegistryCache(logger, home, DefaultRegistryURL)
+func NewDefaultRegistryCache(logger logging.Logger, home string, opts ...CacheOption) (Cache, error) {
+	return NewRegistryCache(logger, home, DefaultRegistryURL, opts...)
 }
 
 // NewRegistryCache creates a new registry cache
-func NewRegistryCache(logger logging.Logger, home, registryURL string) (Cache, error) {
+func NewRegistryCache(logger logging.Logger, home, registryURL string, opts ...CacheOption) (Cache, error) {
 	if _, err := os.Stat(home); err != nil {
 		return Cache{}, errors.Wrapf(err, "finding home %s", home)
 	}
@@ -68,11 +79,16 @@ func NewRegistryCache(logger logging.Logger, home, registryURL string) (Cache, e
 	key.Write([]byte(normalizedURL.String()))
 	cacheDir := fmt.Sprintf("%s-%s", defaultRegistryDir, hex.EncodeToString(key.Sum(nil)))
 
-	return Cache{
+	cache := Cache{
 		url:    normalizedURL,
 		logger: logger,
 		Root:   filepath.Join(home, cacheDir),
-	}, nil
+	}
+	for _, opt := range opts {
+		opt(&cache)
+	}
+
+	return cache, nil
 }
 
 // LocateBuildpack stored in registry
@@ -118,6 +134,17 @@ func (r *Cache) LocateBuildpack(bp string) (Buildpack, error) {
 
 // Refresh local Registry Cache
 func (r *Cache) Refresh() error {
+	if r.offline {
+		if _, err := os.Stat(r.Root); err != nil {
+			if os.IsNotExist(err) {
+				return fmt.Errorf("registry index %s has not been cloned to %s and cannot be cloned in offline mode", style.Symbol(r.url.String()), style.Symbol(r.Root))
+			}
+			return err
+		}
+		r.logger.Debugf("Using registry cache for %s/%s without refreshing in offline mode", r.url.Host, r.url.Path)
+		return nil
+	}
+
 	r.logger.Debugf("Refreshing registry cache for %s/%s", r.url.Host, r.url.Path)
 
 	if err := r.Initialize(); err != nil {
@@ -199,6 +199,47 @@ func testRegistryCache(t *testing.T, when spec.G, it spec.S) {
 				h.AssertNil(t, os.RemoveAll(registryCache.RegistryDir))
 			})
 		})
+
+		when("offline", func() {
+			it("fails if the registry has not been cloned", func() {
+				offlineCache, err := NewRegistryCache(logger, tmpDir, registryFixture, WithOffline(true))
+				h.AssertNil(t, err)
+
+				err = offlineCache.Refresh()
+				h.AssertError(t, err, "cannot be cloned in offline mode")
+				_, err = os.Stat(offlineCache.Root)
+				h.AssertTrue(t, os.IsNotExist(err))
+			})
+
+			it("uses the existing clone without pulling", func() {
+				h.AssertNil(t, registryCache.Refresh())
+
+				r, err := git.PlainOpen(registryFixture)
+				h.AssertNil(t, err)
+				w, err := r.Worktree()
+				h.AssertNil(t, err)
+				_, err = w.Commit("second", &git.CommitOptions{
+					Author: &object.Signature{
+						Name:  "John Doe",
+						Email: "john@doe.org",
+						When:  time.Now(),
+					},
+				})
+				h.AssertNil(t, err)
+
+				offlineCache, err := NewRegistryCache(logger, tmpDir, registryFixture, WithOffline(true))
+				h.AssertNil(t, err)
+				h.AssertNil(t, offlineCache.Refresh())
+
+				head, err := r.Head()
+				h.AssertNil(t, err)
+				clone, err := git.PlainOpen(offlineCache.Root)
+				h.AssertNil(t, err)
+				cloneHead, err := clone.Head()
+				h.AssertNil(t, err)
+				h.AssertNotEq(t, cloneHead.Hash(), head.Hash())
+			})
+		})
 	})
 
 	when("#Initialize", func() {
@@ -1,6 +1,8 @@
 package strings
 
 import (
+	"crypto/rand"
+
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 )
@@ -16,3 +18,15 @@ func ValueOrDefault(str, def string) string {
 func Title(lower string) string {
 	return cases.Title(language.English).String(lower)
 }
+
+// Random returns a string of n random lowercase letters.
+func Random(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	for i := range b {
+		b[i] = 'a' + (b[i] % 26)
+	}
+	return string(b), nil
+}
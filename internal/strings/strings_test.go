@@ -34,5 +34,16 @@ func TestValueOrDefault(t *testing.T) {
 				assert.Equal(output, "To Title Case")
 			})
 		})
+
+		when("#Random", func() {
+			it("returns a string of lowercase letters of the given length", func() {
+				output, err := strings.Random(10)
+				assert.Nil(err)
+				assert.Equal(len(output), 10)
+				for _, c := range output {
+					assert.TrueWithMessage(c >= 'a' && c <= 'z', "expected a lowercase letter")
+				}
+			})
+		})
 	})
 }
@@ -3,12 +3,14 @@ package blob
 import (
 	"context"
 	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/mitchellh/ioprogress"
 	"github.com/pkg/errors"
@@ -36,14 +38,47 @@ func WithClient(client *http.Client) DownloaderOption {
 	}
 }
 
+// WithOffline makes the downloader resolve http(s) URIs only from its download cache.
+func WithOffline(offline bool) DownloaderOption {
+	return func(d *downloader) {
+		d.offline = offline
+	}
+}
+
+// DownloadOption configures a single call to Downloader.Download.
+type DownloadOption func(opts *downloadOptions)
+
+type downloadOptions struct {
+	sha256 string
+}
+
+// WithSHA256 sets the expected sha256 digest of the downloaded content, given as hex with an optional
+// 'sha256:' prefix. The download fails if the content does not match, and a cached download matching
+// the digest is used without contacting the remote server.
+func WithSHA256(digest string) DownloadOption {
+	return func(opts *downloadOptions) {
+		opts.sha256 = strings.ToLower(strings.TrimPrefix(digest, "sha256:"))
+	}
+}
+
+// DownloadOptionsFor returns the download options verifying the given sha256 digest, or none if the
+// digest is empty.
+func DownloadOptionsFor(sha256Digest string) []DownloadOption {
+	if sha256Digest == "" {
+		return nil
+	}
+	return []DownloadOption{WithSHA256(sha256Digest)}
+}
+
 type Downloader interface {
-	Download(ctx context.Context, pathOrURI string) (Blob, error)
+	Download(ctx context.Context, pathOrURI string, opts ...DownloadOption) (Blob, error)
 }
 
 type downloader struct {
 	logger       Logger
 	baseCacheDir string
 	client       *http.Client
+	offline      bool
 }
 
 func NewDownloader(logger Logger, baseCacheDir string, opts ...DownloaderOption) Downloader {
@@ -60,7 +95,17 @@ func NewDownloader(logger Logger, baseCacheDir string, opts ...DownloaderOption)
 	return d
 }
 
-func (d *downloader) Download(ctx context.Context, pathOrURI string) (Blob, error) {
+// SetOffline changes whether the downloader only uses its download cache, as set by WithOffline.
+func (d *downloader) SetOffline(offline bool) {
+	d.offline = offline
+}
+
+func (d *downloader) Download(ctx context.Context, pathOrURI string, opts ...DownloadOption) (Blob, error) {
+	var o downloadOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	if paths.IsURI(pathOrURI) {
 		parsedURL, err := url.Parse(pathOrURI)
 		if err != nil {
@@ -71,11 +116,14 @@ func (d *downloader) Download(ctx context.Context, pathOrURI string) (Blob, erro
 		switch parsedURL.Scheme {
 		case "file":
 			path, err = paths.URIToFilePath(pathOrURI)
+			if err == nil {
+				err = verifyFile(path, pathOrURI, o.sha256)
+			}
 		case "http", "https":
-			path, err = d.handleHTTP(ctx, pathOrURI)
-			if err != nil {
+			path, err = d.handleHTTP(ctx, pathOrURI, o.sha256)
+			if err != nil && !isDigestMismatch(err) {
 				// retry as we sometimes see `wsarecv: An existing connection was forcibly closed by the remote host.` on Windows
-				path, err = d.handleHTTP(ctx, pathOrURI)
+				path, err = d.handleHTTP(ctx, pathOrURI, o.sha256)
 			}
 		default:
 			err = fmt.Errorf("unsupported protocol %s in URI %s", style.Symbol(parsedURL.Scheme), style.Symbol(pathOrURI))
@@ -88,6 +136,9 @@ func (d *downloader) Download(ctx context.Context, pathOrURI string) (Blob, erro
 	}
 
 	path := d.handleFile(pathOrURI)
+	if err := verifyFile(path, pathOrURI, o.sha256); err != nil {
+		return nil, err
+	}
 
 	return &blob{path: path}, nil
 }
@@ -101,7 +152,7 @@ func (d *downloader) handleFile(path string) string {
 	return path
 }
 
-func (d *downloader) handleHTTP(ctx context.Context, uri string) (string, error) {
+func (d *downloader) handleHTTP(ctx context.Context, uri string, expectedDigest string) (string, error) {
 	cacheDir := d.versionedCacheDir()
 
 	if err := os.MkdirAll(cacheDir, 0750); err != nil {
@@ -110,6 +161,17 @@ func (d *downloader) handleHTTP(ctx context.Context, uri string) (string, error)
 
 	cachePath := filepath.Join(cacheDir, fmt.Sprintf("%x", sha256.Sum256([]byte(uri))))
 
+	if expectedDigest != "" {
+		if actual, err := fileDigest(cachePath); err == nil && actual == expectedDigest {
+			d.logger.Debugf("Using cached version of %s matching sha256 %s", style.Symbol(uri), style.Symbol(expectedDigest))
+			return cachePath, nil
+		}
+	}
+
+	if d.offline {
+		return d.handleOffline(uri, cachePath, expectedDigest)
+	}
+
 	etagFile := cachePath + ".etag"
 	etagExists, err := fileExists(etagFile)
 	if err != nil {
@@ -125,6 +187,11 @@ func (d *downloader) handleHTTP(ctx context.Context, uri string) (string, error)
 		etag = string(bytes)
 	}
 
+	if expectedDigest != "" {
+		// the cached content doesn't match the digest, so it must be downloaded again regardless of its etag
+		etag = ""
+	}
+
 	reader, etag, err := d.downloadAsStream(ctx, uri, etag)
 	if err != nil {
 		return "", err
@@ -139,11 +206,19 @@ func (d *downloader) handleHTTP(ctx context.Context, uri string) (string, error)
 	}
 	defer fh.Close()
 
-	_, err = io.Copy(fh, reader)
+	hasher := sha256.New()
+	_, err = io.Copy(io.MultiWriter(fh, hasher), reader)
 	if err != nil {
 		return "", errors.Wrap(err, "writing cache")
 	}
 
+	if actual := hex.EncodeToString(hasher.Sum(nil)); expectedDigest != "" && actual != expectedDigest {
+		fh.Close()
+		os.Remove(cachePath)
+		os.Remove(etagFile)
+		return "", &DigestMismatchError{URI: uri, Expected: expectedDigest, Actual: actual}
+	}
+
 	if err = os.WriteFile(etagFile, []byte(etag), 0744); err != nil {
 		return "", errors.Wrap(err, "writing etag")
 	}
@@ -151,6 +226,23 @@ func (d *downloader) handleHTTP(ctx context.Context, uri string) (string, error)
 	return cachePath, nil
 }
 
+func (d *downloader) handleOffline(uri, cachePath, expectedDigest string) (string, error) {
+	exists, err := fileExists(cachePath)
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		return "", fmt.Errorf("%s is not in the download cache and cannot be downloaded in offline mode", style.Symbol(uri))
+	}
+	if expectedDigest != "" {
+		// a cached version matching the digest would have been returned already
+		return "", fmt.Errorf("cached version of %s does not match sha256 %s and cannot be downloaded again in offline mode", style.Symbol(uri), style.Symbol(expectedDigest))
+	}
+
+	d.logger.Debugf("Using cached version of %s in offline mode", style.Symbol(uri))
+	return cachePath, nil
+}
+
 func (d *downloader) downloadAsStream(ctx context.Context, uri string, etag string) (io.ReadCloser, string, error) {
 	req, err := http.NewRequest("GET", uri, nil)
 	if err != nil {
@@ -213,3 +305,56 @@ func fileExists(file string) (bool, error) {
 	}
 	return true, nil
 }
+
+// DigestMismatchError is returned when downloaded content does not match the expected sha256 digest.
+type DigestMismatchError struct {
+	URI      string
+	Expected string
+	Actual   string
+}
+
+func (e *DigestMismatchError) Error() string {
+	return fmt.Sprintf("sha256 mismatch for %s: expected %s, got %s", style.Symbol(e.URI), style.Symbol(e.Expected), style.Symbol(e.Actual))
+}
+
+func isDigestMismatch(err error) bool {
+	var mismatch *DigestMismatchError
+	return errors.As(err, &mismatch)
+}
+
+func verifyFile(path, pathOrURI, expectedDigest string) error {
+	if expectedDigest == "" {
+		return nil
+	}
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		return errors.Wrapf(err, "reading %s", style.Symbol(pathOrURI))
+	}
+	if fi.IsDir() {
+		return fmt.Errorf("sha256 can only be verified for files, but %s is a directory", style.Symbol(pathOrURI))
+	}
+
+	actual, err := fileDigest(path)
+	if err != nil {
+		return errors.Wrapf(err, "computing sha256 of %s", style.Symbol(pathOrURI))
+	}
+	if actual != expectedDigest {
+		return &DigestMismatchError{URI: pathOrURI, Expected: expectedDigest, Actual: actual}
+	}
+	return nil
+}
+
+func fileDigest(path string) (string, error) {
+	fh, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return "", err
+	}
+	defer fh.Close()
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, fh); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
@@ -2,6 +2,7 @@ package blob_test
 
 import (
 	"context"
+	"crypto/sha256"
 	"fmt"
 	"io"
 	"net/http"
@@ -85,6 +86,34 @@ func testDownloader(t *testing.T, when spec.G, it spec.S) {
 					assertBlob(t, b)
 				})
 			})
+
+			when("sha256 is provided", func() {
+				var tgz string
+
+				it.Before(func() {
+					tgz = h.CreateTGZ(t, filepath.Join("testdata", "blob"), "./", 0777)
+				})
+
+				it.After(func() {
+					os.Remove(tgz)
+				})
+
+				it("returns the blob when the digest matches", func() {
+					b, err := subject.Download(context.TODO(), tgz, blob.WithSHA256("sha256:"+fileSHA256(t, tgz)))
+					h.AssertNil(t, err)
+					assertBlob(t, b)
+				})
+
+				it("should return error when the digest does not match", func() {
+					_, err := subject.Download(context.TODO(), tgz, blob.WithSHA256("0000"))
+					h.AssertError(t, err, "sha256 mismatch")
+				})
+
+				it("should return error when the path is a directory", func() {
+					_, err := subject.Download(context.TODO(), relPath, blob.WithSHA256("0000"))
+					h.AssertError(t, err, "is a directory")
+				})
+			})
 		})
 
 		when("is uri", func() {
@@ -135,6 +164,93 @@ func testDownloader(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 
+			when("sha256 is provided", func() {
+				var digest string
+
+				it.Before(func() {
+					digest = fileSHA256(t, tgz)
+				})
+
+				it("downloads when the digest matches", func() {
+					server.AppendHandlers(func(w http.ResponseWriter, r *http.Request) {
+						w.Header().Add("ETag", "A")
+						http.ServeFile(w, r, tgz)
+					})
+
+					b, err := subject.Download(context.TODO(), uri, blob.WithSHA256(digest))
+					h.AssertNil(t, err)
+					assertBlob(t, b)
+				})
+
+				it("uses the cache without a request when the cached digest matches", func() {
+					server.AppendHandlers(func(w http.ResponseWriter, r *http.Request) {
+						w.Header().Add("ETag", "A")
+						http.ServeFile(w, r, tgz)
+					})
+
+					_, err := subject.Download(context.TODO(), uri, blob.WithSHA256(digest))
+					h.AssertNil(t, err)
+
+					b, err := subject.Download(context.TODO(), uri, blob.WithSHA256(digest))
+					h.AssertNil(t, err)
+					assertBlob(t, b)
+					h.AssertEq(t, len(server.ReceivedRequests()), 1)
+				})
+
+				it("should return error without retrying when the digest does not match", func() {
+					server.AppendHandlers(func(w http.ResponseWriter, r *http.Request) {
+						w.Header().Add("ETag", "A")
+						http.ServeFile(w, r, tgz)
+					})
+
+					_, err := subject.Download(context.TODO(), uri, blob.WithSHA256("sha256:0000"))
+					h.AssertError(t, err, "sha256 mismatch")
+					h.AssertError(t, err, "expected '0000'")
+					h.AssertEq(t, len(server.ReceivedRequests()), 1)
+				})
+			})
+
+			when("offline", func() {
+				var offlineSubject blob.Downloader
+
+				it.Before(func() {
+					offlineSubject = blob.NewDownloader(&logger{io.Discard}, cacheDir, blob.WithOffline(true))
+				})
+
+				it("uses the download cache without a request", func() {
+					server.AppendHandlers(func(w http.ResponseWriter, r *http.Request) {
+						w.Header().Add("ETag", "A")
+						http.ServeFile(w, r, tgz)
+					})
+					_, err := subject.Download(context.TODO(), uri)
+					h.AssertNil(t, err)
+
+					b, err := offlineSubject.Download(context.TODO(), uri)
+					h.AssertNil(t, err)
+					assertBlob(t, b)
+					h.AssertEq(t, len(server.ReceivedRequests()), 1)
+				})
+
+				it("should return error when the uri is not in the download cache", func() {
+					_, err := offlineSubject.Download(context.TODO(), uri)
+					h.AssertError(t, err, fmt.Sprintf("'%s' is not in the download cache and cannot be downloaded in offline mode", uri))
+					h.AssertEq(t, len(server.ReceivedRequests()), 0)
+				})
+
+				it("should return error when the cached download does not match the digest", func() {
+					server.AppendHandlers(func(w http.ResponseWriter, r *http.Request) {
+						w.Header().Add("ETag", "A")
+						http.ServeFile(w, r, tgz)
+					})
+					_, err := subject.Download(context.TODO(), uri)
+					h.AssertNil(t, err)
+
+					_, err = offlineSubject.Download(context.TODO(), uri, blob.WithSHA256("0000"))
+					h.AssertError(t, err, "does not match sha256 '0000'")
+					h.AssertEq(t, len(server.ReceivedRequests()), 1)
+				})
+			})
+
 			when("uri is invalid", func() {
 				when("uri file is not found", func() {
 					it.Before(func() {
@@ -177,6 +293,13 @@ func assertBlob(t *testing.T, b blob.Blob) {
 	h.AssertEq(t, string(bytes), "contents")
 }
 
+func fileSHA256(t *testing.T, path string) string {
+	t.Helper()
+	contents, err := os.ReadFile(path)
+	h.AssertNil(t, err)
+	return fmt.Sprintf("%x", sha256.Sum256(contents))
+}
+
 type logger struct {
 	writer io.Writer
 }
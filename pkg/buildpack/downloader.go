@@ -33,7 +33,7 @@ type ImageFetcher interface {
 }
 
 type Downloader interface {
-	Download(ctx context.Context, pathOrURI string) (blob.Blob, error)
+	Download(ctx context.Context, pathOrURI string, opts ...blob.DownloadOption) (blob.Blob, error)
 }
 
 //go:generate mockgen -package testmocks -destination ../testmocks/mock_registry_resolver.go github.com/buildpacks/pack/pkg/buildpack RegistryResolver
@@ -58,6 +58,16 @@ func NewDownloader(logger Logger, imageFetcher ImageFetcher, downloader Download
 	}
 }
 
+// SetOffline changes whether the image fetcher, downloader and registry resolver of the downloader work
+// without network access, for those supporting it.
+func (c *buildpackDownloader) SetOffline(offline bool) {
+	for _, component := range []interface{}{c.imageFetcher, c.downloader, c.registryResolver} {
+		if o, ok := component.(interface{ SetOffline(bool) }); ok {
+			o.SetOffline(offline)
+		}
+	}
+}
+
 type DownloadOptions struct {
 	// Buildpack registry name. Defines where all registry buildpacks will be pulled from.
 	RegistryName string
@@ -80,6 +90,10 @@ type DownloadOptions struct {
 
 	// The OS/Architecture/Variant to download.
 	Target *dist.Target
+
+	// The expected sha256 digest of a module located by URI. When set, the download fails if the
+	// content does not match.
+	SHA256 string
 }
 
 func (c *buildpackDownloader) Download(ctx context.Context, moduleURI string, opts DownloadOptions) (BuildModule, []BuildModule, error) {
@@ -137,7 +151,7 @@ func (c *buildpackDownloader) Download(ctx context.Context, moduleURI string, op
 
 		c.logger.Debugf("Downloading %s from URI: %s", kind, style.Symbol(moduleURI))
 
-		blob, err := c.downloader.Download(ctx, moduleURI)
+		blob, err := c.downloader.Download(ctx, moduleURI, blob.DownloadOptionsFor(opts.SHA256)...)
 		if err != nil {
 			return nil, nil, errors.Wrapf(err, "downloading %s from %s", kind, style.Symbol(moduleURI))
 		}
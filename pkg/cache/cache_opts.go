@@ -25,6 +25,7 @@ const (
 	CacheVolume Format = iota
 	CacheImage
 	CacheBind
+	CacheRegistry
 )
 
 func (f Format) String() string {
@@ -35,13 +36,15 @@ func (f Format) String() string {
 		return "volume"
 	case CacheBind:
 		return "bind"
+	case CacheRegistry:
+		return "registry"
 	}
 	return ""
 }
 
 func (c *CacheInfo) SourceName() string {
 	switch c.Format {
-	case CacheImage:
+	case CacheImage, CacheRegistry:
 		fallthrough
 	case CacheVolume:
 		return "name"
@@ -96,6 +99,8 @@ func (c *CacheOpts) Set(value string) error {
 				cache.Format = CacheVolume
 			case "bind":
 				cache.Format = CacheBind
+			case "registry":
+				cache.Format = CacheRegistry
 			default:
 				return errors.Errorf("invalid cache format '%s'", value)
 			}
@@ -41,6 +41,11 @@ func testCacheOpts(t *testing.T, when spec.G, it spec.S) {
 					input:  "type=launch;format=image;name=io.test.io/myorg/my-cache:build",
 					output: "type=build;format=volume;type=launch;format=image;name=io.test.io/myorg/my-cache:build;",
 				},
+				{
+					name:   "Build cache as Registry image",
+					input:  "type=build;format=registry;name=io.test.io/myorg/my-cache:build",
+					output: "type=build;format=registry;name=io.test.io/myorg/my-cache:build;type=launch;format=volume;",
+				},
 			}
 
 			for _, testcase := range testcases {
@@ -4,6 +4,7 @@ const (
 	Image Type = iota
 	Volume
 	Bind
+	Registry
 )
 
 type Type int
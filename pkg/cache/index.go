@@ -0,0 +1,90 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/pkg/errors"
+)
+
+// IndexFileName is the name of the file, relative to the pack home directory, in which
+// pack records the caches it has used.
+const IndexFileName = "caches.toml"
+
+// Record describes a cache pack used for an app image.
+type Record struct {
+	Name     string    `toml:"name"`
+	Format   string    `toml:"format"`
+	Kind     string    `toml:"kind"`
+	Image    string    `toml:"image"`
+	LastUsed time.Time `toml:"last-used"`
+}
+
+// Index tracks caches used by pack builds so they can later be listed and pruned.
+type Index struct {
+	path    string
+	Records []Record `toml:"caches"`
+}
+
+// ReadIndex reads the cache index at path. A missing file results in an empty index.
+func ReadIndex(path string) (*Index, error) {
+	idx := &Index{path: path}
+	if _, err := toml.DecodeFile(path, idx); err != nil && !os.IsNotExist(err) {
+		return nil, errors.Wrapf(err, "reading cache index at path %s", path)
+	}
+	return idx, nil
+}
+
+// Find returns the record with the given cache name, if any.
+func (i *Index) Find(name string) (Record, bool) {
+	for _, r := range i.Records {
+		if r.Name == name {
+			return r, true
+		}
+	}
+	return Record{}, false
+}
+
+// Touch adds the record to the index, replacing any record with the same name.
+func (i *Index) Touch(record Record) {
+	for n, r := range i.Records {
+		if r.Name == record.Name {
+			i.Records[n] = record
+			return
+		}
+	}
+	i.Records = append(i.Records, record)
+}
+
+// Remove deletes the record with the given cache name from the index.
+func (i *Index) Remove(name string) {
+	var records []Record
+	for _, r := range i.Records {
+		if r.Name != name {
+			records = append(records, r)
+		}
+	}
+	i.Records = records
+}
+
+// Save writes the index back to the path it was read from.
+func (i *Index) Save() error {
+	if err := os.MkdirAll(filepath.Dir(i.path), 0750); err != nil {
+		return errors.Wrap(err, "creating cache index directory")
+	}
+
+	sort.Slice(i.Records, func(a, b int) bool {
+		return i.Records[a].Name < i.Records[b].Name
+	})
+
+	w, err := os.Create(i.path)
+	if err != nil {
+		return errors.Wrapf(err, "writing cache index at path %s", i.path)
+	}
+	defer w.Close()
+
+	return toml.NewEncoder(w).Encode(i)
+}
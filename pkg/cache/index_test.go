@@ -0,0 +1,93 @@
+package cache_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/cache"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestIndex(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+
+	spec.Run(t, "Index", testIndex, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testIndex(t *testing.T, when spec.G, it spec.S) {
+	var (
+		tmpDir    string
+		indexPath string
+	)
+
+	it.Before(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "pack.cache.index.test.")
+		h.AssertNil(t, err)
+		indexPath = filepath.Join(tmpDir, "home", cache.IndexFileName)
+	})
+
+	it.After(func() {
+		h.AssertNil(t, os.RemoveAll(tmpDir))
+	})
+
+	when("#ReadIndex", func() {
+		it("returns an empty index when the file does not exist", func() {
+			idx, err := cache.ReadIndex(indexPath)
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(idx.Records), 0)
+		})
+
+		it("errors when the file is invalid", func() {
+			h.AssertNil(t, os.MkdirAll(filepath.Dir(indexPath), 0755))
+			h.AssertNil(t, os.WriteFile(indexPath, []byte("not toml ["), 0600))
+
+			_, err := cache.ReadIndex(indexPath)
+			h.AssertError(t, err, "reading cache index")
+		})
+	})
+
+	when("#Touch", func() {
+		it("adds and replaces records by name", func() {
+			idx, err := cache.ReadIndex(indexPath)
+			h.AssertNil(t, err)
+
+			lastUsed := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			idx.Touch(cache.Record{Name: "some-cache", Format: "volume", Kind: "build", Image: "some-image"})
+			idx.Touch(cache.Record{Name: "some-cache", Format: "volume", Kind: "build", Image: "other-image", LastUsed: lastUsed})
+			h.AssertNil(t, idx.Save())
+
+			idx, err = cache.ReadIndex(indexPath)
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(idx.Records), 1)
+
+			record, found := idx.Find("some-cache")
+			h.AssertTrue(t, found)
+			h.AssertEq(t, record.Image, "other-image")
+			h.AssertEq(t, record.LastUsed, lastUsed)
+		})
+	})
+
+	when("#Remove", func() {
+		it("removes the record", func() {
+			idx, err := cache.ReadIndex(indexPath)
+			h.AssertNil(t, err)
+
+			idx.Touch(cache.Record{Name: "some-cache"})
+			idx.Touch(cache.Record{Name: "other-cache"})
+			idx.Remove("some-cache")
+
+			_, found := idx.Find("some-cache")
+			h.AssertFalse(t, found)
+			_, found = idx.Find("other-cache")
+			h.AssertTrue(t, found)
+		})
+	})
+}
@@ -0,0 +1,33 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/google/go-containerregistry/pkg/name"
+)
+
+// RegistryCache is a build cache stored as an image in a remote registry. Unlike ImageCache it
+// may be used for daemon builds as well as published builds.
+type RegistryCache struct {
+	image string
+}
+
+func NewRegistryCache(imageRef name.Reference) *RegistryCache {
+	return &RegistryCache{
+		image: imageRef.Name(),
+	}
+}
+
+func (c *RegistryCache) Name() string {
+	return c.image
+}
+
+// Clear is a no-op: the cache image lives in a remote registry and is skipped by the lifecycle
+// when the cache is cleared, then overwritten on export.
+func (c *RegistryCache) Clear(ctx context.Context) error {
+	return nil
+}
+
+func (c *RegistryCache) Type() Type {
+	return Registry
+}
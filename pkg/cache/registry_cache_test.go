@@ -0,0 +1,60 @@
+package cache_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/cache"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestRegistryCache(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+
+	spec.Run(t, "RegistryCache", testRegistryCache, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testRegistryCache(t *testing.T, when spec.G, it spec.S) {
+	when("#NewRegistryCache", func() {
+		when("#Name", func() {
+			it("should return the image reference used in initialization", func() {
+				refName := "gcr.io/my/repo:tag"
+				ref, err := name.ParseReference(refName, name.WeakValidation)
+				h.AssertNil(t, err)
+				subject := cache.NewRegistryCache(ref)
+				h.AssertEq(t, subject.Name(), refName)
+			})
+		})
+
+		it("resolves implied tag", func() {
+			ref, err := name.ParseReference("my/repo", name.WeakValidation)
+			h.AssertNil(t, err)
+			subject := cache.NewRegistryCache(ref)
+			h.AssertEq(t, subject.Name(), "index.docker.io/my/repo:latest")
+		})
+	})
+
+	when("#Type", func() {
+		it("returns the cache type", func() {
+			ref, err := name.ParseReference("my/repo", name.WeakValidation)
+			h.AssertNil(t, err)
+			subject := cache.NewRegistryCache(ref)
+			h.AssertEq(t, subject.Type(), cache.Registry)
+		})
+	})
+
+	when("#Clear", func() {
+		it("leaves the remote image in place", func() {
+			ref, err := name.ParseReference("my/repo", name.WeakValidation)
+			h.AssertNil(t, err)
+			subject := cache.NewRegistryCache(ref)
+			h.AssertNil(t, subject.Clear(context.TODO()))
+		})
+	})
+}
@@ -0,0 +1,137 @@
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	projectTypes "github.com/buildpacks/pack/pkg/project/types"
+)
+
+const (
+	bindingTypeFile     = "type"
+	bindingProviderFile = "provider"
+)
+
+// Names of bindings and of their entries, as specified by the Kubernetes service binding specification
+var bindingNameRegexp = regexp.MustCompile(`^[-._a-zA-Z0-9]+$`)
+
+// bindings returns the service bindings of the project descriptor, with their paths relative to
+// ProjectDescriptorBaseDir, and the ones of Bindings, which replace those of the descriptor with the same name.
+func (opts BuildOptions) bindings() []projectTypes.Binding {
+	var bindings []projectTypes.Binding
+	overridden := map[string]bool{}
+	for _, binding := range opts.Bindings {
+		overridden[binding.Name] = true
+	}
+
+	for _, binding := range opts.ProjectDescriptor.Build.Bindings {
+		if overridden[binding.Name] {
+			continue
+		}
+		if !filepath.IsAbs(binding.Path) {
+			binding.Path = filepath.Join(opts.ProjectDescriptorBaseDir, binding.Path)
+		}
+		bindings = append(bindings, binding)
+	}
+	return append(bindings, opts.Bindings...)
+}
+
+// prepareBindings lays out the service bindings in a new directory as specified by the Kubernetes service binding
+// specification: a directory per binding, named after it, holding a file per entry of the binding along with the type
+// and provider files. It returns an empty path when there are no bindings.
+func prepareBindings(bindings []projectTypes.Binding) (string, error) {
+	if len(bindings) == 0 {
+		return "", nil
+	}
+
+	dir, err := os.MkdirTemp("", "pack.bindings.")
+	if err != nil {
+		return "", errors.Wrap(err, "creating bindings directory")
+	}
+
+	for _, binding := range bindings {
+		if err := writeBinding(filepath.Join(dir, binding.Name), binding); err != nil {
+			os.RemoveAll(dir)
+			return "", errors.Wrapf(err, "invalid binding %s", style.Symbol(binding.Name))
+		}
+	}
+	return dir, nil
+}
+
+func writeBinding(dst string, binding projectTypes.Binding) error {
+	if !isValidBindingName(binding.Name) {
+		return errors.New("name must only contain alphanumeric characters, '-', '_' and '.'")
+	}
+
+	entries, err := os.ReadDir(binding.Path)
+	if err != nil {
+		return errors.Wrapf(err, "reading entries at '%s'", binding.Path)
+	}
+
+	files := map[string][]byte{}
+	for _, entry := range entries {
+		name := entry.Name()
+		// hidden files, such as the ..data directory of the secrets mounted by Kubernetes, are not entries
+		if strings.HasPrefix(name, ".") {
+			continue
+		}
+		if !isValidBindingName(name) {
+			return errors.Errorf("entry %s must only contain alphanumeric characters, '-', '_' and '.'", style.Symbol(name))
+		}
+
+		// entries are read through symbolic links, which is how Kubernetes mounts them
+		path := filepath.Join(binding.Path, name)
+		info, err := os.Stat(path)
+		if err != nil {
+			return errors.Wrapf(err, "reading entry %s", style.Symbol(name))
+		}
+		if !info.Mode().IsRegular() {
+			return errors.Errorf("entry %s must be a file", style.Symbol(name))
+		}
+		if files[name], err = os.ReadFile(path); err != nil {
+			return errors.Wrapf(err, "reading entry %s", style.Symbol(name))
+		}
+	}
+
+	if err := setBindingEntry(files, bindingTypeFile, binding.Type); err != nil {
+		return err
+	}
+	if len(files[bindingTypeFile]) == 0 {
+		return errors.New("type must be set")
+	}
+	if err := setBindingEntry(files, bindingProviderFile, binding.Provider); err != nil {
+		return err
+	}
+
+	if err := os.Mkdir(dst, 0700); err != nil {
+		return err
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dst, name), content, 0600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setBindingEntry sets the type or provider entry of a binding, which must match the entry of the binding directory,
+// if any.
+func setBindingEntry(files map[string][]byte, name, value string) error {
+	if value == "" {
+		return nil
+	}
+	if current, ok := files[name]; ok && strings.TrimSpace(string(current)) != value {
+		return errors.Errorf("%s %s doesn't match %s %s of the binding directory", name, style.Symbol(value), name, style.Symbol(strings.TrimSpace(string(current))))
+	}
+	files[name] = []byte(value)
+	return nil
+}
+
+func isValidBindingName(name string) bool {
+	return bindingNameRegexp.MatchString(name) && name != "." && name != ".."
+}
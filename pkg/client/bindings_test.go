@@ -0,0 +1,115 @@
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	projectTypes "github.com/buildpacks/pack/pkg/project/types"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestBindings(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "bindings", testBindings, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testBindings(t *testing.T, when spec.G, it spec.S) {
+	var bindingPath string
+
+	it.Before(func() {
+		bindingPath = t.TempDir()
+		h.AssertNil(t, os.WriteFile(filepath.Join(bindingPath, "username"), []byte("some-user"), 0600))
+	})
+
+	when("#bindings", func() {
+		it("resolves the paths of the project descriptor and replaces its bindings with those of the options", func() {
+			opts := BuildOptions{
+				ProjectDescriptorBaseDir: "/some/project",
+				ProjectDescriptor: projectTypes.Descriptor{Build: projectTypes.Build{Bindings: []projectTypes.Binding{
+					{Name: "db", Type: "postgresql", Path: "bindings/db"},
+					{Name: "cache", Type: "redis", Path: "bindings/cache"},
+				}}},
+				Bindings: []projectTypes.Binding{{Name: "cache", Type: "memcached", Path: "/some/cache"}},
+			}
+
+			h.AssertEq(t, opts.bindings(), []projectTypes.Binding{
+				{Name: "db", Type: "postgresql", Path: filepath.Join("/some/project", "bindings", "db")},
+				{Name: "cache", Type: "memcached", Path: "/some/cache"},
+			})
+		})
+	})
+
+	when("#prepareBindings", func() {
+		it("returns no directory when there are no bindings", func() {
+			dir, err := prepareBindings(nil)
+			h.AssertNil(t, err)
+			h.AssertEq(t, dir, "")
+		})
+
+		it("lays out each binding with its entries, type and provider", func() {
+			h.AssertNil(t, os.WriteFile(filepath.Join(bindingPath, ".hidden"), []byte("some-content"), 0600))
+
+			dir, err := prepareBindings([]projectTypes.Binding{{Name: "db", Type: "postgresql", Provider: "bitnami", Path: bindingPath}})
+			h.AssertNil(t, err)
+			defer os.RemoveAll(dir)
+
+			h.AssertEq(t, readBindingEntry(t, dir, "db", "username"), "some-user")
+			h.AssertEq(t, readBindingEntry(t, dir, "db", "type"), "postgresql")
+			h.AssertEq(t, readBindingEntry(t, dir, "db", "provider"), "bitnami")
+			h.AssertPathDoesNotExists(t, filepath.Join(dir, "db", ".hidden"))
+		})
+
+		it("uses the type of the binding directory", func() {
+			h.AssertNil(t, os.WriteFile(filepath.Join(bindingPath, "type"), []byte("postgresql\n"), 0600))
+
+			dir, err := prepareBindings([]projectTypes.Binding{{Name: "db", Path: bindingPath}})
+			h.AssertNil(t, err)
+			defer os.RemoveAll(dir)
+
+			h.AssertEq(t, readBindingEntry(t, dir, "db", "type"), "postgresql\n")
+		})
+
+		it("errors when the type doesn't match the type of the binding directory", func() {
+			h.AssertNil(t, os.WriteFile(filepath.Join(bindingPath, "type"), []byte("mysql"), 0600))
+
+			_, err := prepareBindings([]projectTypes.Binding{{Name: "db", Type: "postgresql", Path: bindingPath}})
+			h.AssertError(t, err, "invalid binding 'db': type 'postgresql' doesn't match type 'mysql' of the binding directory")
+		})
+
+		it("errors when there is no type", func() {
+			_, err := prepareBindings([]projectTypes.Binding{{Name: "db", Path: bindingPath}})
+			h.AssertError(t, err, "invalid binding 'db': type must be set")
+		})
+
+		it("errors when the name is invalid", func() {
+			_, err := prepareBindings([]projectTypes.Binding{{Name: "some/db", Type: "postgresql", Path: bindingPath}})
+			h.AssertError(t, err, "invalid binding 'some/db': name must only contain alphanumeric characters")
+		})
+
+		it("errors when an entry is a directory", func() {
+			h.AssertNil(t, os.Mkdir(filepath.Join(bindingPath, "certs"), 0700))
+
+			_, err := prepareBindings([]projectTypes.Binding{{Name: "db", Type: "postgresql", Path: bindingPath}})
+			h.AssertError(t, err, "invalid binding 'db': entry 'certs' must be a file")
+		})
+
+		it("errors when the path doesn't exist", func() {
+			_, err := prepareBindings([]projectTypes.Binding{{Name: "db", Type: "postgresql", Path: filepath.Join(bindingPath, "missing")}})
+			h.AssertError(t, err, "invalid binding 'db': reading entries at")
+		})
+	})
+}
+
+func readBindingEntry(t *testing.T, dir, binding, entry string) string {
+	t.Helper()
+
+	content, err := os.ReadFile(filepath.Join(dir, binding, entry))
+	h.AssertNil(t, err)
+	return string(content)
+}
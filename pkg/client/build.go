@@ -42,10 +42,12 @@ import (
 	"github.com/buildpacks/pack/pkg/buildpack"
 	"github.com/buildpacks/pack/pkg/cache"
 	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/events"
 	"github.com/buildpacks/pack/pkg/image"
 	"github.com/buildpacks/pack/pkg/logging"
 	projectTypes "github.com/buildpacks/pack/pkg/project/types"
 	v02 "github.com/buildpacks/pack/pkg/project/v02"
+	"github.com/buildpacks/pack/pkg/signing"
 )
 
 const (
@@ -76,6 +78,15 @@ type LifecycleExecutor interface {
 
 type IsTrustedBuilder func(string) bool
 
+// TrustedBuilderPolicy restricts trust in a builder to images with a given digest, or signed with given keys.
+type TrustedBuilderPolicy struct {
+	// Digest the builder image must have, in the form 'sha256:<hex>'.
+	Digest string
+
+	// The builder image must be signed with one of the keys of this verifier.
+	Verifier *signing.Verifier
+}
+
 // BuildOptions defines configuration settings for a Build.
 type BuildOptions struct {
 	// The base directory to use to resolve relative assets
@@ -116,6 +127,17 @@ type BuildOptions struct {
 	// Buildpacks may both read and overwrite these values.
 	Env map[string]string
 
+	// Secrets available to the buildpacks during the build phase only, keyed by ID. Each secret is written to
+	// /run/secrets/<id>, a tmpfs mount of the container of the build phase, readable by the builder user, and never to
+	// disk, the layers, the cache, the image or the report. The build image must provide sh and tar to receive them.
+	// Building with secrets runs the lifecycle phases in separate containers, even with a trusted builder.
+	Secrets map[string][]byte
+
+	// Service bindings given to the buildpacks of the detect and build phases, following the Kubernetes service binding
+	// specification, in addition to those of the ProjectDescriptor. A binding replaces the one of the ProjectDescriptor
+	// with the same name. Each binding is copied to /platform/bindings/<name>, which SERVICE_BINDING_ROOT points to.
+	Bindings []projectTypes.Binding
+
 	// Used to configure various cache available options
 	Cache cache.CacheOpts
 
@@ -159,7 +181,11 @@ type BuildOptions struct {
 	// Process type that will be used when setting container start command.
 	DefaultProcessType string
 
-	// Platform is the desired platform to build on (e.g., linux/amd64)
+	// Platform is the desired platform to build on (e.g., linux/amd64).
+	// A comma separated list of platforms builds the app for each of them, and requires Publish:
+	// each image is published with the platform appended to its tag (e.g., <image>:<tag>-linux-amd64),
+	// and an image index referencing them is published as Image and AdditionalTags. The index can't be signed or given
+	// provenance, so Signer and AttachProvenance are not supported then.
 	Platform string
 
 	// Strategy for updating local images before a build.
@@ -201,17 +227,63 @@ type BuildOptions struct {
 	// Only trust builders from reputable sources.
 	TrustBuilder IsTrustedBuilder
 
+	// Requirements the builder image must meet for TrustBuilder to apply.
+	// Builders that TrustBuilder trusts but that do not meet them are treated as untrusted.
+	TrustedBuilderPolicy *TrustedBuilderPolicy
+
 	// Directory to output any SBOM artifacts
 	SBOMDestinationDir string
 
 	// Directory to output the report.toml metadata artifact
 	ReportDestinationDir string
 
+	// Directory to output a SLSA provenance attestation describing how the image was built.
+	// Images in the daemon, such as the app image when it is not published, are recorded with their
+	// image ID under the 'dockerImageId' digest key, as the daemon does not know their manifest digest.
+	ProvenanceDestinationDir string
+
+	// Option only valid if Publish is true
+	// Attach the SLSA provenance attestation to the published image as an OCI referrer.
+	AttachProvenance bool
+
+	// Option only valid if Publish is true
+	// Sign the published image, in each repository it is tagged in, with this signer.
+	Signer *signing.Signer
+
+	// Require the builder and run image to be signed with one of the keys of this verifier before they are used.
+	Verifier *signing.Verifier
+
+	// Receives machine-readable events as the build progresses: phases starting and ending, their output,
+	// the detected group, cache hits and misses, and the digest of the exported image.
+	// Ignored when Interactive is true.
+	Events events.Emitter
+
+	// Run detection at debug level and report why each group of the order passed or failed, with the group selected
+	// to build the app: logged after the build, or emitted as an event when Events is set. When the lifecycle phases run
+	// in a single container, with a trusted builder, all of them run at debug level.
+	// Ignored when Interactive is true.
+	ExplainDetect bool
+
+	// Log a summary of the time spent in each lifecycle phase after the build, and write it to TimingsFileName in
+	// ReportDestinationDir when set. The run time of the creator is split by the lifecycle phases it runs.
+	// Ignored when Interactive is true.
+	Timings bool
+
 	// Desired create time in the output image config
 	CreationTime *time.Time
 
 	// Configuration to export to OCI layout format
 	LayoutConfig *LayoutConfig
+
+	// Executor runs the lifecycle somewhere other than the docker daemon, such as a Kubernetes cluster or an OCI runtime.
+	// The builder is used as published, so the build cannot add buildpacks, extensions, environment variables
+	// or volumes to it, and the image must be published or exported to OCI layout. When exporting to OCI layout,
+	// the builder is read from the layout repository too. Defaults to running the lifecycle with the docker daemon.
+	Executor LifecycleExecutor
+}
+
+func (b *BuildOptions) provenance() bool {
+	return b.ProvenanceDestinationDir != "" || b.AttachProvenance
 }
 
 func (b *BuildOptions) Layout() bool {
@@ -291,6 +363,10 @@ var IsTrustedBuilderFunc = func(b string) bool {
 // If any configuration is deemed invalid, or if any lifecycle phases fail,
 // an error will be returned and no image produced.
 func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
+	if platforms := splitPlatforms(opts.Platform); len(platforms) > 1 {
+		return c.buildPlatforms(ctx, opts, platforms)
+	}
+
 	var pathsConfig layoutPathConfig
 
 	imageRef, err := c.parseReference(opts)
@@ -300,6 +376,41 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 	imgRegistry := imageRef.Context().RegistryStr()
 	imageName := imageRef.Name()
 
+	if c.offline {
+		if opts.Publish {
+			return errors.New("cannot publish an image in offline mode")
+		}
+		if opts.Cache.Build.Format == cache.CacheRegistry {
+			return errors.Errorf("cache format %s cannot be used in offline mode", style.Symbol(cache.CacheRegistry.String()))
+		}
+	}
+
+	if opts.AttachProvenance && !opts.Publish {
+		return errors.New("provenance can only be attached to published images")
+	}
+	if opts.provenance() && opts.Layout() {
+		return errors.New("provenance is not supported when exporting to OCI layout")
+	}
+	if opts.Signer != nil && !opts.Publish {
+		return errors.New("only published images can be signed")
+	}
+	if opts.Verifier != nil && opts.Layout() {
+		return errors.New("signature verification is not supported when exporting to OCI layout")
+	}
+	if opts.Executor != nil {
+		if err := validateExecutorOptions(opts); err != nil {
+			return err
+		}
+	}
+
+	bindingsDir, err := prepareBindings(opts.bindings())
+	if err != nil {
+		return err
+	}
+	if bindingsDir != "" {
+		defer os.RemoveAll(bindingsDir)
+	}
+
 	if opts.Layout() {
 		pathsConfig, err = c.processLayoutPath(opts.LayoutConfig.InputImage, opts.LayoutConfig.PreviousInputImage)
 		if err != nil {
@@ -340,18 +451,30 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		}
 	}()
 
-	rawBuilderImage, err := c.imageFetcher.Fetch(
-		ctx,
-		builderRef.Name(),
-		image.FetchOptions{
-			Daemon:     true,
-			Target:     requestedTarget,
-			PullPolicy: opts.PullPolicy},
-	)
+	builderFetchOptions := image.FetchOptions{
+		Daemon:     opts.Executor == nil,
+		Target:     requestedTarget,
+		PullPolicy: opts.PullPolicy,
+	}
+	if opts.Executor != nil && opts.Layout() {
+		// without a daemon, the builder is read from the layout repository like the run image
+		builderPath, err := layout.ParseRefToPath(builderRef.Name())
+		if err != nil {
+			return err
+		}
+		builderFetchOptions.LayoutOption = image.LayoutOption{Path: filepath.Join(opts.LayoutConfig.LayoutRepoDir, builderPath)}
+	}
+	rawBuilderImage, err := c.imageFetcher.Fetch(ctx, builderRef.Name(), builderFetchOptions)
 	if err != nil {
 		return errors.Wrapf(err, "failed to fetch builder image '%s'", builderRef.Name())
 	}
 
+	if opts.Verifier != nil {
+		if err := c.verifyImage(ctx, opts.Verifier, builderRef.Name(), rawBuilderImage); err != nil {
+			return errors.Wrapf(err, "verifying builder %s", style.Symbol(builderRef.Name()))
+		}
+	}
+
 	var targetToUse *dist.Target
 	if requestedTarget != nil {
 		targetToUse = requestedTarget
@@ -394,6 +517,12 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		return errors.Wrapf(err, "invalid run-image '%s'", runImageName)
 	}
 
+	if opts.Verifier != nil {
+		if err := c.verifyImage(ctx, opts.Verifier, runImageName, runImage); err != nil {
+			return errors.Wrapf(err, "verifying run-image %s", style.Symbol(runImageName))
+		}
+	}
+
 	var runMixins []string
 	if _, err := dist.GetLabel(runImage, stack.MixinsLabel, &runMixins); err != nil {
 		return err
@@ -414,6 +543,14 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		opts.TrustBuilder = IsTrustedBuilderFunc
 	}
 
+	trustBuilder := opts.TrustBuilder(opts.Builder)
+	if trustBuilder && opts.TrustedBuilderPolicy != nil {
+		if err := c.checkTrustedBuilderPolicy(ctx, builderRef.Name(), rawBuilderImage, *opts.TrustedBuilderPolicy); err != nil {
+			c.logger.Warnf("Builder %s does not meet its trusted builder policy and will be treated as untrusted: %s", style.Symbol(opts.Builder), err)
+			trustBuilder = false
+		}
+	}
+
 	// Ensure the builder's platform APIs are supported
 	var builderPlatformAPIs builder.APISet
 	builderPlatformAPIs = append(builderPlatformAPIs, bldr.LifecycleDescriptor().APIs.Platform.Deprecated...)
@@ -426,19 +563,28 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 
 	// Get the platform API version to use
 	lifecycleVersion := bldr.LifecycleDescriptor().Info.Version
-	useCreator := supportsCreator(lifecycleVersion) && opts.TrustBuilder(opts.Builder)
+	// secrets are only given to the build phase, so the phases must run in separate containers
+	useCreator := supportsCreator(lifecycleVersion) && trustBuilder && len(opts.Secrets) == 0
 	var (
+		lifecycleImageName          string
 		lifecycleOptsLifecycleImage string
 		lifecycleAPIs               []string
 	)
 	if !(useCreator) {
-		// fetch the lifecycle image
 		if supportsLifecycleImage(lifecycleVersion) {
-			lifecycleImageName := opts.LifecycleImage
+			lifecycleImageName = opts.LifecycleImage
 			if lifecycleImageName == "" {
 				lifecycleImageName = fmt.Sprintf("%s:%s", internalConfig.DefaultLifecycleImageRepo, lifecycleVersion.String())
 			}
+		}
+
+		// the executor pulls the lifecycle image where it runs the lifecycle
+		if supportsLifecycleImage(lifecycleVersion) && opts.Executor != nil {
+			lifecycleOptsLifecycleImage = lifecycleImageName
+		}
 
+		// fetch the lifecycle image
+		if supportsLifecycleImage(lifecycleVersion) && opts.Executor == nil {
 			lifecycleImage, err := c.imageFetcher.Fetch(
 				ctx,
 				lifecycleImageName,
@@ -510,11 +656,15 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		buildEnvs[k] = v
 	}
 
-	ephemeralBuilder, err := c.createEphemeralBuilder(rawBuilderImage, buildEnvs, order, fetchedBPs, orderExtensions, fetchedExs, usingPlatformAPI.LessThan("0.12"), opts.RunImage)
-	if err != nil {
-		return err
+	// with an executor the builder is used as published, since nothing may be added to it
+	ephemeralBuilder := bldr
+	if opts.Executor == nil {
+		ephemeralBuilder, err = c.createEphemeralBuilder(rawBuilderImage, buildEnvs, order, fetchedBPs, orderExtensions, fetchedExs, usingPlatformAPI.LessThan("0.12"), opts.RunImage)
+		if err != nil {
+			return err
+		}
+		defer c.docker.ImageRemove(context.Background(), ephemeralBuilder.Name(), types.RemoveOptions{Force: true})
 	}
-	defer c.docker.ImageRemove(context.Background(), ephemeralBuilder.Name(), types.RemoveOptions{Force: true})
 
 	if len(bldr.OrderExtensions()) > 0 || len(ephemeralBuilder.OrderExtensions()) > 0 {
 		if targetToUse.OS == "windows" {
@@ -563,6 +713,22 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		}
 	}
 
+	var buildEvents events.Emitter
+	if !opts.Interactive {
+		buildEvents = opts.Events
+	}
+	phaseEvents := buildEvents
+	var timings *phaseTimings
+	if opts.Timings && !opts.Interactive {
+		timings = newPhaseTimings(phaseEvents)
+		phaseEvents = timings
+	}
+	var explainer *detectExplainer
+	if opts.ExplainDetect && !opts.Interactive {
+		explainer = newDetectExplainer(phaseEvents)
+		phaseEvents = explainer
+	}
+
 	lifecycleOpts := build.LifecycleOptions{
 		AppPath:                  appPath,
 		Image:                    imageRef,
@@ -573,7 +739,7 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		ProjectMetadata:          projectMetadata,
 		ClearCache:               opts.ClearCache,
 		Publish:                  opts.Publish,
-		TrustBuilder:             opts.TrustBuilder(opts.Builder),
+		TrustBuilder:             trustBuilder,
 		UseCreator:               useCreator,
 		UseCreatorWithExtensions: supportsCreatorWithExtensions(lifecycleVersion),
 		DockerHost:               opts.DockerHost,
@@ -585,6 +751,8 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		Network:                  opts.ContainerConfig.Network,
 		AdditionalTags:           opts.AdditionalTags,
 		Volumes:                  processedVolumes,
+		Secrets:                  opts.Secrets,
+		BindingsDir:              bindingsDir,
 		DefaultProcessType:       opts.DefaultProcessType,
 		FileFilter:               fileFilter,
 		Workspace:                opts.Workspace,
@@ -598,6 +766,29 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		CreationTime:             opts.CreationTime,
 		Layout:                   opts.Layout(),
 		Keychain:                 c.keychain,
+		Events:                   phaseEvents,
+		ExplainDetect:            explainer != nil,
+	}
+
+	if opts.Executor == nil {
+		if podman := c.detectPodman(ctx); podman != nil {
+			c.logger.Debugf("Using Podman service (rootless: %t)", podman.rootless)
+			if lifecycleOpts.DockerHost == "" && podman.socket != "" {
+				// the phases with daemon access mount the podman socket rather than the default docker socket
+				lifecycleOpts.DockerHost = "unix://" + podman.socket
+			}
+			lifecycleOpts.RootlessPodman = podman.rootless
+		}
+	}
+
+	if opts.Signer != nil && lifecycleOpts.ReportDestinationDir == "" {
+		// the image is signed by the digest the exporter reports
+		reportDir, err := os.MkdirTemp("", "pack.report.")
+		if err != nil {
+			return errors.Wrap(err, "creating report directory")
+		}
+		defer os.RemoveAll(reportDir)
+		lifecycleOpts.ReportDestinationDir = reportDir
 	}
 
 	switch {
@@ -606,7 +797,7 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 	case supportsLifecycleImage(lifecycleVersion):
 		lifecycleOpts.LifecycleImage = lifecycleOptsLifecycleImage
 		lifecycleOpts.LifecycleApis = lifecycleAPIs
-	case !opts.TrustBuilder(opts.Builder):
+	case !trustBuilder:
 		return errors.Errorf("Lifecycle %s does not have an associated lifecycle image. Builder must be trusted.", lifecycleVersion.String())
 	}
 
@@ -757,9 +948,69 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		return ephemeralRunImageName, nil
 	}
 
-	if err = c.lifecycleExecutor.Execute(ctx, lifecycleOpts); err != nil {
+	lifecycleExecutor := c.lifecycleExecutor
+	if opts.Executor != nil {
+		lifecycleExecutor = opts.Executor
+	}
+
+	buildStarted := time.Now()
+	err = lifecycleExecutor.Execute(ctx, lifecycleOpts)
+	if explainer != nil {
+		// detection is explained whether or not it passed
+		if explainErr := c.explainDetect(explainer, ephemeralBuilder, opts); explainErr != nil {
+			c.logger.Warnf("Failed to explain detection: %s", explainErr)
+		}
+	}
+	if err != nil {
 		return fmt.Errorf("executing lifecycle: %w", err)
 	}
+
+	if timings != nil {
+		c.reportTimings(timings, opts.ReportDestinationDir)
+	}
+
+	// caches of other executors are not kept by the docker daemon
+	if opts.Executor == nil {
+		if err := c.recordCaches(imageRef, opts); err != nil {
+			c.logger.Warnf("Failed to record build caches: %s", err)
+		}
+	}
+
+	if opts.provenance() {
+		err := c.generateProvenance(ctx, imageRef, opts, buildProvenance{
+			builderName:      builderRef.Name(),
+			builderImage:     rawBuilderImage,
+			ephemeralBuilder: ephemeralBuilder.Image(),
+			runImageName:     runImageName,
+			runImage:         runImage,
+			lifecycleVersion: lifecycleVersion.String(),
+			lifecycleImage:   lifecycleImageName,
+			platformAPI:      usingPlatformAPI.String(),
+			env:              buildEnvs,
+			appPath:          appPath,
+			fileFilter:       fileFilter,
+			startedOn:        buildStarted,
+		})
+		if err != nil {
+			return errors.Wrap(err, "generating provenance")
+		}
+	}
+
+	if opts.Signer != nil {
+		digest, err := reportedDigest(lifecycleOpts.ReportDestinationDir)
+		if err != nil {
+			return errors.Wrap(err, "signing image")
+		}
+		if err := c.signImage(ctx, opts.Signer, imageRef.Name(), digest, opts.AdditionalTags...); err != nil {
+			return errors.Wrap(err, "signing image")
+		}
+	}
+
+	if opts.Events != nil && !opts.Interactive {
+		// the image event takes the place of the image name and digest logged in quiet mode
+		c.emitImageEvent(ctx, opts, imageRef)
+		return nil
+	}
 	return c.logImageNameAndSha(ctx, opts.Publish, imageRef)
 }
 
@@ -1260,6 +1511,7 @@ func (c *Client) fetchBuildpack(ctx context.Context, bp string, relativeBaseDir
 			RelativeBaseDir: relativeBaseDir,
 			Daemon:          !publish,
 			PullPolicy:      pullPolicy,
+			SHA256:          projectDescriptorDigest(opts.ProjectDescriptor, bp),
 		}
 		if kind == buildpack.KindExtension {
 			downloadOptions.ModuleKind = kind
@@ -1297,6 +1549,7 @@ func (c *Client) fetchBuildpackDependencies(ctx context.Context, bp string, pack
 				Daemon:          downloadOptions.Daemon,
 				PullPolicy:      downloadOptions.PullPolicy,
 				RelativeBaseDir: filepath.Join(bp, packageCfg.Buildpack.URI),
+				SHA256:          dep.SHA256,
 			})
 
 			if err != nil {
@@ -1310,6 +1563,20 @@ func (c *Client) fetchBuildpackDependencies(ctx context.Context, bp string, pack
 	return nil, err
 }
 
+// projectDescriptorDigest returns the sha256 digest the project descriptor pins for the buildpack
+// declared with the given URI, if any.
+func projectDescriptorDigest(descriptor projectTypes.Descriptor, uri string) string {
+	groups := [][]projectTypes.Buildpack{descriptor.Build.Buildpacks, descriptor.Build.Pre.Buildpacks, descriptor.Build.Post.Buildpacks}
+	for _, group := range groups {
+		for _, bp := range group {
+			if bp.URI != "" && bp.URI == uri {
+				return bp.SHA256
+			}
+		}
+	}
+	return ""
+}
+
 func getBuildpackLocator(bp projectTypes.Buildpack, stackID string) (string, error) {
 	switch {
 	case bp.ID != "" && bp.Script.Inline != "" && bp.URI == "":
@@ -1575,6 +1842,45 @@ func (c *Client) logImageNameAndSha(ctx context.Context, publish bool, imageRef
 	return err
 }
 
+// reportTimings logs a summary of the time spent in each lifecycle phase, and writes it to the report directory if
+// there is one. The image is already built, so failing to report the timings only logs a warning.
+func (c *Client) reportTimings(timings *phaseTimings, reportDir string) {
+	if timings.empty() {
+		return
+	}
+
+	summary, err := timings.summary()
+	if err != nil {
+		c.logger.Warnf("Failed to summarize phase timings: %s", err)
+		return
+	}
+	c.logger.Info(style.Step("TIMINGS"))
+	c.logger.Info(strings.TrimSuffix(summary, "\n"))
+
+	if reportDir != "" {
+		if err := timings.write(reportDir); err != nil {
+			c.logger.Warnf("Failed to write phase timings: %s", err)
+			return
+		}
+		c.logger.Debugf("Wrote phase timings to %s", style.Symbol(filepath.Join(reportDir, TimingsFileName)))
+	}
+}
+
+// emitImageEvent emits an event with the name of the built image and, unless it was exported to OCI layout, its digest.
+func (c *Client) emitImageEvent(ctx context.Context, opts BuildOptions, imageRef name.Reference) {
+	event := events.Event{Type: events.Image, Image: imageRef.Name()}
+	if !opts.Layout() {
+		img, err := c.imageFetcher.Fetch(ctx, imageRef.Name(), image.FetchOptions{Daemon: !opts.Publish, PullPolicy: image.PullNever})
+		if err == nil {
+			event.Digest, err = imageDigest(img)
+		}
+		if err != nil {
+			c.logger.Debugf("Failed to read digest of built image: %s", err)
+		}
+	}
+	opts.Events.Emit(event)
+}
+
 func parseDigestFromImageID(id imgutil.Identifier) string {
 	var digest string
 	switch v := id.(type) {
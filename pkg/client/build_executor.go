@@ -0,0 +1,33 @@
+package client
+
+import (
+	"github.com/pkg/errors"
+)
+
+// validateExecutorOptions makes sure a build run by BuildOptions.Executor needs no docker daemon: nothing is added to
+// the builder, which would have to be saved to the daemon, and the image is published or exported to OCI layout.
+// Executors reject the options they don't support themselves, such as volumes.
+func validateExecutorOptions(opts BuildOptions) error {
+	switch {
+	case !opts.Publish && !opts.Layout():
+		return errors.New("builds run by an executor must publish the image or export it to OCI layout")
+	case opts.Interactive:
+		return errors.New("interactive mode is not supported by executors")
+	case opts.DockerHost != "":
+		return errors.New("docker host cannot be used with an executor")
+	case len(opts.Buildpacks) > 0 || len(opts.PreBuildpacks) > 0 || len(opts.PostBuildpacks) > 0 || len(opts.ProjectDescriptor.Build.Buildpacks) > 0:
+		return errors.New("buildpacks cannot be added to the builder with an executor")
+	case len(opts.Extensions) > 0:
+		return errors.New("extensions cannot be added to the builder with an executor")
+	case len(opts.Env) > 0 || len(opts.ProjectDescriptor.Build.Env) > 0:
+		return errors.New("environment variables cannot be added to the builder with an executor")
+	case len(opts.Secrets) > 0:
+		return errors.New("secrets are not supported by executors")
+	case len(opts.Bindings) > 0 || len(opts.ProjectDescriptor.Build.Bindings) > 0:
+		return errors.New("bindings are not supported by executors")
+	case opts.Signer != nil:
+		// images are signed by the digest in the export report, which executors do not copy out
+		return errors.New("signing is not supported by executors")
+	}
+	return nil
+}
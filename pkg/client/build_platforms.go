@@ -0,0 +1,122 @@
+package client
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	"github.com/buildpacks/imgutil"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/cache"
+)
+
+// splitPlatforms returns the platforms in a comma separated list.
+func splitPlatforms(platforms string) []string {
+	var result []string
+	for _, platform := range strings.Split(platforms, ",") {
+		if platform = strings.TrimSpace(platform); platform != "" {
+			result = append(result, platform)
+		}
+	}
+	return result
+}
+
+// buildPlatforms builds the app once for each platform, publishing each image with the platform appended to its tag,
+// and then publishes an image index referencing them under the name and additional tags of the image.
+func (c *Client) buildPlatforms(ctx context.Context, opts BuildOptions, platforms []string) error {
+	if !opts.Publish {
+		return errors.New("building for multiple platforms requires publishing the image")
+	}
+	if opts.Interactive {
+		return errors.New("building for multiple platforms is not supported in interactive mode")
+	}
+	if opts.PreviousImage != "" {
+		return errors.New("previous image cannot be used when building for multiple platforms")
+	}
+	// the image index published under the tags of the image is neither signed nor given the provenance of its images
+	if opts.Signer != nil {
+		return errors.New("signing is not supported when building for multiple platforms")
+	}
+	if opts.AttachProvenance {
+		return errors.New("provenance cannot be attached when building for multiple platforms")
+	}
+
+	imageRef, err := c.parseTagReference(opts.Image)
+	if err != nil {
+		return errors.Wrapf(err, "invalid image name '%s'", opts.Image)
+	}
+
+	var platformImages []string
+	for _, platform := range platforms {
+		platformOpts := opts
+		platformOpts.Platform = platform
+		platformOpts.Image = platformTag(imageRef, platform)
+		platformOpts.AdditionalTags = nil
+		if opts.CacheImage != "" {
+			platformOpts.CacheImage = opts.CacheImage + "-" + platformSuffix(platform)
+		}
+		if (opts.Cache.Build.Format == cache.CacheImage || opts.Cache.Build.Format == cache.CacheRegistry) && opts.Cache.Build.Source != "" {
+			platformOpts.Cache.Build.Source = opts.Cache.Build.Source + "-" + platformSuffix(platform)
+		}
+		platformOpts.SBOMDestinationDir = platformDir(opts.SBOMDestinationDir, platform)
+		platformOpts.ReportDestinationDir = platformDir(opts.ReportDestinationDir, platform)
+		platformOpts.ProvenanceDestinationDir = platformDir(opts.ProvenanceDestinationDir, platform)
+
+		c.logger.Infof("Building %s for platform %s", style.Symbol(platformOpts.Image), style.Symbol(platform))
+		if err := c.Build(ctx, platformOpts); err != nil {
+			return errors.Wrapf(err, "building for platform %s", style.Symbol(platform))
+		}
+		platformImages = append(platformImages, platformOpts.Image)
+	}
+
+	for _, indexName := range append([]string{imageRef.Name()}, opts.AdditionalTags...) {
+		if err := c.publishPlatformIndex(ctx, indexName, platformImages); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Client) publishPlatformIndex(ctx context.Context, indexName string, platformImages []string) error {
+	index, err := c.indexFactory.CreateIndex(indexName, imgutil.WithMediaType(types.OCIImageIndex))
+	if err != nil {
+		return errors.Wrapf(err, "creating image index %s", style.Symbol(indexName))
+	}
+
+	for _, platformImage := range platformImages {
+		if err := c.addManifestToIndex(ctx, platformImage, index); err != nil {
+			return errors.Wrapf(err, "adding %s to image index %s", style.Symbol(platformImage), style.Symbol(indexName))
+		}
+	}
+
+	// push to a registry without saving a local copy
+	if err := index.Push(imgutil.WithMediaType(types.OCIImageIndex), imgutil.WithPurge(true)); err != nil {
+		return errors.Wrapf(err, "pushing image index %s", style.Symbol(indexName))
+	}
+	c.logger.Infof("Successfully pushed image index %s", style.Symbol(indexName))
+	return nil
+}
+
+// platformSuffix returns the platform in a form that can be part of a tag, such as 'linux-arm64-v8'.
+func platformSuffix(platform string) string {
+	return strings.ReplaceAll(platform, "/", "-")
+}
+
+func platformTag(ref name.Reference, platform string) string {
+	tag := "latest"
+	if t, ok := ref.(name.Tag); ok {
+		tag = t.TagStr()
+	}
+	return ref.Context().Tag(tag + "-" + platformSuffix(platform)).Name()
+}
+
+func platformDir(dir, platform string) string {
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, platformSuffix(platform))
+}
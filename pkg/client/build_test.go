@@ -3,6 +3,9 @@ package client
 import (
 	"bytes"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
@@ -22,24 +25,33 @@ import (
 	"github.com/buildpacks/imgutil/remote"
 	"github.com/buildpacks/lifecycle/api"
 	"github.com/buildpacks/lifecycle/platform/files"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/system"
 	dockerclient "github.com/docker/docker/client"
+	"github.com/golang/mock/gomock"
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/heroku/color"
 	"github.com/onsi/gomega/ghttp"
 	"github.com/sclevine/spec"
 	"github.com/sclevine/spec/report"
 
+	buildfakes "github.com/buildpacks/pack/internal/build/fakes"
 	"github.com/buildpacks/pack/internal/builder"
 	cfg "github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/container"
 	ifakes "github.com/buildpacks/pack/internal/fakes"
 	rg "github.com/buildpacks/pack/internal/registry"
 	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/pkg/blob"
 	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/cache"
 	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/events"
 	"github.com/buildpacks/pack/pkg/image"
 	"github.com/buildpacks/pack/pkg/logging"
 	projectTypes "github.com/buildpacks/pack/pkg/project/types"
+	"github.com/buildpacks/pack/pkg/signing"
+	"github.com/buildpacks/pack/pkg/testmocks"
 	h "github.com/buildpacks/pack/testhelpers"
 )
 
@@ -280,6 +292,56 @@ func testBuild(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("Events option", func() {
+			var (
+				emitter    *buildfakes.FakeEmitter
+				builtImage *fakes.Image
+			)
+
+			it.Before(func() {
+				emitter = &buildfakes.FakeEmitter{}
+				builtImage = fakes.NewImage("index.docker.io/some/app:latest", "", local.IDIdentifier{
+					ImageID: "363c754893f0efe22480b4359a5956cf3bd3ce22742fc576973c61348308c2e4",
+				})
+				fakeImageFetcher.LocalImages[builtImage.Name()] = builtImage
+			})
+
+			it.After(func() {
+				logger.WantQuiet(false)
+				h.AssertNilE(t, builtImage.Cleanup())
+			})
+
+			it("passes on the events of the lifecycle and emits the digest of the built image", func() {
+				fakeLifecycle.Events = []events.Event{{Type: events.PhaseStart, Phase: "creator"}}
+
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:   "some/app",
+					Builder: defaultBuilderName,
+					AppPath: filepath.Join("testdata", "some-app"),
+					Events:  emitter,
+				}))
+
+				h.AssertEq(t, len(emitter.Events(events.PhaseStart)), 1)
+				imageEvents := emitter.Events(events.Image)
+				h.AssertEq(t, len(imageEvents), 1)
+				h.AssertEq(t, imageEvents[0].Image, "index.docker.io/some/app:latest")
+				h.AssertEq(t, imageEvents[0].Digest, "sha256:363c754893f0efe22480b4359a5956cf3bd3ce22742fc576973c61348308c2e4")
+			})
+
+			it("does not print the app name and sha in quiet mode", func() {
+				logger.WantQuiet(true)
+
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:   "some/app",
+					Builder: defaultBuilderName,
+					AppPath: filepath.Join("testdata", "some-app"),
+					Events:  emitter,
+				}))
+
+				h.AssertEq(t, strings.TrimSpace(outBuf.String()), "")
+			})
+		})
+
 		when("AppDir option", func() {
 			it("defaults to the current working directory", func() {
 				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
@@ -1975,6 +2037,30 @@ api = "0.2"
 			})
 		})
 
+		when("Bindings option", func() {
+			it("gives the bindings to the lifecycle and removes them after the build", func() {
+				bindingPath := t.TempDir()
+				h.AssertNil(t, os.WriteFile(filepath.Join(bindingPath, "username"), []byte("some-user"), 0600))
+
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:    "some/app",
+					Builder:  defaultBuilderName,
+					Bindings: []projectTypes.Binding{{Name: "db", Type: "postgresql", Path: bindingPath}},
+				}))
+				h.AssertNotEq(t, fakeLifecycle.Opts.BindingsDir, "")
+				h.AssertPathDoesNotExists(t, fakeLifecycle.Opts.BindingsDir)
+			})
+
+			it("errors when a binding is invalid", func() {
+				err := subject.Build(context.TODO(), BuildOptions{
+					Image:    "some/app",
+					Builder:  defaultBuilderName,
+					Bindings: []projectTypes.Binding{{Name: "db", Path: t.TempDir()}},
+				})
+				h.AssertError(t, err, "invalid binding 'db': type must be set")
+			})
+		})
+
 		when("Publish option", func() {
 			var remoteRunImage, builderWithoutLifecycleImageOrCreator *fakes.Image
 
@@ -2080,6 +2166,60 @@ api = "0.2"
 							args := fakeImageFetcher.FetchCalls[fakeLifecycleImage.Name()]
 							h.AssertNil(t, args)
 						})
+
+						it("uses the 5 phases when there are secrets", func() {
+							h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+								Image:        "some/app",
+								Builder:      defaultBuilderName,
+								Publish:      true,
+								TrustBuilder: func(string) bool { return true },
+								Secrets:      map[string][]byte{"some-secret": []byte("some-value")},
+							}))
+							h.AssertEq(t, fakeLifecycle.Opts.UseCreator, false)
+							h.AssertEq(t, string(fakeLifecycle.Opts.Secrets["some-secret"]), "some-value")
+						})
+					})
+
+					when("a trusted builder policy is provided", func() {
+						var (
+							pinnedBuilderName = "example.com/pinned/builder:tag"
+							pinnedDigest      = "sha256:363c754893f0efe22480b4359a5956cf3bd3ce22742fc576973c61348308c2e4"
+						)
+
+						it.Before(func() {
+							digest, err := name.NewDigest("example.com/pinned/builder@" + pinnedDigest)
+							h.AssertNil(t, err)
+							pinnedBuilderImage := newFakeBuilderImage(t, tmpDir, pinnedBuilderName, defaultBuilderStackID, defaultRunImageName, builder.DefaultLifecycleVersion,
+								func(name, topLayerSha string, _ imgutil.Identifier) *fakes.Image {
+									return newLinuxImage(name, topLayerSha, remote.DigestIdentifier{Digest: digest})
+								})
+							fakeImageFetcher.LocalImages[pinnedBuilderImage.Name()] = pinnedBuilderImage
+						})
+
+						it("uses the creator when the builder meets the policy", func() {
+							h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+								Image:                "some/app",
+								Builder:              pinnedBuilderName,
+								Publish:              true,
+								TrustBuilder:         func(string) bool { return true },
+								TrustedBuilderPolicy: &TrustedBuilderPolicy{Digest: pinnedDigest},
+							}))
+							h.AssertEq(t, fakeLifecycle.Opts.UseCreator, true)
+							h.AssertEq(t, fakeLifecycle.Opts.TrustBuilder, true)
+						})
+
+						it("treats the builder as untrusted when it does not meet the policy", func() {
+							h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+								Image:                "some/app",
+								Builder:              pinnedBuilderName,
+								Publish:              true,
+								TrustBuilder:         func(string) bool { return true },
+								TrustedBuilderPolicy: &TrustedBuilderPolicy{Digest: "sha256:0000000000000000000000000000000000000000000000000000000000000000"},
+							}))
+							h.AssertEq(t, fakeLifecycle.Opts.UseCreator, false)
+							h.AssertEq(t, fakeLifecycle.Opts.TrustBuilder, false)
+							h.AssertContains(t, outBuf.String(), "does not meet its trusted builder policy and will be treated as untrusted")
+						})
 					})
 
 					when("lifecycle doesn't support creator", func() {
@@ -2265,6 +2405,289 @@ api = "0.2"
 			})
 		})
 
+		when("Executor option", func() {
+			var executor *ifakes.FakeLifecycle
+
+			it.Before(func() {
+				executor = &ifakes.FakeLifecycle{}
+				fakeImageFetcher.RemoteImages[defaultBuilderName] = defaultBuilderImage
+				fakeImageFetcher.RemoteImages["default/run"] = fakeDefaultRunImage
+			})
+
+			it("runs the lifecycle with the executor, using the published builder and lifecycle images", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:    "example.com/some/app",
+					Builder:  defaultBuilderName,
+					Publish:  true,
+					Executor: executor,
+				}))
+
+				h.AssertEq(t, fakeImageFetcher.FetchCalls[defaultBuilderName].Daemon, false)
+				h.AssertEq(t, executor.Opts.Image.Name(), "example.com/some/app:latest")
+				h.AssertEq(t, executor.Opts.Builder.Name(), defaultBuilderName)
+				lifecycleImageName := fmt.Sprintf("%s:%s", cfg.DefaultLifecycleImageRepo, builder.DefaultLifecycleVersion)
+				h.AssertEq(t, executor.Opts.LifecycleImage, lifecycleImageName)
+				_, fetched := fakeImageFetcher.FetchCalls[lifecycleImageName]
+				h.AssertEq(t, fetched, false)
+				h.AssertNil(t, fakeLifecycle.Opts.Image)
+			})
+
+			it("reads the builder from the layout repository when exporting to OCI layout", func() {
+				h.SkipIf(t, runtime.GOOS == "windows", "skip on windows")
+				inputImageReference := ParseInputImageReference(fmt.Sprintf("oci:%s", filepath.Join(tmpDir, "my-app")))
+
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:   inputImageReference.Name(),
+					Builder: defaultBuilderName,
+					LayoutConfig: &LayoutConfig{
+						InputImage:    inputImageReference,
+						LayoutRepoDir: filepath.Join(tmpDir, "local-repo"),
+					},
+					Executor: executor,
+				}))
+
+				args := fakeImageFetcher.FetchCalls[defaultBuilderName]
+				h.AssertEq(t, args.Daemon, false)
+				h.AssertContains(t, args.LayoutOption.Path, filepath.Join(tmpDir, "local-repo"))
+				h.AssertEq(t, executor.Opts.Layout, true)
+				h.AssertNil(t, fakeLifecycle.Opts.Image)
+			})
+
+			it("errors when the image is not published", func() {
+				err := subject.Build(context.TODO(), BuildOptions{
+					Image:    "example.com/some/app",
+					Builder:  defaultBuilderName,
+					Executor: executor,
+				})
+				h.AssertError(t, err, "builds run by an executor must publish the image or export it to OCI layout")
+			})
+
+			it("errors when buildpacks are added to the builder", func() {
+				err := subject.Build(context.TODO(), BuildOptions{
+					Image:      "example.com/some/app",
+					Builder:    defaultBuilderName,
+					Publish:    true,
+					Buildpacks: []string{"example.com/some/package"},
+					Executor:   executor,
+				})
+				h.AssertError(t, err, "buildpacks cannot be added to the builder with an executor")
+			})
+
+			it("errors when there are secrets", func() {
+				err := subject.Build(context.TODO(), BuildOptions{
+					Image:    "example.com/some/app",
+					Builder:  defaultBuilderName,
+					Publish:  true,
+					Secrets:  map[string][]byte{"some-secret": []byte("some-value")},
+					Executor: executor,
+				})
+				h.AssertError(t, err, "secrets are not supported by executors")
+			})
+
+			it("errors when there are bindings", func() {
+				err := subject.Build(context.TODO(), BuildOptions{
+					Image:    "example.com/some/app",
+					Builder:  defaultBuilderName,
+					Publish:  true,
+					Bindings: []projectTypes.Binding{{Name: "db", Type: "postgresql", Path: "some-path"}},
+					Executor: executor,
+				})
+				h.AssertError(t, err, "bindings are not supported by executors")
+			})
+
+			it("errors when the image is signed", func() {
+				key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+				h.AssertNil(t, err)
+
+				err = subject.Build(context.TODO(), BuildOptions{
+					Image:    "example.com/some/app",
+					Builder:  defaultBuilderName,
+					Publish:  true,
+					Signer:   signing.NewSigner(key),
+					Executor: executor,
+				})
+				h.AssertError(t, err, "signing is not supported by executors")
+			})
+
+			it("errors when environment variables are added to the builder", func() {
+				err := subject.Build(context.TODO(), BuildOptions{
+					Image:    "example.com/some/app",
+					Builder:  defaultBuilderName,
+					Publish:  true,
+					Env:      map[string]string{"some-key": "some-value"},
+					Executor: executor,
+				})
+				h.AssertError(t, err, "environment variables cannot be added to the builder with an executor")
+			})
+
+			it("leaves volumes to the executor", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:           "example.com/some/app",
+					Builder:         defaultBuilderName,
+					Publish:         true,
+					ContainerConfig: ContainerConfig{Volumes: []string{tmpDir + ":/some/target"}},
+					Executor:        executor,
+				}))
+				h.AssertEq(t, executor.Opts.Volumes, []string{tmpDir + ":/some/target:ro"})
+			})
+		})
+
+		when("the daemon is a Podman service", func() {
+			var mockDocker *testmocks.MockCommonAPIClient
+
+			it.Before(func() {
+				mockDocker = testmocks.NewMockCommonAPIClient(gomock.NewController(t))
+				mockDocker.EXPECT().ServerVersion(gomock.Any()).Return(types.Version{
+					Components: []types.ComponentVersion{{Name: "Podman Engine", Version: "5.0.0"}},
+				}, nil).AnyTimes()
+				mockDocker.EXPECT().ImageRemove(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+				subject.docker = mockDocker
+				h.AssertNil(t, os.Setenv("DOCKER_HOST", "unix:///run/podman/podman.sock"))
+			})
+
+			it.After(func() {
+				h.AssertNil(t, os.Unsetenv("DOCKER_HOST"))
+			})
+
+			it("mounts the podman socket in the phases with daemon access and maps the user running podman", func() {
+				mockDocker.EXPECT().Info(gomock.Any()).Return(system.Info{SecurityOptions: []string{"name=seccomp,profile=default", "name=rootless"}}, nil)
+
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:   "some/app",
+					Builder: defaultBuilderName,
+				}))
+
+				h.AssertEq(t, fakeLifecycle.Opts.DockerHost, "unix:///run/podman/podman.sock")
+				h.AssertEq(t, fakeLifecycle.Opts.RootlessPodman, true)
+			})
+
+			it("keeps the docker host requested", func() {
+				mockDocker.EXPECT().Info(gomock.Any()).Return(system.Info{}, nil)
+
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:      "some/app",
+					Builder:    defaultBuilderName,
+					DockerHost: "inherit",
+				}))
+
+				h.AssertEq(t, fakeLifecycle.Opts.DockerHost, "inherit")
+				h.AssertEq(t, fakeLifecycle.Opts.RootlessPodman, false)
+			})
+		})
+
+		when("multiple platforms", func() {
+			var (
+				mockController *gomock.Controller
+				indexes        map[string]*h.MockImageIndex
+			)
+
+			it.Before(func() {
+				mockController = gomock.NewController(t)
+				mockIndexFactory := testmocks.NewMockIndexFactory(mockController)
+				subject.indexFactory = mockIndexFactory
+
+				h.AssertNil(t, os.Setenv("XDG_RUNTIME_DIR", tmpDir))
+				indexes = map[string]*h.MockImageIndex{}
+				for _, indexName := range []string{"example.com/some/app:tag", "example.com/some/app:other-tag"} {
+					index := h.NewMockImageIndex(t, indexName, 0, 0)
+					indexes[indexName] = index
+					mockIndexFactory.EXPECT().CreateIndex(indexName, gomock.Any()).Return(index, nil).AnyTimes()
+				}
+
+				fakeImageFetcher.RemoteImages["default/run"] = fakeDefaultRunImage
+				for _, platformImage := range []string{"example.com/some/app:tag-linux-amd64", "example.com/some/app:tag-linux-arm64"} {
+					fakeImageFetcher.RemoteImages[platformImage] = h.NewFakeWithRandomUnderlyingV1Image(t, platformImage, nil)
+				}
+			})
+
+			it.After(func() {
+				mockController.Finish()
+			})
+
+			it("builds each platform and publishes an image index referencing them", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:          "example.com/some/app:tag",
+					AdditionalTags: []string{"example.com/some/app:other-tag"},
+					Builder:        defaultBuilderName,
+					Platform:       "linux/amd64, linux/arm64",
+					Publish:        true,
+				}))
+
+				h.AssertContains(t, outBuf.String(), "Building 'example.com/some/app:tag-linux-amd64' for platform 'linux/amd64'")
+				h.AssertContains(t, outBuf.String(), "Building 'example.com/some/app:tag-linux-arm64' for platform 'linux/arm64'")
+				h.AssertEq(t, fakeLifecycle.Opts.Image.Name(), "example.com/some/app:tag-linux-arm64")
+				h.AssertEq(t, len(fakeLifecycle.Opts.AdditionalTags), 0)
+
+				for indexName, index := range indexes {
+					h.AssertTrue(t, index.PushCalled)
+					h.AssertTrue(t, index.PurgeOption)
+					manifest, err := index.IndexManifest()
+					h.AssertNil(t, err)
+					h.AssertEq(t, len(manifest.Manifests), 2)
+					h.AssertContains(t, outBuf.String(), fmt.Sprintf("Successfully pushed image index '%s'", indexName))
+				}
+			})
+
+			for _, format := range []cache.Format{cache.CacheImage, cache.CacheRegistry} {
+				format := format
+				it(fmt.Sprintf("appends the platform to the name of a build cache in %s format", format), func() {
+					var cacheOpts cache.CacheOpts
+					cacheOpts.Build = cache.CacheInfo{Format: format, Source: "example.com/some/cache"}
+
+					h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+						Image:    "example.com/some/app:tag",
+						Builder:  defaultBuilderName,
+						Platform: "linux/amd64,linux/arm64",
+						Publish:  true,
+						Cache:    cacheOpts,
+					}))
+
+					h.AssertEq(t, fakeLifecycle.Opts.Cache.Build.Source, "example.com/some/cache-linux-arm64")
+				})
+			}
+
+			it("requires publishing the image", func() {
+				h.AssertError(t, subject.Build(context.TODO(), BuildOptions{
+					Image:    "example.com/some/app:tag",
+					Builder:  defaultBuilderName,
+					Platform: "linux/amd64,linux/arm64",
+				}), "building for multiple platforms requires publishing the image")
+			})
+
+			it("does not support a previous image", func() {
+				h.AssertError(t, subject.Build(context.TODO(), BuildOptions{
+					Image:         "example.com/some/app:tag",
+					Builder:       defaultBuilderName,
+					Platform:      "linux/amd64,linux/arm64",
+					Publish:       true,
+					PreviousImage: "example.com/some/app:previous",
+				}), "previous image cannot be used when building for multiple platforms")
+			})
+
+			it("does not support signing", func() {
+				key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+				h.AssertNil(t, err)
+
+				h.AssertError(t, subject.Build(context.TODO(), BuildOptions{
+					Image:    "example.com/some/app:tag",
+					Builder:  defaultBuilderName,
+					Platform: "linux/amd64,linux/arm64",
+					Publish:  true,
+					Signer:   signing.NewSigner(key),
+				}), "signing is not supported when building for multiple platforms")
+			})
+
+			it("does not support attaching provenance", func() {
+				h.AssertError(t, subject.Build(context.TODO(), BuildOptions{
+					Image:            "example.com/some/app:tag",
+					Builder:          defaultBuilderName,
+					Platform:         "linux/amd64,linux/arm64",
+					Publish:          true,
+					AttachProvenance: true,
+				}), "provenance cannot be attached when building for multiple platforms")
+			})
+		})
+
 		when("PullPolicy", func() {
 			when("never", func() {
 				it("uses the local builder and run images without updating", func() {
@@ -2971,6 +3394,40 @@ api = "0.2"
 			})
 		})
 
+		when("offline", func() {
+			it.Before(func() {
+				subject.offline = true
+			})
+
+			it("builds to the daemon", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Builder: defaultBuilderName,
+					Image:   "example.com/some/repo:tag",
+				}))
+				h.AssertEq(t, fakeLifecycle.Opts.Publish, false)
+			})
+
+			it("fails to publish", func() {
+				err := subject.Build(context.TODO(), BuildOptions{
+					Builder: defaultBuilderName,
+					Image:   "example.com/some/repo:tag",
+					Publish: true,
+				})
+				h.AssertError(t, err, "cannot publish an image in offline mode")
+			})
+
+			it("fails to use a registry cache", func() {
+				err := subject.Build(context.TODO(), BuildOptions{
+					Builder: defaultBuilderName,
+					Image:   "example.com/some/repo:tag",
+					Cache: cache.CacheOpts{
+						Build: cache.CacheInfo{Format: cache.CacheRegistry, Source: "example.com/some/cache"},
+					},
+				})
+				h.AssertError(t, err, "cache format 'registry' cannot be used in offline mode")
+			})
+		})
+
 		when("previous-image option", func() {
 			it("previous-image is passed to lifecycle", func() {
 				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
@@ -3016,6 +3473,262 @@ api = "0.2"
 			})
 		})
 
+		when("the lifecycle reports phase timings", func() {
+			it.Before(func() {
+				fakeLifecycle.Events = []events.Event{
+					{Type: events.PhaseStep, Phase: "creator", Step: events.StepCreate, DurationMs: 100},
+					{Type: events.PhaseStep, Phase: "creator", Step: events.StepCopyIn, DurationMs: 250},
+					{Type: events.PhaseStep, Phase: "creator", Step: events.StepRun, DurationMs: 3000},
+					{Type: events.PhaseEnd, Phase: "creator", DurationMs: 3500},
+				}
+			})
+
+			it("logs a summary when requested", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Builder: defaultBuilderName,
+					Image:   "example.com/some/repo:tag",
+					Timings: true,
+				}))
+
+				h.AssertContains(t, outBuf.String(), "===> TIMINGS")
+				h.AssertContainsMatch(t, outBuf.String(), `creator\s+3.5s\s+100ms\s+250ms\s+3s\s+0s`)
+			})
+
+			it("doesn't log a summary by default", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Builder: defaultBuilderName,
+					Image:   "example.com/some/repo:tag",
+				}))
+
+				h.AssertNotContains(t, outBuf.String(), "TIMINGS")
+			})
+
+			it("writes the timings next to the report", func() {
+				reportDir := filepath.Join(tmpDir, "report")
+
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Builder:              defaultBuilderName,
+					Image:                "example.com/some/repo:tag",
+					ReportDestinationDir: reportDir,
+					Timings:              true,
+				}))
+
+				contents, err := os.ReadFile(filepath.Join(reportDir, TimingsFileName))
+				h.AssertNil(t, err)
+				h.AssertContains(t, string(contents), `phase = "creator"`)
+				h.AssertContains(t, string(contents), "total-ms = 3500")
+				h.AssertContains(t, string(contents), "copy-in-ms = 250")
+			})
+
+			it("warns when the timings cannot be written", func() {
+				reportDir := filepath.Join(tmpDir, "report")
+				h.AssertNil(t, os.WriteFile(reportDir, []byte("not a directory"), 0600))
+
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Builder:              defaultBuilderName,
+					Image:                "example.com/some/repo:tag",
+					ReportDestinationDir: reportDir,
+					Timings:              true,
+				}))
+
+				h.AssertContains(t, outBuf.String(), "Warning: Failed to write phase timings")
+			})
+		})
+
+		when("explain detect option", func() {
+			it.Before(func() {
+				for _, line := range []string{
+					"======== Results ========",
+					"fail: buildpack.1.id@buildpack.1.version",
+					"======== Results ========",
+					"pass: buildpack.2.id@buildpack.2.version",
+					"Resolving plan... (try #1)",
+					"buildpack.2.id buildpack.2.version",
+				} {
+					fakeLifecycle.Events = append(fakeLifecycle.Events, events.Event{Type: events.Output, Phase: "detector", Stream: "stdout", Line: line})
+				}
+			})
+
+			it("logs why each group passed or failed detection", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Builder:       defaultBuilderName,
+					Image:         "example.com/some/repo:tag",
+					ExplainDetect: true,
+				}))
+
+				h.AssertTrue(t, fakeLifecycle.Opts.ExplainDetect)
+				h.AssertContains(t, outBuf.String(), "===> DETECTION")
+				h.AssertContainsMatch(t, outBuf.String(), `Group 1: fail\n\s+fail\s+buildpack.1.id@buildpack.1.version`)
+				h.AssertContainsMatch(t, outBuf.String(), `Group 2: selected\n\s+pass\s+buildpack.2.id@buildpack.2.version`)
+				h.AssertContains(t, outBuf.String(), "Selected: buildpack.2.id@buildpack.2.version")
+			})
+
+			it("explains detection when it fails", func() {
+				fakeLifecycle.ExecuteErr = fmt.Errorf("running detector: %w", container.StatusError{StatusCode: 20})
+
+				err := subject.Build(context.TODO(), BuildOptions{
+					Builder:       defaultBuilderName,
+					Image:         "example.com/some/repo:tag",
+					ExplainDetect: true,
+				})
+				h.AssertNotNil(t, err)
+				h.AssertContains(t, outBuf.String(), "===> DETECTION")
+			})
+
+			it("emits the explanation as an event", func() {
+				emitter := &buildfakes.FakeEmitter{}
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Builder:       defaultBuilderName,
+					Image:         "example.com/some/repo:tag",
+					ExplainDetect: true,
+					Events:        emitter,
+				}))
+
+				var detection *events.Detection
+				for _, event := range emitter.Events() {
+					if event.Type == events.Detect {
+						detection = event.Detect
+					}
+				}
+				h.AssertNotNil(t, detection)
+				h.AssertEq(t, len(detection.Order), 2)
+				h.AssertEq(t, detection.Order[1].Status, events.DetectSelected)
+				h.AssertEq(t, detection.Selected, []events.Module{{ID: "buildpack.2.id", Version: "buildpack.2.version"}})
+				h.AssertNotContains(t, outBuf.String(), "===> DETECTION")
+			})
+		})
+
+		when("provenance destination dir option", func() {
+			var builtImage *fakes.Image
+
+			it.Before(func() {
+				builtImage = fakes.NewImage("index.docker.io/some/app:latest", "", local.IDIdentifier{
+					ImageID: "363c754893f0efe22480b4359a5956cf3bd3ce22742fc576973c61348308c2e4",
+				})
+				h.AssertNil(t, builtImage.SetLabel("io.buildpacks.build.metadata", `{"buildpacks":[{"id":"buildpack.1.id","version":"buildpack.1.version"}]}`))
+				fakeImageFetcher.LocalImages[builtImage.Name()] = builtImage
+			})
+
+			it.After(func() {
+				h.AssertNilE(t, builtImage.Cleanup())
+			})
+
+			it("writes a provenance attestation of the build", func() {
+				provenanceDir := filepath.Join(tmpDir, "provenance")
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:                    "some/app",
+					Builder:                  defaultBuilderName,
+					AppPath:                  filepath.Join("testdata", "some-app"),
+					Env:                      map[string]string{"SOME_SECRET": "some-value"},
+					ProvenanceDestinationDir: provenanceDir,
+				}))
+
+				contents, err := os.ReadFile(filepath.Join(provenanceDir, "provenance.json"))
+				h.AssertNil(t, err)
+				h.AssertNotContains(t, string(contents), "some-value")
+
+				var statement struct {
+					Subject []struct {
+						Name   string            `json:"name"`
+						Digest map[string]string `json:"digest"`
+					} `json:"subject"`
+					PredicateType string `json:"predicateType"`
+					Predicate     struct {
+						BuildDefinition struct {
+							ExternalParameters struct {
+								Builder string   `json:"builder"`
+								Env     []string `json:"env"`
+							} `json:"externalParameters"`
+							InternalParameters struct {
+								LifecycleVersion string `json:"lifecycleVersion"`
+							} `json:"internalParameters"`
+							ResolvedDependencies []struct {
+								Name   string            `json:"name"`
+								URI    string            `json:"uri"`
+								Digest map[string]string `json:"digest"`
+							} `json:"resolvedDependencies"`
+						} `json:"buildDefinition"`
+					} `json:"predicate"`
+				}
+				h.AssertNil(t, json.Unmarshal(contents, &statement))
+
+				h.AssertEq(t, statement.PredicateType, "https://slsa.dev/provenance/v1")
+				h.AssertEq(t, statement.Subject[0].Name, "index.docker.io/some/app")
+				h.AssertEq(t, statement.Subject[0].Digest, map[string]string{
+					"dockerImageId": "363c754893f0efe22480b4359a5956cf3bd3ce22742fc576973c61348308c2e4",
+				})
+
+				buildDefinition := statement.Predicate.BuildDefinition
+				h.AssertEq(t, buildDefinition.ExternalParameters.Builder, defaultBuilderName)
+				h.AssertEq(t, buildDefinition.ExternalParameters.Env, []string{"SOME_SECRET"})
+				h.AssertNotEq(t, buildDefinition.InternalParameters.LifecycleVersion, "")
+
+				dependencies := map[string]string{}
+				for _, dep := range buildDefinition.ResolvedDependencies {
+					dependencies[dep.Name] = dep.URI
+				}
+				h.AssertEq(t, dependencies["builder"], defaultBuilderName)
+				h.AssertEq(t, dependencies["run-image"], "default/run")
+				h.AssertEq(t, dependencies["buildpack:buildpack.1.id"], "urn:cnb:buildpack:buildpack.1.id@buildpack.1.version")
+				h.AssertEq(t, len(buildDefinition.ResolvedDependencies[0].Digest["sha256"]), 64)
+			})
+
+			it("fails to attach provenance to an image that is not published", func() {
+				err := subject.Build(context.TODO(), BuildOptions{
+					Image:            "some/app",
+					Builder:          defaultBuilderName,
+					AttachProvenance: true,
+				})
+				h.AssertError(t, err, "provenance can only be attached to published images")
+			})
+		})
+
+		when("signing options", func() {
+			it("fails to sign an image that is not published", func() {
+				key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+				h.AssertNil(t, err)
+
+				err = subject.Build(context.TODO(), BuildOptions{
+					Image:   "some/app",
+					Builder: defaultBuilderName,
+					Signer:  signing.NewSigner(key),
+				})
+				h.AssertError(t, err, "only published images can be signed")
+			})
+
+			it("signs the digest in the export report", func() {
+				remoteRunImage := fakes.NewImage("default/run", "", nil)
+				h.AssertNil(t, remoteRunImage.SetLabel("io.buildpacks.stack.id", defaultBuilderStackID))
+				h.AssertNil(t, remoteRunImage.SetLabel("io.buildpacks.stack.mixins", `["mixinA", "mixinX", "run:mixinZ"]`))
+				fakeImageFetcher.RemoteImages[remoteRunImage.Name()] = remoteRunImage
+				key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+				h.AssertNil(t, err)
+
+				err = subject.Build(context.TODO(), BuildOptions{
+					Image:   "some/app",
+					Builder: defaultBuilderName,
+					Publish: true,
+					Signer:  signing.NewSigner(key),
+				})
+				// the fake lifecycle does not write a report
+				h.AssertError(t, err, "signing image: reading export report")
+				h.AssertNotEq(t, fakeLifecycle.Opts.ReportDestinationDir, "")
+			})
+
+			it("fails to verify signatures when exporting to OCI layout", func() {
+				key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+				h.AssertNil(t, err)
+
+				err = subject.Build(context.TODO(), BuildOptions{
+					Image:        "some/app",
+					Builder:      defaultBuilderName,
+					Verifier:     signing.NewVerifier(key.Public()),
+					LayoutConfig: &LayoutConfig{InputImage: ParseInputImageReference(fmt.Sprintf("oci:%s", filepath.Join(tmpDir, "my-app")))},
+				})
+				h.AssertError(t, err, "signature verification is not supported when exporting to OCI layout")
+			})
+		})
+
 		when("there are extensions", func() {
 			withExtensionsLabel = true
 
@@ -3175,6 +3888,75 @@ api = "0.2"
 			})
 		})
 	})
+
+	when("#TestBuildpack", func() {
+		var opts TestBuildpackOptions
+
+		it.Before(func() {
+			opts = TestBuildpackOptions{
+				BuildpackPath: filepath.Join("testdata", "buildpack"),
+				FixturePath:   filepath.Join("testdata", "some-app"),
+				Builder:       defaultBuilderName,
+			}
+		})
+
+		it("builds the fixture with the buildpack alone", func() {
+			_, err := subject.TestBuildpack(context.TODO(), opts)
+			h.AssertNil(t, err)
+
+			bldr, ok := fakeLifecycle.Opts.Builder.(*builder.Builder)
+			h.AssertTrue(t, ok)
+			h.AssertEq(t, bldr.Order(), dist.Order{
+				{Group: []dist.ModuleRef{{ModuleInfo: dist.ModuleInfo{ID: "bp.one", Version: "1.2.3", Homepage: "http://one.buildpack"}}}},
+			})
+			h.AssertContains(t, fakeLifecycle.Opts.AppPath, filepath.Join("testdata", "some-app"))
+		})
+
+		it("fails when detection fails", func() {
+			fakeLifecycle.ExecuteErr = fmt.Errorf("running detector: %w", container.StatusError{StatusCode: 20})
+
+			result, err := subject.TestBuildpack(context.TODO(), opts)
+			h.AssertNil(t, err)
+			h.AssertEq(t, result.Fixture, "some-app")
+			h.AssertEq(t, result.Failures, []string{"detection failed, expected it to pass"})
+			h.AssertFalse(t, result.Passed())
+		})
+
+		it("passes when detection fails as expected", func() {
+			fakeLifecycle.ExecuteErr = container.StatusError{StatusCode: 20}
+			pass := false
+			opts.Spec.Detect.Pass = &pass
+
+			result, err := subject.TestBuildpack(context.TODO(), opts)
+			h.AssertNil(t, err)
+			h.AssertTrue(t, result.Passed())
+		})
+
+		it("fails when detection passes while expected to fail", func() {
+			pass := false
+			opts.Spec.Detect.Pass = &pass
+
+			result, err := subject.TestBuildpack(context.TODO(), opts)
+			h.AssertNil(t, err)
+			h.AssertEq(t, result.Failures, []string{"detection passed, expected it to fail"})
+		})
+
+		it("reports other build errors", func() {
+			fakeLifecycle.ExecuteErr = container.StatusError{StatusCode: 51}
+
+			result, err := subject.TestBuildpack(context.TODO(), opts)
+			h.AssertNil(t, err)
+			h.AssertError(t, result.Error, "failed with status code: 51")
+			h.AssertEq(t, len(result.Failures), 0)
+		})
+
+		it("errors without a fixture", func() {
+			opts.FixturePath = ""
+
+			_, err := subject.TestBuildpack(context.TODO(), opts)
+			h.AssertError(t, err, "buildpack path and fixture path must be set")
+		})
+	})
 }
 
 func makeFakePackage(t *testing.T, tmpDir string, stackID string) *fakes.Image {
@@ -0,0 +1,350 @@
+package client
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/volume"
+	dockerClient "github.com/docker/docker/client"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/cache"
+)
+
+const volumeCachePrefix = "pack-cache-"
+
+// CacheInfo describes a cache created by pack.
+type CacheInfo struct {
+	// Name of the volume, directory or image holding the cache.
+	Name string
+
+	// Format of the cache, one of volume, bind, image or registry.
+	Format string
+
+	// Kind of the cache, one of build, launch or kaniko.
+	Kind string
+
+	// Image is the app image the cache was used for, if known.
+	Image string
+
+	// Size of the cache in bytes, or -1 if it cannot be determined.
+	Size int64
+
+	// LastUsed is the last time a pack build used the cache, or the creation time
+	// of the cache if no build has been recorded.
+	LastUsed time.Time
+}
+
+// PruneCachesOptions is a configuration struct that controls which caches are removed by PruneCaches.
+type PruneCachesOptions struct {
+	// Remove caches not used within this duration. Zero disables age based pruning.
+	OlderThan time.Duration
+
+	// Remove least recently used caches until the total size of the remaining caches
+	// is at most MaxSize bytes. Zero disables size based pruning.
+	MaxSize int64
+
+	// Report the caches that would be removed without removing them.
+	DryRun bool
+}
+
+// volumeClient is implemented by docker clients that can inspect volumes, such as the client of the docker
+// daemon. It is kept out of DockerClient so that clients given to WithDockerClient need not implement it.
+type volumeClient interface {
+	VolumeInspect(ctx context.Context, volumeID string) (volume.Volume, error)
+	DiskUsage(ctx context.Context, options types.DiskUsageOptions) (types.DiskUsage, error)
+}
+
+func (c *Client) volumeClient() (volumeClient, error) {
+	vc, ok := c.docker.(volumeClient)
+	if !ok {
+		return nil, errors.New("docker client does not support inspecting volumes")
+	}
+	return vc, nil
+}
+
+// ListCaches returns the volume, bind and image caches created by pack, most recently used first.
+func (c *Client) ListCaches(ctx context.Context) ([]CacheInfo, error) {
+	idx, err := readCacheIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	vc, err := c.volumeClient()
+	if err != nil {
+		return nil, err
+	}
+
+	du, err := vc.DiskUsage(ctx, types.DiskUsageOptions{Types: []types.DiskUsageObject{types.VolumeObject}})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing volumes")
+	}
+
+	volumes := map[string]*volume.Volume{}
+	for _, v := range du.Volumes {
+		volumes[v.Name] = v
+	}
+
+	var caches []CacheInfo
+	seen := map[string]bool{}
+	for _, record := range idx.Records {
+		info, exists, err := c.cacheInfoFromRecord(ctx, record, volumes)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			caches = append(caches, info)
+			seen[record.Name] = true
+		}
+	}
+
+	for _, v := range du.Volumes {
+		if seen[v.Name] || !strings.HasPrefix(v.Name, volumeCachePrefix) {
+			continue
+		}
+		caches = append(caches, CacheInfo{
+			Name:     v.Name,
+			Format:   cache.CacheVolume.String(),
+			Kind:     volumeCacheKind(v.Name),
+			Size:     volumeSize(v),
+			LastUsed: volumeCreatedAt(v),
+		})
+	}
+
+	sort.SliceStable(caches, func(i, j int) bool {
+		return caches[i].LastUsed.After(caches[j].LastUsed)
+	})
+
+	return caches, nil
+}
+
+// InspectCache returns information about the cache with the given name.
+func (c *Client) InspectCache(ctx context.Context, cacheName string) (*CacheInfo, error) {
+	caches, err := c.ListCaches(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, info := range caches {
+		if info.Name == cacheName {
+			return &info, nil
+		}
+	}
+
+	return nil, errors.Errorf("cache %s not found", style.Symbol(cacheName))
+}
+
+// PruneCaches removes caches selected by the given options and returns them.
+// Registry caches are never removed since they do not reside on this machine.
+func (c *Client) PruneCaches(ctx context.Context, opts PruneCachesOptions) ([]CacheInfo, error) {
+	caches, err := c.ListCaches(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []CacheInfo
+	for _, info := range caches {
+		if info.Format != cache.CacheRegistry.String() {
+			candidates = append(candidates, info)
+		}
+	}
+
+	var (
+		pruned    []CacheInfo
+		remaining []CacheInfo
+		totalSize int64
+	)
+	cutoff := time.Now().Add(-opts.OlderThan)
+	for _, info := range candidates {
+		if opts.OlderThan > 0 && info.LastUsed.Before(cutoff) {
+			pruned = append(pruned, info)
+			continue
+		}
+		remaining = append(remaining, info)
+		if info.Size > 0 {
+			totalSize += info.Size
+		}
+	}
+
+	if opts.MaxSize > 0 {
+		// candidates are sorted most recently used first, so evict from the end
+		for i := len(remaining) - 1; i >= 0 && totalSize > opts.MaxSize; i-- {
+			pruned = append(pruned, remaining[i])
+			if remaining[i].Size > 0 {
+				totalSize -= remaining[i].Size
+			}
+		}
+	}
+
+	if opts.DryRun || len(pruned) == 0 {
+		return pruned, nil
+	}
+
+	idx, err := readCacheIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, info := range pruned {
+		if err := c.removeCache(ctx, info); err != nil {
+			return nil, errors.Wrapf(err, "removing cache %s", style.Symbol(info.Name))
+		}
+		c.logger.Debugf("Removed cache %s", style.Symbol(info.Name))
+		idx.Remove(info.Name)
+	}
+
+	if err := idx.Save(); err != nil {
+		return nil, err
+	}
+
+	return pruned, nil
+}
+
+func (c *Client) removeCache(ctx context.Context, info CacheInfo) error {
+	switch info.Format {
+	case cache.CacheVolume.String():
+		if err := c.docker.VolumeRemove(ctx, info.Name, true); err != nil && !dockerClient.IsErrNotFound(err) {
+			return err
+		}
+	case cache.CacheBind.String():
+		return os.RemoveAll(info.Name)
+	case cache.CacheImage.String():
+		if _, err := c.docker.ImageRemove(ctx, info.Name, image.RemoveOptions{Force: true}); err != nil && !dockerClient.IsErrNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Client) cacheInfoFromRecord(ctx context.Context, record cache.Record, volumes map[string]*volume.Volume) (CacheInfo, bool, error) {
+	info := CacheInfo{
+		Name:     record.Name,
+		Format:   record.Format,
+		Kind:     record.Kind,
+		Image:    record.Image,
+		Size:     -1,
+		LastUsed: record.LastUsed,
+	}
+
+	switch record.Format {
+	case cache.CacheVolume.String():
+		v, ok := volumes[record.Name]
+		if !ok {
+			return info, false, nil
+		}
+		info.Size = volumeSize(v)
+	case cache.CacheBind.String():
+		size, err := dirSize(record.Name)
+		if os.IsNotExist(err) {
+			return info, false, nil
+		}
+		if err != nil {
+			return info, false, errors.Wrapf(err, "reading cache dir %s", style.Symbol(record.Name))
+		}
+		info.Size = size
+	case cache.CacheImage.String():
+		inspect, _, err := c.docker.ImageInspectWithRaw(ctx, record.Name)
+		if err == nil {
+			info.Size = inspect.Size
+		} else if !dockerClient.IsErrNotFound(err) {
+			return info, false, errors.Wrapf(err, "inspecting cache image %s", style.Symbol(record.Name))
+		}
+	}
+
+	return info, true, nil
+}
+
+// recordCaches notes the caches used to build imageRef in the cache index so that they can
+// later be found by ListCaches.
+func (c *Client) recordCaches(imageRef name.Reference, opts BuildOptions) error {
+	idx, err := readCacheIndex()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	record := func(cacheName string, format cache.Format, kind string) {
+		idx.Touch(cache.Record{
+			Name:     cacheName,
+			Format:   format.String(),
+			Kind:     kind,
+			Image:    imageRef.Name(),
+			LastUsed: now,
+		})
+	}
+
+	switch {
+	case opts.CacheImage != "":
+		record(opts.CacheImage, cache.CacheImage, "build")
+	case opts.Cache.Build.Format == cache.CacheImage || opts.Cache.Build.Format == cache.CacheRegistry:
+		record(opts.Cache.Build.Source, opts.Cache.Build.Format, "build")
+	case opts.Cache.Build.Format == cache.CacheBind:
+		record(cache.NewBindCache(opts.Cache.Build, c.docker).Name(), cache.CacheBind, "build")
+	default:
+		record(cache.NewVolumeCache(imageRef, opts.Cache.Build, "build", c.docker).Name(), cache.CacheVolume, "build")
+	}
+
+	if !opts.Publish && !opts.Layout() {
+		record(cache.NewVolumeCache(imageRef, opts.Cache.Launch, "launch", c.docker).Name(), cache.CacheVolume, "launch")
+	}
+
+	return idx.Save()
+}
+
+func readCacheIndex() (*cache.Index, error) {
+	home, err := config.PackHome()
+	if err != nil {
+		return nil, errors.Wrap(err, "getting pack home")
+	}
+	return cache.ReadIndex(filepath.Join(home, cache.IndexFileName))
+}
+
+func volumeCacheKind(volumeName string) string {
+	if i := strings.LastIndex(volumeName, "."); i >= 0 {
+		return volumeName[i+1:]
+	}
+	return ""
+}
+
+func volumeSize(v *volume.Volume) int64 {
+	if v.UsageData == nil {
+		return -1
+	}
+	return v.UsageData.Size
+}
+
+func volumeCreatedAt(v *volume.Volume) time.Time {
+	created, err := time.Parse(time.RFC3339, v.CreatedAt)
+	if err != nil {
+		return time.Time{}
+	}
+	return created
+}
+
+func dirSize(path string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type().IsRegular() {
+			fi, err := d.Info()
+			if err != nil {
+				return err
+			}
+			size += fi.Size()
+		}
+		return nil
+	})
+	return size, err
+}
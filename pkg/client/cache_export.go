@@ -0,0 +1,162 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/builder"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/cache"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+// cacheMountPath is where a cache volume is mounted in the helper container used to copy
+// its contents in and out.
+const cacheMountPath = "/cache"
+
+// ExportCacheOptions is a configuration struct used to export a volume cache to a tarball.
+type ExportCacheOptions struct {
+	// Name of the cache volume to export.
+	Name string
+
+	// Path of the tarball to write.
+	Path string
+
+	// Image used for the helper container the volume is mounted into.
+	// Defaults to the lifecycle image matching the default lifecycle version.
+	HelperImage string
+}
+
+// ImportCacheOptions is a configuration struct used to import a tarball created by ExportCache
+// into a volume cache.
+type ImportCacheOptions struct {
+	// Name of the cache volume to import into. The volume is created if it does not exist.
+	Name string
+
+	// Path of the tarball to read.
+	Path string
+
+	// App image the cache belongs to, recorded so that the cache shows up in ListCaches.
+	Image string
+
+	// Kind of the cache, one of build, launch or kaniko. Defaults to build.
+	Kind string
+
+	// Image used for the helper container the volume is mounted into.
+	// Defaults to the lifecycle image matching the default lifecycle version.
+	HelperImage string
+}
+
+// ExportCache writes the contents of a volume cache to a tarball so that it can be carried
+// to another machine and restored with ImportCache.
+func (c *Client) ExportCache(ctx context.Context, opts ExportCacheOptions) error {
+	if opts.Name == "" {
+		return errors.New("cache name must be provided")
+	}
+
+	vc, err := c.volumeClient()
+	if err != nil {
+		return err
+	}
+	if _, err := vc.VolumeInspect(ctx, opts.Name); err != nil {
+		return errors.Wrapf(err, "inspecting cache volume %s", style.Symbol(opts.Name))
+	}
+
+	ctrID, err := c.createCacheHelperContainer(ctx, opts.Name, opts.HelperImage)
+	if err != nil {
+		return err
+	}
+	defer c.docker.ContainerRemove(context.Background(), ctrID, containertypes.RemoveOptions{Force: true})
+
+	rc, _, err := c.docker.CopyFromContainer(ctx, ctrID, cacheMountPath)
+	if err != nil {
+		return errors.Wrapf(err, "reading cache volume %s", style.Symbol(opts.Name))
+	}
+	defer rc.Close()
+
+	if err := os.MkdirAll(filepath.Dir(opts.Path), 0750); err != nil {
+		return errors.Wrap(err, "creating output directory")
+	}
+
+	f, err := os.Create(opts.Path)
+	if err != nil {
+		return errors.Wrapf(err, "creating %s", style.Symbol(opts.Path))
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(f, rc); err != nil {
+		return errors.Wrapf(err, "writing %s", style.Symbol(opts.Path))
+	}
+
+	return nil
+}
+
+// ImportCache restores a tarball created by ExportCache into a volume cache.
+func (c *Client) ImportCache(ctx context.Context, opts ImportCacheOptions) error {
+	if opts.Name == "" {
+		return errors.New("cache name must be provided")
+	}
+
+	f, err := os.Open(opts.Path)
+	if err != nil {
+		return errors.Wrapf(err, "opening %s", style.Symbol(opts.Path))
+	}
+	defer f.Close()
+
+	ctrID, err := c.createCacheHelperContainer(ctx, opts.Name, opts.HelperImage)
+	if err != nil {
+		return err
+	}
+	defer c.docker.ContainerRemove(context.Background(), ctrID, containertypes.RemoveOptions{Force: true})
+
+	if err := c.docker.CopyToContainer(ctx, ctrID, "/", f, types.CopyToContainerOptions{}); err != nil {
+		return errors.Wrapf(err, "writing cache volume %s", style.Symbol(opts.Name))
+	}
+
+	kind := opts.Kind
+	if kind == "" {
+		kind = "build"
+	}
+
+	idx, err := readCacheIndex()
+	if err != nil {
+		return err
+	}
+	idx.Touch(cache.Record{
+		Name:     opts.Name,
+		Format:   cache.CacheVolume.String(),
+		Kind:     kind,
+		Image:    opts.Image,
+		LastUsed: time.Now().UTC(),
+	})
+	return idx.Save()
+}
+
+func (c *Client) createCacheHelperContainer(ctx context.Context, volumeName, helperImage string) (string, error) {
+	if helperImage == "" {
+		helperImage = fmt.Sprintf("%s:%s", config.DefaultLifecycleImageRepo, builder.DefaultLifecycleVersion)
+	}
+
+	if _, err := c.imageFetcher.Fetch(ctx, helperImage, image.FetchOptions{Daemon: true, PullPolicy: image.PullIfNotPresent}); err != nil {
+		return "", errors.Wrapf(err, "fetching helper image %s", style.Symbol(helperImage))
+	}
+
+	ctr, err := c.docker.ContainerCreate(ctx,
+		&containertypes.Config{Image: helperImage},
+		&containertypes.HostConfig{Binds: []string{fmt.Sprintf("%s:%s", volumeName, cacheMountPath)}},
+		nil, nil, "",
+	)
+	if err != nil {
+		return "", errors.Wrap(err, "creating helper container")
+	}
+	return ctr.ID, nil
+}
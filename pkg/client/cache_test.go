@@ -0,0 +1,155 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/cache"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestCaches(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Caches", testCaches, spec.Sequential(), spec.Report(report.Terminal{}))
+}
+
+func testCaches(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject          *Client
+		mockDockerClient *testmocks.MockCommonAPIClient
+		mockController   *gomock.Controller
+		out              bytes.Buffer
+		tmpDir           string
+		bindDir          string
+		now              time.Time
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockDockerClient = testmocks.NewMockCommonAPIClient(mockController)
+
+		var err error
+		tmpDir, err = os.MkdirTemp("", "pack.caches.test.")
+		h.AssertNil(t, err)
+		h.AssertNil(t, os.Setenv("PACK_HOME", tmpDir))
+
+		bindDir = filepath.Join(tmpDir, "bind", "build-cache")
+		h.AssertNil(t, os.MkdirAll(bindDir, 0755))
+		h.AssertNil(t, os.WriteFile(filepath.Join(bindDir, "some-file"), []byte("some-content"), 0600))
+
+		now = time.Now().UTC().Truncate(time.Second)
+		idx, err := cache.ReadIndex(filepath.Join(tmpDir, cache.IndexFileName))
+		h.AssertNil(t, err)
+		idx.Touch(cache.Record{Name: "pack-cache-my-app.build", Format: "volume", Kind: "build", Image: "my-app:latest", LastUsed: now.Add(-time.Hour)})
+		idx.Touch(cache.Record{Name: "pack-cache-gone.build", Format: "volume", Kind: "build", Image: "gone:latest", LastUsed: now})
+		idx.Touch(cache.Record{Name: bindDir, Format: "bind", Kind: "build", Image: "my-bind-app:latest", LastUsed: now.Add(-10 * 24 * time.Hour)})
+		idx.Touch(cache.Record{Name: "registry.example.com/cache", Format: "registry", Kind: "build", Image: "my-app:latest", LastUsed: now.Add(-30 * 24 * time.Hour)})
+		h.AssertNil(t, idx.Save())
+
+		mockDockerClient.EXPECT().DiskUsage(gomock.Any(), gomock.Any()).Return(types.DiskUsage{
+			Volumes: []*volume.Volume{
+				{Name: "pack-cache-my-app.build", UsageData: &volume.UsageData{Size: 100}},
+				{Name: "pack-cache-unknown.launch", CreatedAt: now.Add(-2 * time.Hour).Format(time.RFC3339), UsageData: &volume.UsageData{Size: 50}},
+				{Name: "some-other-volume"},
+			},
+		}, nil).AnyTimes()
+
+		subject, err = NewClient(WithLogger(logging.NewLogWithWriters(&out, &out)), WithDockerClient(mockDockerClient))
+		h.AssertNil(t, err)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+		h.AssertNil(t, os.Unsetenv("PACK_HOME"))
+		h.AssertNil(t, os.RemoveAll(tmpDir))
+	})
+
+	when("#ListCaches", func() {
+		it("lists recorded and discovered caches, most recently used first", func() {
+			caches, err := subject.ListCaches(context.TODO())
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, caches, []CacheInfo{
+				{Name: "pack-cache-my-app.build", Format: "volume", Kind: "build", Image: "my-app:latest", Size: 100, LastUsed: now.Add(-time.Hour)},
+				{Name: "pack-cache-unknown.launch", Format: "volume", Kind: "launch", Size: 50, LastUsed: now.Add(-2 * time.Hour)},
+				{Name: bindDir, Format: "bind", Kind: "build", Image: "my-bind-app:latest", Size: 12, LastUsed: now.Add(-10 * 24 * time.Hour)},
+				{Name: "registry.example.com/cache", Format: "registry", Kind: "build", Image: "my-app:latest", Size: -1, LastUsed: now.Add(-30 * 24 * time.Hour)},
+			})
+		})
+
+		it("errors when the docker client cannot inspect volumes", func() {
+			subject, err := NewClient(WithLogger(logging.NewLogWithWriters(&out, &out)), WithDockerClient(struct{ DockerClient }{mockDockerClient}))
+			h.AssertNil(t, err)
+
+			_, err = subject.ListCaches(context.TODO())
+			h.AssertError(t, err, "docker client does not support inspecting volumes")
+		})
+	})
+
+	when("#InspectCache", func() {
+		it("returns the named cache", func() {
+			info, err := subject.InspectCache(context.TODO(), "pack-cache-my-app.build")
+			h.AssertNil(t, err)
+			h.AssertEq(t, info.Image, "my-app:latest")
+		})
+
+		it("errors when the cache does not exist", func() {
+			_, err := subject.InspectCache(context.TODO(), "pack-cache-gone.build")
+			h.AssertError(t, err, "cache 'pack-cache-gone.build' not found")
+		})
+	})
+
+	when("#PruneCaches", func() {
+		it("removes caches older than the given age, except registry caches", func() {
+			pruned, err := subject.PruneCaches(context.TODO(), PruneCachesOptions{OlderThan: 7 * 24 * time.Hour})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, len(pruned), 1)
+			h.AssertEq(t, pruned[0].Name, bindDir)
+			h.AssertNil(t, err)
+			_, err = os.Stat(bindDir)
+			h.AssertTrue(t, os.IsNotExist(err))
+
+			idx, err := cache.ReadIndex(filepath.Join(tmpDir, cache.IndexFileName))
+			h.AssertNil(t, err)
+			_, found := idx.Find(bindDir)
+			h.AssertFalse(t, found)
+		})
+
+		it("removes least recently used caches until under the size limit", func() {
+			mockDockerClient.EXPECT().VolumeRemove(gomock.Any(), "pack-cache-unknown.launch", true).Return(nil)
+
+			pruned, err := subject.PruneCaches(context.TODO(), PruneCachesOptions{MaxSize: 100})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, len(pruned), 2)
+			h.AssertEq(t, pruned[0].Name, bindDir)
+			h.AssertEq(t, pruned[1].Name, "pack-cache-unknown.launch")
+		})
+
+		when("dry run", func() {
+			it("does not remove anything", func() {
+				pruned, err := subject.PruneCaches(context.TODO(), PruneCachesOptions{OlderThan: time.Minute, DryRun: true})
+				h.AssertNil(t, err)
+
+				h.AssertEq(t, len(pruned), 3)
+				_, err = os.Stat(bindDir)
+				h.AssertNil(t, err)
+			})
+		})
+	})
+}
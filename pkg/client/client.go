@@ -74,8 +74,8 @@ type ImageFetcher interface {
 // BlobDownloader is an interface for collecting both remote and local assets as blobs.
 type BlobDownloader interface {
 	// Download collects both local and remote assets and provides a blob object
-	// used to read asset contents.
-	Download(ctx context.Context, pathOrURI string) (blob.Blob, error)
+	// used to read asset contents. Options may pin the expected digest of the content.
+	Download(ctx context.Context, pathOrURI string, opts ...blob.DownloadOption) (blob.Blob, error)
 }
 
 //go:generate mockgen -package testmocks -destination ../testmocks/mock_image_factory.go github.com/buildpacks/pack/pkg/client ImageFactory
@@ -127,6 +127,7 @@ type Client struct {
 	buildpackDownloader BuildpackDownloader
 
 	experimental    bool
+	offline         bool
 	registryMirrors map[string]string
 	version         string
 }
@@ -203,6 +204,27 @@ func WithExperimental(experimental bool) Option {
 	}
 }
 
+// WithOffline sets whether the client must work without network access.
+// When offline, images are only resolved from the daemon or OCI layouts, downloads only from the
+// download cache and buildpack registries only from their existing local clones. Templates from git
+// repositories cannot be used.
+func WithOffline(offline bool) Option {
+	return func(c *Client) {
+		c.offline = offline
+	}
+}
+
+// SetOffline changes whether the client must work without network access once created, as set by
+// WithOffline, including for its image fetcher, downloader and buildpack downloader when they support it.
+func (c *Client) SetOffline(offline bool) {
+	c.offline = offline
+	for _, component := range []interface{}{c.imageFetcher, c.downloader, c.buildpackDownloader} {
+		if o, ok := component.(interface{ SetOffline(bool) }); ok {
+			o.SetOffline(offline)
+		}
+	}
+}
+
 // WithRegistryMirrors sets mirrors to pull images from.
 func WithRegistryMirrors(registryMirrors map[string]string) Option {
 	return func(c *Client) {
@@ -250,11 +272,11 @@ func NewClient(opts ...Option) (*Client, error) {
 		if err != nil {
 			return nil, errors.Wrap(err, "getting pack home")
 		}
-		client.downloader = blob.NewDownloader(client.logger, filepath.Join(packHome, "download-cache"))
+		client.downloader = blob.NewDownloader(client.logger, filepath.Join(packHome, "download-cache"), blob.WithOffline(client.offline))
 	}
 
 	if client.imageFetcher == nil {
-		client.imageFetcher = image.NewFetcher(client.logger, client.docker, image.WithRegistryMirrors(client.registryMirrors), image.WithKeychain(client.keychain))
+		client.imageFetcher = image.NewFetcher(client.logger, client.docker, image.WithRegistryMirrors(client.registryMirrors), image.WithKeychain(client.keychain), image.WithOffline(client.offline))
 	}
 
 	if client.imageFactory == nil {
@@ -282,7 +304,8 @@ func NewClient(opts ...Option) (*Client, error) {
 			client.imageFetcher,
 			client.downloader,
 			&registryResolver{
-				logger: client.logger,
+				logger:  client.logger,
+				offline: client.offline,
 			},
 		)
 	}
@@ -293,11 +316,16 @@ func NewClient(opts ...Option) (*Client, error) {
 }
 
 type registryResolver struct {
-	logger logging.Logger
+	logger  logging.Logger
+	offline bool
+}
+
+func (r *registryResolver) SetOffline(offline bool) {
+	r.offline = offline
 }
 
 func (r *registryResolver) Resolve(registryName, bpName string) (string, error) {
-	cache, err := getRegistry(r.logger, registryName)
+	cache, err := getRegistry(r.logger, registryName, r.offline)
 	if err != nil {
 		return "", errors.Wrapf(err, "lookup registry %s", style.Symbol(registryName))
 	}
@@ -2,6 +2,8 @@ package client
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"os"
 	"testing"
 
@@ -10,6 +12,7 @@ import (
 	"github.com/sclevine/spec"
 	"github.com/sclevine/spec/report"
 
+	"github.com/buildpacks/pack/pkg/image"
 	"github.com/buildpacks/pack/pkg/logging"
 	"github.com/buildpacks/pack/pkg/testmocks"
 	h "github.com/buildpacks/pack/testhelpers"
@@ -111,6 +114,19 @@ func testClient(t *testing.T, when spec.G, it spec.S) {
 		})
 	})
 
+	when("#SetOffline", func() {
+		it("makes the client and its default image fetcher work offline", func() {
+			cl, err := NewClient()
+			h.AssertNil(t, err)
+
+			cl.SetOffline(true)
+
+			h.AssertEq(t, cl.offline, true)
+			_, err = cl.imageFetcher.Fetch(context.TODO(), "some/image", image.FetchOptions{Daemon: false, PullPolicy: image.PullAlways})
+			h.AssertTrue(t, errors.Is(err, image.ErrOffline))
+		})
+	})
+
 	when("#WithRegistryMirror", func() {
 		it("uses registry mirrors provided", func() {
 			registryMirrors := map[string]string{
@@ -77,7 +77,7 @@ func (c *Client) resolveRunImage(runImage, imgRegistry, bldrRegistry string, run
 	return runImageName
 }
 
-func getRegistry(logger logging.Logger, registryName string) (registry.Cache, error) {
+func getRegistry(logger logging.Logger, registryName string, offline bool) (registry.Cache, error) {
 	home, err := config.PackHome()
 	if err != nil {
 		return registry.Cache{}, err
@@ -93,12 +93,12 @@ func getRegistry(logger logging.Logger, registryName string) (registry.Cache, er
 	}
 
 	if registryName == "" {
-		return registry.NewDefaultRegistryCache(logger, home)
+		return registry.NewDefaultRegistryCache(logger, home, registry.WithOffline(offline))
 	}
 
 	for _, reg := range config.GetRegistries(cfg) {
 		if reg.Name == registryName {
-			return registry.NewRegistryCache(logger, home, reg.URL)
+			return registry.NewRegistryCache(logger, home, reg.URL, registry.WithOffline(offline))
 		}
 	}
 
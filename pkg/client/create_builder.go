@@ -16,9 +16,11 @@ import (
 	"github.com/buildpacks/pack/internal/builder"
 	"github.com/buildpacks/pack/internal/paths"
 	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/blob"
 	"github.com/buildpacks/pack/pkg/buildpack"
 	"github.com/buildpacks/pack/pkg/dist"
 	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/signing"
 )
 
 // CreateBuilderOptions is a configuration object used to change the behavior of
@@ -54,18 +56,28 @@ type CreateBuilderOptions struct {
 
 	// Target platforms to build builder images for
 	Targets []dist.Target
+
+	// Option only valid if Publish is true
+	// Sign the published builder, or the image index of a multi-platform builder, with this signer.
+	Signer *signing.Signer
 }
 
 // CreateBuilder creates and saves a builder image to a registry with the provided options.
 // If any configuration is invalid, it will error and exit without creating any images.
 func (c *Client) CreateBuilder(ctx context.Context, opts CreateBuilderOptions) error {
+	if opts.Signer != nil && !opts.Publish {
+		return errors.New("only published builders can be signed")
+	}
+
 	targets, err := c.processBuilderCreateTargets(ctx, opts)
 	if err != nil {
 		return err
 	}
 
+	// digest of the published builder or image index, to sign
+	var signedDigest string
 	if len(targets) == 0 {
-		_, err = c.createBuilderTarget(ctx, opts, nil, false)
+		signedDigest, err = c.createBuilderTarget(ctx, opts, nil, false)
 		if err != nil {
 			return err
 		}
@@ -80,13 +92,27 @@ func (c *Client) CreateBuilder(ctx context.Context, opts CreateBuilderOptions) e
 			}
 			digests = append(digests, digest)
 		}
+		signedDigest = digests[0]
 
 		if multiArch && len(digests) > 1 {
-			return c.CreateManifest(ctx, CreateManifestOptions{
+			signedDigest, err = c.createManifest(ctx, CreateManifestOptions{
 				IndexRepoName: opts.BuilderName,
 				RepoNames:     digests,
 				Publish:       true,
 			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if opts.Signer != nil {
+		digest, err := digestOf(signedDigest)
+		if err != nil {
+			return errors.Wrap(err, "signing builder")
+		}
+		if err := c.signImage(ctx, opts.Signer, opts.BuilderName, digest); err != nil {
+			return errors.Wrap(err, "signing builder")
 		}
 	}
 
@@ -125,8 +151,8 @@ func (c *Client) createBuilderTarget(ctx context.Context, opts CreateBuilderOpti
 		return "", err
 	}
 
-	if multiArch {
-		// We need to keep the identifier to create the image index
+	if multiArch || opts.Signer != nil {
+		// We need to keep the identifier to create the image index, and to sign the builder
 		id, err := bldr.Image().Identifier()
 		if err != nil {
 			return "", errors.Wrapf(err, "determining image manifest digest")
@@ -280,12 +306,12 @@ func (c *Client) fetchLifecycle(ctx context.Context, config pubbldr.LifecycleCon
 		uri = c.uriFromLifecycleVersion(*semver.MustParse(builder.DefaultLifecycleVersion), os, architecture)
 	}
 
-	blob, err := c.downloader.Download(ctx, uri)
+	lifecycleBlob, err := c.downloader.Download(ctx, uri, blob.DownloadOptionsFor(config.SHA256)...)
 	if err != nil {
 		return nil, errors.Wrap(err, "downloading lifecycle")
 	}
 
-	lifecycle, err := builder.NewLifecycle(blob)
+	lifecycle, err := builder.NewLifecycle(lifecycleBlob)
 	if err != nil {
 		return nil, errors.Wrap(err, "invalid lifecycle")
 	}
@@ -334,6 +360,7 @@ func (c *Client) addConfig(ctx context.Context, kind string, config pubbldr.Modu
 		RegistryName:    opts.Registry,
 		RelativeBaseDir: opts.RelativeBaseDir,
 		Target:          target,
+		SHA256:          config.SHA256,
 	})
 	if err != nil {
 		return errors.Wrapf(err, "downloading %s", kind)
@@ -71,7 +71,7 @@ func ProcessDockerContext(logger logging.Logger) error {
 
 		if skip(configuration) {
 			logger.Debug("docker context is default or empty, skipping it")
-			return nil
+			return processPodmanConnection(logger)
 		}
 
 		configMetaData, err := readConfigMetadata(dockerConfigDir, configuration.CurrentContext)
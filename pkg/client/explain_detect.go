@@ -0,0 +1,333 @@
+package client
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	pubbldr "github.com/buildpacks/pack/builder"
+	"github.com/buildpacks/pack/internal/builder"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/events"
+)
+
+var (
+	// Logged by the lifecycle at the start of each phase when running the creator.
+	detectCreatorPhaseRegexp = regexp.MustCompile(`^===> (.+)$`)
+	// Logged by the detector at debug level before the output of each buildpack it runs.
+	detectOutputRegexp = regexp.MustCompile(`^======== (?:Output|Error): \S+ ========$`)
+	// Logged by the detector at debug level before the results of each group it tries.
+	detectResultsRegexp = regexp.MustCompile(`^======== Results ========$`)
+	// Logged by the detector for each buildpack of the group it tries, with the exit code of those that errored.
+	detectResultRegexp = regexp.MustCompile(`^(pass|fail|skip|err):\s+(\S+@\S*)(?: \(\d+\))?$`)
+	// Logged by the detector for the buildpacks removed from the group while resolving its build plan.
+	detectResolutionRegexp = regexp.MustCompile(`^(?:fail|skip): (\S+@\S*) ((?:requires|provides unused) .+)$`)
+	// Logged by the detector for each buildpack of the group that passed detection.
+	detectGroupEntryRegexp = regexp.MustCompile(`^([A-Za-z0-9_./@-]+)\s+(\S+)$`)
+)
+
+// detectExplainer records the results of each group the detector tries from the build events it receives, and
+// passes the events on to next if set. It needs the detector to log at debug level.
+type detectExplainer struct {
+	mu           sync.Mutex
+	next         events.Emitter
+	creatorPhase string
+	inResults    bool
+	attempts     []*detectAttempt
+}
+
+// detectAttempt is the results of a group tried by the detector, logged after '======== Results ========'. Groups with
+// composite buildpacks are tried once for each combination of the groups of their orders.
+type detectAttempt struct {
+	statuses   map[string]string
+	resolution map[string][]string
+	selected   []events.Module
+}
+
+func newDetectExplainer(next events.Emitter) *detectExplainer {
+	return &detectExplainer{next: next}
+}
+
+func (e *detectExplainer) Emit(event events.Event) {
+	if e.next != nil {
+		e.next.Emit(event)
+	}
+	if event.Type != events.Output || event.Stream != "stdout" {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if m := detectCreatorPhaseRegexp.FindStringSubmatch(event.Line); m != nil {
+		e.creatorPhase = m[1]
+		e.inResults = false
+		return
+	}
+	if event.Phase != "detector" && (event.Phase != "creator" || e.creatorPhase != "DETECTING") {
+		return
+	}
+	e.line(event.Line)
+}
+
+func (e *detectExplainer) line(line string) {
+	switch {
+	case detectOutputRegexp.MatchString(line):
+		e.inResults = false
+	case detectResultsRegexp.MatchString(line):
+		e.inResults = true
+		e.attempts = append(e.attempts, &detectAttempt{statuses: map[string]string{}, resolution: map[string][]string{}})
+	case !e.inResults:
+	case detectResolutionRegexp.MatchString(line):
+		m := detectResolutionRegexp.FindStringSubmatch(line)
+		attempt := e.attempts[len(e.attempts)-1]
+		if !contains(attempt.resolution[m[1]], m[2]) {
+			attempt.resolution[m[1]] = append(attempt.resolution[m[1]], m[2])
+		}
+	case detectResultRegexp.MatchString(line):
+		m := detectResultRegexp.FindStringSubmatch(line)
+		status := m[1]
+		if status == "err" {
+			status = events.DetectError
+		}
+		e.attempts[len(e.attempts)-1].statuses[m[2]] = status
+	case detectGroupEntryRegexp.MatchString(line):
+		m := detectGroupEntryRegexp.FindStringSubmatch(line)
+		attempt := e.attempts[len(e.attempts)-1]
+		attempt.selected = append(attempt.selected, events.Module{ID: m[1], Version: m[2]})
+	}
+}
+
+// tried tells whether the detector logged the results of a group.
+func (e *detectExplainer) tried() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return len(e.attempts) > 0
+}
+
+// explain annotates the groups of order, as expanded by the DetectionOrderCalculator, with the recorded results.
+func (e *detectExplainer) explain(order pubbldr.DetectionOrder) *events.Detection {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	detection := &events.Detection{}
+	selected := -1
+	for i, attempts := range e.assignAttempts(order) {
+		for _, attempt := range attempts {
+			if len(attempt.selected) > 0 {
+				detection.Selected = attempt.selected
+				selected = i
+			}
+		}
+		detection.Order = append(detection.Order, groupResults(order[i].GroupDetectionOrder, attempts))
+	}
+
+	for i := range detection.Order {
+		switch {
+		case i == selected:
+			detection.Order[i].Status = events.DetectSelected
+		case detection.Order[i].Status == events.DetectPass:
+			// its buildpacks passed, but its build plan could not be resolved
+			detection.Order[i].Status = events.DetectFail
+		}
+	}
+	return detection
+}
+
+// assignAttempts returns the attempts of each group of order. The detector tries the groups in order, so each attempt
+// belongs to the first group from the one of the previous attempt with all of its buildpacks. Only groups with
+// composite buildpacks are tried more than once.
+func (e *detectExplainer) assignAttempts(order pubbldr.DetectionOrder) [][]*detectAttempt {
+	result := make([][]*detectAttempt, len(order))
+	current := 0
+	for _, attempt := range e.attempts {
+		if len(attempt.statuses) == 0 {
+			continue
+		}
+		if current < len(order) && len(result[current]) > 0 && !hasComposite(order[current].GroupDetectionOrder) {
+			current++
+		}
+		for i := current; i < len(order); i++ {
+			if attempt.within(order[i].GroupDetectionOrder) {
+				result[i] = append(result[i], attempt)
+				current = i
+				break
+			}
+		}
+	}
+	return result
+}
+
+// within tells whether all the buildpacks of the attempt are buildpacks of the group, or of the groups of its
+// composite buildpacks.
+func (a *detectAttempt) within(entries pubbldr.DetectionOrder) bool {
+	ids := map[string]bool{}
+	var collect func(entries pubbldr.DetectionOrder)
+	collect = func(entries pubbldr.DetectionOrder) {
+		for _, entry := range entries {
+			if len(entry.GroupDetectionOrder) > 0 {
+				collect(entry.GroupDetectionOrder)
+				continue
+			}
+			ids[entry.ID] = true
+		}
+	}
+	collect(entries)
+
+	for key := range a.statuses {
+		if id, _, _ := strings.Cut(key, "@"); !ids[id] {
+			return false
+		}
+	}
+	return true
+}
+
+func hasComposite(entries pubbldr.DetectionOrder) bool {
+	for _, entry := range entries {
+		if len(entry.GroupDetectionOrder) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// groupResults annotates the buildpacks of a group with the results of its attempts, where a composite buildpack is
+// a run of consecutive entries referencing it, one for each group of its order.
+func groupResults(entries pubbldr.DetectionOrder, attempts []*detectAttempt) events.DetectGroup {
+	group := events.DetectGroup{Buildpacks: []events.DetectBuildpack{}}
+	for i := 0; i < len(entries); i++ {
+		entry := entries[i]
+		bp := events.DetectBuildpack{ID: entry.ID, Version: entry.Version, Optional: entry.Optional}
+		if len(entry.GroupDetectionOrder) == 0 {
+			bp.Status, bp.Resolution = buildpackResults(entry.ModuleRef, attempts)
+		} else {
+			for ; i < len(entries) && sameModule(entries[i].ModuleRef, entry.ModuleRef) && len(entries[i].GroupDetectionOrder) > 0; i++ {
+				bp.Order = append(bp.Order, groupResults(entries[i].GroupDetectionOrder, attempts))
+			}
+			i--
+		}
+		group.Buildpacks = append(group.Buildpacks, bp)
+	}
+	group.Status = groupStatus(group)
+	return group
+}
+
+// buildpackResults returns the status and resolution of a buildpack in the last attempt that ran it, resolving the
+// version of buildpacks referenced without one.
+func buildpackResults(ref dist.ModuleRef, attempts []*detectAttempt) (string, []string) {
+	for i := len(attempts) - 1; i >= 0; i-- {
+		for key, status := range attempts[i].statuses {
+			if key == ref.ID+"@"+ref.Version || (ref.Version == "" && strings.HasPrefix(key, ref.ID+"@")) {
+				return status, attempts[i].resolution[key]
+			}
+		}
+	}
+	return events.DetectNotRun, nil
+}
+
+// groupStatus is fail when a required buildpack of the group failed or was removed while resolving the build plan,
+// pass when its buildpacks passed and not-run when none of them ran.
+func groupStatus(group events.DetectGroup) string {
+	ran, failed := false, false
+	for _, bp := range group.Buildpacks {
+		status := bp.Status
+		if len(bp.Order) > 0 {
+			status = events.DetectNotRun
+			for _, subGroup := range bp.Order {
+				if subGroup.Status == events.DetectPass {
+					status = events.DetectPass
+					break
+				}
+				if subGroup.Status == events.DetectFail {
+					status = events.DetectFail
+				}
+			}
+		}
+
+		if status == events.DetectNotRun {
+			continue
+		}
+		ran = true
+		if !bp.Optional && (status == events.DetectFail || status == events.DetectError || len(bp.Resolution) > 0) {
+			failed = true
+		}
+	}
+
+	switch {
+	case !ran:
+		return events.DetectNotRun
+	case failed:
+		return events.DetectFail
+	default:
+		return events.DetectPass
+	}
+}
+
+func sameModule(a, b dist.ModuleRef) bool {
+	return a.ID == b.ID && a.Version == b.Version
+}
+
+// explainDetect emits or logs why each group of the order of the builder passed or failed detection.
+func (c *Client) explainDetect(explainer *detectExplainer, ephemeralBuilder *builder.Builder, opts BuildOptions) error {
+	if !explainer.tried() {
+		c.logger.Warn("Detection could not be explained, as the detector did not log its results")
+		return nil
+	}
+
+	var layers dist.ModuleLayers
+	if _, err := dist.GetLabel(ephemeralBuilder.Image(), dist.BuildpackLayersLabel, &layers); err != nil {
+		return errors.Wrapf(err, "reading buildpack layers of builder")
+	}
+	order, err := builder.NewDetectionOrderCalculator().Order(ephemeralBuilder.Order(), layers, pubbldr.OrderDetectionMaxDepth)
+	if err != nil {
+		return errors.Wrap(err, "calculating detection order")
+	}
+	detection := explainer.explain(order)
+
+	if opts.Events != nil {
+		opts.Events.Emit(events.Event{Type: events.Detect, Detect: detection})
+		return nil
+	}
+
+	c.logger.Info(style.Step("DETECTION"))
+	for i, group := range detection.Order {
+		c.logDetectGroup(fmt.Sprintf("Group %d", i+1), group, "")
+	}
+	if len(detection.Selected) == 0 {
+		c.logger.Info("No group passed detection")
+		return nil
+	}
+	var selected []string
+	for _, module := range detection.Selected {
+		selected = append(selected, module.ID+"@"+module.Version)
+	}
+	c.logger.Infof("Selected: %s", strings.Join(selected, ", "))
+	return nil
+}
+
+func (c *Client) logDetectGroup(name string, group events.DetectGroup, indent string) {
+	c.logger.Infof("%s%s: %s", indent, name, group.Status)
+	for _, bp := range group.Buildpacks {
+		ref := dist.ModuleInfo{ID: bp.ID, Version: bp.Version}.FullName()
+		if len(bp.Order) > 0 {
+			c.logger.Infof("%s  %s", indent, ref)
+			for i, subGroup := range bp.Order {
+				c.logDetectGroup(fmt.Sprintf("Group %d", i+1), subGroup, indent+"    ")
+			}
+			continue
+		}
+
+		line := fmt.Sprintf("%s  %-7s %s", indent, bp.Status, ref)
+		if bp.Optional {
+			line += " (optional)"
+		}
+		if len(bp.Resolution) > 0 {
+			line += ": " + strings.Join(bp.Resolution, ", ")
+		}
+		c.logger.Info(line)
+	}
+}
@@ -0,0 +1,196 @@
+package client
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	pubbldr "github.com/buildpacks/pack/builder"
+	buildfakes "github.com/buildpacks/pack/internal/build/fakes"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/events"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestDetectExplainer(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "DetectExplainer", testDetectExplainer, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testDetectExplainer(t *testing.T, when spec.G, it spec.S) {
+	var (
+		next    *buildfakes.FakeEmitter
+		subject *detectExplainer
+		order   pubbldr.DetectionOrder
+	)
+
+	ref := func(id string, optional bool) dist.ModuleRef {
+		return dist.ModuleRef{ModuleInfo: dist.ModuleInfo{ID: id, Version: "1.0.0"}, Optional: optional}
+	}
+	leaf := func(id string, optional bool) pubbldr.DetectionOrderEntry {
+		return pubbldr.DetectionOrderEntry{ModuleRef: ref(id, optional)}
+	}
+	group := func(entries ...pubbldr.DetectionOrderEntry) pubbldr.DetectionOrderEntry {
+		return pubbldr.DetectionOrderEntry{GroupDetectionOrder: entries}
+	}
+	emit := func(phase string, lines ...string) {
+		for _, line := range lines {
+			subject.Emit(events.Event{Type: events.Output, Phase: phase, Stream: "stdout", Line: line})
+		}
+	}
+
+	it.Before(func() {
+		next = &buildfakes.FakeEmitter{}
+		subject = newDetectExplainer(next)
+
+		composite := ref("example/python", false)
+		order = pubbldr.DetectionOrder{
+			group(leaf("example/node", false), leaf("example/yarn", true), leaf("example/npm", false)),
+			group(
+				pubbldr.DetectionOrderEntry{ModuleRef: composite, GroupDetectionOrder: pubbldr.DetectionOrder{leaf("example/pip", false)}},
+				pubbldr.DetectionOrderEntry{ModuleRef: composite, GroupDetectionOrder: pubbldr.DetectionOrder{leaf("example/poetry", false)}},
+				leaf("example/procfile", true),
+			),
+			group(leaf("example/go", false), leaf("example/npm", false)),
+		}
+	})
+
+	it("passes events on", func() {
+		emit("detector", "some-line")
+
+		h.AssertEq(t, next.Events(), []events.Event{{Type: events.Output, Phase: "detector", Stream: "stdout", Line: "some-line"}})
+	})
+
+	it("annotates the order with the results of each group and the selected group", func() {
+		emit("detector",
+			"======== Output: example/node@1.0.0 ========",
+			"no package.json",
+			"======== Results ========",
+			"fail: example/node@1.0.0",
+			"skip: example/yarn@1.0.0",
+			"pass: example/npm@1.0.0",
+			"======== Output: example/pip@1.0.0 ========",
+			"some output",
+			"======== Results ========",
+			"pass: example/pip@1.0.0",
+			"pass: example/procfile@1.0.0",
+			"Resolving plan... (try #1)",
+			"skip: example/procfile@1.0.0 provides unused procfile",
+			"1 of 2 buildpacks participating",
+			"example/pip 1.0.0",
+		)
+
+		detection := subject.explain(order)
+		h.AssertEq(t, detection.Selected, []events.Module{{ID: "example/pip", Version: "1.0.0"}})
+		h.AssertEq(t, detection.Order, []events.DetectGroup{
+			{
+				Status: events.DetectFail,
+				Buildpacks: []events.DetectBuildpack{
+					{ID: "example/node", Version: "1.0.0", Status: events.DetectFail},
+					{ID: "example/yarn", Version: "1.0.0", Optional: true, Status: events.DetectSkip},
+					{ID: "example/npm", Version: "1.0.0", Status: events.DetectPass},
+				},
+			},
+			{
+				Status: events.DetectSelected,
+				Buildpacks: []events.DetectBuildpack{
+					{ID: "example/python", Version: "1.0.0", Order: []events.DetectGroup{
+						{Status: events.DetectPass, Buildpacks: []events.DetectBuildpack{{ID: "example/pip", Version: "1.0.0", Status: events.DetectPass}}},
+						{Status: events.DetectNotRun, Buildpacks: []events.DetectBuildpack{{ID: "example/poetry", Version: "1.0.0", Status: events.DetectNotRun}}},
+					}},
+					{ID: "example/procfile", Version: "1.0.0", Optional: true, Status: events.DetectPass, Resolution: []string{"provides unused procfile"}},
+				},
+			},
+			{
+				Status: events.DetectNotRun,
+				Buildpacks: []events.DetectBuildpack{
+					{ID: "example/go", Version: "1.0.0", Status: events.DetectNotRun},
+					{ID: "example/npm", Version: "1.0.0", Status: events.DetectNotRun},
+				},
+			},
+		})
+	})
+
+	it("fails groups whose build plan could not be resolved", func() {
+		emit("creator",
+			"===> ANALYZING",
+			"======== Results ========",
+			"===> DETECTING",
+			"======== Results ========",
+			"fail: example/node@1.0.0",
+			"fail: no viable buildpacks in group",
+			"======== Results ========",
+			"pass: example/pip@1.0.0",
+			"======== Results ========",
+			"pass: example/poetry@1.0.0",
+			"======== Results ========",
+			"err:  example/go@1.0.0 (1)",
+			"pass: example/npm@1.0.0",
+			"Resolving plan... (try #1)",
+			"fail: example/npm@1.0.0 requires node",
+			"===> RESTORING",
+			"pass: example/go@1.0.0",
+		)
+
+		detection := subject.explain(order)
+		h.AssertEq(t, len(detection.Selected), 0)
+		h.AssertEq(t, detection.Order[0].Status, events.DetectFail)
+		h.AssertEq(t, detection.Order[1].Status, events.DetectFail)
+		h.AssertEq(t, detection.Order[1].Buildpacks[1].Status, events.DetectNotRun)
+		h.AssertEq(t, detection.Order[2].Status, events.DetectFail)
+		h.AssertEq(t, detection.Order[2].Buildpacks[0].Status, events.DetectError)
+		h.AssertEq(t, detection.Order[2].Buildpacks[1].Resolution, []string{"requires node"})
+	})
+
+	it("keeps the results of each group to that group", func() {
+		emit("detector",
+			"======== Results ========",
+			"fail: example/node@1.0.0",
+			"skip: example/yarn@1.0.0",
+			"pass: example/npm@1.0.0",
+			"Resolving plan... (try #1)",
+			"fail: example/npm@1.0.0 requires node",
+			"======== Results ========",
+			"fail: example/pip@1.0.0",
+			"======== Results ========",
+			"fail: example/poetry@1.0.0",
+			"======== Results ========",
+			"fail: example/go@1.0.0",
+			"pass: example/npm@1.0.0",
+		)
+
+		detection := subject.explain(order)
+		h.AssertEq(t, detection.Order[0].Buildpacks[2], events.DetectBuildpack{ID: "example/npm", Version: "1.0.0", Status: events.DetectPass, Resolution: []string{"requires node"}})
+		h.AssertEq(t, detection.Order[1].Buildpacks[0].Order[0].Status, events.DetectFail)
+		h.AssertEq(t, detection.Order[1].Buildpacks[0].Order[1].Status, events.DetectFail)
+		h.AssertEq(t, detection.Order[1].Buildpacks[1].Status, events.DetectNotRun)
+		h.AssertEq(t, detection.Order[2].Buildpacks[1], events.DetectBuildpack{ID: "example/npm", Version: "1.0.0", Status: events.DetectPass})
+	})
+
+	it("logs the order as a tree", func() {
+		var out bytes.Buffer
+		client := &Client{logger: logging.NewLogWithWriters(&out, &out)}
+
+		client.logDetectGroup("Group 1", events.DetectGroup{
+			Status: events.DetectSelected,
+			Buildpacks: []events.DetectBuildpack{
+				{ID: "example/python", Version: "1.0.0", Order: []events.DetectGroup{
+					{Status: events.DetectPass, Buildpacks: []events.DetectBuildpack{{ID: "example/pip", Version: "1.0.0", Status: events.DetectPass}}},
+				}},
+				{ID: "example/procfile", Version: "1.0.0", Optional: true, Status: events.DetectPass, Resolution: []string{"provides unused procfile"}},
+			},
+		}, "")
+
+		h.AssertEq(t, out.String(), `Group 1: selected
+  example/python@1.0.0
+    Group 1: pass
+      pass    example/pip@1.0.0
+  pass    example/procfile@1.0.0 (optional): provides unused procfile
+`)
+	})
+}
@@ -50,6 +50,16 @@ type BuilderInfo struct {
 
 	// Detailed ordering of extensions.
 	OrderExtensions pubbldr.DetectionOrder
+
+	// Environment variables of the build-config env directory of the builder, by file name such as
+	// MY_VAR.override. Only recorded by builders created since it was added to the builder metadata.
+	BuildConfigEnv map[string]string
+
+	// Labels of the builder image.
+	Labels map[string]string
+
+	// Targets the builder image is for.
+	Targets []dist.Target
 }
 
 // BuildpackInfoKey contains all information needed to determine buildpack equivalence.
@@ -105,5 +115,8 @@ func (c *Client) InspectBuilder(name string, daemon bool, modifiers ...BuilderIn
 		CreatedBy:       info.CreatedBy,
 		Extensions:      info.Extensions,
 		OrderExtensions: info.OrderExtensions,
+		BuildConfigEnv:  info.BuildConfigEnv,
+		Labels:          info.Labels,
+		Targets:         info.Targets,
 	}, nil
 }
@@ -227,6 +227,8 @@ func testInspectBuilder(t *testing.T, when spec.G, it spec.S) {
 						assert.Nil(err)
 						apiVersion, err := api.NewVersion("0.2")
 						assert.Nil(err)
+						labels, err := builderImage.Labels()
+						assert.Nil(err)
 
 						want := BuilderInfo{
 							Description: "Some description",
@@ -344,6 +346,8 @@ func testInspectBuilder(t *testing.T, when spec.G, it spec.S) {
 								Name:    "pack",
 								Version: "1.2.3",
 							},
+							Labels:  labels,
+							Targets: []dist.Target{{OS: "linux", Arch: "amd64"}},
 						}
 
 						if diff := cmp.Diff(want, *builderInfo); diff != "" {
@@ -58,6 +58,28 @@ type ImageInfo struct {
 
 	// If the image can be rebased
 	Rebasable bool
+
+	// Layers contributed by each buildpack, with their digests.
+	BuildpackLayers []buildpack.LayersMetadata
+
+	// Layers of the image from the bottom up, with their size and what added them. Nil unless requested with
+	// WithLayers, or if the image does not expose its layers.
+	Layers []LayerInfo
+}
+
+// ImageInspectionConfig controls what InspectImage reads about an image.
+type ImageInspectionConfig struct {
+	// List every layer of the image, which requires reading its config and manifest, and the sizes of its layers.
+	Layers bool
+}
+
+type ImageInspectionModifier func(config *ImageInspectionConfig)
+
+// WithLayers makes InspectImage list every layer of the image in ImageInfo.Layers.
+func WithLayers() ImageInspectionModifier {
+	return func(config *ImageInspectionConfig) {
+		config.Layers = true
+	}
 }
 
 // ProcessDetails is a collection of all start command metadata
@@ -72,8 +94,9 @@ type ProcessDetails struct {
 
 // Deserialize just the subset of fields we need to avoid breaking changes
 type layersMetadata struct {
-	RunImage files.RunImageForRebase `json:"runImage" toml:"run-image"`
-	Stack    files.Stack             `json:"stack" toml:"stack"`
+	RunImage   files.RunImageForRebase    `json:"runImage" toml:"run-image"`
+	Stack      files.Stack                `json:"stack" toml:"stack"`
+	Buildpacks []buildpack.LayersMetadata `json:"buildpacks" toml:"buildpacks"`
 }
 
 const (
@@ -92,7 +115,12 @@ const (
 // using this metadata, and returns it.
 // If daemon is true, first the local registry will be searched for the image.
 // Otherwise it assumes the image is remote.
-func (c *Client) InspectImage(name string, daemon bool) (*ImageInfo, error) {
+func (c *Client) InspectImage(name string, daemon bool, modifiers ...ImageInspectionModifier) (*ImageInfo, error) {
+	var inspectionConfig ImageInspectionConfig
+	for _, mod := range modifiers {
+		mod(&inspectionConfig)
+	}
+
 	img, err := c.imageFetcher.Fetch(context.Background(), name, image.FetchOptions{Daemon: daemon, PullPolicy: image.PullNever})
 	if err != nil {
 		if errors.Cause(err) == image.ErrNotFound {
@@ -192,6 +220,13 @@ func (c *Client) InspectImage(name string, daemon bool) (*ImageInfo, error) {
 		stackCompat = layersMd.Stack
 	}
 
+	var layers []LayerInfo
+	if inspectionConfig.Layers {
+		if layers, err = c.imageLayers(img, daemon, layersMd.RunImage); err != nil {
+			return nil, err
+		}
+	}
+
 	if buildMD.Extensions != nil {
 		return &ImageInfo{
 			StackID:    stackID,
@@ -202,6 +237,9 @@ func (c *Client) InspectImage(name string, daemon bool) (*ImageInfo, error) {
 			Extensions: buildMD.Extensions,
 			Processes:  processDetails,
 			Rebasable:  rebasable,
+
+			BuildpackLayers: layersMd.Buildpacks,
+			Layers:          layers,
 		}, nil
 	}
 
@@ -213,6 +251,9 @@ func (c *Client) InspectImage(name string, daemon bool) (*ImageInfo, error) {
 		Buildpacks: buildMD.Buildpacks,
 		Processes:  processDetails,
 		Rebasable:  rebasable,
+
+		BuildpackLayers: layersMd.Buildpacks,
+		Layers:          layers,
 	}, nil
 }
 
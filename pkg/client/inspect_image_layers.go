@@ -0,0 +1,207 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/buildpacks/imgutil"
+	"github.com/buildpacks/lifecycle/platform"
+	"github.com/buildpacks/lifecycle/platform/files"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/pkg/dist"
+)
+
+// Groups the layers of an app image belong to.
+const (
+	LayerGroupRunImage  = "run-image"
+	LayerGroupBuildpack = "buildpack"
+	LayerGroupApp       = "app"
+	LayerGroupLauncher  = "launcher"
+	LayerGroupSBOM      = "sbom"
+	LayerGroupUnknown   = "unknown"
+)
+
+// LayerInfo describes a layer of an app image.
+type LayerInfo struct {
+	// Diff ID of the layer, as recorded in the lifecycle metadata.
+	DiffID string
+
+	// Size of the layer in bytes, -1 if it is not known. Layers of images in the daemon are measured uncompressed,
+	// layers of images in a registry compressed.
+	Size int64
+
+	// Group the layer belongs to, one of the LayerGroup constants.
+	Group string
+
+	// What added the layer, such as '<buildpack id>:<layer name>' for buildpack layers. Empty for run image layers
+	// and layers the lifecycle metadata does not mention.
+	Owner string
+}
+
+type layerOwner struct {
+	group string
+	owner string
+}
+
+// imageLayers lists the layers of the image from the bottom up, or returns nil if the image does not expose them.
+func (c *Client) imageLayers(img imgutil.Image, daemon bool, runImage files.RunImageForRebase) ([]LayerInfo, error) {
+	underlying := img.UnderlyingImage()
+	if underlying == nil {
+		return nil, nil
+	}
+
+	configFile, err := underlying.ConfigFile()
+	if err != nil {
+		return nil, errors.Wrap(err, "reading image config")
+	}
+	diffIDs := configFile.RootFS.DiffIDs
+
+	owners, err := layerOwners(img)
+	if err != nil {
+		return nil, err
+	}
+
+	var sizes []int64
+	if daemon {
+		sizes = c.daemonLayerSizes(img.Name(), configFile)
+	} else {
+		if sizes, err = registryLayerSizes(underlying); err != nil {
+			return nil, err
+		}
+	}
+
+	// layers up to the top layer of the run image are from the run image, unless the lifecycle added them
+	runImageTop := -1
+	for i, diffID := range diffIDs {
+		if diffID.String() == runImage.TopLayer {
+			runImageTop = i
+		}
+	}
+
+	var layers []LayerInfo
+	for i, diffID := range diffIDs {
+		layer := LayerInfo{DiffID: diffID.String(), Size: -1, Group: LayerGroupUnknown}
+		if i < len(sizes) {
+			layer.Size = sizes[i]
+		}
+		if owner, ok := owners[layer.DiffID]; ok {
+			layer.Group = owner.group
+			layer.Owner = owner.owner
+		} else if i <= runImageTop {
+			layer.Group = LayerGroupRunImage
+		}
+		layers = append(layers, layer)
+	}
+	return layers, nil
+}
+
+// layerOwners returns what added each layer the lifecycle metadata of the image mentions, by diff ID.
+func layerOwners(img imgutil.Image) (map[string]layerOwner, error) {
+	var md files.LayersMetadataCompat
+	if _, err := dist.GetLabel(img, platform.LifecycleMetadataLabel, &md); err != nil {
+		return nil, err
+	}
+
+	owners := map[string]layerOwner{}
+	add := func(sha, group, owner string) {
+		if sha != "" {
+			owners[sha] = layerOwner{group: group, owner: owner}
+		}
+	}
+
+	for _, bp := range md.Buildpacks {
+		for name, layer := range bp.Layers {
+			add(layer.SHA, LayerGroupBuildpack, fmt.Sprintf("%s:%s", bp.ID, name))
+		}
+	}
+	for _, sha := range appLayerSHAs(md.App) {
+		add(sha, LayerGroupApp, "app")
+	}
+	add(md.Launcher.SHA, LayerGroupLauncher, "launcher")
+	add(md.Config.SHA, LayerGroupLauncher, "config")
+	add(md.ProcessTypes.SHA, LayerGroupLauncher, "process-types")
+	if md.BOM != nil {
+		add(md.BOM.SHA, LayerGroupSBOM, "sbom")
+	}
+	return owners, nil
+}
+
+// appLayerSHAs returns the diff IDs of the app layers, which older lifecycles recorded as a single layer rather than
+// a list.
+func appLayerSHAs(app interface{}) []string {
+	sha := func(layer interface{}) string {
+		if m, ok := layer.(map[string]interface{}); ok {
+			if s, ok := m["sha"].(string); ok {
+				return s
+			}
+		}
+		return ""
+	}
+
+	switch app := app.(type) {
+	case []interface{}:
+		var result []string
+		for _, layer := range app {
+			result = append(result, sha(layer))
+		}
+		return result
+	case map[string]interface{}:
+		return []string{sha(app)}
+	default:
+		return nil
+	}
+}
+
+func registryLayerSizes(img v1.Image) ([]int64, error) {
+	layers, err := img.Layers()
+	if err != nil {
+		return nil, errors.Wrap(err, "reading image layers")
+	}
+
+	var sizes []int64
+	for _, layer := range layers {
+		size, err := layer.Size()
+		if err != nil {
+			return nil, errors.Wrap(err, "reading layer size")
+		}
+		sizes = append(sizes, size)
+	}
+	return sizes, nil
+}
+
+// daemonLayerSizes reads the size of each layer from the history the daemon keeps of the image, whose entries match
+// those of the image config. Sizes are unknown if the history cannot be matched to the layers.
+func (c *Client) daemonLayerSizes(name string, configFile *v1.ConfigFile) []int64 {
+	history, err := c.docker.ImageHistory(context.Background(), name)
+	if err != nil {
+		c.logger.Debugf("Unable to read the size of layers: %s", err)
+		return nil
+	}
+
+	// the daemon lists the most recent entry first
+	for i, j := 0, len(history)-1; i < j; i, j = i+1, j-1 {
+		history[i], history[j] = history[j], history[i]
+	}
+
+	var sizes []int64
+	switch {
+	case len(configFile.History) == len(history):
+		for i, entry := range configFile.History {
+			if !entry.EmptyLayer {
+				sizes = append(sizes, history[i].Size)
+			}
+		}
+	case len(configFile.History) == 0:
+		for _, entry := range history {
+			sizes = append(sizes, entry.Size)
+		}
+	}
+
+	if len(sizes) != len(configFile.RootFS.DiffIDs) {
+		c.logger.Debugf("Unable to read the size of layers: history of %s does not match its layers", name)
+		return nil
+	}
+	return sizes
+}
@@ -0,0 +1,158 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/buildpacks/lifecycle/platform/files"
+	"github.com/docker/docker/api/types/image"
+	"github.com/golang/mock/gomock"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestInspectImageLayers(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "InspectImageLayers", testInspectImageLayers, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+// imageWithLayers is an image exposing the layers of an underlying image, which fake images do not have.
+type imageWithLayers struct {
+	*testmocks.MockImage
+	underlying v1.Image
+}
+
+func (i *imageWithLayers) UnderlyingImage() v1.Image {
+	return i.underlying
+}
+
+func testInspectImageLayers(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject          *Client
+		mockDockerClient *testmocks.MockCommonAPIClient
+		mockController   *gomock.Controller
+		out              bytes.Buffer
+		img              *imageWithLayers
+		diffIDs          []string
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockDockerClient = testmocks.NewMockCommonAPIClient(mockController)
+
+		var err error
+		subject, err = NewClient(WithLogger(logging.NewLogWithWriters(&out, &out)), WithDockerClient(mockDockerClient))
+		h.AssertNil(t, err)
+
+		underlying, err := random.Image(100, 6)
+		h.AssertNil(t, err)
+		configFile, err := underlying.ConfigFile()
+		h.AssertNil(t, err)
+		diffIDs = nil
+		for _, diffID := range configFile.RootFS.DiffIDs {
+			diffIDs = append(diffIDs, diffID.String())
+		}
+
+		img = &imageWithLayers{MockImage: testmocks.NewImage("some/image", "", nil), underlying: underlying}
+		h.AssertNil(t, img.SetLabel("io.buildpacks.lifecycle.metadata", fmt.Sprintf(`{
+  "app": [{"sha": %q}],
+  "buildpacks": [{"key": "some-buildpack", "layers": {"some-layer": {"sha": %q}}}],
+  "launcher": {"sha": %q},
+  "sbom": {"sha": %q},
+  "runImage": {"topLayer": %q}
+}`, diffIDs[4], diffIDs[2], diffIDs[3], diffIDs[5], diffIDs[1])))
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	it("groups the layers and names what added them", func() {
+		layers, err := subject.imageLayers(img, false, files.RunImageForRebase{TopLayer: diffIDs[1]})
+		h.AssertNil(t, err)
+
+		h.AssertEq(t, len(layers), 6)
+		h.AssertEq(t, layers[0], LayerInfo{DiffID: diffIDs[0], Size: layers[0].Size, Group: LayerGroupRunImage})
+		h.AssertEq(t, layers[1].Group, LayerGroupRunImage)
+		h.AssertEq(t, layers[2], LayerInfo{DiffID: diffIDs[2], Size: layers[2].Size, Group: LayerGroupBuildpack, Owner: "some-buildpack:some-layer"})
+		h.AssertEq(t, layers[3], LayerInfo{DiffID: diffIDs[3], Size: layers[3].Size, Group: LayerGroupLauncher, Owner: "launcher"})
+		h.AssertEq(t, layers[4], LayerInfo{DiffID: diffIDs[4], Size: layers[4].Size, Group: LayerGroupApp, Owner: "app"})
+		h.AssertEq(t, layers[5], LayerInfo{DiffID: diffIDs[5], Size: layers[5].Size, Group: LayerGroupSBOM, Owner: "sbom"})
+	})
+
+	it("reads the compressed size of layers in a registry", func() {
+		layers, err := subject.imageLayers(img, false, files.RunImageForRebase{TopLayer: diffIDs[1]})
+		h.AssertNil(t, err)
+
+		underlyingLayers, err := img.underlying.Layers()
+		h.AssertNil(t, err)
+		for i, layer := range underlyingLayers {
+			size, err := layer.Size()
+			h.AssertNil(t, err)
+			h.AssertEq(t, layers[i].Size, size)
+		}
+	})
+
+	when("the image is in the daemon", func() {
+		it("reads the size of layers from its history", func() {
+			var history []image.HistoryResponseItem
+			for i := 5; i >= 0; i-- {
+				history = append(history, image.HistoryResponseItem{Size: int64(1000 + i)})
+			}
+			mockDockerClient.EXPECT().ImageHistory(gomock.Any(), "some/image").Return(history, nil)
+
+			layers, err := subject.imageLayers(img, true, files.RunImageForRebase{TopLayer: diffIDs[1]})
+			h.AssertNil(t, err)
+
+			for i, layer := range layers {
+				h.AssertEq(t, layer.Size, int64(1000+i))
+			}
+		})
+
+		it("leaves sizes unknown if the history does not match the layers", func() {
+			mockDockerClient.EXPECT().ImageHistory(gomock.Any(), "some/image").Return([]image.HistoryResponseItem{{Size: 1}}, nil)
+
+			layers, err := subject.imageLayers(img, true, files.RunImageForRebase{TopLayer: diffIDs[1]})
+			h.AssertNil(t, err)
+
+			for _, layer := range layers {
+				h.AssertEq(t, layer.Size, int64(-1))
+			}
+		})
+	})
+
+	it("returns no layers for images that do not expose them", func() {
+		layers, err := subject.imageLayers(testmocks.NewImage("some/image", "", nil), false, files.RunImageForRebase{TopLayer: ""})
+		h.AssertNil(t, err)
+		h.AssertEq(t, len(layers), 0)
+	})
+
+	when("inspecting the image", func() {
+		it.Before(func() {
+			mockImageFetcher := testmocks.NewMockImageFetcher(mockController)
+			mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/image", gomock.Any()).Return(img, nil)
+			subject.imageFetcher = mockImageFetcher
+		})
+
+		it("lists the layers when asked", func() {
+			info, err := subject.InspectImage("some/image", false, WithLayers())
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(info.Layers), 6)
+		})
+
+		it("does not read the layers otherwise", func() {
+			info, err := subject.InspectImage("some/image", false)
+			h.AssertNil(t, err)
+			h.AssertTrue(t, info.Layers == nil)
+		})
+	})
+}
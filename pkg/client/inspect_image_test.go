@@ -358,6 +358,29 @@ func testInspectImage(t *testing.T, when spec.G, it spec.S) {
 						files.Stack{RunImage: files.RunImageForExport{Image: "is everything"}})
 				})
 
+				it("returns the layers of each buildpack", func() {
+					h.AssertNil(t, mockImage.SetLabel(
+						"io.buildpacks.lifecycle.metadata",
+						`{
+  "buildpacks": [
+    {
+      "key": "some-buildpack",
+      "version": "some-version",
+      "layers": {
+        "some-layer": {"sha": "sha256:some-layer-digest", "launch": true}
+      }
+    }
+  ]
+}`,
+					))
+					info, err := subject.InspectImage("some/image", useDaemon)
+					h.AssertNil(t, err)
+					h.AssertEq(t, len(info.BuildpackLayers), 1)
+					h.AssertEq(t, info.BuildpackLayers[0].ID, "some-buildpack")
+					h.AssertEq(t, info.BuildpackLayers[0].Version, "some-version")
+					h.AssertEq(t, info.BuildpackLayers[0].Layers["some-layer"].SHA, "sha256:some-layer-digest")
+				})
+
 				it("returns the stack", func() {
 					info, err := subject.InspectImage("some/image", useDaemon)
 					h.AssertNil(t, err)
@@ -0,0 +1,598 @@
+package client
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/Masterminds/semver"
+	"github.com/buildpacks/lifecycle/api"
+	"github.com/pkg/errors"
+
+	pubbldr "github.com/buildpacks/pack/builder"
+	"github.com/buildpacks/pack/internal/paths"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/internal/target"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/project"
+)
+
+// Kinds of files linted
+const (
+	LintKindBuildpack = "buildpack"
+	LintKindBuilder   = "builder"
+	LintKindProject   = "project"
+)
+
+// LintSeverity is how serious a LintIssue is. Files with errors fail to package, create or build, files with
+// warnings don't behave as expected or rely on deprecated features.
+type LintSeverity string
+
+const (
+	LintSeverityError   LintSeverity = "error"
+	LintSeverityWarning LintSeverity = "warning"
+)
+
+// Rules checked by Lint
+const (
+	// The file can't be read, or is inconsistent
+	LintRuleInvalid = "invalid"
+	// A key isn't part of the schema of the file
+	LintRuleUnknownKey = "unknown-key"
+	// The Buildpack API is missing or not supported
+	LintRuleAPI = "api"
+	// A bin script of the buildpack is missing or not executable
+	LintRuleBinScript = "bin-script"
+	// An order group references a module the builder doesn't declare
+	LintRuleOrder = "order"
+	// A target is unknown, or doesn't match the stack or the targets of a buildpack
+	LintRuleTarget = "target"
+	// A deprecated field is used
+	LintRuleDeprecated = "deprecated"
+)
+
+// Distributions of the well-known stacks, used to find targets not matching them
+var stackDistributions = map[string]dist.Distribution{
+	"io.buildpacks.stacks.bionic":       {Name: "ubuntu", Version: "18.04"},
+	"io.buildpacks.stacks.jammy":        {Name: "ubuntu", Version: "22.04"},
+	"io.buildpacks.stacks.jammy.tiny":   {Name: "ubuntu", Version: "22.04"},
+	"io.buildpacks.stacks.jammy.static": {Name: "ubuntu", Version: "22.04"},
+}
+
+type LintOptions struct {
+	// Paths of the files to lint, or of directories whose buildpack.toml, builder.toml and project.toml are linted.
+	Paths []string
+
+	// Kind of the files, one of buildpack, builder or project, which is otherwise inferred from their names.
+	Kind string
+}
+
+// LintIssue is a mistake found in a file.
+type LintIssue struct {
+	File     string       `json:"file" yaml:"file"`
+	Severity LintSeverity `json:"severity" yaml:"severity"`
+	Rule     string       `json:"rule" yaml:"rule"`
+	// Key of the file the issue is about, if any
+	Key     string `json:"key,omitempty" yaml:"key,omitempty"`
+	Message string `json:"message" yaml:"message"`
+}
+
+// LintResult lists the files linted and the issues found in them.
+type LintResult struct {
+	Files  []string    `json:"files" yaml:"files"`
+	Issues []LintIssue `json:"issues" yaml:"issues"`
+}
+
+// Count returns the number of issues of the severity.
+func (r LintResult) Count(severity LintSeverity) int {
+	count := 0
+	for _, issue := range r.Issues {
+		if issue.Severity == severity {
+			count++
+		}
+	}
+	return count
+}
+
+// Lint statically validates buildpack.toml, builder.toml and project.toml files against their schemas and the
+// Buildpack API they declare. Mistakes are returned as issues, an error is only returned when the files can't be found.
+func (c *Client) Lint(opts LintOptions) (*LintResult, error) {
+	if opts.Kind != "" && opts.Kind != LintKindBuildpack && opts.Kind != LintKindBuilder && opts.Kind != LintKindProject {
+		return nil, errors.Errorf("kind %s must be one of %s, %s or %s", style.Symbol(opts.Kind),
+			style.Symbol(LintKindBuildpack), style.Symbol(LintKindBuilder), style.Symbol(LintKindProject))
+	}
+
+	result := &LintResult{}
+	for _, path := range opts.Paths {
+		files, err := lintFiles(path, opts.Kind)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			l := &linter{file: file.path}
+			switch file.kind {
+			case LintKindBuildpack:
+				l.lintBuildpack()
+			case LintKindBuilder:
+				l.lintBuilder()
+			case LintKindProject:
+				l.lintProject()
+			}
+			result.Files = append(result.Files, file.path)
+			result.Issues = append(result.Issues, l.issues...)
+		}
+	}
+	return result, nil
+}
+
+type lintFile struct {
+	path string
+	kind string
+}
+
+// lintFiles returns the file at path, or the descriptors of the directory at path.
+func lintFiles(path, kind string) ([]lintFile, error) {
+	isDir, err := paths.IsDir(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isDir {
+		if kind == "" {
+			kind = lintKindOf(filepath.Base(path))
+		}
+		if kind == "" {
+			return nil, errors.Errorf("cannot infer the kind of %s, set it to %s, %s or %s", style.Symbol(path),
+				style.Symbol(LintKindBuildpack), style.Symbol(LintKindBuilder), style.Symbol(LintKindProject))
+		}
+		return []lintFile{{path: path, kind: kind}}, nil
+	}
+
+	var files []lintFile
+	for _, name := range []string{"buildpack.toml", "builder.toml", "project.toml"} {
+		if _, err := os.Stat(filepath.Join(path, name)); err == nil {
+			files = append(files, lintFile{path: filepath.Join(path, name), kind: lintKindOf(name)})
+		}
+	}
+	if len(files) == 0 {
+		return nil, errors.Errorf("no buildpack.toml, builder.toml or project.toml found in %s", style.Symbol(path))
+	}
+	return files, nil
+}
+
+func lintKindOf(name string) string {
+	switch name {
+	case "buildpack.toml":
+		return LintKindBuildpack
+	case "builder.toml":
+		return LintKindBuilder
+	case "project.toml":
+		return LintKindProject
+	}
+	return ""
+}
+
+type linter struct {
+	file   string
+	issues []LintIssue
+}
+
+func (l *linter) errorf(rule, key, format string, args ...interface{}) {
+	l.issues = append(l.issues, LintIssue{File: l.file, Severity: LintSeverityError, Rule: rule, Key: key, Message: fmt.Sprintf(format, args...)})
+}
+
+func (l *linter) warnf(rule, key, format string, args ...interface{}) {
+	l.issues = append(l.issues, LintIssue{File: l.file, Severity: LintSeverityWarning, Rule: rule, Key: key, Message: fmt.Sprintf(format, args...)})
+}
+
+// lintUnknownKeys reports the undecoded keys, but not those of their tables, unless allowed.
+func (l *linter) lintUnknownKeys(md toml.MetaData, allowed func(key string) bool) int {
+	undecoded := map[string]bool{}
+	for _, key := range md.Undecoded() {
+		undecoded[key.String()] = true
+	}
+
+	count := 0
+	for _, key := range md.Undecoded() {
+		keyName := key.String()
+		parentUndecoded := false
+		for i := 1; i < len(key); i++ {
+			if undecoded[key[:i].String()] {
+				parentUndecoded = true
+			}
+		}
+		if parentUndecoded || allowed(keyName) {
+			continue
+		}
+		l.errorf(LintRuleUnknownKey, keyName, "unknown key %s", lintQuote(keyName))
+		count++
+	}
+	return count
+}
+
+func (l *linter) lintBuildpack() {
+	var descriptor dist.BuildpackDescriptor
+	md, err := toml.DecodeFile(l.file, &descriptor)
+	if err != nil {
+		l.errorf(LintRuleInvalid, "", "decoding toml contents: %s", err)
+		return
+	}
+
+	l.lintUnknownKeys(md, func(key string) bool {
+		return key == "metadata" || strings.HasPrefix(key, "metadata.") ||
+			key == "buildpack.clear-env" || key == "buildpack.sbom-formats"
+	})
+
+	if descriptor.WithAPI == nil {
+		l.errorf(LintRuleAPI, "api", "%s is required", lintQuote("api"))
+	} else if !api.Buildpack.IsSupported(descriptor.WithAPI) {
+		l.warnf(LintRuleAPI, "api", "Buildpack API %s is not supported by the lifecycle, which supports %s",
+			lintQuote(descriptor.WithAPI.String()), strings.Join(apiStrings(api.Buildpack.Supported), ", "))
+	}
+
+	if descriptor.WithInfo.ID == "" {
+		l.errorf(LintRuleInvalid, "buildpack.id", "%s is required", lintQuote("buildpack.id"))
+	}
+	if descriptor.WithInfo.Version == "" {
+		l.errorf(LintRuleInvalid, "buildpack.version", "%s is required", lintQuote("buildpack.version"))
+	}
+
+	if len(descriptor.WithStacks) > 0 {
+		l.warnf(LintRuleDeprecated, "stacks", "%s are deprecated, prefer %s", lintQuote("stacks"), lintQuote("targets"))
+	}
+	l.lintTargets(descriptor.WithTargets)
+	for _, stack := range descriptor.WithStacks {
+		l.lintStackTargets(stack.ID, descriptor.WithTargets)
+	}
+
+	for i, group := range descriptor.WithOrder {
+		for j, ref := range group.Group {
+			if ref.ID == "" {
+				l.errorf(LintRuleOrder, fmt.Sprintf("order[%d].group[%d]", i, j), "buildpacks of order groups must have an %s", lintQuote("id"))
+			}
+		}
+	}
+
+	if len(descriptor.WithOrder) == 0 {
+		l.lintBinScripts(filepath.Dir(l.file), descriptor.WithTargets)
+	}
+}
+
+// lintBinScripts checks the bin/detect and bin/build scripts of a component buildpack exist for the OSes it targets.
+func (l *linter) lintBinScripts(dir string, targets []dist.Target) {
+	oses := map[string]bool{}
+	for _, t := range targets {
+		oses[t.OS] = true
+	}
+	if len(oses) == 0 {
+		oses[dist.DefaultTargetOSLinux] = true
+	}
+
+	for _, name := range []string{"detect", "build"} {
+		if oses[dist.DefaultTargetOSWindows] {
+			found := false
+			for _, ext := range []string{".bat", ".exe"} {
+				if _, err := os.Stat(filepath.Join(dir, "bin", name+ext)); err == nil {
+					found = true
+				}
+			}
+			if !found {
+				l.errorf(LintRuleBinScript, "", "%s or %s is required to run on windows", lintQuote("bin/"+name+".bat"), lintQuote("bin/"+name+".exe"))
+			}
+		}
+
+		if !oses[dist.DefaultTargetOSLinux] {
+			continue
+		}
+		script := "bin/" + name
+		info, err := os.Stat(filepath.Join(dir, filepath.FromSlash(script)))
+		switch {
+		case os.IsNotExist(err):
+			l.errorf(LintRuleBinScript, "", "%s is missing", lintQuote(script))
+		case err != nil:
+			l.errorf(LintRuleBinScript, "", "reading %s: %s", lintQuote(script), err)
+		case info.IsDir():
+			l.errorf(LintRuleBinScript, "", "%s must be a file", lintQuote(script))
+		case runtime.GOOS != "windows" && info.Mode().Perm()&0111 == 0:
+			l.errorf(LintRuleBinScript, "", "%s is not executable", lintQuote(script))
+		}
+	}
+}
+
+// lintTargets checks the targets are platforms buildpacks run on.
+func (l *linter) lintTargets(targets []dist.Target) {
+	for i, t := range targets {
+		key := fmt.Sprintf("targets[%d]", i)
+		switch {
+		case t.OS != dist.DefaultTargetOSLinux && t.OS != dist.DefaultTargetOSWindows:
+			l.errorf(LintRuleTarget, key, "target os %s must be %s or %s", lintQuote(t.OS),
+				lintQuote(dist.DefaultTargetOSLinux), lintQuote(dist.DefaultTargetOSWindows))
+		case t.Arch != "" && !target.SupportsPlatform(t.OS, t.Arch, t.ArchVariant):
+			l.errorf(LintRuleTarget, key, "unknown target %s", lintQuote(t.ValuesAsPlatform()))
+		}
+	}
+}
+
+// lintStackTargets checks the targets with distributions list the distribution of the well-known stack.
+func (l *linter) lintStackTargets(stackID string, targets []dist.Target) {
+	distro, ok := stackDistributions[stackID]
+	if !ok {
+		return
+	}
+	for i, t := range targets {
+		if t.OS != dist.DefaultTargetOSLinux || len(t.Distributions) == 0 {
+			continue
+		}
+		found := false
+		for _, d := range t.Distributions {
+			if d.Name == distro.Name && (d.Version == "" || d.Version == distro.Version) {
+				found = true
+			}
+		}
+		if !found {
+			l.warnf(LintRuleTarget, fmt.Sprintf("targets[%d]", i), "stack %s runs %s %s, which target %s doesn't list",
+				lintQuote(stackID), distro.Name, distro.Version, lintQuote(t.ValuesAsPlatform()))
+		}
+	}
+}
+
+// lintModule is a buildpack or extension of a builder, with the descriptor of local ones.
+type lintModule struct {
+	info       dist.ModuleInfo
+	descriptor *dist.BuildpackDescriptor
+}
+
+func (l *linter) lintBuilder() {
+	var config pubbldr.Config
+	md, err := toml.DecodeFile(l.file, &config)
+	if err != nil {
+		l.errorf(LintRuleInvalid, "", "decoding toml contents: %s", err)
+		return
+	}
+
+	if unknownKeys := l.lintUnknownKeys(md, func(string) bool { return false }); unknownKeys == 0 {
+		// ReadConfig merges the stack with the build and run images, which validation relies on
+		merged, _, err := pubbldr.ReadConfig(l.file)
+		if err != nil {
+			l.errorf(LintRuleInvalid, "", "%s", err)
+		} else if err := pubbldr.ValidateConfig(merged); err != nil {
+			l.errorf(LintRuleInvalid, "", "%s", err)
+		}
+	}
+
+	if config.Stack.ID != "" || config.Stack.BuildImage != "" || config.Stack.RunImage != "" {
+		l.warnf(LintRuleDeprecated, "stack", "%s is deprecated, prefer %s and %s",
+			lintQuote("stack"), lintQuote("build.image"), lintQuote("run.images"))
+	}
+
+	if config.Lifecycle.Version != "" && config.Lifecycle.URI != "" {
+		l.errorf(LintRuleInvalid, "lifecycle", "%s can only declare %s or %s, not both",
+			lintQuote("lifecycle"), lintQuote("version"), lintQuote("uri"))
+	}
+	if config.Lifecycle.Version != "" {
+		if _, err := semver.NewVersion(config.Lifecycle.Version); err != nil {
+			l.errorf(LintRuleInvalid, "lifecycle.version", "%s must be a valid semver", lintQuote("lifecycle.version"))
+		}
+	}
+
+	l.lintTargets(config.Targets)
+	l.lintStackTargets(config.Stack.ID, config.Targets)
+
+	relativeBaseDir := filepath.Dir(l.file)
+	buildpacks := l.lintBuilderModules("buildpacks", buildpack.KindBuildpack, config.Buildpacks, relativeBaseDir)
+	extensions := l.lintBuilderModules("extensions", buildpack.KindExtension, config.Extensions, relativeBaseDir)
+
+	if len(config.Order) == 0 {
+		l.warnf(LintRuleOrder, "order", "empty %s definition", lintQuote("order"))
+	}
+	l.lintBuilderOrder("order", "buildpacks", config.Order, buildpacks)
+	l.lintBuilderOrder("order-extensions", "extensions", config.OrderExtensions, extensions)
+
+	for _, module := range buildpacks {
+		if module.descriptor != nil {
+			l.lintBuilderModuleSupport(module, config)
+		}
+	}
+}
+
+// lintBuilderModules returns the modules of the builder, reading the ID, version and targets of local ones from their
+// descriptors. The ID of the other modules is unknown when not set.
+func (l *linter) lintBuilderModules(key, kind string, modules pubbldr.ModuleCollection, relativeBaseDir string) []lintModule {
+	var result []lintModule
+	for i, module := range modules {
+		lm := lintModule{info: module.ModuleInfo}
+
+		if uri := module.URI; uri != "" && (!paths.IsURI(uri) || strings.HasPrefix(uri, "file://")) {
+			path := uri
+			if strings.HasPrefix(uri, "file://") {
+				path, _ = paths.URIToFilePath(uri)
+			} else if !filepath.IsAbs(path) {
+				path = filepath.Join(relativeBaseDir, path)
+			}
+
+			if _, err := os.Stat(path); err != nil {
+				l.errorf(LintRuleInvalid, fmt.Sprintf("%s[%d].uri", key, i), "%s not found", lintQuote(uri))
+			} else if isDir, _ := paths.IsDir(path); isDir {
+				info, descriptor, ok := readLintModuleDescriptor(kind, path)
+				if ok && lm.info.ID == "" {
+					lm.info = info
+				}
+				lm.descriptor = descriptor
+			}
+		}
+		result = append(result, lm)
+	}
+	return result
+}
+
+// readLintModuleDescriptor reads the info of the module of the kind in dir from its descriptor, along with the
+// descriptor itself for buildpacks. It returns false if the descriptor can't be read.
+func readLintModuleDescriptor(kind, dir string) (dist.ModuleInfo, *dist.BuildpackDescriptor, bool) {
+	if kind == buildpack.KindExtension {
+		var descriptor dist.ExtensionDescriptor
+		if _, err := toml.DecodeFile(filepath.Join(dir, "extension.toml"), &descriptor); err != nil {
+			return dist.ModuleInfo{}, nil, false
+		}
+		return descriptor.WithInfo, nil, true
+	}
+
+	var descriptor dist.BuildpackDescriptor
+	if _, err := toml.DecodeFile(filepath.Join(dir, "buildpack.toml"), &descriptor); err != nil {
+		return dist.ModuleInfo{}, nil, false
+	}
+	return descriptor.WithInfo, &descriptor, true
+}
+
+// lintBuilderOrder checks the order groups reference the modules of the builder. References are only errors when the
+// ID of every module is known.
+func (l *linter) lintBuilderOrder(orderKey, modulesKey string, order dist.Order, modules []lintModule) {
+	versions := map[string][]string{}
+	allKnown := true
+	for _, module := range modules {
+		if module.info.ID == "" {
+			allKnown = false
+			continue
+		}
+		versions[module.info.ID] = append(versions[module.info.ID], module.info.Version)
+	}
+
+	for i, group := range order {
+		for j, ref := range group.Group {
+			key := fmt.Sprintf("%s[%d].group[%d]", orderKey, i, j)
+			if ref.ID == "" {
+				l.errorf(LintRuleOrder, key, "modules of order groups must have an %s", lintQuote("id"))
+				continue
+			}
+
+			moduleVersions, ok := versions[ref.ID]
+			if !ok {
+				if allKnown {
+					l.errorf(LintRuleOrder, key, "%s is not declared in %s", lintQuote(ref.ID), lintQuote(modulesKey))
+				} else {
+					l.warnf(LintRuleOrder, key, "%s is not declared by id in %s", lintQuote(ref.ID), lintQuote(modulesKey))
+				}
+				continue
+			}
+
+			if ref.Version == "" {
+				continue
+			}
+			found := false
+			for _, version := range moduleVersions {
+				if version == "" || version == ref.Version {
+					found = true
+				}
+			}
+			if !found {
+				sort.Strings(moduleVersions)
+				l.errorf(LintRuleOrder, key, "%s is declared in %s with version %s", lintQuote(ref.FullName()),
+					lintQuote(modulesKey), strings.Join(moduleVersions, ", "))
+			}
+		}
+	}
+}
+
+// lintBuilderModuleSupport checks a local buildpack supports the stack and the targets of the builder.
+func (l *linter) lintBuilderModuleSupport(module lintModule, config pubbldr.Config) {
+	descriptor := module.descriptor
+	if config.Stack.ID != "" && len(descriptor.Stacks()) > 0 {
+		supported := false
+		for _, stack := range descriptor.Stacks() {
+			if stack.ID == config.Stack.ID || stack.ID == "*" {
+				supported = true
+			}
+		}
+		if !supported {
+			l.errorf(LintRuleTarget, "stack.id", "buildpack %s does not support stack %s",
+				lintQuote(module.info.FullName()), lintQuote(config.Stack.ID))
+		}
+	}
+
+	for _, t := range dist.ExpandTargetsDistributions(config.Targets...) {
+		var distroName, distroVersion string
+		if len(t.Distributions) > 0 {
+			distroName, distroVersion = t.Distributions[0].Name, t.Distributions[0].Version
+		}
+		if err := descriptor.EnsureTargetSupport(t.OS, t.Arch, distroName, distroVersion); err != nil {
+			l.errorf(LintRuleTarget, "targets", "buildpack %s does not support target %s",
+				lintQuote(module.info.FullName()), lintQuote(t.ValuesAsPlatform()))
+		}
+	}
+}
+
+func (l *linter) lintProject() {
+	descriptor, schemaVersion, unsupportedKeys, err := project.ParseProjectDescriptor(l.file)
+	if err != nil {
+		l.errorf(LintRuleInvalid, "", "%s", err)
+		return
+	}
+
+	switch schemaVersion {
+	case "":
+		l.warnf(LintRuleDeprecated, "_.schema-version", "no schema version declared, defaulting to schema version 0.1, prefer 0.2")
+	case "0.1":
+		l.warnf(LintRuleDeprecated, "_.schema-version", "schema version 0.1 is superseded by 0.2")
+	}
+
+	version := schemaVersion
+	if version == "" {
+		version = "0.1"
+	}
+	for _, key := range unsupportedKeys {
+		l.warnf(LintRuleUnknownKey, key, "key %s is not supported in schema version %s, and is ignored", lintQuote(key), version)
+	}
+
+	if err := project.Validate(descriptor); err != nil {
+		l.errorf(LintRuleInvalid, "", "%s", strings.TrimPrefix(err.Error(), "project.toml: "))
+	}
+
+	relativeBaseDir := filepath.Dir(l.file)
+	for i, bp := range descriptor.Build.Buildpacks {
+		if bp.URI == "" || (paths.IsURI(bp.URI) && !strings.HasPrefix(bp.URI, "file://")) {
+			continue
+		}
+		path := bp.URI
+		if strings.HasPrefix(path, "file://") {
+			path, _ = paths.URIToFilePath(path)
+		} else if !filepath.IsAbs(path) {
+			path = filepath.Join(relativeBaseDir, path)
+		}
+		if _, err := os.Stat(path); err != nil {
+			l.errorf(LintRuleInvalid, fmt.Sprintf("buildpacks[%d].uri", i), "buildpack %s not found", lintQuote(bp.URI))
+		}
+	}
+
+	for i, binding := range descriptor.Build.Bindings {
+		if binding.Path == "" {
+			continue
+		}
+		path := binding.Path
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(relativeBaseDir, path)
+		}
+		key := fmt.Sprintf("bindings[%d].path", i)
+		if _, err := os.Stat(path); err != nil {
+			l.errorf(LintRuleInvalid, key, "path %s of binding %s not found", lintQuote(binding.Path), lintQuote(binding.Name))
+		} else if isDir, _ := paths.IsDir(path); !isDir {
+			l.errorf(LintRuleInvalid, key, "path %s of binding %s is not a directory", lintQuote(binding.Path), lintQuote(binding.Name))
+		}
+	}
+}
+
+func apiStrings(versions api.List) []string {
+	var result []string
+	for _, version := range versions {
+		result = append(result, version.String())
+	}
+	return result
+}
+
+// lintQuote quotes values of issue messages, which are not colored as they are also written as JSON or YAML.
+func lintQuote(value string) string {
+	return "'" + value + "'"
+}
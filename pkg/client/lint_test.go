@@ -0,0 +1,469 @@
+package client_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/client"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestLint(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Lint", testLint, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testLint(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject *client.Client
+		tmpDir  string
+	)
+
+	it.Before(func() {
+		var err error
+		tmpDir = t.TempDir()
+
+		subject, err = client.NewClient()
+		h.AssertNil(t, err)
+	})
+
+	writeFile := func(path, contents string, mode os.FileMode) {
+		t.Helper()
+		h.AssertNil(t, os.MkdirAll(filepath.Dir(filepath.Join(tmpDir, path)), 0755))
+		h.AssertNil(t, os.WriteFile(filepath.Join(tmpDir, path), []byte(contents), mode))
+	}
+
+	writeBuildpack := func(dir, buildpackTOML string) {
+		t.Helper()
+		writeFile(filepath.Join(dir, "buildpack.toml"), buildpackTOML, 0644)
+		writeFile(filepath.Join(dir, "bin", "detect"), "#!/usr/bin/env bash", 0755)
+		writeFile(filepath.Join(dir, "bin", "build"), "#!/usr/bin/env bash", 0755)
+	}
+
+	lint := func(opts client.LintOptions) []client.LintIssue {
+		t.Helper()
+		result, err := subject.Lint(opts)
+		h.AssertNil(t, err)
+		return result.Issues
+	}
+
+	when("#Lint", func() {
+		when("buildpack.toml", func() {
+			it("passes for a valid buildpack", func() {
+				writeBuildpack("bp", `
+api = "0.10"
+
+[buildpack]
+id = "example/bp"
+version = "1.0.0"
+sbom-formats = ["application/vnd.cyclonedx+json"]
+
+[[targets]]
+os = "linux"
+arch = "amd64"
+
+[metadata]
+some-key = "some-value"
+`)
+
+				result, err := subject.Lint(client.LintOptions{Paths: []string{filepath.Join(tmpDir, "bp")}})
+				h.AssertNil(t, err)
+				h.AssertEq(t, result.Files, []string{filepath.Join(tmpDir, "bp", "buildpack.toml")})
+				h.AssertEq(t, len(result.Issues), 0)
+			})
+
+			it("reports unknown keys, unsupported APIs and deprecated stacks", func() {
+				writeBuildpack("bp", `
+api = "0.4"
+unknown = true
+
+[buildpack]
+id = "example/bp"
+version = "1.0.0"
+homepag = "https://example.com"
+
+[[stacks]]
+id = "io.buildpacks.stacks.jammy"
+
+[[targets]]
+os = "linux"
+arch = "amd64"
+[[targets.distros]]
+name = "ubuntu"
+version = "20.04"
+`)
+				buildpackTOML := filepath.Join(tmpDir, "bp", "buildpack.toml")
+
+				issues := lint(client.LintOptions{Paths: []string{buildpackTOML}})
+				h.AssertEq(t, issues, []client.LintIssue{
+					{File: buildpackTOML, Severity: client.LintSeverityError, Rule: client.LintRuleUnknownKey, Key: "unknown", Message: "unknown key 'unknown'"},
+					{File: buildpackTOML, Severity: client.LintSeverityError, Rule: client.LintRuleUnknownKey, Key: "buildpack.homepag", Message: "unknown key 'buildpack.homepag'"},
+					{File: buildpackTOML, Severity: client.LintSeverityWarning, Rule: client.LintRuleAPI, Key: "api", Message: "Buildpack API '0.4' is not supported by the lifecycle, which supports 0.7, 0.8, 0.9, 0.10, 0.11"},
+					{File: buildpackTOML, Severity: client.LintSeverityWarning, Rule: client.LintRuleDeprecated, Key: "stacks", Message: "'stacks' are deprecated, prefer 'targets'"},
+					{File: buildpackTOML, Severity: client.LintSeverityWarning, Rule: client.LintRuleTarget, Key: "targets[0]", Message: "stack 'io.buildpacks.stacks.jammy' runs ubuntu 22.04, which target 'linux/amd64/ubuntu@20.04' doesn't list"},
+				})
+			})
+
+			it("reports unknown targets", func() {
+				writeBuildpack("bp", `
+api = "0.10"
+
+[buildpack]
+id = "example/bp"
+version = "1.0.0"
+
+[[targets]]
+os = "darwin"
+arch = "arm64"
+
+[[targets]]
+os = "linux"
+arch = "amd46"
+`)
+
+				issues := lint(client.LintOptions{Paths: []string{filepath.Join(tmpDir, "bp")}})
+				h.AssertEq(t, len(issues), 2)
+				h.AssertEq(t, issues[0].Message, "target os 'darwin' must be 'linux' or 'windows'")
+				h.AssertEq(t, issues[1].Message, "unknown target 'linux/amd46'")
+			})
+
+			it("reports missing and non-executable bin scripts", func() {
+				writeFile("bp/buildpack.toml", `
+api = "0.10"
+
+[buildpack]
+id = "example/bp"
+version = "1.0.0"
+
+[[targets]]
+os = "linux"
+
+[[targets]]
+os = "windows"
+`, 0644)
+				writeFile("bp/bin/build", "#!/usr/bin/env bash", 0644)
+				writeFile("bp/bin/build.bat", "", 0644)
+
+				var messages []string
+				for _, issue := range lint(client.LintOptions{Paths: []string{filepath.Join(tmpDir, "bp")}}) {
+					h.AssertEq(t, issue.Rule, client.LintRuleBinScript)
+					messages = append(messages, issue.Message)
+				}
+				expected := []string{
+					"'bin/detect.bat' or 'bin/detect.exe' is required to run on windows",
+					"'bin/detect' is missing",
+				}
+				if runtime.GOOS != "windows" {
+					expected = append(expected, "'bin/build' is not executable")
+				}
+				h.AssertEq(t, messages, expected)
+			})
+
+			it("doesn't require bin scripts for meta-buildpacks", func() {
+				writeFile("bp/buildpack.toml", `
+api = "0.10"
+
+[buildpack]
+id = "example/meta"
+version = "1.0.0"
+
+[[order]]
+[[order.group]]
+id = "example/bp"
+version = "1.0.0"
+`, 0644)
+
+				h.AssertEq(t, len(lint(client.LintOptions{Paths: []string{filepath.Join(tmpDir, "bp")}})), 0)
+			})
+
+			it("reports invalid toml", func() {
+				writeFile("bp/buildpack.toml", `api = `, 0644)
+
+				issues := lint(client.LintOptions{Paths: []string{filepath.Join(tmpDir, "bp")}})
+				h.AssertEq(t, len(issues), 1)
+				h.AssertEq(t, issues[0].Rule, client.LintRuleInvalid)
+				h.AssertContains(t, issues[0].Message, "decoding toml contents")
+			})
+		})
+
+		when("builder.toml", func() {
+			var builderTOML string
+
+			it.Before(func() {
+				builderTOML = filepath.Join(tmpDir, "builder.toml")
+				writeBuildpack("bp", `
+api = "0.10"
+
+[buildpack]
+id = "example/bp"
+version = "1.0.0"
+
+[[targets]]
+os = "linux"
+arch = "amd64"
+`)
+			})
+
+			it("passes for a valid builder", func() {
+				writeFile("builder.toml", `
+[[buildpacks]]
+uri = "./bp"
+
+[[order]]
+[[order.group]]
+id = "example/bp"
+version = "1.0.0"
+
+[build]
+image = "some/build"
+
+[[run.images]]
+image = "some/run"
+
+[[targets]]
+os = "linux"
+arch = "amd64"
+`, 0644)
+
+				h.AssertEq(t, len(lint(client.LintOptions{Paths: []string{tmpDir}, Kind: client.LintKindBuilder})), 0)
+			})
+
+			it("reports order groups referencing buildpacks not declared", func() {
+				writeFile("builder.toml", `
+[[buildpacks]]
+uri = "./bp"
+
+[[buildpacks]]
+id = "example/other"
+uri = "docker://example/other:1.0.0"
+
+[[order]]
+[[order.group]]
+id = "example/bp"
+version = "2.0.0"
+
+[[order.group]]
+id = "example/missing"
+
+[build]
+image = "some/build"
+
+[[run.images]]
+image = "some/run"
+`, 0644)
+
+				h.AssertEq(t, lint(client.LintOptions{Paths: []string{builderTOML}}), []client.LintIssue{
+					{File: builderTOML, Severity: client.LintSeverityError, Rule: client.LintRuleOrder, Key: "order[0].group[0]", Message: "'example/bp@2.0.0' is declared in 'buildpacks' with version 1.0.0"},
+					{File: builderTOML, Severity: client.LintSeverityError, Rule: client.LintRuleOrder, Key: "order[0].group[1]", Message: "'example/missing' is not declared in 'buildpacks'"},
+				})
+			})
+
+			it("reads the id and version of local extensions from their descriptor", func() {
+				writeFile("ext/extension.toml", `
+api = "0.10"
+
+[extension]
+id = "example/ext"
+version = "1.0.0"
+`, 0644)
+				writeFile("builder.toml", `
+[[buildpacks]]
+uri = "./bp"
+
+[[extensions]]
+uri = "./ext"
+
+[[order]]
+[[order.group]]
+id = "example/bp"
+
+[[order-extensions]]
+[[order-extensions.group]]
+id = "example/ext"
+version = "2.0.0"
+
+[build]
+image = "some/build"
+
+[[run.images]]
+image = "some/run"
+`, 0644)
+
+				h.AssertEq(t, lint(client.LintOptions{Paths: []string{builderTOML}}), []client.LintIssue{
+					{File: builderTOML, Severity: client.LintSeverityError, Rule: client.LintRuleOrder, Key: "order-extensions[0].group[0]", Message: "'example/ext@2.0.0' is declared in 'extensions' with version 1.0.0"},
+				})
+			})
+
+			it("only warns about references when buildpacks have unknown ids", func() {
+				writeFile("builder.toml", `
+[[buildpacks]]
+uri = "docker://example/some-buildpack"
+
+[[order]]
+[[order.group]]
+id = "example/missing"
+
+[build]
+image = "some/build"
+
+[[run.images]]
+image = "some/run"
+`, 0644)
+
+				issues := lint(client.LintOptions{Paths: []string{builderTOML}})
+				h.AssertEq(t, len(issues), 1)
+				h.AssertEq(t, issues[0].Severity, client.LintSeverityWarning)
+				h.AssertEq(t, issues[0].Message, "'example/missing' is not declared by id in 'buildpacks'")
+			})
+
+			it("reports unknown keys, deprecated stacks and target mismatches", func() {
+				writeFile("builder.toml", `
+unknown = true
+
+[[buildpacks]]
+uri = "./bp"
+
+[[order]]
+[[order.group]]
+id = "example/bp"
+
+[stack]
+id = "io.buildpacks.stacks.jammy"
+build-image = "some/build"
+run-image = "some/run"
+
+[[targets]]
+os = "linux"
+arch = "arm64"
+`, 0644)
+
+				h.AssertEq(t, lint(client.LintOptions{Paths: []string{builderTOML}}), []client.LintIssue{
+					{File: builderTOML, Severity: client.LintSeverityError, Rule: client.LintRuleUnknownKey, Key: "unknown", Message: "unknown key 'unknown'"},
+					{File: builderTOML, Severity: client.LintSeverityWarning, Rule: client.LintRuleDeprecated, Key: "stack", Message: "'stack' is deprecated, prefer 'build.image' and 'run.images'"},
+					{File: builderTOML, Severity: client.LintSeverityError, Rule: client.LintRuleTarget, Key: "targets", Message: "buildpack 'example/bp@1.0.0' does not support target 'linux/arm64'"},
+				})
+			})
+
+			it("reports invalid builders", func() {
+				writeFile("builder.toml", `
+[[buildpacks]]
+uri = "./missing"
+
+[[order]]
+[[order.group]]
+id = "example/bp"
+
+[lifecycle]
+version = "0.20.0"
+uri = "https://example.com/lifecycle.tgz"
+`, 0644)
+
+				h.AssertEq(t, lint(client.LintOptions{Paths: []string{builderTOML}}), []client.LintIssue{
+					{File: builderTOML, Severity: client.LintSeverityError, Rule: client.LintRuleInvalid, Message: "build.image is required"},
+					{File: builderTOML, Severity: client.LintSeverityError, Rule: client.LintRuleInvalid, Key: "lifecycle", Message: "'lifecycle' can only declare 'version' or 'uri', not both"},
+					{File: builderTOML, Severity: client.LintSeverityError, Rule: client.LintRuleInvalid, Key: "buildpacks[0].uri", Message: "'./missing' not found"},
+					{File: builderTOML, Severity: client.LintSeverityWarning, Rule: client.LintRuleOrder, Key: "order[0].group[0]", Message: "'example/bp' is not declared by id in 'buildpacks'"},
+				})
+			})
+		})
+
+		when("project.toml", func() {
+			var projectTOML string
+
+			it.Before(func() {
+				projectTOML = filepath.Join(tmpDir, "project.toml")
+			})
+
+			it("passes for a valid project", func() {
+				writeFile("bp/buildpack.toml", "", 0644)
+				writeFile("project.toml", `
+[_]
+schema-version = "0.2"
+name = "example-app"
+
+[[io.buildpacks.group]]
+uri = "./bp"
+
+[_.metadata]
+some-key = "some-value"
+`, 0644)
+
+				h.AssertEq(t, len(lint(client.LintOptions{Paths: []string{projectTOML}})), 0)
+			})
+
+			it("reports keys not supported by the schema and missing buildpacks", func() {
+				writeFile("project.toml", `
+[_]
+schema-version = "0.2"
+unknown = true
+
+[[io.buildpacks.group]]
+uri = "./missing"
+`, 0644)
+
+				h.AssertEq(t, lint(client.LintOptions{Paths: []string{projectTOML}}), []client.LintIssue{
+					{File: projectTOML, Severity: client.LintSeverityWarning, Rule: client.LintRuleUnknownKey, Key: "_.unknown", Message: "key '_.unknown' is not supported in schema version 0.2, and is ignored"},
+					{File: projectTOML, Severity: client.LintSeverityError, Rule: client.LintRuleInvalid, Key: "buildpacks[0].uri", Message: "buildpack './missing' not found"},
+				})
+			})
+
+			it("reports missing binding paths", func() {
+				writeFile("bindings/db/password", "some-password", 0644)
+				writeFile("bindings/file", "", 0644)
+				writeFile("project.toml", `
+[_]
+schema-version = "0.2"
+
+[[io.buildpacks.bindings]]
+name = "db"
+path = "bindings/db"
+
+[[io.buildpacks.bindings]]
+name = "missing"
+path = "bindings/missing"
+
+[[io.buildpacks.bindings]]
+name = "file"
+path = "bindings/file"
+`, 0644)
+
+				h.AssertEq(t, lint(client.LintOptions{Paths: []string{projectTOML}}), []client.LintIssue{
+					{File: projectTOML, Severity: client.LintSeverityError, Rule: client.LintRuleInvalid, Key: "bindings[1].path", Message: "path 'bindings/missing' of binding 'missing' not found"},
+					{File: projectTOML, Severity: client.LintSeverityError, Rule: client.LintRuleInvalid, Key: "bindings[2].path", Message: "path 'bindings/file' of binding 'file' is not a directory"},
+				})
+			})
+
+			it("reports deprecated schema versions and invalid projects", func() {
+				writeFile("project.toml", `
+[build]
+include = ["a"]
+exclude = ["b"]
+`, 0644)
+
+				h.AssertEq(t, lint(client.LintOptions{Paths: []string{projectTOML}}), []client.LintIssue{
+					{File: projectTOML, Severity: client.LintSeverityWarning, Rule: client.LintRuleDeprecated, Key: "_.schema-version", Message: "no schema version declared, defaulting to schema version 0.1, prefer 0.2"},
+					{File: projectTOML, Severity: client.LintSeverityError, Rule: client.LintRuleInvalid, Message: "cannot have both include and exclude defined"},
+				})
+			})
+		})
+
+		it("errors when the kind of a file can't be inferred", func() {
+			writeFile("some.toml", "", 0644)
+
+			_, err := subject.Lint(client.LintOptions{Paths: []string{filepath.Join(tmpDir, "some.toml")}})
+			h.AssertError(t, err, "cannot infer the kind of")
+		})
+
+		it("errors when a directory has nothing to lint", func() {
+			_, err := subject.Lint(client.LintOptions{Paths: []string{tmpDir}})
+			h.AssertError(t, err, "no buildpack.toml, builder.toml or project.toml found in")
+		})
+	})
+}
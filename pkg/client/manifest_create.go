@@ -29,21 +29,27 @@ type CreateManifestOptions struct {
 
 // CreateManifest implements commands.PackClient.
 func (c *Client) CreateManifest(ctx context.Context, opts CreateManifestOptions) (err error) {
+	_, err = c.createManifest(ctx, opts)
+	return err
+}
+
+// createManifest creates the image index, returning its digest when it is published.
+func (c *Client) createManifest(ctx context.Context, opts CreateManifestOptions) (string, error) {
 	ops := parseOptsToIndexOptions(opts)
 
 	if c.indexFactory.Exists(opts.IndexRepoName) {
-		return fmt.Errorf("manifest list '%s' already exists in local storage; use 'pack manifest remove' to "+
+		return "", fmt.Errorf("manifest list '%s' already exists in local storage; use 'pack manifest remove' to "+
 			"remove it before creating a new manifest list with the same name", style.Symbol(opts.IndexRepoName))
 	}
 
 	index, err := c.indexFactory.CreateIndex(opts.IndexRepoName, ops...)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	for _, repoName := range opts.RepoNames {
 		if err = c.addManifestToIndex(ctx, repoName, index); err != nil {
-			return err
+			return "", err
 		}
 	}
 
@@ -51,19 +57,19 @@ func (c *Client) CreateManifest(ctx context.Context, opts CreateManifestOptions)
 		// push to a registry without saving a local copy
 		ops = append(ops, imgutil.WithPurge(true))
 		if err = index.Push(ops...); err != nil {
-			return err
+			return "", err
 		}
 
 		c.logger.Infof("Successfully pushed manifest list %s to registry", style.Symbol(opts.IndexRepoName))
-		return nil
+		return pushedIndexDigest(index)
 	}
 
 	if err = index.SaveDir(); err != nil {
-		return fmt.Errorf("manifest list %s could not be saved to local storage: %w", style.Symbol(opts.IndexRepoName), err)
+		return "", fmt.Errorf("manifest list %s could not be saved to local storage: %w", style.Symbol(opts.IndexRepoName), err)
 	}
 
 	c.logger.Infof("Successfully created manifest list %s", style.Symbol(opts.IndexRepoName))
-	return nil
+	return "", nil
 }
 
 func parseOptsToIndexOptions(opts CreateManifestOptions) (idxOpts []imgutil.IndexOption) {
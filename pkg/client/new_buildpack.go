@@ -27,6 +27,87 @@ exit 0
 	bashBinDetect = `#!/usr/bin/env bash
 
 exit 0
+`
+
+	execDBinBuild = `#!/usr/bin/env bash
+
+set -euo pipefail
+
+layers_dir="$1"
+hello_layer="${layers_dir}/hello"
+
+# The launch layer is added to the app image, along with its exec.d and profile.d scripts
+mkdir -p "${hello_layer}/exec.d" "${hello_layer}/profile.d"
+cat > "${hello_layer}.toml" <<TOML
+[types]
+launch = true
+TOML
+
+# exec.d executables run before the app process, and write the environment variables to set as TOML to fd 3
+cat > "${hello_layer}/exec.d/hello" <<'SCRIPT'
+#!/usr/bin/env bash
+echo 'HELLO_FROM_EXEC_D = "true"' >&3
+SCRIPT
+chmod +x "${hello_layer}/exec.d/hello"
+
+# profile.d scripts are sourced by the launcher when the app process runs in a shell
+cat > "${hello_layer}/profile.d/hello.sh" <<'SCRIPT'
+export HELLO_FROM_PROFILE_D=true
+SCRIPT
+
+exit 0
+`
+
+	goModTemplate = `module {{ .ID }}
+
+go 1.22
+
+require github.com/buildpacks/libcnb/v2 v2.0.0
+`
+	goMainTemplate = `package main
+
+import (
+	"github.com/buildpacks/libcnb/v2"
+
+	"{{ .ID }}/{{ .GoPackage }}"
+)
+
+func main() {
+	libcnb.BuildpackMain({{ .GoPackage }}.Detect, {{ .GoPackage }}.Build)
+}
+`
+	goDetectTemplate = `package {{ .GoPackage }}
+
+import (
+	"github.com/buildpacks/libcnb/v2"
+)
+
+// Detect passes for every app.
+func Detect(context libcnb.DetectContext) (libcnb.DetectResult, error) {
+	return libcnb.DetectResult{Pass: true}, nil
+}
+`
+	goBuildTemplate = `package {{ .GoPackage }}
+
+import (
+	"github.com/buildpacks/libcnb/v2"
+)
+
+// Build contributes nothing to the app image yet.
+func Build(context libcnb.BuildContext) (libcnb.BuildResult, error) {
+	return libcnb.NewBuildResult(), nil
+}
+`
+	goBuildScriptTemplate = `#!/usr/bin/env bash
+
+# Builds bin/main, which runs as bin/detect and bin/build, for linux
+set -euo pipefail
+
+cd "$(dirname "$0")/.."
+
+GOOS=linux CGO_ENABLED=0 go build -mod=mod -ldflags="-s -w" -o bin/main ./cmd/main
+ln -sf main bin/detect
+ln -sf main bin/build
 `
 )
 
@@ -48,51 +129,49 @@ type NewBuildpackOptions struct {
 
 	// the targets this buildpack will work with
 	Targets []dist.Target
+
+	// Template generating the files of the buildpack: one of the built-in templates (bash, go or exec.d), the path of
+	// a directory or the URL of a git repository. Files of directory and git templates ending in .tmpl are rendered
+	// as Go templates, given the ID, Version, API, Name and GoPackage of the buildpack. Defaults to bash.
+	Template string
+
+	// Generate a package.toml packaging the buildpack for its targets.
+	Package bool
 }
 
-func (c *Client) NewBuildpack(ctx context.Context, opts NewBuildpackOptions) error {
-	err := createBuildpackTOML(opts.Path, opts.ID, opts.Version, opts.API, opts.Stacks, opts.Targets, c)
-	if err != nil {
-		return err
-	}
-	return createBashBuildpack(opts.Path, c)
+// Built-in templates of new buildpacks
+var buildpackTemplates = map[string][]scaffoldFile{
+	"bash": {
+		{path: "bin/build", contents: bashBinBuild, executable: true},
+		{path: "bin/detect", contents: bashBinDetect, executable: true},
+	},
+	"go": {
+		{path: "go.mod", contents: goModTemplate},
+		{path: "cmd/main/main.go", contents: goMainTemplate},
+		{path: "{{ .GoPackage }}/detect.go", contents: goDetectTemplate},
+		{path: "{{ .GoPackage }}/build.go", contents: goBuildTemplate},
+		{path: "scripts/build.sh", contents: goBuildScriptTemplate, executable: true},
+		{path: ".gitignore", contents: "/bin/\n"},
+	},
+	"exec.d": {
+		{path: "bin/build", contents: execDBinBuild, executable: true},
+		{path: "bin/detect", contents: bashBinDetect, executable: true},
+	},
 }
 
-func createBashBuildpack(path string, c *Client) error {
-	if err := createBinScript(path, "build", bashBinBuild, c); err != nil {
+func (c *Client) NewBuildpack(ctx context.Context, opts NewBuildpackOptions) error {
+	data := newScaffoldData(opts.ID, opts.Version, opts.API)
+	if err := c.writeScaffold(ctx, opts.Path, opts.Template, buildpackTemplates, data); err != nil {
 		return err
 	}
 
-	if err := createBinScript(path, "detect", bashBinDetect, c); err != nil {
+	err := createBuildpackTOML(opts.Path, opts.ID, opts.Version, opts.API, opts.Stacks, opts.Targets, c)
+	if err != nil {
 		return err
 	}
 
-	return nil
-}
-
-func createBinScript(path, name, contents string, c *Client) error {
-	binDir := filepath.Join(path, "bin")
-	binFile := filepath.Join(binDir, name)
-
-	_, err := os.Stat(binFile)
-	if os.IsNotExist(err) {
-		// The following line's comment is for gosec, it will ignore rule 301 in this case
-		// G301: Expect directory permissions to be 0750 or less
-		/* #nosec G301 */
-		if err := os.MkdirAll(binDir, 0755); err != nil {
-			return err
-		}
-		// The following line's comment is for gosec, it will ignore rule 306 in this case
-		// G306: Expect WriteFile permissions to be 0600 or less
-		/* #nosec G306 */
-		err = os.WriteFile(binFile, []byte(contents), 0755)
-		if err != nil {
-			return err
-		}
-
-		if c != nil {
-			c.logger.Infof("    %s  bin/%s", style.Symbol("create"), name)
-		}
+	if opts.Package {
+		return c.createPackageTOML(opts.Path, packageTOML{Buildpack: &dist.BuildpackURI{URI: "."}, Targets: opts.Targets})
 	}
 	return nil
 }
@@ -138,3 +217,30 @@ func createBuildpackTOML(path, id, version, apiStr string, stacks []dist.Stack,
 
 	return nil
 }
+
+func createBinScript(path, name, contents string, c *Client) error {
+	binDir := filepath.Join(path, "bin")
+	binFile := filepath.Join(binDir, name)
+
+	_, err := os.Stat(binFile)
+	if os.IsNotExist(err) {
+		// The following line's comment is for gosec, it will ignore rule 301 in this case
+		// G301: Expect directory permissions to be 0750 or less
+		/* #nosec G301 */
+		if err := os.MkdirAll(binDir, 0755); err != nil {
+			return err
+		}
+		// The following line's comment is for gosec, it will ignore rule 306 in this case
+		// G306: Expect WriteFile permissions to be 0600 or less
+		/* #nosec G306 */
+		err = os.WriteFile(binFile, []byte(contents), 0755)
+		if err != nil {
+			return err
+		}
+
+		if c != nil {
+			c.logger.Infof("    %s  bin/%s", style.Symbol("create"), name)
+		}
+	}
+	return nil
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/sclevine/spec"
 	"github.com/sclevine/spec/report"
 
+	"github.com/buildpacks/pack/buildpackage"
 	"github.com/buildpacks/pack/pkg/client"
 	"github.com/buildpacks/pack/pkg/dist"
 	h "github.com/buildpacks/pack/testhelpers"
@@ -74,6 +75,111 @@ func testNewBuildpack(t *testing.T, when spec.G, it spec.S) {
 			assertBuildpackToml(t, tmpDir, "example/my-cnb")
 		})
 
+		it("should create the files of the go template", func() {
+			err := subject.NewBuildpack(context.TODO(), client.NewBuildpackOptions{
+				API:      "0.10",
+				Path:     tmpDir,
+				ID:       "example/my-cnb",
+				Version:  "0.0.0",
+				Template: "go",
+			})
+			h.AssertNil(t, err)
+
+			content, err := os.ReadFile(filepath.Join(tmpDir, "cmd", "main", "main.go"))
+			h.AssertNil(t, err)
+			h.AssertContains(t, string(content), `"example/my-cnb/mycnb"`)
+			h.AssertContains(t, string(content), "libcnb.BuildpackMain(mycnb.Detect, mycnb.Build)")
+
+			for _, file := range []string{"go.mod", "mycnb/detect.go", "mycnb/build.go", "scripts/build.sh"} {
+				_, err = os.Stat(filepath.Join(tmpDir, file))
+				h.AssertNil(t, err)
+			}
+			assertBuildpackToml(t, tmpDir, "example/my-cnb")
+		})
+
+		it("should create the build script of the exec.d template", func() {
+			err := subject.NewBuildpack(context.TODO(), client.NewBuildpackOptions{
+				API:      "0.10",
+				Path:     tmpDir,
+				ID:       "example/my-cnb",
+				Version:  "0.0.0",
+				Template: "exec.d",
+			})
+			h.AssertNil(t, err)
+
+			content, err := os.ReadFile(filepath.Join(tmpDir, "bin", "build"))
+			h.AssertNil(t, err)
+			h.AssertContains(t, string(content), "exec.d/hello")
+			h.AssertContains(t, string(content), "profile.d/hello.sh")
+		})
+
+		it("should render the files of a directory template", func() {
+			templateDir := filepath.Join(tmpDir, "template")
+			h.AssertNil(t, os.MkdirAll(filepath.Join(templateDir, "bin"), 0755))
+			h.AssertNil(t, os.WriteFile(filepath.Join(templateDir, "bin", "detect"), []byte("{{ .ID }}"), 0755))
+			h.AssertNil(t, os.WriteFile(filepath.Join(templateDir, "README.md.tmpl"), []byte("# {{ .Name }} {{ .Version }}"), 0644))
+			buildpackDir := filepath.Join(tmpDir, "buildpack")
+
+			err := subject.NewBuildpack(context.TODO(), client.NewBuildpackOptions{
+				API:      "0.10",
+				Path:     buildpackDir,
+				ID:       "example/my-cnb",
+				Version:  "0.0.0",
+				Template: templateDir,
+			})
+			h.AssertNil(t, err)
+
+			content, err := os.ReadFile(filepath.Join(buildpackDir, "bin", "detect"))
+			h.AssertNil(t, err)
+			h.AssertEq(t, string(content), "{{ .ID }}")
+
+			content, err = os.ReadFile(filepath.Join(buildpackDir, "README.md"))
+			h.AssertNil(t, err)
+			h.AssertEq(t, string(content), "# my-cnb 0.0.0")
+			assertBuildpackToml(t, buildpackDir, "example/my-cnb")
+		})
+
+		it("should create a package.toml", func() {
+			err := subject.NewBuildpack(context.TODO(), client.NewBuildpackOptions{
+				API:     "0.10",
+				Path:    tmpDir,
+				ID:      "example/my-cnb",
+				Version: "0.0.0",
+				Targets: []dist.Target{{OS: "linux", Arch: "amd64"}},
+				Package: true,
+			})
+			h.AssertNil(t, err)
+
+			config, err := buildpackage.NewConfigReader().Read(filepath.Join(tmpDir, "package.toml"))
+			h.AssertNil(t, err)
+			h.AssertEq(t, config.Buildpack.URI, ".")
+			h.AssertEq(t, config.Targets, []dist.Target{{OS: "linux", Arch: "amd64"}})
+		})
+
+		it("should error with an unknown template", func() {
+			err := subject.NewBuildpack(context.TODO(), client.NewBuildpackOptions{
+				API:      "0.10",
+				Path:     tmpDir,
+				ID:       "example/my-cnb",
+				Version:  "0.0.0",
+				Template: "ruby",
+			})
+			h.AssertError(t, err, "template 'ruby' must be a directory, a git repository URL or one of 'bash', 'exec.d', 'go'")
+		})
+
+		it("should error with a git template when offline", func() {
+			subject.SetOffline(true)
+
+			err := subject.NewBuildpack(context.TODO(), client.NewBuildpackOptions{
+				API:      "0.10",
+				Path:     tmpDir,
+				ID:       "example/my-cnb",
+				Version:  "0.0.0",
+				Template: "https://example.com/some-template.git",
+			})
+			h.AssertError(t, err, "template 'https://example.com/some-template.git' cannot be cloned in offline mode")
+		})
+
 		when("files exist", func() {
 			it.Before(func() {
 				var err error
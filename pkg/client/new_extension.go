@@ -0,0 +1,111 @@
+package client
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/BurntSushi/toml"
+	"github.com/buildpacks/lifecycle/api"
+
+	"github.com/buildpacks/pack/pkg/dist"
+)
+
+var (
+	bashExtensionBinDetect = `#!/usr/bin/env bash
+
+exit 0
+`
+	bashExtensionBinGenerate = `#!/usr/bin/env bash
+
+set -euo pipefail
+
+# Dockerfiles written to the output directory extend the build image (build.Dockerfile) or the run image (run.Dockerfile)
+output_dir="${CNB_OUTPUT_DIR}"
+
+cat > "${output_dir}/build.Dockerfile" <<'DOCKERFILE'
+ARG base_image
+FROM ${base_image}
+
+USER root
+RUN echo "extending the build image"
+
+ARG user_id
+USER ${user_id}
+DOCKERFILE
+
+# To switch the run image, uncomment:
+# echo "FROM some-registry/some-run-image" > "${output_dir}/run.Dockerfile"
+
+exit 0
+`
+)
+
+type NewExtensionOptions struct {
+	// api compat version of the output extension artifact.
+	API string
+
+	// The base directory to generate assets
+	Path string
+
+	// The ID of the output extension artifact.
+	ID string
+
+	// version of the output extension artifact.
+	Version string
+
+	// the targets of the package.toml of the extension
+	Targets []dist.Target
+
+	// Template generating the files of the extension: the built-in bash template, the path of a directory or the URL
+	// of a git repository, rendered as for NewBuildpackOptions. Defaults to bash.
+	Template string
+
+	// Generate a package.toml packaging the extension for its targets.
+	Package bool
+}
+
+// Built-in templates of new extensions
+var extensionTemplates = map[string][]scaffoldFile{
+	"bash": {
+		{path: "bin/detect", contents: bashExtensionBinDetect, executable: true},
+		{path: "bin/generate", contents: bashExtensionBinGenerate, executable: true},
+	},
+}
+
+// NewExtension generates the scaffolding of an extension, whose bin/generate outputs Dockerfiles.
+func (c *Client) NewExtension(ctx context.Context, opts NewExtensionOptions) error {
+	data := newScaffoldData(opts.ID, opts.Version, opts.API)
+	if err := c.writeScaffold(ctx, opts.Path, opts.Template, extensionTemplates, data); err != nil {
+		return err
+	}
+
+	if err := c.createExtensionTOML(opts.Path, opts.ID, opts.Version, opts.API); err != nil {
+		return err
+	}
+
+	if opts.Package {
+		return c.createPackageTOML(opts.Path, packageTOML{Extension: &dist.BuildpackURI{URI: "."}, Targets: opts.Targets})
+	}
+	return nil
+}
+
+func (c *Client) createExtensionTOML(path, id, version, apiStr string) error {
+	api, err := api.NewVersion(apiStr)
+	if err != nil {
+		return err
+	}
+
+	extensionTOML := dist.ExtensionDescriptor{
+		WithAPI: api,
+		WithInfo: dist.ModuleInfo{
+			ID:      id,
+			Version: version,
+		},
+	}
+
+	var contents bytes.Buffer
+	if err := toml.NewEncoder(&contents).Encode(extensionTOML); err != nil {
+		return err
+	}
+	return c.writeScaffoldFile(path, "extension.toml", contents.Bytes(), 0644)
+}
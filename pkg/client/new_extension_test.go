@@ -0,0 +1,108 @@
+package client_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/pelletier/go-toml"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/buildpackage"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/dist"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestNewExtension(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "NewExtension", testNewExtension, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testNewExtension(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject *client.Client
+		tmpDir  string
+	)
+
+	it.Before(func() {
+		var err error
+		tmpDir = t.TempDir()
+
+		subject, err = client.NewClient()
+		h.AssertNil(t, err)
+	})
+
+	when("#NewExtension", func() {
+		it("should create bash scripts generating a Dockerfile", func() {
+			err := subject.NewExtension(context.TODO(), client.NewExtensionOptions{
+				API:     "0.10",
+				Path:    tmpDir,
+				ID:      "example/my-extension",
+				Version: "0.0.0",
+			})
+			h.AssertNil(t, err)
+
+			for _, script := range []string{"detect", "generate"} {
+				info, err := os.Stat(filepath.Join(tmpDir, "bin", script))
+				h.AssertNil(t, err)
+				if runtime.GOOS != "windows" {
+					h.AssertTrue(t, info.Mode()&0100 != 0)
+				}
+			}
+			content, err := os.ReadFile(filepath.Join(tmpDir, "bin", "generate"))
+			h.AssertNil(t, err)
+			h.AssertContains(t, string(content), "build.Dockerfile")
+
+			f, err := os.Open(filepath.Join(tmpDir, "extension.toml"))
+			h.AssertNil(t, err)
+			defer f.Close()
+			var extensionDescriptor dist.ExtensionDescriptor
+			h.AssertNil(t, toml.NewDecoder(f).Decode(&extensionDescriptor))
+			h.AssertEq(t, extensionDescriptor.Info().ID, "example/my-extension")
+			h.AssertEq(t, extensionDescriptor.API().String(), "0.10")
+		})
+
+		it("should create a package.toml", func() {
+			err := subject.NewExtension(context.TODO(), client.NewExtensionOptions{
+				API:     "0.10",
+				Path:    tmpDir,
+				ID:      "example/my-extension",
+				Version: "0.0.0",
+				Targets: []dist.Target{{OS: "linux", Arch: "amd64"}},
+				Package: true,
+			})
+			h.AssertNil(t, err)
+
+			config, err := buildpackage.NewConfigReader().Read(filepath.Join(tmpDir, "package.toml"))
+			h.AssertNil(t, err)
+			h.AssertEq(t, config.Extension.URI, ".")
+			h.AssertEq(t, config.Targets, []dist.Target{{OS: "linux", Arch: "amd64"}})
+		})
+
+		it("should not clobber files that exist", func() {
+			h.AssertNil(t, os.MkdirAll(filepath.Join(tmpDir, "bin"), 0755))
+			h.AssertNil(t, os.WriteFile(filepath.Join(tmpDir, "bin", "generate"), []byte("expected value"), 0755))
+			h.AssertNil(t, os.WriteFile(filepath.Join(tmpDir, "extension.toml"), []byte("expected value"), 0644))
+
+			err := subject.NewExtension(context.TODO(), client.NewExtensionOptions{
+				API:     "0.10",
+				Path:    tmpDir,
+				ID:      "example/my-extension",
+				Version: "0.0.0",
+			})
+			h.AssertNil(t, err)
+
+			for _, file := range []string{"bin/generate", "extension.toml"} {
+				content, err := os.ReadFile(filepath.Join(tmpDir, file))
+				h.AssertNil(t, err)
+				h.AssertEq(t, string(content), "expected value")
+			}
+		})
+	})
+}
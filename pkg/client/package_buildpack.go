@@ -15,6 +15,7 @@ import (
 	"github.com/buildpacks/pack/pkg/buildpack"
 	"github.com/buildpacks/pack/pkg/dist"
 	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/signing"
 )
 
 const (
@@ -65,6 +66,10 @@ type PackageBuildpackOptions struct {
 
 	// Target platforms to build packages for
 	Targets []dist.Target
+
+	// Option only valid if Publish is true and Format is FormatImage
+	// Sign the published package, or the image index of a multi-platform package, with this signer.
+	Signer *signing.Signer
 }
 
 // PackageBuildpack packages buildpack(s) into either an image or file.
@@ -72,6 +77,9 @@ func (c *Client) PackageBuildpack(ctx context.Context, opts PackageBuildpackOpti
 	if opts.Format == "" {
 		opts.Format = FormatImage
 	}
+	if opts.Signer != nil && (!opts.Publish || opts.Format != FormatImage) {
+		return errors.New("only published buildpackages can be signed")
+	}
 
 	targets, err := c.processPackageBuildpackTargets(ctx, opts)
 	if err != nil {
@@ -89,13 +97,31 @@ func (c *Client) PackageBuildpack(ctx context.Context, opts PackageBuildpackOpti
 		digests = append(digests, digest)
 	}
 
+	// digest of the published buildpackage or image index, to sign
+	var signedDigest string
+	if len(digests) > 0 {
+		signedDigest = digests[0]
+	}
 	if opts.Publish && len(digests) > 1 {
 		// Image Index must be created only when we pushed to registry
-		return c.CreateManifest(ctx, CreateManifestOptions{
+		signedDigest, err = c.createManifest(ctx, CreateManifestOptions{
 			IndexRepoName: opts.Name,
 			RepoNames:     digests,
 			Publish:       true,
 		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if opts.Signer != nil {
+		digest, err := digestOf(signedDigest)
+		if err != nil {
+			return errors.Wrap(err, "signing buildpackage")
+		}
+		if err := c.signImage(ctx, opts.Signer, opts.Name, digest); err != nil {
+			return errors.Wrap(err, "signing buildpackage")
+		}
 	}
 
 	return nil
@@ -133,7 +159,7 @@ func (c *Client) packageBuildpackTarget(ctx context.Context, opts PackageBuildpa
 		bpURI = platformRootFolder
 	}
 
-	mainBlob, err := c.downloadBuildpackFromURI(ctx, bpURI, opts.RelativeBaseDir)
+	mainBlob, err := c.downloadBuildpackFromURI(ctx, bpURI, opts.Config.Buildpack.SHA256, opts.RelativeBaseDir)
 	if err != nil {
 		return digest, err
 	}
@@ -167,6 +193,7 @@ func (c *Client) packageBuildpackTarget(ctx context.Context, opts PackageBuildpa
 			Daemon:          !opts.Publish,
 			PullPolicy:      opts.PullPolicy,
 			Target:          &target,
+			SHA256:          dep.SHA256,
 		})
 		if err != nil {
 			return digest, errors.Wrapf(err, "packaging dependencies (uri=%s,image=%s)", style.Symbol(dep.URI), style.Symbol(dep.ImageName))
@@ -196,8 +223,8 @@ func (c *Client) packageBuildpackTarget(ctx context.Context, opts PackageBuildpa
 		if err != nil {
 			return digest, errors.Wrapf(err, "saving image")
 		}
-		if multiArch {
-			// We need to keep the identifier to create the image index
+		if multiArch || opts.Signer != nil {
+			// We need to keep the identifier to create the image index, and to sign the buildpackage
 			id, err := img.Identifier()
 			if err != nil {
 				return digest, errors.Wrapf(err, "determining image manifest digest")
@@ -210,7 +237,7 @@ func (c *Client) packageBuildpackTarget(ctx context.Context, opts PackageBuildpa
 	return digest, nil
 }
 
-func (c *Client) downloadBuildpackFromURI(ctx context.Context, uri, relativeBaseDir string) (blob.Blob, error) {
+func (c *Client) downloadBuildpackFromURI(ctx context.Context, uri, sha256Digest, relativeBaseDir string) (blob.Blob, error) {
 	absPath, err := paths.FilePathToURI(uri, relativeBaseDir)
 	if err != nil {
 		return nil, errors.Wrapf(err, "making absolute: %s", style.Symbol(uri))
@@ -218,7 +245,7 @@ func (c *Client) downloadBuildpackFromURI(ctx context.Context, uri, relativeBase
 	uri = absPath
 
 	c.logger.Debugf("Downloading buildpack from URI: %s", style.Symbol(uri))
-	blob, err := c.downloader.Download(ctx, uri)
+	blob, err := c.downloader.Download(ctx, uri, blob.DownloadOptionsFor(sha256Digest)...)
 	if err != nil {
 		return nil, errors.Wrapf(err, "downloading buildpack from %s", style.Symbol(uri))
 	}
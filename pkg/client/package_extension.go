@@ -16,6 +16,9 @@ func (c *Client) PackageExtension(ctx context.Context, opts PackageBuildpackOpti
 	if opts.Format == "" {
 		opts.Format = FormatImage
 	}
+	if opts.Signer != nil && (!opts.Publish || opts.Format != FormatImage) {
+		return errors.New("only published extension packages can be signed")
+	}
 
 	if opts.Config.Platform.OS == "windows" && !c.experimental {
 		return NewExperimentError("Windows extensionpackage support is currently experimental.")
@@ -38,7 +41,7 @@ func (c *Client) PackageExtension(ctx context.Context, opts PackageBuildpackOpti
 		return errors.New("extension URI must be provided")
 	}
 
-	mainBlob, err := c.downloadBuildpackFromURI(ctx, exURI, opts.RelativeBaseDir)
+	mainBlob, err := c.downloadBuildpackFromURI(ctx, exURI, opts.Config.Extension.SHA256, opts.RelativeBaseDir)
 	if err != nil {
 		return err
 	}
@@ -55,8 +58,18 @@ func (c *Client) PackageExtension(ctx context.Context, opts PackageBuildpackOpti
 	case FormatFile:
 		return packageBuilder.SaveAsFile(opts.Name, target, map[string]string{})
 	case FormatImage:
-		_, err = packageBuilder.SaveAsImage(opts.Name, opts.Publish, target, map[string]string{})
-		return errors.Wrapf(err, "saving image")
+		img, err := packageBuilder.SaveAsImage(opts.Name, opts.Publish, target, map[string]string{})
+		if err != nil {
+			return errors.Wrapf(err, "saving image")
+		}
+		if opts.Signer != nil {
+			digest, err := publishedDigest(img)
+			if err != nil {
+				return errors.Wrap(err, "signing extension package")
+			}
+			return errors.Wrap(c.signImage(ctx, opts.Signer, opts.Name, digest), "signing extension package")
+		}
+		return nil
 	default:
 		return errors.Errorf("unknown format: %s", style.Symbol(opts.Format))
 	}
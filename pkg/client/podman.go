@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"strings"
+)
+
+const (
+	podmanEngineComponent = "Podman Engine"
+	rootlessSecurityOpt   = "name=rootless"
+	userRuntimeDirPrefix  = "/run/user/"
+)
+
+// podmanService is a Podman service serving the docker API in place of a docker daemon.
+type podmanService struct {
+	// socket of the service on the host running the containers, if known
+	socket string
+	// rootless is set when the containers run in a user namespace of the user running the service
+	rootless bool
+}
+
+// detectPodman returns the Podman service serving the docker API, or nil when it is served by a docker daemon or
+// cannot be reached.
+func (c *Client) detectPodman(ctx context.Context) *podmanService {
+	version, err := c.docker.ServerVersion(ctx)
+	if err != nil {
+		c.logger.Debugf("Unable to read version of the daemon: %s", err)
+		return nil
+	}
+
+	isPodman := false
+	for _, component := range version.Components {
+		if component.Name == podmanEngineComponent {
+			isPodman = true
+		}
+	}
+	if !isPodman {
+		return nil
+	}
+
+	podman := &podmanService{socket: podmanSocketPath(os.Getenv(dockerHostEnvVar))}
+	// sockets of the services run by users are in their runtime directory
+	podman.rootless = strings.HasPrefix(podman.socket, userRuntimeDirPrefix)
+	if info, err := c.docker.Info(ctx); err == nil {
+		for _, opt := range info.SecurityOptions {
+			if opt == rootlessSecurityOpt {
+				podman.rootless = true
+			}
+		}
+	}
+	return podman
+}
+
+// podmanSocketPath returns the path of the socket of the service at dockerHost. For connections over ssh, it is the
+// path of the socket on the remote host, where the containers run.
+func podmanSocketPath(dockerHost string) string {
+	u, err := url.Parse(dockerHost)
+	if err != nil {
+		return ""
+	}
+	switch u.Scheme {
+	case "unix", "ssh":
+		return u.Path
+	}
+	return ""
+}
@@ -0,0 +1,196 @@
+package client
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+const (
+	containerHostEnvVar         = "CONTAINER_HOST"
+	containerSSHKeyEnvVar       = "CONTAINER_SSHKEY"
+	containersConfEnvVar        = "CONTAINERS_CONF"
+	dockerHostSSHIdentityEnvVar = "DOCKER_HOST_SSH_IDENTITY"
+	xdgConfigHomeEnvVar         = "XDG_CONFIG_HOME"
+	xdgRuntimeDirEnvVar         = "XDG_RUNTIME_DIR"
+
+	defaultDockerSocket       = "/var/run/docker.sock"
+	rootfulPodmanSocket       = "/run/podman/podman.sock"
+	containersConfFileName    = "containers.conf"
+	podmanConnectionsFileName = "podman-connections.json"
+)
+
+// System wide containers.conf files, in the order Podman reads them
+var systemContainersConfFiles = []string{
+	"/usr/share/containers/containers.conf",
+	"/etc/containers/containers.conf",
+}
+
+// Example containers.conf with Podman connections:
+//
+//	[engine]
+//	active_service = "podman-machine-default"
+//	[engine.service_destinations.podman-machine-default]
+//	uri = "ssh://core@127.0.0.1:50175/run/user/501/podman/podman.sock"
+//	identity = "/Users/user/.local/share/containers/podman/machine/machine"
+type containersConf struct {
+	Engine struct {
+		ActiveService       string                      `toml:"active_service"`
+		ServiceDestinations map[string]podmanConnection `toml:"service_destinations"`
+	} `toml:"engine"`
+}
+
+// Example podman-connections.json, replacing the connections of containers.conf since Podman 5:
+//
+//	{
+//	  "Connection": {
+//	    "Default": "podman-machine-default",
+//	    "Connections": {
+//	      "podman-machine-default": {
+//	        "URI": "ssh://core@127.0.0.1:50175/run/user/501/podman/podman.sock",
+//	        "Identity": "/Users/user/.local/share/containers/podman/machine/machine"
+//	      }
+//	    }
+//	  }
+//	}
+type podmanConnections struct {
+	Connection struct {
+		Default     string
+		Connections map[string]podmanConnection
+	}
+}
+
+type podmanConnection struct {
+	URI      string `toml:"uri" json:"URI"`
+	Identity string `toml:"identity" json:"Identity"`
+}
+
+// processPodmanConnection points DOCKER_HOST to a Podman service when no docker daemon is configured. The service is
+// the one of the CONTAINER_HOST environment variable, the default Podman connection, or a Podman socket of this host.
+func processPodmanConnection(logger logging.Logger) error {
+	if containerHost := os.Getenv(containerHostEnvVar); containerHost != "" {
+		logger.Debugf("'%s=%s' environment variable is being used", containerHostEnvVar, containerHost)
+		usePodmanConnection(podmanConnection{URI: containerHost, Identity: os.Getenv(containerSSHKeyEnvVar)})
+		return nil
+	}
+
+	if _, err := os.Stat(defaultDockerSocket); err == nil {
+		logger.Debugf("docker socket found at: %s", defaultDockerSocket)
+		return nil
+	}
+
+	name, connections, err := readPodmanConnections()
+	if err != nil {
+		// an invalid Podman configuration shouldn't prevent using pack without Podman
+		logger.Warnf("skipping podman discovery: %s", err)
+		return nil
+	}
+	if name != "" {
+		if connection, ok := connections[name]; ok {
+			usePodmanConnection(connection)
+			logger.Debugf("using podman connection '%s' with uri = '%s'", name, connection.URI)
+			return nil
+		}
+		logger.Warnf("podman connection '%s' doesn't exist", name)
+	}
+
+	if socket := podmanSocket(); socket != "" {
+		usePodmanConnection(podmanConnection{URI: "unix://" + socket})
+		logger.Debugf("using podman socket at: %s", socket)
+	}
+	return nil
+}
+
+func usePodmanConnection(connection podmanConnection) {
+	os.Setenv(dockerHostEnvVar, connection.URI)
+	if connection.Identity != "" && os.Getenv(dockerHostSSHIdentityEnvVar) == "" {
+		os.Setenv(dockerHostSSHIdentityEnvVar, connection.Identity)
+	}
+}
+
+// readPodmanConnections returns the name of the default Podman connection, if any, and the Podman connections
+// configured in containers.conf and podman-connections.json.
+func readPodmanConnections() (string, map[string]podmanConnection, error) {
+	userConfigDir, err := containersConfigDir()
+	if err != nil {
+		return "", nil, err
+	}
+
+	confFiles := append(append([]string{}, systemContainersConfFiles...), filepath.Join(userConfigDir, containersConfFileName))
+	if path := os.Getenv(containersConfEnvVar); path != "" {
+		confFiles = []string{path}
+	}
+
+	var defaultConnection string
+	connections := map[string]podmanConnection{}
+	for _, path := range confFiles {
+		var conf containersConf
+		if _, err := toml.DecodeFile(path, &conf); err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return "", nil, errors.Wrapf(err, "reading containers configuration at '%s'", path)
+		}
+		if conf.Engine.ActiveService != "" {
+			defaultConnection = conf.Engine.ActiveService
+		}
+		for name, connection := range conf.Engine.ServiceDestinations {
+			connections[name] = connection
+		}
+	}
+
+	path := filepath.Join(userConfigDir, podmanConnectionsFileName)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return defaultConnection, connections, nil
+		}
+		return "", nil, err
+	}
+	var conf podmanConnections
+	if err := json.Unmarshal(data, &conf); err != nil {
+		return "", nil, errors.Wrapf(err, "parsing %s", path)
+	}
+	if conf.Connection.Default != "" {
+		defaultConnection = conf.Connection.Default
+	}
+	for name, connection := range conf.Connection.Connections {
+		connections[name] = connection
+	}
+	return defaultConnection, connections, nil
+}
+
+// containersConfigDir returns the directory of the configuration of Podman for the user, which is the same on every OS.
+func containersConfigDir() (string, error) {
+	dir := os.Getenv(xdgConfigHomeEnvVar)
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", errors.Wrap(err, "determining user home directory")
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "containers"), nil
+}
+
+// podmanSocket returns the socket of the Podman service of the user, or else of the system wide one, if either is
+// listening.
+func podmanSocket() string {
+	var sockets []string
+	if runtimeDir := os.Getenv(xdgRuntimeDirEnvVar); runtimeDir != "" {
+		sockets = append(sockets, filepath.Join(runtimeDir, "podman", "podman.sock"))
+	}
+	sockets = append(sockets, rootfulPodmanSocket)
+
+	for _, socket := range sockets {
+		if _, err := os.Stat(socket); err == nil {
+			return socket
+		}
+	}
+	return ""
+}
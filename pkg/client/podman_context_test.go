@@ -0,0 +1,137 @@
+package client_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestProcessPodmanConnection(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "processPodmanConnection", testProcessPodmanConnection, spec.Report(report.Terminal{}))
+}
+
+func testProcessPodmanConnection(t *testing.T, when spec.G, it spec.S) {
+	var (
+		outBuf    bytes.Buffer
+		logger    logging.Logger
+		configDir string
+	)
+
+	it.Before(func() {
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf, logging.WithVerbose())
+
+		configDir = t.TempDir()
+		h.AssertNil(t, os.MkdirAll(filepath.Join(configDir, "containers"), 0755))
+		for key, value := range map[string]string{
+			"DOCKER_HOST":     "",
+			"CONTAINER_HOST":  "",
+			"XDG_CONFIG_HOME": configDir,
+			"XDG_RUNTIME_DIR": t.TempDir(),
+			// ignores the system wide containers.conf files
+			"CONTAINERS_CONF": filepath.Join(configDir, "containers", "containers.conf"),
+		} {
+			h.AssertNil(t, os.Setenv(key, value))
+		}
+		setDockerConfig(t, happyCase, "default-context")
+	})
+
+	it.After(func() {
+		for _, key := range []string{"DOCKER_HOST", "CONTAINER_HOST", "CONTAINER_SSHKEY", "XDG_CONFIG_HOME", "XDG_RUNTIME_DIR", "CONTAINERS_CONF", "DOCKER_HOST_SSH_IDENTITY"} {
+			h.AssertNil(t, os.Unsetenv(key))
+		}
+	})
+
+	when("env CONTAINER_HOST is set", func() {
+		it("uses it with its ssh key", func() {
+			h.AssertNil(t, os.Setenv("CONTAINER_HOST", "ssh://user@some-host/run/user/1000/podman/podman.sock"))
+			h.AssertNil(t, os.Setenv("CONTAINER_SSHKEY", "/some/key"))
+
+			h.AssertNil(t, client.ProcessDockerContext(logger))
+			h.AssertEq(t, os.Getenv("DOCKER_HOST"), "ssh://user@some-host/run/user/1000/podman/podman.sock")
+			h.AssertEq(t, os.Getenv("DOCKER_HOST_SSH_IDENTITY"), "/some/key")
+		})
+	})
+
+	when("there is no docker socket", func() {
+		it.Before(func() {
+			_, err := os.Stat("/var/run/docker.sock")
+			h.SkipIf(t, err == nil, "docker socket exists")
+		})
+
+		when("containers.conf has an active service", func() {
+			it("uses the service destination", func() {
+				h.AssertNil(t, os.WriteFile(os.Getenv("CONTAINERS_CONF"), []byte(`[engine]
+active_service = "some-connection"
+[engine.service_destinations.some-connection]
+uri = "ssh://core@127.0.0.1:50175/run/user/501/podman/podman.sock"
+identity = "/some/machine/key"
+`), 0600))
+
+				h.AssertNil(t, client.ProcessDockerContext(logger))
+				h.AssertEq(t, os.Getenv("DOCKER_HOST"), "ssh://core@127.0.0.1:50175/run/user/501/podman/podman.sock")
+				h.AssertEq(t, os.Getenv("DOCKER_HOST_SSH_IDENTITY"), "/some/machine/key")
+				h.AssertContains(t, outBuf.String(), "using podman connection 'some-connection'")
+			})
+
+			it("warns when the service destination doesn't exist", func() {
+				h.AssertNil(t, os.WriteFile(os.Getenv("CONTAINERS_CONF"), []byte(`[engine]
+active_service = "some-connection"
+`), 0600))
+
+				h.AssertNil(t, client.ProcessDockerContext(logger))
+				h.AssertEq(t, os.Getenv("DOCKER_HOST"), "")
+				h.AssertContains(t, outBuf.String(), "podman connection 'some-connection' doesn't exist")
+			})
+
+			it("warns and skips podman discovery when containers.conf is invalid", func() {
+				h.AssertNil(t, os.WriteFile(os.Getenv("CONTAINERS_CONF"), []byte(`[engine`), 0600))
+				socket := filepath.Join(os.Getenv("XDG_RUNTIME_DIR"), "podman", "podman.sock")
+				h.AssertNil(t, os.MkdirAll(filepath.Dir(socket), 0755))
+				h.AssertNil(t, os.WriteFile(socket, nil, 0600))
+
+				h.AssertNil(t, client.ProcessDockerContext(logger))
+				h.AssertEq(t, os.Getenv("DOCKER_HOST"), "")
+				h.AssertContains(t, outBuf.String(), "Warning: skipping podman discovery: reading containers configuration")
+			})
+		})
+
+		when("podman-connections.json has a default connection", func() {
+			it("uses the connection", func() {
+				h.AssertNil(t, os.WriteFile(filepath.Join(configDir, "containers", "podman-connections.json"), []byte(`{
+  "Connection": {
+    "Default": "some-connection",
+    "Connections": {
+      "some-connection": {"URI": "unix:///some/podman.sock"}
+    }
+  }
+}`), 0600))
+
+				h.AssertNil(t, client.ProcessDockerContext(logger))
+				h.AssertEq(t, os.Getenv("DOCKER_HOST"), "unix:///some/podman.sock")
+			})
+		})
+
+		when("the podman socket of the user exists", func() {
+			it("uses the socket", func() {
+				socket := filepath.Join(os.Getenv("XDG_RUNTIME_DIR"), "podman", "podman.sock")
+				h.AssertNil(t, os.MkdirAll(filepath.Dir(socket), 0755))
+				h.AssertNil(t, os.WriteFile(socket, nil, 0600))
+
+				h.AssertNil(t, client.ProcessDockerContext(logger))
+				h.AssertEq(t, os.Getenv("DOCKER_HOST"), "unix://"+socket)
+				h.AssertContains(t, outBuf.String(), "using podman socket at: "+socket)
+			})
+		})
+	})
+}
@@ -0,0 +1,198 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/buildpacks/imgutil"
+	"github.com/buildpacks/imgutil/local"
+	"github.com/buildpacks/lifecycle/buildpack"
+	"github.com/buildpacks/lifecycle/platform"
+	"github.com/buildpacks/lifecycle/platform/files"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/provenance"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+// buildProvenance holds what is known about a build before the lifecycle runs, for the provenance attestation
+// generated once it completes.
+type buildProvenance struct {
+	builderName      string
+	builderImage     imgutil.Image
+	ephemeralBuilder imgutil.Image
+	runImageName     string
+	runImage         imgutil.Image
+	lifecycleVersion string
+	lifecycleImage   string
+	platformAPI      string
+	env              map[string]string
+	appPath          string
+	fileFilter       func(string) bool
+	startedOn        time.Time
+}
+
+func (c *Client) generateProvenance(ctx context.Context, imageRef name.Reference, opts BuildOptions, bp buildProvenance) error {
+	appImage, err := c.imageFetcher.Fetch(ctx, imageRef.Name(), image.FetchOptions{Daemon: !opts.Publish, PullPolicy: image.PullNever})
+	if err != nil {
+		return fmt.Errorf("fetching built image: %w", err)
+	}
+
+	appDigest, err := imageDigestSet(appImage)
+	if err != nil {
+		return errors.Wrap(err, "reading image digest")
+	}
+
+	statement, err := bp.statement(imageRef, appImage, appDigest, opts.Publish)
+	if err != nil {
+		return err
+	}
+
+	if opts.ProvenanceDestinationDir != "" {
+		if err := provenance.Write(opts.ProvenanceDestinationDir, statement); err != nil {
+			return err
+		}
+		c.logger.Debugf("Wrote provenance to %s", style.Symbol(opts.ProvenanceDestinationDir))
+	}
+
+	if opts.AttachProvenance {
+		hex, ok := appDigest["sha256"]
+		if !ok {
+			return errors.Errorf("digest of image %s is not known", style.Symbol(imageRef.Name()))
+		}
+		digest, err := provenance.Attach(ctx, imageRef.Context().Digest("sha256:"+hex), c.keychain, statement)
+		if err != nil {
+			return err
+		}
+		c.logger.Infof("Attached provenance %s to %s", style.Symbol(digest.String()), style.Symbol(imageRef.Name()))
+	}
+
+	return nil
+}
+
+func (bp buildProvenance) statement(imageRef name.Reference, appImage imgutil.Image, appDigest map[string]string, publish bool) (provenance.Statement, error) {
+	sourceDigest, err := provenance.SourceDigest(bp.appPath, bp.fileFilter)
+	if err != nil {
+		return provenance.Statement{}, errors.Wrapf(err, "hashing app path %s", style.Symbol(bp.appPath))
+	}
+
+	builderDigest, err := imageDigestSet(bp.builderImage)
+	if err != nil {
+		return provenance.Statement{}, errors.Wrap(err, "reading builder digest")
+	}
+
+	runImageDigest, err := imageDigestSet(bp.runImage)
+	if err != nil {
+		return provenance.Statement{}, errors.Wrap(err, "reading run image digest")
+	}
+
+	modules, err := bp.resolvedModules(appImage)
+	if err != nil {
+		return provenance.Statement{}, err
+	}
+
+	var envNames []string
+	for k := range bp.env {
+		envNames = append(envNames, k)
+	}
+	sort.Strings(envNames)
+
+	dependencies := []provenance.ResourceDescriptor{
+		{Name: "source", URI: bp.appPath, Digest: provenance.DigestSet(sourceDigest)},
+		{Name: "builder", URI: bp.builderName, Digest: builderDigest},
+		{Name: "run-image", URI: bp.runImageName, Digest: runImageDigest},
+	}
+	dependencies = append(dependencies, modules...)
+
+	return provenance.Statement{
+		Type: provenance.StatementType,
+		Subject: []provenance.Subject{{
+			Name:   imageRef.Context().Name(),
+			Digest: appDigest,
+		}},
+		PredicateType: provenance.PredicateType,
+		Predicate: provenance.Predicate{
+			BuildDefinition: provenance.BuildDefinition{
+				BuildType: provenance.BuildType,
+				ExternalParameters: provenance.ExternalParameters{
+					Image:    imageRef.Name(),
+					Builder:  bp.builderName,
+					RunImage: bp.runImageName,
+					Publish:  publish,
+					Env:      envNames,
+				},
+				InternalParameters: provenance.InternalParameters{
+					LifecycleVersion: bp.lifecycleVersion,
+					LifecycleImage:   bp.lifecycleImage,
+					PlatformAPI:      bp.platformAPI,
+				},
+				ResolvedDependencies: dependencies,
+			},
+			RunDetails: provenance.RunDetails{
+				Builder: provenance.Builder{ID: provenance.BuilderID},
+				Metadata: provenance.Metadata{
+					StartedOn:  bp.startedOn.UTC(),
+					FinishedOn: time.Now().UTC(),
+				},
+			},
+		},
+	}, nil
+}
+
+// resolvedModules describes the buildpacks and extensions that took part in the build, according to the
+// build metadata of the app image, with the diff IDs of their layers on the builder.
+func (bp buildProvenance) resolvedModules(appImage imgutil.Image) ([]provenance.ResourceDescriptor, error) {
+	var buildMD files.BuildMetadata
+	if _, err := dist.GetLabel(appImage, platform.BuildMetadataLabel, &buildMD); err != nil {
+		return nil, err
+	}
+
+	var bpLayers, extLayers dist.ModuleLayers
+	if _, err := dist.GetLabel(bp.ephemeralBuilder, dist.BuildpackLayersLabel, &bpLayers); err != nil {
+		return nil, err
+	}
+	if _, err := dist.GetLabel(bp.ephemeralBuilder, dist.ExtensionLayersLabel, &extLayers); err != nil {
+		return nil, err
+	}
+
+	describe := func(kind string, modules []buildpack.GroupElement, layers dist.ModuleLayers) []provenance.ResourceDescriptor {
+		var descriptors []provenance.ResourceDescriptor
+		for _, module := range modules {
+			descriptors = append(descriptors, provenance.ResourceDescriptor{
+				Name:   fmt.Sprintf("%s:%s", kind, module.ID),
+				URI:    fmt.Sprintf("urn:cnb:%s:%s@%s", kind, module.ID, module.Version),
+				Digest: provenance.DigestSet(layers[module.ID][module.Version].LayerDiffID),
+			})
+		}
+		return descriptors
+	}
+
+	return append(describe("buildpack", buildMD.Buildpacks, bpLayers), describe("extension", buildMD.Extensions, extLayers)...), nil
+}
+
+func imageDigest(img imgutil.Image) (string, error) {
+	id, err := img.Identifier()
+	if err != nil {
+		return "", err
+	}
+	return parseDigestFromImageID(id), nil
+}
+
+// imageDigestSet returns the digest set of an image: the digest of its manifest when it is in a registry, or its
+// image ID under provenance.DockerImageIDKey when it is in the daemon, as the image ID is not a manifest digest.
+func imageDigestSet(img imgutil.Image) (map[string]string, error) {
+	id, err := img.Identifier()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := id.(local.IDIdentifier); ok {
+		return map[string]string{provenance.DockerImageIDKey: strings.TrimPrefix(id.String(), "sha256:")}, nil
+	}
+	return provenance.DigestSet(parseDigestFromImageID(id)), nil
+}
@@ -2,10 +2,13 @@ package client
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
 
 	"github.com/BurntSushi/toml"
+	"github.com/buildpacks/imgutil"
+	"github.com/buildpacks/lifecycle/api"
 	"github.com/buildpacks/lifecycle/phase"
 	"github.com/buildpacks/lifecycle/platform"
 	"github.com/buildpacks/lifecycle/platform/files"
@@ -16,6 +19,7 @@ import (
 	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/pkg/dist"
 	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/signing"
 )
 
 // RebaseOptions is a configuration struct that controls image rebase behavior.
@@ -48,14 +52,142 @@ type RebaseOptions struct {
 
 	// Image reference to use as the previous image for rebase.
 	PreviousImage string
+
+	// Option only valid if Publish is true
+	// Sign the rebased image with this signer.
+	Signer *signing.Signer
 }
 
 // Rebase updates the run image layers in an app image.
 // This operation mutates the image specified in opts.
 func (c *Client) Rebase(ctx context.Context, opts RebaseOptions) error {
+	if opts.Signer != nil && !opts.Publish {
+		return errors.New("only published images can be signed")
+	}
+
+	return c.rebase(ctx, opts, nil)
+}
+
+// rebase rebases the app image, fetching the run image through runImages when it is not nil.
+func (c *Client) rebase(ctx context.Context, opts RebaseOptions, runImages *runImageCache) error {
+	appImage, baseImage, err := c.fetchRebaseImages(ctx, opts, runImages)
+	if err != nil {
+		return err
+	}
+
+	c.logger.Infof("Rebasing %s on run image %s", style.Symbol(appImage.Name()), style.Symbol(baseImage.Name()))
+	rebaser := &phase.Rebaser{Logger: c.logger, PlatformAPI: build.SupportedPlatformAPIVersions.Latest(), Force: opts.Force}
+	report, err := rebaser.Rebase(appImage, baseImage, opts.RepoName, nil)
+	if err != nil {
+		return err
+	}
+
+	appImageIdentifier, err := appImage.Identifier()
+	if err != nil {
+		return err
+	}
+
+	c.logger.Infof("Rebased Image: %s", style.Symbol(appImageIdentifier.String()))
+
+	if opts.Signer != nil {
+		digest, err := publishedDigest(appImage)
+		if err != nil {
+			return errors.Wrap(err, "signing image")
+		}
+		if err := c.signImage(ctx, opts.Signer, opts.RepoName, digest); err != nil {
+			return errors.Wrap(err, "signing image")
+		}
+	}
+
+	if opts.ReportDestinationDir != "" {
+		reportPath := filepath.Join(opts.ReportDestinationDir, "report.toml")
+		reportFile, err := os.OpenFile(reportPath, os.O_RDWR|os.O_CREATE, 0644)
+		if err != nil {
+			c.logger.Warnf("unable to open %s for writing rebase report", reportPath)
+			return err
+		}
+
+		defer reportFile.Close()
+		err = toml.NewEncoder(reportFile).Encode(report)
+		if err != nil {
+			c.logger.Warnf("unable to write rebase report to %s", reportPath)
+			return err
+		}
+	}
+	return nil
+}
+
+// RebasePlan describes what rebasing an app image would do.
+type RebasePlan struct {
+	// Name of the app image.
+	AppImage string
+
+	// Name of the run image the app image would be rebased on, resolved the way Rebase resolves it.
+	RunImage string
+
+	// Reference and top layer of the run image the app image is currently based on.
+	CurrentRunImageReference string
+	CurrentTopLayer          string
+
+	// Reference and top layer of the run image the app image would be rebased on.
+	NewRunImageReference string
+	NewTopLayer          string
+
+	// True if the new run image is compatible with the app image, otherwise IncompatibleReason tells why.
+	// A forced rebase ignores the target of the images.
+	Compatible         bool
+	IncompatibleReason string
+
+	// True if rebasing would change the app image, because the new run image has different layers.
+	RebaseNeeded bool
+}
+
+// PlanRebase resolves the run image an app image would be rebased on and reports what rebasing would change, without
+// changing the image.
+func (c *Client) PlanRebase(ctx context.Context, opts RebaseOptions) (*RebasePlan, error) {
+	appImage, baseImage, err := c.fetchRebaseImages(ctx, opts, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var md files.LayersMetadataCompat
+	if _, err := dist.GetLabel(appImage, platform.LifecycleMetadataLabel, &md); err != nil {
+		return nil, err
+	}
+
+	newTopLayer, err := baseImage.TopLayer()
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting top layer of run image %s", style.Symbol(baseImage.Name()))
+	}
+	newIdentifier, err := baseImage.Identifier()
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting identifier of run image %s", style.Symbol(baseImage.Name()))
+	}
+
+	reason, err := rebaseIncompatibility(appImage, baseImage, opts.Force)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RebasePlan{
+		AppImage:                 appImage.Name(),
+		RunImage:                 baseImage.Name(),
+		CurrentRunImageReference: md.RunImage.Reference,
+		CurrentTopLayer:          md.RunImage.TopLayer,
+		NewRunImageReference:     newIdentifier.String(),
+		NewTopLayer:              newTopLayer,
+		Compatible:               reason == "",
+		IncompatibleReason:       reason,
+		RebaseNeeded:             md.RunImage.TopLayer != newTopLayer,
+	}, nil
+}
+
+// fetchRebaseImages fetches the app image to rebase and the run image to rebase it on. The run image is fetched
+// through runImages when it is not nil, so that images rebased together share it.
+func (c *Client) fetchRebaseImages(ctx context.Context, opts RebaseOptions, runImages *runImageCache) (appImage, baseImage imgutil.Image, err error) {
 	imageRef, err := c.parseTagReference(opts.RepoName)
 	if err != nil {
-		return errors.Wrapf(err, "invalid image name '%s'", opts.RepoName)
+		return nil, nil, errors.Wrapf(err, "invalid image name '%s'", opts.RepoName)
 	}
 
 	repoName := opts.RepoName
@@ -64,26 +196,26 @@ func (c *Client) Rebase(ctx context.Context, opts RebaseOptions) error {
 		repoName = opts.PreviousImage
 	}
 
-	appImage, err := c.imageFetcher.Fetch(ctx, repoName, image.FetchOptions{Daemon: !opts.Publish, PullPolicy: opts.PullPolicy})
+	appImage, err = c.imageFetcher.Fetch(ctx, repoName, image.FetchOptions{Daemon: !opts.Publish, PullPolicy: opts.PullPolicy})
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
 	appOS, err := appImage.OS()
 	if err != nil {
-		return errors.Wrapf(err, "getting app OS")
+		return nil, nil, errors.Wrapf(err, "getting app OS")
 	}
 
 	appArch, err := appImage.Architecture()
 	if err != nil {
-		return errors.Wrapf(err, "getting app architecture")
+		return nil, nil, errors.Wrapf(err, "getting app architecture")
 	}
 
 	var md files.LayersMetadataCompat
 	if ok, err := dist.GetLabel(appImage, platform.LifecycleMetadataLabel, &md); err != nil {
-		return err
+		return nil, nil, err
 	} else if !ok {
-		return errors.Errorf("could not find label %s on image", style.Symbol(platform.LifecycleMetadataLabel))
+		return nil, nil, errors.Errorf("could not find label %s on image", style.Symbol(platform.LifecycleMetadataLabel))
 	}
 	var runImageMD builder.RunImageMetadata
 	if md.RunImage.Image != "" {
@@ -116,42 +248,83 @@ func (c *Client) Rebase(ctx context.Context, opts RebaseOptions) error {
 	)
 
 	if runImageName == "" {
-		return errors.New("run image must be specified")
+		return nil, nil, errors.New("run image must be specified")
 	}
 
-	baseImage, err := c.imageFetcher.Fetch(ctx, runImageName, fetchOptions)
-	if err != nil {
-		return err
+	if runImages != nil {
+		baseImage, err = runImages.fetch(ctx, c.imageFetcher, runImageName, fetchOptions)
+	} else {
+		baseImage, err = c.imageFetcher.Fetch(ctx, runImageName, fetchOptions)
 	}
-
-	c.logger.Infof("Rebasing %s on run image %s", style.Symbol(appImage.Name()), style.Symbol(baseImage.Name()))
-	rebaser := &phase.Rebaser{Logger: c.logger, PlatformAPI: build.SupportedPlatformAPIVersions.Latest(), Force: opts.Force}
-	report, err := rebaser.Rebase(appImage, baseImage, opts.RepoName, nil)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
+	return appImage, baseImage, nil
+}
 
-	appImageIdentifier, err := appImage.Identifier()
+// rebaseIncompatibility returns why the lifecycle would refuse to rebase the app image on the run image, or an empty
+// string if it would not. Like the lifecycle, it compares stacks for images exported with a platform API older than
+// 0.12, and targets for newer ones unless forced.
+func rebaseIncompatibility(appImage, baseImage imgutil.Image, force bool) (string, error) {
+	appPlatformAPI, err := appImage.Env(platform.EnvPlatformAPI)
 	if err != nil {
-		return err
+		return "", errors.Wrap(err, "reading app image platform API")
 	}
 
-	c.logger.Infof("Rebased Image: %s", style.Symbol(appImageIdentifier.String()))
-
-	if opts.ReportDestinationDir != "" {
-		reportPath := filepath.Join(opts.ReportDestinationDir, "report.toml")
-		reportFile, err := os.OpenFile(reportPath, os.O_RDWR|os.O_CREATE, 0644)
+	usesStacks := appPlatformAPI == ""
+	if !usesStacks {
+		version, err := api.NewVersion(appPlatformAPI)
 		if err != nil {
-			c.logger.Warnf("unable to open %s for writing rebase report", reportPath)
-			return err
+			return "", errors.Wrapf(err, "parsing app image platform API %s", style.Symbol(appPlatformAPI))
 		}
+		usesStacks = version.LessThan("0.12")
+	}
 
-		defer reportFile.Close()
-		err = toml.NewEncoder(reportFile).Encode(report)
+	if usesStacks {
+		appStackID, err := appImage.Label(platform.StackIDLabel)
 		if err != nil {
-			c.logger.Warnf("unable to write rebase report to %s", reportPath)
-			return err
+			return "", err
+		}
+		baseStackID, err := baseImage.Label(platform.StackIDLabel)
+		if err != nil {
+			return "", err
 		}
+		if appStackID != baseStackID {
+			return fmt.Sprintf("run image stack %s does not match app image stack %s", style.Symbol(baseStackID), style.Symbol(appStackID)), nil
+		}
+		return "", nil
 	}
-	return nil
+
+	if force {
+		return "", nil
+	}
+
+	rebasable, err := getRebasableLabel(appImage)
+	if err != nil {
+		return "", err
+	}
+	if !rebasable {
+		return "app image is not marked as rebasable", nil
+	}
+
+	appTarget, err := platform.GetTargetMetadata(appImage)
+	if err != nil {
+		return "", errors.Wrap(err, "reading app image target")
+	}
+	baseTarget, err := platform.GetTargetMetadata(baseImage)
+	if err != nil {
+		return "", errors.Wrap(err, "reading run image target")
+	}
+	if !platform.TargetSatisfiedForRebase(*baseTarget, *appTarget) {
+		return fmt.Sprintf("run image target %s does not satisfy app image target %s", style.Symbol(targetString(baseTarget)), style.Symbol(targetString(appTarget))), nil
+	}
+	return "", nil
+}
+
+func targetString(target *files.TargetMetadata) string {
+	result := target.OS + "/" + target.Arch
+	if target.ArchVariant != "" {
+		result += "/" + target.ArchVariant
+	}
+	return result
 }
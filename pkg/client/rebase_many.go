@@ -0,0 +1,140 @@
+package client
+
+import (
+	"context"
+	"sync"
+
+	"github.com/buildpacks/imgutil"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+// DefaultRebaseParallelism is the number of images RebaseMany rebases at once when RebaseManyOptions.Parallelism is
+// not set.
+const DefaultRebaseParallelism = 4
+
+// RebaseManyOptions is a configuration struct that controls rebasing many images at once.
+type RebaseManyOptions struct {
+	// Names of the images we wish to rebase.
+	RepoNames []string
+
+	// Maximum number of images to rebase at once. Defaults to DefaultRebaseParallelism.
+	Parallelism int
+
+	// Flag to publish images to remote registry after rebase completion.
+	Publish bool
+
+	// Strategy for pulling images during rebase.
+	PullPolicy image.PullPolicy
+
+	// Image to rebase against. This image must have
+	// the same StackID as the previous run image of every image.
+	RunImage string
+
+	// A mapping from StackID to an array of mirrors.
+	// This mapping used only if both RunImage is omitted and Publish is true.
+	AdditionalMirrors map[string][]string
+
+	// Pass-through force flag to lifecycle rebase command to skip target data
+	// validated (will not have any effect if API < 0.12).
+	Force bool
+}
+
+// RebaseResult is the outcome of rebasing one of many images.
+type RebaseResult struct {
+	// Name of the image.
+	RepoName string
+
+	// Error rebasing the image, nil if it was rebased.
+	Err error
+}
+
+// RebaseMany rebases each image in opts, several at once. Run images are pulled once. A run image from a registry is
+// shared by every image rebased on it, while each image rebased on the daemon gets its own handle on the run image, as
+// daemon images are not safe for concurrent use. A failure to rebase one image does not stop the others; the result
+// for each image, in the order of opts.RepoNames, tells whether it was rebased.
+func (c *Client) RebaseMany(ctx context.Context, opts RebaseManyOptions) ([]RebaseResult, error) {
+	if opts.Parallelism < 0 {
+		return nil, errors.New("parallelism must be positive")
+	}
+	parallelism := opts.Parallelism
+	if parallelism == 0 {
+		parallelism = DefaultRebaseParallelism
+	}
+
+	results := make([]RebaseResult, len(opts.RepoNames))
+	runImages := &runImageCache{}
+	sem := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+	for i, repoName := range opts.RepoNames {
+		results[i].RepoName = repoName
+
+		wg.Add(1)
+		go func(i int, repoName string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			if err := ctx.Err(); err != nil {
+				results[i].Err = err
+				return
+			}
+			results[i].Err = c.rebase(ctx, RebaseOptions{
+				RepoName:          repoName,
+				Publish:           opts.Publish,
+				PullPolicy:        opts.PullPolicy,
+				RunImage:          opts.RunImage,
+				AdditionalMirrors: opts.AdditionalMirrors,
+				Force:             opts.Force,
+			}, runImages)
+		}(i, repoName)
+	}
+	wg.Wait()
+
+	return results, nil
+}
+
+// runImageCache fetches each run image once, however many images are rebased on it. Daemon images cache the layers
+// they read and are not safe for concurrent use, so after the first fetch, a daemon run image is fetched again for each
+// image rebased on it, without pulling.
+type runImageCache struct {
+	mu      sync.Mutex
+	fetches map[string]*runImageFetch
+}
+
+type runImageFetch struct {
+	once sync.Once
+	img  imgutil.Image
+	err  error
+}
+
+func (r *runImageCache) fetch(ctx context.Context, fetcher ImageFetcher, name string, options image.FetchOptions) (imgutil.Image, error) {
+	key := name
+	if options.Target != nil {
+		key += " " + options.Target.OS + "/" + options.Target.Arch
+	}
+
+	r.mu.Lock()
+	if r.fetches == nil {
+		r.fetches = map[string]*runImageFetch{}
+	}
+	f, ok := r.fetches[key]
+	if !ok {
+		f = &runImageFetch{}
+		r.fetches[key] = f
+	}
+	r.mu.Unlock()
+
+	first := false
+	f.once.Do(func() {
+		f.img, f.err = fetcher.Fetch(ctx, name, options)
+		first = true
+	})
+	if f.err != nil || first || !options.Daemon {
+		return f.img, f.err
+	}
+
+	options.PullPolicy = image.PullNever
+	return fetcher.Fetch(ctx, name, options)
+}
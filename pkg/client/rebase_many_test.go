@@ -0,0 +1,177 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/buildpacks/imgutil"
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/buildpacks/lifecycle/auth"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	ifakes "github.com/buildpacks/pack/internal/fakes"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestRebaseMany(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "rebase many", testRebaseMany, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+// countingImageFetcher serializes fetches, which the fake image fetcher does not support concurrently, and counts them.
+// Like the daemon, it returns a new handle each time a daemon image is fetched.
+type countingImageFetcher struct {
+	*ifakes.FakeImageFetcher
+	mu        sync.Mutex
+	counts    map[string]int
+	pulls     map[string]int
+	runImages []*daemonRunImage
+}
+
+func (f *countingImageFetcher) Fetch(ctx context.Context, name string, options image.FetchOptions) (imgutil.Image, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.counts[name]++
+	if options.PullPolicy != image.PullNever {
+		f.pulls[name]++
+	}
+
+	img, err := f.FakeImageFetcher.Fetch(ctx, name, options)
+	if err != nil || !options.Daemon || name != "some/run" {
+		return img, err
+	}
+	runImage := &daemonRunImage{Image: img.(*fakes.Image)}
+	f.runImages = append(f.runImages, runImage)
+	return runImage, nil
+}
+
+// daemonRunImage records reading its top layer without synchronization, like daemon images cache the layers they read,
+// so that the race detector catches it being used by several rebases at once.
+type daemonRunImage struct {
+	*fakes.Image
+	topLayerReads int
+}
+
+func (i *daemonRunImage) TopLayer() (string, error) {
+	i.topLayerReads++
+	return i.Image.TopLayer()
+}
+
+func testRebaseMany(t *testing.T, when spec.G, it spec.S) {
+	var (
+		fetcher   *countingImageFetcher
+		subject   *Client
+		appImages []*fakes.Image
+		out       bytes.Buffer
+	)
+
+	it.Before(func() {
+		fetcher = &countingImageFetcher{FakeImageFetcher: ifakes.NewFakeImageFetcher(), counts: map[string]int{}, pulls: map[string]int{}}
+
+		appImages = nil
+		for i := 0; i < 5; i++ {
+			name := fmt.Sprintf("some/app-%d", i)
+			appImage := fakes.NewImage(name, "", &fakeIdentifier{name: name + "-digest"})
+			h.AssertNil(t, appImage.SetLabel("io.buildpacks.lifecycle.metadata", `{"runImage":{"image":"some/run"}}`))
+			h.AssertNil(t, appImage.SetLabel("io.buildpacks.stack.id", "io.buildpacks.stacks.jammy"))
+			fetcher.LocalImages[name] = appImage
+			appImages = append(appImages, appImage)
+		}
+
+		runImage := fakes.NewImage("some/run", "run-image-top-layer-sha", &fakeIdentifier{name: "run-image-digest"})
+		h.AssertNil(t, runImage.SetLabel("io.buildpacks.stack.id", "io.buildpacks.stacks.jammy"))
+		fetcher.LocalImages["some/run"] = runImage
+
+		keychain, err := auth.DefaultKeychain("pack-test/dummy")
+		h.AssertNil(t, err)
+
+		subject = &Client{
+			logger:       logging.NewLogWithWriters(&out, &out),
+			imageFetcher: fetcher,
+			keychain:     keychain,
+		}
+	})
+
+	it("rebases every image, pulling the run image once", func() {
+		var names []string
+		for _, appImage := range appImages {
+			names = append(names, appImage.Name())
+		}
+
+		results, err := subject.RebaseMany(context.TODO(), RebaseManyOptions{RepoNames: names, Parallelism: 2})
+		h.AssertNil(t, err)
+
+		h.AssertEq(t, len(results), len(names))
+		for i, result := range results {
+			h.AssertEq(t, result.RepoName, names[i])
+			h.AssertNil(t, result.Err)
+			h.AssertEq(t, appImages[i].Base(), "some/run")
+		}
+		h.AssertEq(t, fetcher.pulls["some/run"], 1)
+	})
+
+	it("gives each image its own handle on a daemon run image", func() {
+		var names []string
+		for _, appImage := range appImages {
+			names = append(names, appImage.Name())
+		}
+
+		results, err := subject.RebaseMany(context.TODO(), RebaseManyOptions{RepoNames: names, Parallelism: len(names)})
+		h.AssertNil(t, err)
+		for _, result := range results {
+			h.AssertNil(t, result.Err)
+		}
+
+		h.AssertEq(t, len(fetcher.runImages), len(names))
+		for _, runImage := range fetcher.runImages {
+			h.AssertEq(t, runImage.topLayerReads, 1)
+		}
+	})
+
+	when("publishing", func() {
+		it.Before(func() {
+			for _, appImage := range appImages {
+				fetcher.RemoteImages[appImage.Name()] = appImage
+			}
+			fetcher.RemoteImages["some/run"] = fetcher.LocalImages["some/run"]
+		})
+
+		it("shares the run image fetched from the registry", func() {
+			results, err := subject.RebaseMany(context.TODO(), RebaseManyOptions{
+				RepoNames: []string{"some/app-0", "some/app-1", "some/app-2"},
+				Publish:   true,
+				RunImage:  "some/run",
+			})
+			h.AssertNil(t, err)
+			for _, result := range results {
+				h.AssertNil(t, result.Err)
+			}
+			h.AssertEq(t, fetcher.counts["some/run"], 1)
+		})
+	})
+
+	it("collects failures without stopping the other images", func() {
+		results, err := subject.RebaseMany(context.TODO(), RebaseManyOptions{
+			RepoNames: []string{"some/app-0", "some/missing-app", "some/app-1"},
+		})
+		h.AssertNil(t, err)
+
+		h.AssertNil(t, results[0].Err)
+		h.AssertError(t, results[1].Err, "image 'some/missing-app' does not exist on the daemon")
+		h.AssertNil(t, results[2].Err)
+		h.AssertEq(t, appImages[1].Base(), "some/run")
+	})
+
+	it("errors with a negative parallelism", func() {
+		_, err := subject.RebaseMany(context.TODO(), RebaseManyOptions{RepoNames: []string{"some/app-0"}, Parallelism: -1})
+		h.AssertError(t, err, "parallelism must be positive")
+	})
+}
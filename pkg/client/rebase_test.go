@@ -3,6 +3,9 @@ package client
 import (
 	"bytes"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"os"
 	"path/filepath"
 	"testing"
@@ -16,6 +19,7 @@ import (
 	ifakes "github.com/buildpacks/pack/internal/fakes"
 	"github.com/buildpacks/pack/pkg/image"
 	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/signing"
 	h "github.com/buildpacks/pack/testhelpers"
 )
 
@@ -269,6 +273,19 @@ func testRebase(t *testing.T, when spec.G, it spec.S) {
 					})
 				})
 			})
+			when("signer is provided", func() {
+				it("fails to sign an image that is not published", func() {
+					key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+					h.AssertNil(t, err)
+
+					err = subject.Rebase(context.TODO(), RebaseOptions{
+						RepoName: "some/app",
+						Signer:   signing.NewSigner(key),
+					})
+					h.AssertError(t, err, "only published images can be signed")
+				})
+			})
+
 			when("previous image is provided", func() {
 				it("fetches the image using the previous image name", func() {
 					h.AssertNil(t, subject.Rebase(context.TODO(), RebaseOptions{
@@ -302,6 +319,101 @@ func testRebase(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 		})
+
+		when("#PlanRebase", func() {
+			it.Before(func() {
+				h.AssertNil(t, fakeAppImage.SetLabel("io.buildpacks.lifecycle.metadata",
+					`{"runImage":{"image":"some/run","topLayer":"old-top-layer-sha","reference":"old-run-image-digest"}}`))
+			})
+
+			it("reports the current and new run image without rebasing", func() {
+				plan, err := subject.PlanRebase(context.TODO(), RebaseOptions{RepoName: "some/app"})
+				h.AssertNil(t, err)
+
+				h.AssertEq(t, plan, &RebasePlan{
+					AppImage:                 "some/app",
+					RunImage:                 "some/run",
+					CurrentRunImageReference: "old-run-image-digest",
+					CurrentTopLayer:          "old-top-layer-sha",
+					NewRunImageReference:     "run-image-digest",
+					NewTopLayer:              "run-image-top-layer-sha",
+					Compatible:               true,
+					RebaseNeeded:             true,
+				})
+				h.AssertEq(t, fakeAppImage.Base(), "")
+				h.AssertEq(t, fakeAppImage.IsSaved(), false)
+			})
+
+			it("resolves the run image like a rebase", func() {
+				h.AssertNil(t, fakeAppImage.SetLabel("io.buildpacks.lifecycle.metadata",
+					`{"runImage":{"image":"some/run","mirrors":["example.com/some/run"],"topLayer":"old-top-layer-sha"}}`))
+
+				plan, err := subject.PlanRebase(context.TODO(), RebaseOptions{
+					RepoName:          "example.com/some/app",
+					PreviousImage:     "some/app",
+					AdditionalMirrors: map[string][]string{"some/run": {"example.com/some/run"}},
+				})
+				h.AssertNil(t, err)
+				h.AssertEq(t, plan.RunImage, "example.com/some/run")
+				h.AssertEq(t, plan.NewTopLayer, "mirror-top-layer-sha")
+			})
+
+			it("reports when the image is based on the latest run image", func() {
+				h.AssertNil(t, fakeAppImage.SetLabel("io.buildpacks.lifecycle.metadata",
+					`{"runImage":{"image":"some/run","topLayer":"run-image-top-layer-sha","reference":"run-image-digest"}}`))
+
+				plan, err := subject.PlanRebase(context.TODO(), RebaseOptions{RepoName: "some/app"})
+				h.AssertNil(t, err)
+				h.AssertEq(t, plan.RebaseNeeded, false)
+			})
+
+			it("reports a run image with another stack as incompatible", func() {
+				h.AssertNil(t, fakeRunImage.SetLabel("io.buildpacks.stack.id", "io.buildpacks.stacks.other"))
+
+				plan, err := subject.PlanRebase(context.TODO(), RebaseOptions{RepoName: "some/app"})
+				h.AssertNil(t, err)
+				h.AssertEq(t, plan.Compatible, false)
+				h.AssertEq(t, plan.IncompatibleReason, "run image stack 'io.buildpacks.stacks.other' does not match app image stack 'io.buildpacks.stacks.jammy'")
+			})
+
+			it("errors when the app image has an invalid platform API", func() {
+				h.AssertNil(t, fakeAppImage.SetEnv("CNB_PLATFORM_API", "not-a-version"))
+
+				_, err := subject.PlanRebase(context.TODO(), RebaseOptions{RepoName: "some/app"})
+				h.AssertError(t, err, "parsing app image platform API 'not-a-version'")
+			})
+
+			when("the app image was exported with platform API 0.12 or newer", func() {
+				it.Before(func() {
+					h.AssertNil(t, fakeAppImage.SetEnv("CNB_PLATFORM_API", "0.12"))
+				})
+
+				it("compares targets", func() {
+					h.AssertNil(t, fakeRunImage.SetArchitecture("arm64"))
+
+					plan, err := subject.PlanRebase(context.TODO(), RebaseOptions{RepoName: "some/app"})
+					h.AssertNil(t, err)
+					h.AssertEq(t, plan.Compatible, false)
+					h.AssertContains(t, plan.IncompatibleReason, "run image target 'linux/arm64' does not satisfy app image target")
+				})
+
+				it("ignores targets when forced", func() {
+					h.AssertNil(t, fakeRunImage.SetArchitecture("arm64"))
+
+					plan, err := subject.PlanRebase(context.TODO(), RebaseOptions{RepoName: "some/app", Force: true})
+					h.AssertNil(t, err)
+					h.AssertEq(t, plan.Compatible, true)
+				})
+
+				it("reports images not marked as rebasable as incompatible", func() {
+					h.AssertNil(t, fakeAppImage.SetLabel("io.buildpacks.rebasable", "false"))
+
+					plan, err := subject.PlanRebase(context.TODO(), RebaseOptions{RepoName: "some/app"})
+					h.AssertNil(t, err)
+					h.AssertEq(t, plan.IncompatibleReason, "app image is not marked as rebasable")
+				})
+			})
+		})
 	})
 }
 
@@ -0,0 +1,217 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/BurntSushi/toml"
+	"github.com/go-git/go-git/v5"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/dist"
+)
+
+const (
+	// DefaultScaffoldTemplate is the built-in template of new buildpacks and extensions
+	DefaultScaffoldTemplate = "bash"
+
+	// Files of directory and git templates with this suffix are rendered, and written without it
+	scaffoldTemplateSuffix = ".tmpl"
+)
+
+// scaffoldFile is a file of a built-in template. Both its path and contents are rendered.
+type scaffoldFile struct {
+	path       string
+	contents   string
+	executable bool
+}
+
+// scaffoldData is given to the templates of new buildpacks and extensions.
+type scaffoldData struct {
+	ID      string
+	Version string
+	API     string
+	// Name is the last segment of the ID
+	Name string
+	// GoPackage is Name as a Go package name
+	GoPackage string
+}
+
+func newScaffoldData(id, version, api string) scaffoldData {
+	idParts := strings.Split(id, "/")
+	name := idParts[len(idParts)-1]
+	goPackage := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return -1
+	}, strings.ToLower(name))
+	if goPackage == "" || (goPackage[0] >= '0' && goPackage[0] <= '9') {
+		goPackage = "buildpack" + goPackage
+	}
+	return scaffoldData{ID: id, Version: version, API: api, Name: name, GoPackage: goPackage}
+}
+
+// writeScaffold writes the files of a template to path: a built-in template, a directory or a git repository URL. Files
+// which already exist are kept.
+func (c *Client) writeScaffold(ctx context.Context, path, templateName string, builtIns map[string][]scaffoldFile, data scaffoldData) error {
+	if templateName == "" {
+		templateName = DefaultScaffoldTemplate
+	}
+
+	if files, ok := builtIns[templateName]; ok {
+		for _, file := range files {
+			filePath, err := renderScaffold(file.path, file.path, data)
+			if err != nil {
+				return err
+			}
+			contents, err := renderScaffold(file.path, file.contents, data)
+			if err != nil {
+				return err
+			}
+			var mode os.FileMode = 0644
+			if file.executable {
+				mode = 0755
+			}
+			if err := c.writeScaffoldFile(path, filePath, []byte(contents), mode); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if fi, err := os.Stat(templateName); err == nil && fi.IsDir() {
+		return c.copyScaffoldTemplate(templateName, path, data)
+	}
+
+	if !isGitURL(templateName) {
+		var names []string
+		for name := range builtIns {
+			names = append(names, style.Symbol(name))
+		}
+		sort.Strings(names)
+		return errors.Errorf("template %s must be a directory, a git repository URL or one of %s", style.Symbol(templateName), strings.Join(names, ", "))
+	}
+
+	if c.offline {
+		return errors.Errorf("template %s cannot be cloned in offline mode", style.Symbol(templateName))
+	}
+
+	cloneDir, err := os.MkdirTemp("", "pack.template.")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(cloneDir)
+
+	if _, err := git.PlainCloneContext(ctx, cloneDir, false, &git.CloneOptions{URL: templateName, Depth: 1}); err != nil {
+		return errors.Wrapf(err, "cloning template %s", style.Symbol(templateName))
+	}
+	return c.copyScaffoldTemplate(cloneDir, path, data)
+}
+
+// copyScaffoldTemplate copies the files of the template directory to path, rendering those with the template suffix.
+func (c *Client) copyScaffoldTemplate(templateDir, path string, data scaffoldData) error {
+	return filepath.WalkDir(templateDir, func(src string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if entry.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		contents, err := os.ReadFile(src)
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(templateDir, src)
+		if err != nil {
+			return err
+		}
+
+		if strings.HasSuffix(relPath, scaffoldTemplateSuffix) {
+			rendered, err := renderScaffold(relPath, string(contents), data)
+			if err != nil {
+				return err
+			}
+			relPath = strings.TrimSuffix(relPath, scaffoldTemplateSuffix)
+			contents = []byte(rendered)
+		}
+		return c.writeScaffoldFile(path, filepath.ToSlash(relPath), contents, info.Mode().Perm())
+	})
+}
+
+func renderScaffold(name, text string, data scaffoldData) (string, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", errors.Wrapf(err, "parsing template %s", style.Symbol(name))
+	}
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", errors.Wrapf(err, "rendering template %s", style.Symbol(name))
+	}
+	return out.String(), nil
+}
+
+// writeScaffoldFile writes the file at the slash separated relPath of dir, unless it exists.
+func (c *Client) writeScaffoldFile(dir, relPath string, contents []byte, mode os.FileMode) error {
+	filePath := filepath.Join(dir, filepath.FromSlash(relPath))
+	if _, err := os.Stat(filePath); !os.IsNotExist(err) {
+		return err
+	}
+
+	// The following line's comment is for gosec, it will ignore rule 301 in this case
+	// G301: Expect directory permissions to be 0750 or less
+	/* #nosec G301 */
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filePath, contents, mode); err != nil {
+		return err
+	}
+
+	if c != nil {
+		c.logger.Infof("    %s  %s", style.Symbol("create"), relPath)
+	}
+	return nil
+}
+
+// packageTOML is the package.toml of a new buildpack or extension.
+type packageTOML struct {
+	Buildpack *dist.BuildpackURI `toml:"buildpack,omitempty"`
+	Extension *dist.BuildpackURI `toml:"extension,omitempty"`
+	Targets   []dist.Target      `toml:"targets,omitempty"`
+}
+
+func (c *Client) createPackageTOML(path string, config packageTOML) error {
+	var contents bytes.Buffer
+	if err := toml.NewEncoder(&contents).Encode(config); err != nil {
+		return err
+	}
+	return c.writeScaffoldFile(path, "package.toml", contents.Bytes(), 0644)
+}
+
+func isGitURL(s string) bool {
+	for _, prefix := range []string{"https://", "http://", "ssh://", "git://", "git@"} {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return strings.HasSuffix(s, ".git")
+}
@@ -0,0 +1,185 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+	"github.com/buildpacks/imgutil"
+	"github.com/buildpacks/imgutil/remote"
+	"github.com/buildpacks/lifecycle/platform/files"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/signing"
+)
+
+// signImage signs the manifest with the given digest, as published to imageName and the repositories of any
+// additional tags. The digest is the one pack produced, since by the time it is signed the tags may point to
+// manifests pushed by others.
+func (c *Client) signImage(ctx context.Context, signer *signing.Signer, imageName, digest string, additionalTags ...string) error {
+	ref, err := name.ParseReference(imageName, name.WeakValidation)
+	if err != nil {
+		return errors.Wrapf(err, "parsing image name %s", style.Symbol(imageName))
+	}
+
+	repos := []name.Repository{ref.Context()}
+	for _, tag := range additionalTags {
+		tagRef, err := name.ParseReference(tag, name.WeakValidation)
+		if err != nil {
+			return errors.Wrapf(err, "parsing image name %s", style.Symbol(tag))
+		}
+		repos = append(repos, tagRef.Context())
+	}
+
+	signed := map[string]bool{}
+	for _, repo := range repos {
+		if signed[repo.Name()] {
+			continue
+		}
+		signed[repo.Name()] = true
+
+		digestRef := repo.Digest(digest)
+		tag, err := signer.Sign(ctx, digestRef, c.keychain)
+		if err != nil {
+			return err
+		}
+		c.logger.Infof("Signed %s, signature stored at %s", style.Symbol(digestRef.Name()), style.Symbol(tag.Name()))
+	}
+	return nil
+}
+
+// publishedDigest returns the digest of an image saved to a registry.
+func publishedDigest(img imgutil.Image) (string, error) {
+	id, err := img.Identifier()
+	if err != nil {
+		return "", errors.Wrapf(err, "reading identifier of %s", style.Symbol(img.Name()))
+	}
+	digestID, ok := id.(remote.DigestIdentifier)
+	if !ok {
+		return "", errors.Errorf("image %s was not saved to a registry", style.Symbol(img.Name()))
+	}
+	return digestID.Digest.DigestStr(), nil
+}
+
+// digestOf returns the digest of a published image or image index, given as its digest or a digest reference.
+func digestOf(digestOrRef string) (string, error) {
+	if digestOrRef == "" {
+		return "", errors.New("image was not published")
+	}
+	if _, err := v1.NewHash(digestOrRef); err == nil {
+		return digestOrRef, nil
+	}
+	ref, err := name.NewDigest(digestOrRef, name.WeakValidation)
+	if err != nil {
+		return "", errors.Wrapf(err, "parsing digest of %s", style.Symbol(digestOrRef))
+	}
+	return ref.DigestStr(), nil
+}
+
+// reportedDigest returns the digest of the image the exporter recorded in the report.toml in reportDir.
+func reportedDigest(reportDir string) (string, error) {
+	var report files.Report
+	if _, err := toml.DecodeFile(filepath.Join(reportDir, "report.toml"), &report); err != nil {
+		return "", errors.Wrap(err, "reading export report")
+	}
+	if report.Image.Digest == "" {
+		return "", errors.New("export report has no image digest")
+	}
+	return report.Image.Digest, nil
+}
+
+// pushedIndexDigest returns the digest of an image index as pushed, which is that of its serialized manifest.
+func pushedIndexDigest(index imgutil.ImageIndex) (string, error) {
+	withManifest, ok := index.(interface {
+		IndexManifest() (*v1.IndexManifest, error)
+	})
+	if !ok {
+		return "", errors.New("image index has no manifest")
+	}
+	manifest, err := withManifest.IndexManifest()
+	if err != nil {
+		return "", errors.Wrap(err, "reading image index manifest")
+	}
+	raw, err := json.Marshal(manifest)
+	if err != nil {
+		return "", errors.Wrap(err, "serializing image index manifest")
+	}
+	hash, _, err := v1.SHA256(bytes.NewReader(raw))
+	if err != nil {
+		return "", err
+	}
+	return hash.String(), nil
+}
+
+// verifyImage checks the signature of an image fetched as imageName. Images pulled to the daemon are verified
+// through the digest they were pulled with, so images that were never pulled from or pushed to imageName's
+// repository cannot be verified.
+func (c *Client) verifyImage(ctx context.Context, verifier *signing.Verifier, imageName string, img imgutil.Image) error {
+	digest, err := c.imageDigestRef(ctx, imageName, img)
+	if err != nil {
+		return err
+	}
+
+	if err := verifier.Verify(ctx, digest, c.keychain); err != nil {
+		return err
+	}
+	c.logger.Debugf("Verified signature of %s", style.Symbol(digest.Name()))
+	return nil
+}
+
+func (c *Client) imageDigestRef(ctx context.Context, imageName string, img imgutil.Image) (name.Digest, error) {
+	ref, err := name.ParseReference(imageName, name.WeakValidation)
+	if err != nil {
+		return name.Digest{}, errors.Wrapf(err, "parsing image name %s", style.Symbol(imageName))
+	}
+
+	id, err := img.Identifier()
+	if err != nil {
+		return name.Digest{}, errors.Wrapf(err, "reading identifier of %s", style.Symbol(imageName))
+	}
+	if id == nil {
+		return name.Digest{}, errors.Errorf("image %s has no identifier", style.Symbol(imageName))
+	}
+	if digestID, ok := id.(remote.DigestIdentifier); ok {
+		return ref.Context().Digest(digestID.Digest.DigestStr()), nil
+	}
+
+	inspect, _, err := c.docker.ImageInspectWithRaw(ctx, id.String())
+	if err != nil {
+		return name.Digest{}, errors.Wrapf(err, "inspecting %s", style.Symbol(imageName))
+	}
+	for _, repoDigest := range inspect.RepoDigests {
+		digest, err := name.NewDigest(repoDigest, name.WeakValidation)
+		if err == nil && digest.Context().Name() == ref.Context().Name() {
+			return digest, nil
+		}
+	}
+
+	return name.Digest{}, errors.Errorf("image %s has no digest in repository %s, it must be pulled from the registry to be verified",
+		style.Symbol(imageName), style.Symbol(ref.Context().Name()))
+}
+
+// checkTrustedBuilderPolicy returns an error describing why the builder fetched as builderName does not meet the
+// policy, if it does not.
+func (c *Client) checkTrustedBuilderPolicy(ctx context.Context, builderName string, builderImage imgutil.Image, policy TrustedBuilderPolicy) error {
+	digest, err := c.imageDigestRef(ctx, builderName, builderImage)
+	if err != nil {
+		return err
+	}
+
+	if policy.Digest != "" && digest.DigestStr() != policy.Digest {
+		return errors.Errorf("digest %s does not match trusted digest %s", style.Symbol(digest.DigestStr()), style.Symbol(policy.Digest))
+	}
+
+	if policy.Verifier != nil {
+		if err := policy.Verifier.Verify(ctx, digest, c.keychain); err != nil {
+			return err
+		}
+	}
+	return nil
+}
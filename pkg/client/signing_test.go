@@ -0,0 +1,284 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"io"
+	"log"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/buildpacks/imgutil"
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/buildpacks/imgutil/remote"
+	"github.com/docker/docker/api/types"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	ggcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	ggcrtypes "github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/index"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/signing"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestSigning(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Signing", testSigning, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testSigning(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject          *Client
+		mockController   *gomock.Controller
+		mockDockerClient *testmocks.MockCommonAPIClient
+		server           *httptest.Server
+		registryHost     string
+		imageDigest      name.Digest
+		signer           *signing.Signer
+		verifier         *signing.Verifier
+		out              bytes.Buffer
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockDockerClient = testmocks.NewMockCommonAPIClient(mockController)
+
+		server = httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+		u, err := url.Parse(server.URL)
+		h.AssertNil(t, err)
+		registryHost = u.Host
+
+		imageRef, err := name.ParseReference(registryHost + "/some/app:latest")
+		h.AssertNil(t, err)
+		img, err := random.Image(1024, 1)
+		h.AssertNil(t, err)
+		h.AssertNil(t, ggcrremote.Write(imageRef, img))
+		digest, err := img.Digest()
+		h.AssertNil(t, err)
+		imageDigest = imageRef.Context().Digest(digest.String())
+
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		h.AssertNil(t, err)
+		signer = signing.NewSigner(key)
+		verifier = signing.NewVerifier(key.Public())
+
+		subject = &Client{
+			logger:   logging.NewLogWithWriters(&out, &out),
+			docker:   mockDockerClient,
+			keychain: authn.DefaultKeychain,
+		}
+	})
+
+	it.After(func() {
+		server.Close()
+		mockController.Finish()
+	})
+
+	when("#signImage", func() {
+		it("signs the published image", func() {
+			h.AssertNil(t, subject.signImage(context.TODO(), signer, registryHost+"/some/app:latest", imageDigest.DigestStr()))
+			h.AssertNil(t, verifier.Verify(context.TODO(), imageDigest, authn.DefaultKeychain))
+			h.AssertContains(t, out.String(), "Signed '"+imageDigest.Name()+"'")
+		})
+
+		it("signs the image in the repositories of additional tags", func() {
+			otherRef, err := name.ParseReference(registryHost + "/other/app:v1")
+			h.AssertNil(t, err)
+			img, err := ggcrremote.Image(imageDigest)
+			h.AssertNil(t, err)
+			h.AssertNil(t, ggcrremote.Write(otherRef, img))
+
+			h.AssertNil(t, subject.signImage(context.TODO(), signer, registryHost+"/some/app:latest", imageDigest.DigestStr(), registryHost+"/some/app:v1", otherRef.Name()))
+
+			h.AssertNil(t, verifier.Verify(context.TODO(), imageDigest, authn.DefaultKeychain))
+			h.AssertNil(t, verifier.Verify(context.TODO(), otherRef.Context().Digest(imageDigest.DigestStr()), authn.DefaultKeychain))
+
+			signatures, err := ggcrremote.Image(signing.SignatureTag(imageDigest))
+			h.AssertNil(t, err)
+			layers, err := signatures.Layers()
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(layers), 1)
+		})
+
+		it("signs the given digest rather than the manifest the tag points to", func() {
+			imageRef, err := name.ParseReference(registryHost + "/some/app:latest")
+			h.AssertNil(t, err)
+			otherImg, err := random.Image(1024, 1)
+			h.AssertNil(t, err)
+			h.AssertNil(t, ggcrremote.Write(imageRef, otherImg))
+			otherDigest, err := otherImg.Digest()
+			h.AssertNil(t, err)
+
+			h.AssertNil(t, subject.signImage(context.TODO(), signer, imageRef.Name(), imageDigest.DigestStr()))
+
+			h.AssertNil(t, verifier.Verify(context.TODO(), imageDigest, authn.DefaultKeychain))
+			err = verifier.Verify(context.TODO(), imageRef.Context().Digest(otherDigest.String()), authn.DefaultKeychain)
+			h.AssertError(t, err, "no signatures found")
+		})
+	})
+
+	when("#publishedDigest", func() {
+		it("returns the digest of an image saved to a registry", func() {
+			img := fakes.NewImage(registryHost+"/some/app:latest", "", remote.DigestIdentifier{Digest: imageDigest})
+			digest, err := publishedDigest(img)
+			h.AssertNil(t, err)
+			h.AssertEq(t, digest, imageDigest.DigestStr())
+		})
+
+		it("errors when the image was saved to the daemon", func() {
+			img := fakes.NewImage("some/app:latest", "", &fakeIdentifier{name: "some-image-id"})
+			_, err := publishedDigest(img)
+			h.AssertError(t, err, "was not saved to a registry")
+		})
+	})
+
+	when("#reportedDigest", func() {
+		it("returns the digest of the exported image", func() {
+			reportDir := t.TempDir()
+			h.AssertNil(t, os.WriteFile(filepath.Join(reportDir, "report.toml"), []byte(`[image]
+tags = ["some/app:latest"]
+digest = "`+imageDigest.DigestStr()+`"
+`), 0600))
+
+			digest, err := reportedDigest(reportDir)
+			h.AssertNil(t, err)
+			h.AssertEq(t, digest, imageDigest.DigestStr())
+		})
+
+		it("errors when the report has no digest", func() {
+			reportDir := t.TempDir()
+			h.AssertNil(t, os.WriteFile(filepath.Join(reportDir, "report.toml"), []byte("[image]\n"), 0600))
+
+			_, err := reportedDigest(reportDir)
+			h.AssertError(t, err, "export report has no image digest")
+		})
+	})
+
+	when("#pushedIndexDigest", func() {
+		it("returns the digest the registry has for the pushed index", func() {
+			indexRef, err := name.ParseReference(registryHost + "/some/app:index")
+			h.AssertNil(t, err)
+			idx, err := index.NewIndexFactory(authn.DefaultKeychain, t.TempDir()).CreateIndex(indexRef.Name(), imgutil.WithMediaType(ggcrtypes.OCIImageIndex))
+			h.AssertNil(t, err)
+			img, err := ggcrremote.Image(imageDigest)
+			h.AssertNil(t, err)
+			idx.AddManifest(img)
+			h.AssertNil(t, idx.Push(imgutil.WithMediaType(ggcrtypes.OCIImageIndex), imgutil.WithPurge(true)))
+
+			digest, err := pushedIndexDigest(idx)
+			h.AssertNil(t, err)
+
+			desc, err := ggcrremote.Head(indexRef)
+			h.AssertNil(t, err)
+			h.AssertEq(t, digest, desc.Digest.String())
+		})
+	})
+
+	when("#digestOf", func() {
+		it("accepts a digest or a digest reference", func() {
+			digest, err := digestOf(imageDigest.Name())
+			h.AssertNil(t, err)
+			h.AssertEq(t, digest, imageDigest.DigestStr())
+
+			digest, err = digestOf(imageDigest.DigestStr())
+			h.AssertNil(t, err)
+			h.AssertEq(t, digest, imageDigest.DigestStr())
+		})
+
+		it("errors when the image was not published", func() {
+			_, err := digestOf("")
+			h.AssertError(t, err, "image was not published")
+		})
+	})
+
+	when("#verifyImage", func() {
+		it.Before(func() {
+			h.AssertNil(t, subject.signImage(context.TODO(), signer, imageDigest.Name(), imageDigest.DigestStr()))
+		})
+
+		when("the image was fetched from a registry", func() {
+			it("verifies the image digest", func() {
+				img := fakes.NewImage(registryHost+"/some/app:latest", "", remote.DigestIdentifier{Digest: imageDigest})
+				h.AssertNil(t, subject.verifyImage(context.TODO(), verifier, registryHost+"/some/app:latest", img))
+			})
+
+			it("errors when the signature was made with another key", func() {
+				otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+				h.AssertNil(t, err)
+
+				img := fakes.NewImage(registryHost+"/some/app:latest", "", remote.DigestIdentifier{Digest: imageDigest})
+				err = subject.verifyImage(context.TODO(), signing.NewVerifier(otherKey.Public()), registryHost+"/some/app:latest", img)
+				h.AssertError(t, err, "no valid signatures found")
+			})
+		})
+
+		when("the image is on the daemon", func() {
+			var img *fakes.Image
+
+			it.Before(func() {
+				img = fakes.NewImage(registryHost+"/some/app:latest", "", &fakeIdentifier{name: "some-image-id"})
+			})
+
+			it("verifies the digest the image was pulled with", func() {
+				mockDockerClient.EXPECT().ImageInspectWithRaw(gomock.Any(), "some-image-id").Return(types.ImageInspect{
+					RepoDigests: []string{"other/app@sha256:363c754893f0efe22480b4359a5956cf3bd3ce22742fc576973c61348308c2e4", imageDigest.Name()},
+				}, nil, nil)
+
+				h.AssertNil(t, subject.verifyImage(context.TODO(), verifier, registryHost+"/some/app:latest", img))
+			})
+
+			it("errors when the image has no digest in the repository", func() {
+				mockDockerClient.EXPECT().ImageInspectWithRaw(gomock.Any(), "some-image-id").Return(types.ImageInspect{}, nil, nil)
+
+				err := subject.verifyImage(context.TODO(), verifier, registryHost+"/some/app:latest", img)
+				h.AssertError(t, err, "has no digest in repository")
+			})
+		})
+	})
+
+	when("#checkTrustedBuilderPolicy", func() {
+		var img *fakes.Image
+
+		it.Before(func() {
+			img = fakes.NewImage(registryHost+"/some/app:latest", "", remote.DigestIdentifier{Digest: imageDigest})
+		})
+
+		it("accepts a builder with the pinned digest", func() {
+			err := subject.checkTrustedBuilderPolicy(context.TODO(), img.Name(), img, TrustedBuilderPolicy{Digest: imageDigest.DigestStr()})
+			h.AssertNil(t, err)
+		})
+
+		it("rejects a builder with another digest", func() {
+			err := subject.checkTrustedBuilderPolicy(context.TODO(), img.Name(), img, TrustedBuilderPolicy{Digest: "sha256:363c754893f0efe22480b4359a5956cf3bd3ce22742fc576973c61348308c2e4"})
+			h.AssertError(t, err, "does not match trusted digest")
+		})
+
+		it("accepts a builder signed with an allowed key", func() {
+			h.AssertNil(t, subject.signImage(context.TODO(), signer, imageDigest.Name(), imageDigest.DigestStr()))
+
+			err := subject.checkTrustedBuilderPolicy(context.TODO(), img.Name(), img, TrustedBuilderPolicy{Verifier: verifier})
+			h.AssertNil(t, err)
+		})
+
+		it("rejects a builder that is not signed", func() {
+			err := subject.checkTrustedBuilderPolicy(context.TODO(), img.Name(), img, TrustedBuilderPolicy{Verifier: verifier})
+			h.AssertError(t, err, "no signatures found")
+		})
+	})
+}
@@ -0,0 +1,247 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/buildpacks/lifecycle/buildpack"
+	"github.com/buildpacks/lifecycle/launch"
+	types "github.com/docker/docker/api/types/image"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/container"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+// Status code of the detector, or creator, when no group of buildpacks passes detection
+const detectFailedStatusCode = 20
+
+// BuildpackTestSpec describes what a buildpack is expected to contribute when built against a fixture app.
+//
+// Example buildpack-test.toml:
+//
+//	[detect]
+//	pass = true
+//
+//	[[processes]]
+//	type = "web"
+//	command = ["node", "server.js"]
+//	default = true
+//
+//	[[layers]]
+//	name = "node_modules"
+//
+//	[[bom]]
+//	name = "node"
+//	version = "20.11.0"
+type BuildpackTestSpec struct {
+	Detect struct {
+		// Pass is whether the buildpack is expected to pass detection, which it is by default
+		Pass *bool `toml:"pass"`
+	} `toml:"detect"`
+
+	// Processes expected in launch.toml, each with its command and arguments if set
+	Processes []BuildpackTestProcess `toml:"processes"`
+
+	// Launch layers expected in the app image
+	Layers []BuildpackTestLayer `toml:"layers"`
+
+	// Entries expected in the bill of materials, each with its version if set
+	BOM []BuildpackTestBOMEntry `toml:"bom"`
+}
+
+type BuildpackTestProcess struct {
+	Type    string   `toml:"type"`
+	Command []string `toml:"command"`
+	Args    []string `toml:"args"`
+	Default bool     `toml:"default"`
+}
+
+type BuildpackTestLayer struct {
+	Name string `toml:"name"`
+}
+
+type BuildpackTestBOMEntry struct {
+	Name    string `toml:"name"`
+	Version string `toml:"version"`
+}
+
+// ReadBuildpackTestSpec reads the BuildpackTestSpec at path.
+func ReadBuildpackTestSpec(path string) (BuildpackTestSpec, error) {
+	var spec BuildpackTestSpec
+	md, err := toml.DecodeFile(path, &spec)
+	if err != nil {
+		return BuildpackTestSpec{}, errors.Wrapf(err, "reading buildpack test spec at %s", style.Symbol(path))
+	}
+	if undecoded := md.Undecoded(); len(undecoded) > 0 {
+		return BuildpackTestSpec{}, errors.Errorf("unknown keys in buildpack test spec at %s: %s", style.Symbol(path), undecoded)
+	}
+	return spec, nil
+}
+
+type TestBuildpackOptions struct {
+	// Path of the directory of the buildpack to test, which is packaged on the fly.
+	BuildpackPath string
+
+	// Path of the fixture app to build with the buildpack.
+	FixturePath string
+
+	// Builder running the buildpack.
+	Builder string
+
+	// Run image of the app image, which defaults to the one of the builder.
+	RunImage string
+
+	// Environment variables given to the buildpack.
+	Env map[string]string
+
+	// Strategy for updating local images before the build.
+	PullPolicy image.PullPolicy
+
+	// Whether the builder is trusted, which runs the lifecycle in a single container.
+	TrustBuilder bool
+
+	// Expectations on the build.
+	Spec BuildpackTestSpec
+}
+
+// BuildpackTestResult is the outcome of building a fixture app with a buildpack.
+type BuildpackTestResult struct {
+	// Name of the fixture, which is the name of its directory
+	Fixture string
+
+	Duration time.Duration
+
+	// Expectations of the spec the build did not meet
+	Failures []string
+
+	// Error of the build, when it failed for another reason than detection
+	Error error
+}
+
+// Passed returns whether the build met every expectation of the spec.
+func (r BuildpackTestResult) Passed() bool {
+	return r.Error == nil && len(r.Failures) == 0
+}
+
+// TestBuildpack builds the fixture app with the buildpack alone, through the lifecycle of the builder, and checks the
+// resulting app image against the spec. The app image is removed afterwards.
+func (c *Client) TestBuildpack(ctx context.Context, opts TestBuildpackOptions) (*BuildpackTestResult, error) {
+	if opts.BuildpackPath == "" || opts.FixturePath == "" {
+		return nil, errors.New("buildpack path and fixture path must be set")
+	}
+	buildpackPath, err := filepath.Abs(opts.BuildpackPath)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BuildpackTestResult{Fixture: filepath.Base(filepath.Clean(opts.FixturePath))}
+	imageName := fmt.Sprintf("pack.local/buildpack-test/%x:latest", randString(10))
+
+	started := time.Now()
+	err = c.Build(ctx, BuildOptions{
+		Image:        imageName,
+		AppPath:      opts.FixturePath,
+		Builder:      opts.Builder,
+		RunImage:     opts.RunImage,
+		Env:          opts.Env,
+		PullPolicy:   opts.PullPolicy,
+		Buildpacks:   []string{buildpackPath},
+		TrustBuilder: func(string) bool { return opts.TrustBuilder },
+	})
+	result.Duration = time.Since(started)
+
+	expectPass := opts.Spec.Detect.Pass == nil || *opts.Spec.Detect.Pass
+	var statusErr container.StatusError
+	if errors.As(err, &statusErr) && statusErr.StatusCode == detectFailedStatusCode {
+		if expectPass {
+			result.Failures = append(result.Failures, "detection failed, expected it to pass")
+		}
+		return result, nil
+	}
+	if err != nil {
+		result.Error = err
+		return result, nil
+	}
+	defer c.docker.ImageRemove(context.Background(), imageName, types.RemoveOptions{Force: true})
+
+	if !expectPass {
+		result.Failures = append(result.Failures, "detection passed, expected it to fail")
+		return result, nil
+	}
+
+	info, err := c.InspectImage(imageName, true)
+	if err != nil {
+		result.Error = errors.Wrap(err, "inspecting app image")
+		return result, nil
+	}
+	if info == nil {
+		result.Error = errors.Errorf("app image %s not found", style.Symbol(imageName))
+		return result, nil
+	}
+
+	result.Failures = append(result.Failures, checkBuildpackTestSpec(opts.Spec, info)...)
+	return result, nil
+}
+
+// checkBuildpackTestSpec returns the expectations of the spec the app image does not meet.
+func checkBuildpackTestSpec(spec BuildpackTestSpec, info *ImageInfo) []string {
+	var failures []string
+
+	processes := info.Processes.OtherProcesses
+	if info.Processes.DefaultProcess != nil {
+		processes = append([]launch.Process{*info.Processes.DefaultProcess}, processes...)
+	}
+	for _, expected := range spec.Processes {
+		i := slices.IndexFunc(processes, func(process launch.Process) bool { return process.Type == expected.Type })
+		if i < 0 {
+			failures = append(failures, fmt.Sprintf("process %s is missing", style.Symbol(expected.Type)))
+			continue
+		}
+		process := processes[i]
+		if expected.Command != nil && !slices.Equal(process.Command.Entries, expected.Command) {
+			failures = append(failures, fmt.Sprintf("process %s has command %s, expected %s",
+				style.Symbol(expected.Type), style.Symbol(strings.Join(process.Command.Entries, " ")), style.Symbol(strings.Join(expected.Command, " "))))
+		}
+		if expected.Args != nil && !slices.Equal(process.Args, expected.Args) {
+			failures = append(failures, fmt.Sprintf("process %s has arguments %s, expected %s",
+				style.Symbol(expected.Type), style.Symbol(strings.Join(process.Args, " ")), style.Symbol(strings.Join(expected.Args, " "))))
+		}
+		if expected.Default && (info.Processes.DefaultProcess == nil || info.Processes.DefaultProcess.Type != expected.Type) {
+			failures = append(failures, fmt.Sprintf("process %s is not the default process", style.Symbol(expected.Type)))
+		}
+	}
+
+	for _, expected := range spec.Layers {
+		found := false
+		for _, bp := range info.BuildpackLayers {
+			if _, ok := bp.Layers[expected.Name]; ok {
+				found = true
+			}
+		}
+		if !found {
+			failures = append(failures, fmt.Sprintf("layer %s is missing", style.Symbol(expected.Name)))
+		}
+	}
+
+	for _, expected := range spec.BOM {
+		i := slices.IndexFunc(info.BOM, func(entry buildpack.BOMEntry) bool {
+			return entry.Name == expected.Name && (expected.Version == "" || entry.Version == expected.Version)
+		})
+		if i < 0 {
+			name := expected.Name
+			if expected.Version != "" {
+				name += "@" + expected.Version
+			}
+			failures = append(failures, fmt.Sprintf("bill of materials entry %s is missing", style.Symbol(name)))
+		}
+	}
+
+	return failures
+}
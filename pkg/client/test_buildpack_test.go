@@ -0,0 +1,116 @@
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/buildpacks/lifecycle/buildpack"
+	"github.com/buildpacks/lifecycle/launch"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestTestBuildpack(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "TestBuildpack", testTestBuildpack, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testTestBuildpack(t *testing.T, when spec.G, it spec.S) {
+	when("#ReadBuildpackTestSpec", func() {
+		var specPath string
+
+		it.Before(func() {
+			specPath = filepath.Join(t.TempDir(), "buildpack-test.toml")
+		})
+
+		it("reads the expectations", func() {
+			h.AssertNil(t, os.WriteFile(specPath, []byte(`
+[detect]
+pass = false
+
+[[processes]]
+type = "web"
+command = ["node", "server.js"]
+default = true
+
+[[layers]]
+name = "node_modules"
+
+[[bom]]
+name = "node"
+version = "20.11.0"
+`), 0600))
+
+			testSpec, err := ReadBuildpackTestSpec(specPath)
+			h.AssertNil(t, err)
+			h.AssertEq(t, *testSpec.Detect.Pass, false)
+			h.AssertEq(t, testSpec.Processes, []BuildpackTestProcess{{Type: "web", Command: []string{"node", "server.js"}, Default: true}})
+			h.AssertEq(t, testSpec.Layers, []BuildpackTestLayer{{Name: "node_modules"}})
+			h.AssertEq(t, testSpec.BOM, []BuildpackTestBOMEntry{{Name: "node", Version: "20.11.0"}})
+		})
+
+		it("errors with unknown keys", func() {
+			h.AssertNil(t, os.WriteFile(specPath, []byte(`
+[[process]]
+type = "web"
+`), 0600))
+
+			_, err := ReadBuildpackTestSpec(specPath)
+			h.AssertError(t, err, "unknown keys in buildpack test spec")
+		})
+	})
+
+	when("#checkBuildpackTestSpec", func() {
+		var info *ImageInfo
+
+		it.Before(func() {
+			info = &ImageInfo{
+				Processes: ProcessDetails{
+					DefaultProcess: &launch.Process{Type: "web", Command: launch.NewRawCommand([]string{"node", "server.js"})},
+					OtherProcesses: []launch.Process{{Type: "worker", Command: launch.NewRawCommand([]string{"node"}), Args: []string{"worker.js"}}},
+				},
+				BuildpackLayers: []buildpack.LayersMetadata{{ID: "some-buildpack", Layers: map[string]buildpack.LayerMetadata{"node_modules": {}}}},
+				BOM:             []buildpack.BOMEntry{{Require: buildpack.Require{Name: "node", Version: "20.11.0"}}},
+			}
+		})
+
+		it("passes when the image meets the expectations", func() {
+			testSpec := BuildpackTestSpec{
+				Processes: []BuildpackTestProcess{
+					{Type: "web", Command: []string{"node", "server.js"}, Default: true},
+					{Type: "worker", Args: []string{"worker.js"}},
+				},
+				Layers: []BuildpackTestLayer{{Name: "node_modules"}},
+				BOM:    []BuildpackTestBOMEntry{{Name: "node", Version: "20.11.0"}},
+			}
+
+			h.AssertEq(t, len(checkBuildpackTestSpec(testSpec, info)), 0)
+		})
+
+		it("fails for each expectation the image doesn't meet", func() {
+			testSpec := BuildpackTestSpec{
+				Processes: []BuildpackTestProcess{
+					{Type: "web", Command: []string{"npm", "start"}},
+					{Type: "worker", Args: []string{"other.js"}, Default: true},
+					{Type: "cron"},
+				},
+				Layers: []BuildpackTestLayer{{Name: "npm-cache"}},
+				BOM:    []BuildpackTestBOMEntry{{Name: "node", Version: "18.0.0"}},
+			}
+
+			h.AssertEq(t, checkBuildpackTestSpec(testSpec, info), []string{
+				"process 'web' has command 'node server.js', expected 'npm start'",
+				"process 'worker' has arguments 'worker.js', expected 'other.js'",
+				"process 'worker' is not the default process",
+				"process 'cron' is missing",
+				"layer 'npm-cache' is missing",
+				"bill of materials entry 'node@18.0.0' is missing",
+			})
+		})
+	})
+}
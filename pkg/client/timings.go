@@ -0,0 +1,192 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/events"
+)
+
+// TimingsFileName is the name of the file phase timings are written to in the report directory, next to report.toml.
+const TimingsFileName = "timings.toml"
+
+// Logged by the lifecycle at the start of each phase when running the creator, such as "===> BUILDING".
+var stageMarkerRegexp = regexp.MustCompile(`^===> (\S+)`)
+
+// phaseTiming is the wall time spent in a lifecycle phase, in milliseconds. The total includes time spent outside of
+// the phase's container steps, such as preparing its configuration and removing the container.
+type phaseTiming struct {
+	Phase     string `toml:"phase"`
+	TotalMs   int64  `toml:"total-ms"`
+	CreateMs  int64  `toml:"create-ms"`
+	CopyInMs  int64  `toml:"copy-in-ms"`
+	RunMs     int64  `toml:"run-ms"`
+	CopyOutMs int64  `toml:"copy-out-ms"`
+	// Stages split the run time of a phase running several lifecycle phases in one container, as the creator does.
+	Stages []*stageTiming `toml:"stages,omitempty"`
+
+	stageStarted time.Time
+}
+
+// stageTiming is the wall time spent in a lifecycle phase run by the creator, in milliseconds, from its marker in the
+// output to the next one or the end of the run.
+type stageTiming struct {
+	Stage string `toml:"stage"`
+	RunMs int64  `toml:"run-ms"`
+}
+
+// endStage records the time spent in the current stage, if any, up to now.
+func (p *phaseTiming) endStage(now time.Time) {
+	if len(p.Stages) == 0 || p.stageStarted.IsZero() {
+		return
+	}
+	p.Stages[len(p.Stages)-1].RunMs += now.Sub(p.stageStarted).Milliseconds()
+	p.stageStarted = time.Time{}
+}
+
+// phaseTimings records how long each lifecycle phase takes from the build events it receives, and passes the events
+// on to next if set.
+type phaseTimings struct {
+	mu     sync.Mutex
+	next   events.Emitter
+	phases []*phaseTiming
+	now    func() time.Time
+}
+
+func newPhaseTimings(next events.Emitter) *phaseTimings {
+	return &phaseTimings{next: next, now: time.Now}
+}
+
+func (t *phaseTimings) Emit(event events.Event) {
+	if t.next != nil {
+		t.next.Emit(event)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := event.Time
+	if now.IsZero() {
+		now = t.now()
+	}
+
+	switch event.Type {
+	case events.Output:
+		if m := stageMarkerRegexp.FindStringSubmatch(event.Line); m != nil {
+			timing := t.phase(event.Phase)
+			timing.endStage(now)
+			timing.Stages = append(timing.Stages, &stageTiming{Stage: strings.ToLower(m[1])})
+			timing.stageStarted = now
+		}
+	case events.PhaseEnd:
+		timing := t.phase(event.Phase)
+		timing.endStage(now)
+		timing.TotalMs += event.DurationMs
+	case events.PhaseStep:
+		timing := t.phase(event.Phase)
+		switch event.Step {
+		case events.StepCreate:
+			timing.CreateMs += event.DurationMs
+		case events.StepCopyIn:
+			timing.CopyInMs += event.DurationMs
+		case events.StepRun:
+			timing.endStage(now)
+			timing.RunMs += event.DurationMs
+		case events.StepCopyOut:
+			timing.CopyOutMs += event.DurationMs
+		}
+	}
+}
+
+// phase returns the timing of the phase, adding it if it is not known yet so that phases keep the order they ran in.
+func (t *phaseTimings) phase(name string) *phaseTiming {
+	for _, timing := range t.phases {
+		if timing.Phase == name {
+			return timing
+		}
+	}
+	timing := &phaseTiming{Phase: name}
+	t.phases = append(t.phases, timing)
+	return timing
+}
+
+func (t *phaseTimings) empty() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.phases) == 0
+}
+
+// summary returns a table of the time spent in each phase, followed by the time spent in each of its stages.
+func (t *phaseTimings) summary() (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	buf := &bytes.Buffer{}
+	tw := tabwriter.NewWriter(buf, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(tw, "PHASE\tTOTAL\tCREATE\tCOPY IN\tRUN\tCOPY OUT"); err != nil {
+		return "", err
+	}
+
+	var total int64
+	for _, timing := range t.phases {
+		total += timing.TotalMs
+		_, err := fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", timing.Phase,
+			formatMs(timing.TotalMs), formatMs(timing.CreateMs), formatMs(timing.CopyInMs), formatMs(timing.RunMs), formatMs(timing.CopyOutMs))
+		if err != nil {
+			return "", err
+		}
+		for _, stage := range timing.Stages {
+			if _, err := fmt.Fprintf(tw, "  %s\t\t\t\t%s\t\n", stage.Stage, formatMs(stage.RunMs)); err != nil {
+				return "", err
+			}
+		}
+	}
+	if _, err := fmt.Fprintf(tw, "total\t%s\t\t\t\t\n", formatMs(total)); err != nil {
+		return "", err
+	}
+
+	if err := tw.Flush(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// write writes the timings to TimingsFileName in dir.
+func (t *phaseTimings) write(dir string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return errors.Wrapf(err, "creating report directory %s", style.Symbol(dir))
+	}
+
+	path := filepath.Join(dir, TimingsFileName)
+	f, err := os.Create(path)
+	if err != nil {
+		return errors.Wrapf(err, "creating %s", style.Symbol(path))
+	}
+	defer f.Close()
+
+	contents := struct {
+		Phases []*phaseTiming `toml:"phases"`
+	}{t.phases}
+	if err := toml.NewEncoder(f).Encode(contents); err != nil {
+		return errors.Wrapf(err, "writing %s", style.Symbol(path))
+	}
+	return nil
+}
+
+func formatMs(ms int64) string {
+	return (time.Duration(ms) * time.Millisecond).String()
+}
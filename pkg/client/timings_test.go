@@ -0,0 +1,79 @@
+package client
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	buildfakes "github.com/buildpacks/pack/internal/build/fakes"
+	"github.com/buildpacks/pack/pkg/events"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestPhaseTimings(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "PhaseTimings", testPhaseTimings, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testPhaseTimings(t *testing.T, when spec.G, it spec.S) {
+	var (
+		next    *buildfakes.FakeEmitter
+		subject *phaseTimings
+	)
+
+	it.Before(func() {
+		next = &buildfakes.FakeEmitter{}
+		subject = newPhaseTimings(next)
+	})
+
+	it("passes events on", func() {
+		subject.Emit(events.Event{Type: events.Output, Line: "some-line"})
+
+		h.AssertEq(t, next.Events(), []events.Event{{Type: events.Output, Line: "some-line"}})
+	})
+
+	it("summarizes phases in the order they ran", func() {
+		subject.Emit(events.Event{Type: events.PhaseStep, Phase: "analyzer", Step: events.StepRun, DurationMs: 1200})
+		subject.Emit(events.Event{Type: events.PhaseEnd, Phase: "analyzer", DurationMs: 1500})
+		subject.Emit(events.Event{Type: events.PhaseStep, Phase: "builder", Step: events.StepCopyOut, DurationMs: 20})
+		subject.Emit(events.Event{Type: events.PhaseEnd, Phase: "builder", DurationMs: 60000})
+
+		summary, err := subject.summary()
+		h.AssertNil(t, err)
+
+		lines := strings.Split(strings.TrimSpace(summary), "\n")
+		h.AssertEq(t, len(lines), 4)
+		h.AssertContainsMatch(t, lines[0], `^PHASE\s+TOTAL\s+CREATE\s+COPY IN\s+RUN\s+COPY OUT$`)
+		h.AssertContainsMatch(t, lines[1], `^analyzer\s+1.5s\s+0s\s+0s\s+1.2s\s+0s$`)
+		h.AssertContainsMatch(t, lines[2], `^builder\s+1m0s\s+0s\s+0s\s+0s\s+20ms$`)
+		h.AssertContainsMatch(t, lines[3], `^total\s+1m1.5s`)
+	})
+
+	it("splits the run time of the creator by the phases it runs", func() {
+		started := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		at := func(ms int) time.Time { return started.Add(time.Duration(ms) * time.Millisecond) }
+
+		subject.Emit(events.Event{Type: events.Output, Phase: "creator", Line: "===> ANALYZING", Time: at(0)})
+		subject.Emit(events.Event{Type: events.Output, Phase: "creator", Line: "some-line", Time: at(100)})
+		subject.Emit(events.Event{Type: events.Output, Phase: "creator", Line: "===> BUILDING", Time: at(300)})
+		subject.Emit(events.Event{Type: events.Output, Phase: "creator", Line: "===> EXPORTING", Time: at(2300)})
+		subject.Emit(events.Event{Type: events.PhaseStep, Phase: "creator", Step: events.StepRun, DurationMs: 3000, Time: at(3000)})
+		subject.Emit(events.Event{Type: events.PhaseEnd, Phase: "creator", DurationMs: 3500, Time: at(3100)})
+
+		summary, err := subject.summary()
+		h.AssertNil(t, err)
+
+		lines := strings.Split(strings.TrimSpace(summary), "\n")
+		h.AssertEq(t, len(lines), 6)
+		h.AssertContainsMatch(t, lines[1], `^creator\s+3.5s\s+0s\s+0s\s+3s\s+0s$`)
+		h.AssertContainsMatch(t, lines[2], `^  analyzing\s+300ms\s*$`)
+		h.AssertContainsMatch(t, lines[3], `^  building\s+2s\s*$`)
+		h.AssertContainsMatch(t, lines[4], `^  exporting\s+700ms\s*$`)
+		h.AssertContainsMatch(t, lines[5], `^total\s+3.5s`)
+	})
+}
@@ -0,0 +1,244 @@
+package client
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	dcontainer "github.com/docker/docker/api/types/container"
+	"github.com/docker/go-connections/nat"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+const defaultWatchInterval = 500 * time.Millisecond
+
+// WatchOptions configures Watch.
+type WatchOptions struct {
+	// Options for each build. The builder, images and buildpacks fetched by the first build are reused by the next
+	// ones, as are its cache volumes.
+	BuildOptions BuildOptions
+
+	// How long the app must go without changes before it is rebuilt. Defaults to 500ms.
+	Debounce time.Duration
+
+	// How often the app is checked for changes. Defaults to 500ms.
+	PollInterval time.Duration
+
+	// Run a container from the image after each successful build, replacing the container of the previous one.
+	// Option only valid if BuildOptions.Publish is false.
+	RunContainer bool
+
+	// Ports the container publishes, in the form '[<host ip>:][<host port>:]<container port>[/<protocol>]'.
+	RunPorts []string
+}
+
+// Watch builds the app, then rebuilds it each time its files change until the context is cancelled. Files excluded
+// by the project descriptor are ignored. Failed builds are logged, and the app is rebuilt on its next change.
+func (c *Client) Watch(ctx context.Context, opts WatchOptions) error {
+	if opts.BuildOptions.Interactive {
+		return errors.New("interactive mode is not supported when watching")
+	}
+	if opts.RunContainer && (opts.BuildOptions.Publish || opts.BuildOptions.Layout()) {
+		return errors.New("only images built to the daemon can be run when watching")
+	}
+
+	exposedPorts, portBindings, err := nat.ParsePortSpecs(opts.RunPorts)
+	if err != nil {
+		return errors.Wrap(err, "parsing ports")
+	}
+
+	appPath, err := c.processAppPath(opts.BuildOptions.AppPath)
+	if err != nil {
+		return errors.Wrapf(err, "invalid app path '%s'", opts.BuildOptions.AppPath)
+	}
+
+	fileFilter, err := getFileFilter(opts.BuildOptions.ProjectDescriptor)
+	if err != nil {
+		return err
+	}
+
+	interval := opts.PollInterval
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+	debounce := opts.Debounce
+	if debounce <= 0 {
+		debounce = defaultWatchInterval
+	}
+
+	snapshot, err := snapshotApp(appPath, fileFilter)
+	if err != nil {
+		return err
+	}
+
+	app := &appContainer{client: c, exposedPorts: exposedPorts, portBindings: portBindings}
+	defer app.remove()
+
+	buildOpts := opts.BuildOptions
+	for {
+		err := c.Build(ctx, buildOpts)
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		if err != nil {
+			c.logger.Errorf("Build failed: %s", err)
+		} else {
+			// later builds reuse what the first one fetched, and the cache it populated
+			buildOpts.ClearCache = false
+			if buildOpts.PullPolicy == image.PullAlways {
+				buildOpts.PullPolicy = image.PullIfNotPresent
+			}
+
+			if opts.RunContainer {
+				if err := app.restart(ctx, buildOpts.Image); err != nil {
+					c.logger.Errorf("Running %s: %s", style.Symbol(buildOpts.Image), err)
+				}
+			}
+		}
+
+		c.logger.Infof("Watching %s for changes", style.Symbol(appPath))
+		snapshot, err = c.waitForChanges(ctx, appPath, fileFilter, snapshot, interval, debounce)
+		if err != nil {
+			return err
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+		c.logger.Infof("Changes detected in %s, rebuilding", style.Symbol(appPath))
+	}
+}
+
+// waitForChanges polls the app until it differs from the given snapshot, then until it stops changing for the
+// debounce duration, and returns its snapshot at that point. It returns early if the context is cancelled.
+func (c *Client) waitForChanges(ctx context.Context, appPath string, fileFilter func(string) bool, last appSnapshot, interval, debounce time.Duration) (appSnapshot, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var changedAt time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return last, nil
+		case <-ticker.C:
+		}
+
+		current, err := snapshotApp(appPath, fileFilter)
+		if err != nil {
+			return last, err
+		}
+
+		if !current.equal(last) {
+			c.logger.Debugf("Detected changes in %s", style.Symbol(appPath))
+			last = current
+			changedAt = time.Now()
+			continue
+		}
+		if !changedAt.IsZero() && time.Since(changedAt) >= debounce {
+			return current, nil
+		}
+	}
+}
+
+type fileState struct {
+	size    int64
+	modTime time.Time
+	mode    os.FileMode
+}
+
+// appSnapshot is the state of each file of an app, by path relative to the app path.
+type appSnapshot map[string]fileState
+
+func (s appSnapshot) equal(other appSnapshot) bool {
+	if len(s) != len(other) {
+		return false
+	}
+	for path, state := range s {
+		otherState, ok := other[path]
+		if !ok || !otherState.modTime.Equal(state.modTime) || otherState.size != state.size || otherState.mode != state.mode {
+			return false
+		}
+	}
+	return true
+}
+
+// snapshotApp records the state of the files of the app the file filter lets into the build, the way they would be
+// selected to be copied into the build container. An app in a zip file is a single file.
+func snapshotApp(appPath string, fileFilter func(string) bool) (appSnapshot, error) {
+	snapshot := appSnapshot{}
+	err := filepath.Walk(appPath, func(file string, fi os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				// removed while walking, the next snapshot will not have it either
+				return nil
+			}
+			return err
+		}
+
+		relPath, err := filepath.Rel(appPath, file)
+		if err != nil {
+			return err
+		}
+		if fi.IsDir() {
+			// the modification time of directories changes with files the filter leaves out, so only their
+			// files are compared
+			if relPath != "." && (fileFilter == nil || fileFilter(relPath)) {
+				snapshot[relPath] = fileState{mode: fi.Mode()}
+			}
+			return nil
+		}
+		if fileFilter != nil && relPath != "." && !fileFilter(relPath) {
+			return nil
+		}
+
+		snapshot[relPath] = fileState{size: fi.Size(), modTime: fi.ModTime(), mode: fi.Mode()}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading app path %s", style.Symbol(appPath))
+	}
+	return snapshot, nil
+}
+
+// appContainer is the container run from the image built by the latest build.
+type appContainer struct {
+	client       *Client
+	exposedPorts nat.PortSet
+	portBindings nat.PortMap
+	id           string
+}
+
+func (a *appContainer) restart(ctx context.Context, imageName string) error {
+	a.remove()
+
+	ctr, err := a.client.docker.ContainerCreate(ctx,
+		&dcontainer.Config{Image: imageName, ExposedPorts: a.exposedPorts},
+		&dcontainer.HostConfig{PortBindings: a.portBindings},
+		nil, nil, "pack-watch-"+randString(10))
+	if err != nil {
+		return errors.Wrap(err, "creating container")
+	}
+	a.id = ctr.ID
+
+	if err := a.client.docker.ContainerStart(ctx, ctr.ID, dcontainer.StartOptions{}); err != nil {
+		return errors.Wrap(err, "starting container")
+	}
+	a.client.logger.Infof("Started container %s from %s", style.Symbol(ctr.ID[:min(12, len(ctr.ID))]), style.Symbol(imageName))
+	return nil
+}
+
+// remove removes the container, if there is one. It runs after the context of the build is cancelled, so it does
+// not use it.
+func (a *appContainer) remove() {
+	if a.id == "" {
+		return
+	}
+	if err := a.client.docker.ContainerRemove(context.Background(), a.id, dcontainer.RemoveOptions{Force: true}); err != nil {
+		a.client.logger.Warnf("Failed to remove container %s: %s", style.Symbol(a.id), err)
+	}
+	a.id = ""
+}
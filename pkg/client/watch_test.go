@@ -0,0 +1,178 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	dcontainer "github.com/docker/docker/api/types/container"
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/logging"
+	projectTypes "github.com/buildpacks/pack/pkg/project/types"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestWatch(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Watch", testWatch, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testWatch(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject          *Client
+		mockDockerClient *testmocks.MockCommonAPIClient
+		mockController   *gomock.Controller
+		out              bytes.Buffer
+		appDir           string
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockDockerClient = testmocks.NewMockCommonAPIClient(mockController)
+
+		var err error
+		subject, err = NewClient(WithLogger(logging.NewLogWithWriters(&out, &out)), WithDockerClient(mockDockerClient))
+		h.AssertNil(t, err)
+
+		appDir = t.TempDir()
+		h.AssertNil(t, os.MkdirAll(filepath.Join(appDir, "src"), 0755))
+		h.AssertNil(t, os.WriteFile(filepath.Join(appDir, "src", "main.go"), []byte("package main"), 0600))
+		h.AssertNil(t, os.WriteFile(filepath.Join(appDir, "notes.log"), []byte("some-notes"), 0600))
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#Watch", func() {
+		it("errors in interactive mode", func() {
+			err := subject.Watch(context.TODO(), WatchOptions{BuildOptions: BuildOptions{Interactive: true}})
+			h.AssertError(t, err, "interactive mode is not supported when watching")
+		})
+
+		it("errors when running a published image", func() {
+			err := subject.Watch(context.TODO(), WatchOptions{BuildOptions: BuildOptions{Publish: true}, RunContainer: true})
+			h.AssertError(t, err, "only images built to the daemon can be run when watching")
+		})
+
+		it("errors on invalid ports", func() {
+			err := subject.Watch(context.TODO(), WatchOptions{RunContainer: true, RunPorts: []string{"not-a-port"}})
+			h.AssertError(t, err, "parsing ports")
+		})
+	})
+
+	when("#snapshotApp", func() {
+		it("records the files of the app", func() {
+			snapshot, err := snapshotApp(appDir, nil)
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, len(snapshot), 3)
+			h.AssertEq(t, snapshot[filepath.Join("src", "main.go")].size, int64(len("package main")))
+			_, ok := snapshot["notes.log"]
+			h.AssertTrue(t, ok)
+		})
+
+		it("leaves out files excluded by the project descriptor", func() {
+			filter, err := getFileFilter(projectTypes.Descriptor{Build: projectTypes.Build{Exclude: []string{"*.log"}}})
+			h.AssertNil(t, err)
+
+			snapshot, err := snapshotApp(appDir, filter)
+			h.AssertNil(t, err)
+
+			_, ok := snapshot["notes.log"]
+			h.AssertFalse(t, ok)
+			_, ok = snapshot[filepath.Join("src", "main.go")]
+			h.AssertTrue(t, ok)
+		})
+
+		it("is unchanged until a file changes", func() {
+			before, err := snapshotApp(appDir, nil)
+			h.AssertNil(t, err)
+
+			same, err := snapshotApp(appDir, nil)
+			h.AssertNil(t, err)
+			h.AssertTrue(t, before.equal(same))
+
+			h.AssertNil(t, os.WriteFile(filepath.Join(appDir, "src", "main.go"), []byte("package main\n\nfunc main() {}"), 0600))
+			after, err := snapshotApp(appDir, nil)
+			h.AssertNil(t, err)
+			h.AssertFalse(t, before.equal(after))
+		})
+	})
+
+	when("#waitForChanges", func() {
+		it("returns once the app stops changing", func() {
+			filter, err := getFileFilter(projectTypes.Descriptor{Build: projectTypes.Build{Exclude: []string{"*.log"}}})
+			h.AssertNil(t, err)
+			snapshot, err := snapshotApp(appDir, filter)
+			h.AssertNil(t, err)
+
+			go func() {
+				time.Sleep(20 * time.Millisecond)
+				_ = os.WriteFile(filepath.Join(appDir, "src", "new.go"), []byte("package main"), 0600)
+			}()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			current, err := subject.waitForChanges(ctx, appDir, filter, snapshot, 5*time.Millisecond, 20*time.Millisecond)
+			h.AssertNil(t, err)
+			h.AssertNil(t, ctx.Err())
+
+			_, ok := current[filepath.Join("src", "new.go")]
+			h.AssertTrue(t, ok)
+		})
+
+		it("ignores changes to excluded files", func() {
+			filter, err := getFileFilter(projectTypes.Descriptor{Build: projectTypes.Build{Exclude: []string{"*.log"}}})
+			h.AssertNil(t, err)
+			snapshot, err := snapshotApp(appDir, filter)
+			h.AssertNil(t, err)
+
+			h.AssertNil(t, os.WriteFile(filepath.Join(appDir, "other.log"), []byte("more-notes"), 0600))
+
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+			current, err := subject.waitForChanges(ctx, appDir, filter, snapshot, 5*time.Millisecond, 5*time.Millisecond)
+			h.AssertNil(t, err)
+			h.AssertNotNil(t, ctx.Err())
+			h.AssertTrue(t, current.equal(snapshot))
+		})
+	})
+
+	when("#appContainer", func() {
+		it("replaces the container of the previous build", func() {
+			app := &appContainer{client: subject}
+
+			gomock.InOrder(
+				mockDockerClient.EXPECT().
+					ContainerCreate(gomock.Any(), gomock.Any(), gomock.Any(), nil, nil, gomock.Any()).
+					DoAndReturn(func(_ context.Context, config *dcontainer.Config, _ *dcontainer.HostConfig, _, _ interface{}, _ string) (dcontainer.CreateResponse, error) {
+						h.AssertEq(t, config.Image, "some/image")
+						return dcontainer.CreateResponse{ID: "first-container"}, nil
+					}),
+				mockDockerClient.EXPECT().ContainerStart(gomock.Any(), "first-container", gomock.Any()).Return(nil),
+				mockDockerClient.EXPECT().ContainerRemove(gomock.Any(), "first-container", dcontainer.RemoveOptions{Force: true}).Return(nil),
+				mockDockerClient.EXPECT().
+					ContainerCreate(gomock.Any(), gomock.Any(), gomock.Any(), nil, nil, gomock.Any()).
+					Return(dcontainer.CreateResponse{ID: "second-container"}, nil),
+				mockDockerClient.EXPECT().ContainerStart(gomock.Any(), "second-container", gomock.Any()).Return(nil),
+				mockDockerClient.EXPECT().ContainerRemove(gomock.Any(), "second-container", dcontainer.RemoveOptions{Force: true}).Return(nil),
+			)
+
+			h.AssertNil(t, app.restart(context.TODO(), "some/image"))
+			h.AssertContains(t, out.String(), "Started container 'first-contai' from 'some/image'")
+			h.AssertNil(t, app.restart(context.TODO(), "some/image"))
+			app.remove()
+			app.remove()
+		})
+	})
+}
@@ -14,7 +14,8 @@ const (
 )
 
 type BuildpackURI struct {
-	URI string `toml:"uri"`
+	URI    string `toml:"uri"`
+	SHA256 string `toml:"sha256,omitempty"`
 }
 
 type ImageRef struct {
@@ -0,0 +1,147 @@
+// Package events defines the machine-readable events emitted while building an image, and an Emitter writing them
+// as JSON lines.
+package events
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+type Type string
+
+const (
+	// PhaseStart is emitted when a lifecycle phase starts.
+	PhaseStart Type = "phaseStart"
+	// PhaseEnd is emitted when a lifecycle phase ends, with its duration and any error.
+	PhaseEnd Type = "phaseEnd"
+	// PhaseStep is emitted when a step of running a phase's container ends, with its duration.
+	PhaseStep Type = "phaseStep"
+	// Output is emitted for each line a lifecycle phase writes.
+	Output Type = "output"
+	// Group is emitted with the group of buildpacks and extensions that passed detection, read from the group.toml
+	// written by the lifecycle.
+	Group Type = "group"
+	// Cache is emitted for each cached layer once the cache is exported, telling whether it was reused from the previous
+	// build. It is only emitted for volume and bind caches.
+	Cache Type = "cache"
+	// Image is emitted once the image is exported, with its digest.
+	Image Type = "image"
+	// Detect is emitted once detection is done, explaining why each group of the order passed or failed, when requested.
+	Detect Type = "detect"
+)
+
+// Steps of running a phase's container.
+const (
+	// StepCreate creates the container.
+	StepCreate = "create"
+	// StepCopyIn copies the app and other files into the container before it starts.
+	StepCopyIn = "copyIn"
+	// StepRun runs the lifecycle in the container.
+	StepRun = "run"
+	// StepCopyOut copies files such as reports out of the container once it exits.
+	StepCopyOut = "copyOut"
+)
+
+// Event describes something that happened during a build. Which fields are set depends on Type.
+type Event struct {
+	Type       Type         `json:"type"`
+	Time       time.Time    `json:"time"`
+	Phase      string       `json:"phase,omitempty"`
+	Step       string       `json:"step,omitempty"`
+	Buildpack  string       `json:"buildpack,omitempty"`
+	Stream     string       `json:"stream,omitempty"`
+	Line       string       `json:"line,omitempty"`
+	DurationMs int64        `json:"durationMs,omitempty"`
+	Error      string       `json:"error,omitempty"`
+	Group      []Module     `json:"group,omitempty"`
+	Cache      *CacheResult `json:"cache,omitempty"`
+	Image      string       `json:"image,omitempty"`
+	Digest     string       `json:"digest,omitempty"`
+	Detect     *Detection   `json:"detect,omitempty"`
+}
+
+// Module identifies a buildpack or extension.
+type Module struct {
+	ID      string `json:"id"`
+	Version string `json:"version,omitempty"`
+}
+
+// Statuses of the buildpacks and groups of a Detection.
+const (
+	// DetectPass is a buildpack that passed detection, or a group of a composite buildpack whose buildpacks did.
+	DetectPass = "pass"
+	// DetectFail is a buildpack that failed detection, or a group that did not pass.
+	DetectFail = "fail"
+	// DetectSkip is an optional buildpack that failed detection, which doesn't fail its group.
+	DetectSkip = "skip"
+	// DetectError is a buildpack whose detection errored, or couldn't run on the target of the run image.
+	DetectError = "error"
+	// DetectNotRun is a buildpack or group not tried, as a group before it was selected.
+	DetectNotRun = "not-run"
+	// DetectSelected is the group selected to build the app.
+	DetectSelected = "selected"
+)
+
+// Detection explains detection: the groups of the order of the builder, with why each of their buildpacks passed or
+// failed, and the group selected to build the app, if any.
+type Detection struct {
+	Order []DetectGroup `json:"order"`
+	// Selected is the group of buildpacks participating in the build, which is empty when detection failed.
+	Selected []Module `json:"selected,omitempty"`
+}
+
+// DetectGroup is a group of the order of the builder, or of a composite buildpack.
+type DetectGroup struct {
+	Status     string            `json:"status"`
+	Buildpacks []DetectBuildpack `json:"buildpacks"`
+}
+
+// DetectBuildpack is a buildpack of a DetectGroup, with its detection status. Composite buildpacks have an order
+// instead, whose groups are tried in place of them.
+type DetectBuildpack struct {
+	ID       string `json:"id"`
+	Version  string `json:"version,omitempty"`
+	Optional bool   `json:"optional,omitempty"`
+	Status   string `json:"status,omitempty"`
+	// Unmet requires and unused provides of the buildpack when resolving the build plan of its group, such as
+	// "requires node"
+	Resolution []string      `json:"resolution,omitempty"`
+	Order      []DetectGroup `json:"order,omitempty"`
+}
+
+// CacheResult tells whether a cached layer was reused (a hit) or had to be created (a miss).
+type CacheResult struct {
+	Layer string `json:"layer"`
+	Hit   bool   `json:"hit"`
+}
+
+// Emitter receives build events. Implementations must be safe for concurrent use, as phases may run in parallel.
+type Emitter interface {
+	Emit(event Event)
+}
+
+// JSONWriter is an Emitter writing each event as a line of JSON.
+type JSONWriter struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+	now func() time.Time
+}
+
+// NewJSONWriter returns a JSONWriter writing to w.
+func NewJSONWriter(w io.Writer) *JSONWriter {
+	return &JSONWriter{enc: json.NewEncoder(w), now: time.Now}
+}
+
+// Emit writes the event, setting its time if it is not set. Write errors are ignored so that a closed output does not
+// fail the build.
+func (w *JSONWriter) Emit(event Event) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if event.Time.IsZero() {
+		event.Time = w.now()
+	}
+	_ = w.enc.Encode(event)
+}
@@ -0,0 +1,77 @@
+package events_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/events"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestEvents(t *testing.T) {
+	spec.Run(t, "Events", testEvents, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testEvents(t *testing.T, when spec.G, it spec.S) {
+	when("JSONWriter", func() {
+		var (
+			out    bytes.Buffer
+			writer *events.JSONWriter
+		)
+
+		it.Before(func() {
+			out.Reset()
+			writer = events.NewJSONWriter(&out)
+		})
+
+		it("writes each event on its own line", func() {
+			writer.Emit(events.Event{Type: events.PhaseStart, Phase: "detector"})
+			writer.Emit(events.Event{Type: events.PhaseEnd, Phase: "detector", DurationMs: 1500})
+
+			lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+			h.AssertEq(t, len(lines), 2)
+
+			var event events.Event
+			h.AssertNil(t, json.Unmarshal([]byte(lines[1]), &event))
+			h.AssertEq(t, event.Type, events.PhaseEnd)
+			h.AssertEq(t, event.Phase, "detector")
+			h.AssertEq(t, event.DurationMs, int64(1500))
+		})
+
+		it("sets the time of events without one", func() {
+			before := time.Now()
+			writer.Emit(events.Event{Type: events.Output, Line: "some-line"})
+
+			var event events.Event
+			h.AssertNil(t, json.Unmarshal(out.Bytes(), &event))
+			h.AssertFalse(t, event.Time.Before(before.Truncate(time.Second)))
+		})
+
+		it("keeps the time of events with one", func() {
+			eventTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+			writer.Emit(events.Event{Type: events.Output, Time: eventTime})
+
+			var event events.Event
+			h.AssertNil(t, json.Unmarshal(out.Bytes(), &event))
+			h.AssertTrue(t, event.Time.Equal(eventTime))
+		})
+
+		it("omits fields that do not apply to the event", func() {
+			writer.Emit(events.Event{
+				Type:  events.Cache,
+				Time:  time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+				Phase: "exporter",
+				Cache: &events.CacheResult{Layer: "some/buildpack:some-layer", Hit: false},
+			})
+
+			h.AssertEq(t, strings.TrimSpace(out.String()),
+				`{"type":"cache","time":"2024-01-02T03:04:05Z","phase":"exporter","cache":{"layer":"some/buildpack:some-layer","hit":false}}`)
+		})
+	})
+}
@@ -50,6 +50,14 @@ func WithKeychain(keychain authn.Keychain) FetcherOption {
 	}
 }
 
+// WithOffline restricts the fetcher to images already present in the daemon or in an OCI layout.
+// Registries are never contacted, regardless of the pull policy.
+func WithOffline(offline bool) FetcherOption {
+	return func(c *Fetcher) {
+		c.offline = offline
+	}
+}
+
 type DockerClient interface {
 	local.DockerClient
 	ImagePull(ctx context.Context, ref string, options image.PullOptions) (io.ReadCloser, error)
@@ -60,6 +68,7 @@ type Fetcher struct {
 	logger          logging.Logger
 	registryMirrors map[string]string
 	keychain        authn.Keychain
+	offline         bool
 }
 
 type FetchOptions struct {
@@ -83,14 +92,26 @@ func NewFetcher(logger logging.Logger, docker DockerClient, opts ...FetcherOptio
 	return fetcher
 }
 
+// SetOffline changes whether the fetcher is restricted to local images, as set by WithOffline.
+func (f *Fetcher) SetOffline(offline bool) {
+	f.offline = offline
+}
+
 var ErrNotFound = errors.New("not found")
 
+// ErrOffline is returned when an image can only be fetched from a registry while the fetcher is offline.
+var ErrOffline = errors.New("offline")
+
 func (f *Fetcher) Fetch(ctx context.Context, name string, options FetchOptions) (imgutil.Image, error) {
 	name, err := pname.TranslateRegistry(name, f.registryMirrors, f.logger)
 	if err != nil {
 		return nil, err
 	}
 
+	if f.offline {
+		return f.fetchOffline(name, options)
+	}
+
 	if (options.LayoutOption != LayoutOption{}) {
 		return f.fetchLayoutImage(name, options.LayoutOption)
 	}
@@ -137,6 +158,14 @@ func (f *Fetcher) Fetch(ctx context.Context, name string, options FetchOptions)
 }
 
 func (f *Fetcher) CheckReadAccess(repo string, options FetchOptions) bool {
+	if f.offline {
+		if !options.Daemon {
+			f.logger.Debugf("CheckReadAccess skipped for remote image %s in offline mode", repo)
+			return false
+		}
+		_, err := f.fetchDaemonImage(repo)
+		return err == nil
+	}
 	if !options.Daemon || options.PullPolicy == PullAlways {
 		return f.checkRemoteReadAccess(repo)
 	}
@@ -171,6 +200,29 @@ func (f *Fetcher) checkRemoteReadAccess(repo string) bool {
 	}
 }
 
+func (f *Fetcher) fetchOffline(name string, options FetchOptions) (imgutil.Image, error) {
+	if (options.LayoutOption != LayoutOption{}) {
+		image, err := layout.NewImage(options.LayoutOption.Path, layout.FromBaseImagePath(options.LayoutOption.Path))
+		if err != nil {
+			return nil, err
+		}
+		if !image.Found() {
+			return nil, errors.Wrapf(ErrOffline, "image %s does not exist in OCI layout %s and cannot be pulled in offline mode", style.Symbol(name), style.Symbol(options.LayoutOption.Path))
+		}
+		return image, nil
+	}
+
+	if !options.Daemon {
+		return nil, errors.Wrapf(ErrOffline, "image %s cannot be fetched from a registry in offline mode", style.Symbol(name))
+	}
+
+	image, err := f.fetchDaemonImage(name)
+	if errors.Is(err, ErrNotFound) {
+		return nil, errors.Wrapf(ErrOffline, "image %s does not exist on the daemon and cannot be pulled in offline mode", style.Symbol(name))
+	}
+	return image, err
+}
+
 func (f *Fetcher) fetchDaemonImage(name string) (imgutil.Image, error) {
 	image, err := local.NewImage(name, f.docker, local.FromBaseImage(name))
 	if err != nil {
@@ -447,6 +447,55 @@ func testFetcher(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 		})
+
+		when("offline", func() {
+			it.Before(func() {
+				imageFetcher = image.NewFetcher(logging.NewLogWithWriters(&outBuf, &outBuf, logging.WithVerbose()), docker, image.WithOffline(true))
+
+				img, err := remote.NewImage(repoName, authn.DefaultKeychain)
+				h.AssertNil(t, err)
+				h.AssertNil(t, img.Save())
+			})
+
+			when("daemon is false", func() {
+				it("returns an error without accessing the registry", func() {
+					_, err := imageFetcher.Fetch(context.TODO(), repoName, image.FetchOptions{Daemon: false, PullPolicy: image.PullAlways})
+					h.AssertError(t, err, fmt.Sprintf("image '%s' cannot be fetched from a registry in offline mode", repoName))
+					h.AssertTrue(t, errors.Is(err, image.ErrOffline))
+				})
+			})
+
+			when("daemon is true", func() {
+				when("there is a local image", func() {
+					it.Before(func() {
+						img, err := local.NewImage(repoName, docker)
+						h.AssertNil(t, err)
+						h.AssertNil(t, img.Save())
+					})
+
+					it.After(func() {
+						h.DockerRmi(docker, repoName)
+					})
+
+					it("returns the local image regardless of pull policy", func() {
+						_, err := imageFetcher.Fetch(context.TODO(), repoName, image.FetchOptions{Daemon: true, PullPolicy: image.PullAlways})
+						h.AssertNil(t, err)
+						h.AssertNotContains(t, outBuf.String(), "Pulling image")
+					})
+				})
+
+				when("there is no local image", func() {
+					it("returns an error instead of pulling", func() {
+						_, err := imageFetcher.Fetch(context.TODO(), repoName, image.FetchOptions{Daemon: true, PullPolicy: image.PullIfNotPresent})
+						h.AssertError(t, err, fmt.Sprintf("image '%s' does not exist on the daemon and cannot be pulled in offline mode", repoName))
+					})
+
+					it("has no read access", func() {
+						h.AssertFalse(t, imageFetcher.CheckReadAccess(repoName, image.FetchOptions{Daemon: true, PullPolicy: image.PullIfNotPresent}))
+					})
+				})
+			})
+		})
 	})
 
 	when("#CheckReadAccess", func() {
@@ -36,10 +36,11 @@ var _ Logger = (*LogWithWriters)(nil)
 type LogWithWriters struct {
 	sync.Mutex
 	log.Logger
-	wantTime bool
-	clock    func() time.Time
-	out      io.Writer
-	errOut   io.Writer
+	wantTime             bool
+	wantWarningsOnStderr bool
+	clock                func() time.Time
+	out                  io.Writer
+	errOut               io.Writer
 }
 
 // NewLogWithWriters creates a logger to be used with pack CLI.
@@ -93,7 +94,7 @@ func (lw *LogWithWriters) WriterForLevel(level Level) io.Writer {
 		return io.Discard
 	}
 
-	if level == ErrorLevel {
+	if level == ErrorLevel || (level == WarnLevel && lw.wantWarningsOnStderr) {
 		return newLogWriter(lw.errOut, lw.clock, lw.wantTime)
 	}
 
@@ -110,6 +111,12 @@ func (lw *LogWithWriters) WantTime(f bool) {
 	lw.wantTime = f
 }
 
+// WantWarningsOnStderr writes warnings to the error writer rather than the standard one, so that the standard
+// writer is left to machine-readable output
+func (lw *LogWithWriters) WantWarningsOnStderr(f bool) {
+	lw.wantWarningsOnStderr = f
+}
+
 // WantQuiet reduces the number of logs returned
 func (lw *LogWithWriters) WantQuiet(f bool) {
 	if f {
@@ -119,6 +119,17 @@ func testLogWithWriters(t *testing.T, when spec.G, it spec.S) {
 		})
 	})
 
+	when("warnings are wanted on stderr", func() {
+		it("logs warnings to error writer", func() {
+			logger.WantWarningsOnStderr(true)
+			logger.Info("info_")
+			logger.Warn("warn_")
+
+			h.AssertEq(t, fOut(), "info_\n")
+			h.AssertContains(t, fErr(), "warn_\n")
+		})
+	})
+
 	when("colors are disabled", func() {
 		it("don't display colors", func() {
 			outCons.DisableColors(true)
@@ -34,47 +34,81 @@ func ReadProjectDescriptor(pathToFile string, logger logging.Logger) (types.Desc
 		return types.Descriptor{}, err
 	}
 
-	var versionDescriptor struct {
-		Project struct {
-			Version string `toml:"schema-version"`
-		} `toml:"_"`
-	}
-
-	_, err = toml.Decode(string(projectTomlContents), &versionDescriptor)
+	version, err := readSchemaVersion(projectTomlContents)
 	if err != nil {
-		return types.Descriptor{}, errors.Wrapf(err, "parsing schema version")
+		return types.Descriptor{}, err
 	}
-
-	version := versionDescriptor.Project.Version
 	if version == "" {
 		logger.Warn("No schema version declared in project.toml, defaulting to schema version 0.1")
 		version = "0.1"
 	}
 
-	if _, ok := parsers[version]; !ok {
-		return types.Descriptor{}, fmt.Errorf("unknown project descriptor schema version %s", version)
+	descriptor, unsupportedKeys, err := parseProjectDescriptor(version, projectTomlContents)
+	if err != nil {
+		return types.Descriptor{}, err
 	}
 
-	descriptor, tomlMetaData, err := parsers[version](string(projectTomlContents))
+	warnIfTomlContainsKeysNotSupportedBySchema(version, unsupportedKeys, logger)
+
+	return descriptor, Validate(descriptor)
+}
+
+// ParseProjectDescriptor parses the project descriptor at pathToFile without validating it. It returns the declared
+// schema version, which is empty when the descriptor defaults to 0.1, and the keys the schema doesn't support.
+func ParseProjectDescriptor(pathToFile string) (descriptor types.Descriptor, schemaVersion string, unsupportedKeys []string, err error) {
+	projectTomlContents, err := os.ReadFile(filepath.Clean(pathToFile))
 	if err != nil {
-		return types.Descriptor{}, err
+		return types.Descriptor{}, "", nil, err
 	}
 
-	warnIfTomlContainsKeysNotSupportedBySchema(version, tomlMetaData, logger)
+	schemaVersion, err = readSchemaVersion(projectTomlContents)
+	if err != nil {
+		return types.Descriptor{}, "", nil, err
+	}
 
-	return descriptor, validate(descriptor)
+	version := schemaVersion
+	if version == "" {
+		version = "0.1"
+	}
+	descriptor, unsupportedKeys, err = parseProjectDescriptor(version, projectTomlContents)
+	return descriptor, schemaVersion, unsupportedKeys, err
 }
 
-func warnIfTomlContainsKeysNotSupportedBySchema(schemaVersion string, tomlMetaData toml.MetaData, logger logging.Logger) {
-	unsupportedKeys := []string{}
+func readSchemaVersion(projectTomlContents []byte) (string, error) {
+	var versionDescriptor struct {
+		Project struct {
+			Version string `toml:"schema-version"`
+		} `toml:"_"`
+	}
 
+	_, err := toml.Decode(string(projectTomlContents), &versionDescriptor)
+	if err != nil {
+		return "", errors.Wrapf(err, "parsing schema version")
+	}
+	return versionDescriptor.Project.Version, nil
+}
+
+func parseProjectDescriptor(version string, projectTomlContents []byte) (types.Descriptor, []string, error) {
+	if _, ok := parsers[version]; !ok {
+		return types.Descriptor{}, nil, fmt.Errorf("unknown project descriptor schema version %s", version)
+	}
+
+	descriptor, tomlMetaData, err := parsers[version](string(projectTomlContents))
+	if err != nil {
+		return types.Descriptor{}, nil, err
+	}
+
+	var unsupportedKeys []string
 	for _, undecodedKey := range tomlMetaData.Undecoded() {
 		keyName := undecodedKey.String()
-		if unsupportedKey(keyName, schemaVersion) {
+		if unsupportedKey(keyName, version) {
 			unsupportedKeys = append(unsupportedKeys, keyName)
 		}
 	}
+	return descriptor, unsupportedKeys, nil
+}
 
+func warnIfTomlContainsKeysNotSupportedBySchema(schemaVersion string, unsupportedKeys []string, logger logging.Logger) {
 	if len(unsupportedKeys) != 0 {
 		logger.Warnf("The following keys declared in project.toml are not supported in schema version %s:\n", schemaVersion)
 		for _, unsupportedKey := range unsupportedKeys {
@@ -95,7 +129,8 @@ func unsupportedKey(keyName, schemaVersion string) bool {
 	return true
 }
 
-func validate(p types.Descriptor) error {
+// Validate returns the first inconsistency of the project descriptor, if any.
+func Validate(p types.Descriptor) error {
 	if p.Build.Exclude != nil && p.Build.Include != nil {
 		return errors.New("project.toml: cannot have both include and exclude defined")
 	}
@@ -117,5 +152,16 @@ func validate(p types.Descriptor) error {
 		}
 	}
 
+	bindings := map[string]bool{}
+	for _, binding := range p.Build.Bindings {
+		if binding.Name == "" || binding.Path == "" {
+			return errors.New("project.toml: bindings must have a name and path defined")
+		}
+		if bindings[binding.Name] {
+			return errors.Errorf("project.toml: binding %s is defined more than once", binding.Name)
+		}
+		bindings[binding.Name] = true
+	}
+
 	return nil
 }
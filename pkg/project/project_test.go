@@ -12,6 +12,7 @@ import (
 	"github.com/sclevine/spec/report"
 
 	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/project/types"
 	h "github.com/buildpacks/pack/testhelpers"
 )
 
@@ -175,6 +176,31 @@ value = "-Xmx300m"
 					expected, projectDescriptor.Build.Env[0].Value)
 			}
 		})
+		it("should parse bindings of a v0.2 project.toml file", func() {
+			projectToml := `
+[_]
+name = "gallant 0.2"
+schema-version="0.2"
+[[io.buildpacks.bindings]]
+name = "db"
+type = "postgresql"
+provider = "bitnami"
+path = "bindings/db"
+`
+			tmpProjectToml, err := createTmpProjectTomlFile(projectToml)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			projectDescriptor, err := ReadProjectDescriptor(tmpProjectToml.Name(), logger)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			h.AssertEq(t, projectDescriptor.Build.Bindings, []types.Binding{
+				{Name: "db", Type: "postgresql", Provider: "bitnami", Path: "bindings/db"},
+			})
+		})
 		it("should parse a valid v0.1 project.toml file", func() {
 			projectToml := `
 [project]
@@ -370,6 +396,46 @@ version = "1.2.3"
 			}
 		})
 
+		it("should require a name and path for bindings", func() {
+			projectToml := `
+[project]
+name = "bindings should have a name and path defined"
+
+[[build.bindings]]
+name = "db"
+type = "postgresql"
+`
+			tmpProjectToml, err := createTmpProjectTomlFile(projectToml)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = ReadProjectDescriptor(tmpProjectToml.Name(), logger)
+			h.AssertError(t, err, "bindings must have a name and path defined")
+		})
+
+		it("should not allow bindings with the same name", func() {
+			projectToml := `
+[project]
+name = "bindings should have distinct names"
+
+[[build.bindings]]
+name = "db"
+path = "bindings/db"
+
+[[build.bindings]]
+name = "db"
+path = "bindings/other-db"
+`
+			tmpProjectToml, err := createTmpProjectTomlFile(projectToml)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = ReadProjectDescriptor(tmpProjectToml.Name(), logger)
+			h.AssertError(t, err, "binding db is defined more than once")
+		})
+
 		it("should require either a type or uri for licenses", func() {
 			projectToml := `
 [project]
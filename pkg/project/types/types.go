@@ -14,6 +14,7 @@ type Buildpack struct {
 	ID      string `toml:"id"`
 	Version string `toml:"version"`
 	URI     string `toml:"uri"`
+	SHA256  string `toml:"sha256,omitempty"`
 	Script  Script `toml:"script"`
 }
 
@@ -22,11 +23,21 @@ type EnvVar struct {
 	Value string `toml:"value"`
 }
 
+// Binding is a service binding given to the buildpacks, following the Kubernetes service binding specification.
+type Binding struct {
+	Name     string `toml:"name"`
+	Type     string `toml:"type"`
+	Provider string `toml:"provider"`
+	// Path is the directory holding the entries of the binding, one file per entry
+	Path string `toml:"path"`
+}
+
 type Build struct {
 	Include    []string    `toml:"include"`
 	Exclude    []string    `toml:"exclude"`
 	Buildpacks []Buildpack `toml:"buildpacks"`
 	Env        []EnvVar    `toml:"env"`
+	Bindings   []Binding   `toml:"bindings"`
 	Builder    string      `toml:"builder"`
 	Pre        GroupAddition
 	Post       GroupAddition
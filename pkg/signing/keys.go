@@ -0,0 +1,141 @@
+package signing
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+const (
+	pemTypePrivateKey          = "PRIVATE KEY"
+	pemTypeECPrivateKey        = "EC PRIVATE KEY"
+	pemTypeRSAPrivateKey       = "RSA PRIVATE KEY"
+	pemTypeEncryptedSigstore   = "ENCRYPTED SIGSTORE PRIVATE KEY"
+	pemTypeEncryptedCosign     = "ENCRYPTED COSIGN PRIVATE KEY"
+	pemTypePublicKey           = "PUBLIC KEY"
+	encryptedKeyKDF            = "scrypt"
+	encryptedKeyCipher         = "nacl/secretbox"
+	encryptedKeySecretBoxBytes = 32
+)
+
+// encryptedKey is the format of private keys generated by 'cosign generate-key-pair'.
+type encryptedKey struct {
+	KDF struct {
+		Name   string `json:"name"`
+		Params struct {
+			N int `json:"N"`
+			R int `json:"r"`
+			P int `json:"p"`
+		} `json:"params"`
+		Salt []byte `json:"salt"`
+	} `json:"kdf"`
+	Cipher struct {
+		Name  string `json:"name"`
+		Nonce []byte `json:"nonce"`
+	} `json:"cipher"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// LoadPrivateKey reads a PEM encoded private key. Keys generated by 'cosign generate-key-pair' are decrypted
+// with the given password, and unencrypted PKCS#8, EC and RSA private keys are supported as well.
+func LoadPrivateKey(path string, password []byte) (crypto.Signer, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+
+	der := block.Bytes
+	switch block.Type {
+	case pemTypeEncryptedSigstore, pemTypeEncryptedCosign:
+		if der, err = decrypt(block.Bytes, password); err != nil {
+			return nil, errors.Wrapf(err, "decrypting %s", style.Symbol(path))
+		}
+	case pemTypeECPrivateKey:
+		return x509.ParseECPrivateKey(der)
+	case pemTypeRSAPrivateKey:
+		return x509.ParsePKCS1PrivateKey(der)
+	case pemTypePrivateKey:
+	default:
+		return nil, errors.Errorf("unsupported private key type %s in %s", style.Symbol(block.Type), style.Symbol(path))
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing private key %s", style.Symbol(path))
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, errors.Errorf("unsupported private key in %s", style.Symbol(path))
+	}
+	return signer, nil
+}
+
+// LoadPublicKey reads a PEM encoded PKIX public key, such as 'cosign.pub'.
+func LoadPublicKey(path string) (crypto.PublicKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+	if block.Type != pemTypePublicKey {
+		return nil, errors.Errorf("unsupported public key type %s in %s", style.Symbol(block.Type), style.Symbol(path))
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing public key %s", style.Symbol(path))
+	}
+	return key, nil
+}
+
+func readPEM(path string) (*pem.Block, error) {
+	contents, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading key %s", style.Symbol(path))
+	}
+
+	block, _ := pem.Decode(contents)
+	if block == nil {
+		return nil, errors.Errorf("no PEM data found in %s", style.Symbol(path))
+	}
+	return block, nil
+}
+
+func decrypt(contents, password []byte) ([]byte, error) {
+	var key encryptedKey
+	if err := json.Unmarshal(contents, &key); err != nil {
+		return nil, err
+	}
+	if key.KDF.Name != encryptedKeyKDF || key.Cipher.Name != encryptedKeyCipher {
+		return nil, errors.Errorf("unsupported key encryption %s with %s", style.Symbol(key.Cipher.Name), style.Symbol(key.KDF.Name))
+	}
+	if len(key.Cipher.Nonce) != 24 {
+		return nil, errors.New("invalid nonce")
+	}
+
+	secret, err := scrypt.Key(password, key.KDF.Salt, key.KDF.Params.N, key.KDF.Params.R, key.KDF.Params.P, encryptedKeySecretBoxBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		nonce     [24]byte
+		secretKey [encryptedKeySecretBoxBytes]byte
+	)
+	copy(nonce[:], key.Cipher.Nonce)
+	copy(secretKey[:], secret)
+
+	decrypted, ok := secretbox.Open(nil, key.Ciphertext, &nonce, &secretKey)
+	if !ok {
+		return nil, errors.New("invalid password")
+	}
+	return decrypted, nil
+}
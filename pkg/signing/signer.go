@@ -0,0 +1,94 @@
+package signing
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"encoding/base64"
+	"net/http"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/pkg/errors"
+)
+
+// Signer signs images in a registry with a private key.
+type Signer struct {
+	key crypto.Signer
+}
+
+// NewSigner returns a Signer for the given private key.
+func NewSigner(key crypto.Signer) *Signer {
+	return &Signer{key: key}
+}
+
+// LoadSigner returns a Signer for the private key at the given path. See LoadPrivateKey for supported formats.
+func LoadSigner(path string, password []byte) (*Signer, error) {
+	key, err := LoadPrivateKey(path, password)
+	if err != nil {
+		return nil, err
+	}
+	return NewSigner(key), nil
+}
+
+// Sign signs the image with the given digest and pushes the signature next to it, keeping any
+// signatures the image already has. It returns the tag the signatures are stored at.
+func (s *Signer) Sign(ctx context.Context, digest name.Digest, keychain authn.Keychain) (name.Tag, error) {
+	payload, err := newPayload(digest)
+	if err != nil {
+		return name.Tag{}, errors.Wrap(err, "creating signature payload")
+	}
+
+	message, opts, err := signerOpts(s.key.Public(), payload)
+	if err != nil {
+		return name.Tag{}, err
+	}
+	signature, err := s.key.Sign(rand.Reader, message, opts)
+	if err != nil {
+		return name.Tag{}, errors.Wrapf(err, "signing %s", digest.Name())
+	}
+
+	tag := SignatureTag(digest)
+	remoteOpts := []remote.Option{remote.WithContext(ctx), remote.WithAuthFromKeychain(keychain)}
+
+	signatures, err := existingSignatures(tag, remoteOpts...)
+	if err != nil {
+		return name.Tag{}, errors.Wrapf(err, "fetching existing signatures of %s", digest.Name())
+	}
+
+	signatures, err = mutate.Append(signatures, mutate.Addendum{
+		Layer: static.NewLayer(payload, SimpleSigningMediaType),
+		Annotations: map[string]string{
+			SignatureAnnotation: base64.StdEncoding.EncodeToString(signature),
+		},
+	})
+	if err != nil {
+		return name.Tag{}, err
+	}
+
+	if err := remote.Write(tag, signatures, remoteOpts...); err != nil {
+		return name.Tag{}, errors.Wrapf(err, "pushing signature of %s", digest.Name())
+	}
+	return tag, nil
+}
+
+func existingSignatures(tag name.Tag, opts ...remote.Option) (v1.Image, error) {
+	img, err := remote.Image(tag, opts...)
+	if err == nil {
+		return img, nil
+	}
+
+	var terr *transport.Error
+	if errors.As(err, &terr) && terr.StatusCode == http.StatusNotFound {
+		img := mutate.MediaType(empty.Image, types.OCIManifestSchema1)
+		return mutate.ConfigMediaType(img, types.OCIConfigJSON), nil
+	}
+	return nil, err
+}
@@ -0,0 +1,84 @@
+// Package signing signs and verifies images in a format compatible with cosign.
+//
+// Signatures are stored next to the signed image, in an image tagged 'sha256-<digest>.sig' whose layers
+// each hold a simple signing payload naming the signed manifest digest, with the signature of the payload
+// in the 'dev.cosignproject.cosign/signature' annotation.
+package signing
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/pkg/errors"
+)
+
+const (
+	// PasswordEnvVar is the environment variable holding the password of encrypted signing keys.
+	PasswordEnvVar = "COSIGN_PASSWORD"
+
+	// SimpleSigningMediaType is the media type of signature payload layers.
+	SimpleSigningMediaType = "application/vnd.dev.cosign.simplesigning.v1+json"
+
+	// SignatureAnnotation is the layer annotation holding the base64 encoded signature of the payload.
+	SignatureAnnotation = "dev.cosignproject.cosign/signature"
+
+	signatureTagSuffix = "sig"
+	payloadType        = "cosign container image signature"
+)
+
+// Payload is the simple signing payload that is signed for an image.
+type Payload struct {
+	Critical Critical               `json:"critical"`
+	Optional map[string]interface{} `json:"optional"`
+}
+
+type Critical struct {
+	Identity Identity `json:"identity"`
+	Image    Image    `json:"image"`
+	Type     string   `json:"type"`
+}
+
+type Identity struct {
+	DockerReference string `json:"docker-reference"`
+}
+
+type Image struct {
+	DockerManifestDigest string `json:"docker-manifest-digest"`
+}
+
+// SignatureTag returns the tag the signatures of the image with the given digest are stored at.
+func SignatureTag(digest name.Digest) name.Tag {
+	tag := fmt.Sprintf("%s.%s", strings.Replace(digest.DigestStr(), ":", "-", 1), signatureTagSuffix)
+	return digest.Context().Tag(tag)
+}
+
+func newPayload(digest name.Digest) ([]byte, error) {
+	return json.Marshal(Payload{
+		Critical: Critical{
+			Identity: Identity{DockerReference: digest.Context().Name()},
+			Image:    Image{DockerManifestDigest: digest.DigestStr()},
+			Type:     payloadType,
+		},
+	})
+}
+
+// signerOpts returns the message to sign and the options to sign it with for the given key type.
+// ECDSA and RSA keys sign the sha256 digest of the payload, while ed25519 keys sign the payload itself.
+func signerOpts(key crypto.PublicKey, payload []byte) ([]byte, crypto.SignerOpts, error) {
+	switch key.(type) {
+	case *ecdsa.PublicKey, *rsa.PublicKey:
+		sum := sha256.Sum256(payload)
+		return sum[:], crypto.SHA256, nil
+	case ed25519.PublicKey:
+		return payload, crypto.Hash(0), nil
+	default:
+		return nil, nil, errors.Errorf("unsupported key type %T", key)
+	}
+}
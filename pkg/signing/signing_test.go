@@ -0,0 +1,283 @@
+package signing_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"log"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/buildpacks/pack/pkg/signing"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestSigning(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Signing", testSigning, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testSigning(t *testing.T, when spec.G, it spec.S) {
+	var tmpDir string
+
+	it.Before(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "signing")
+		h.AssertNil(t, err)
+	})
+
+	it.After(func() {
+		h.AssertNil(t, os.RemoveAll(tmpDir))
+	})
+
+	when("#SignatureTag", func() {
+		it("names the tag after the digest", func() {
+			digest, err := name.NewDigest("registry.example.com/some/app@sha256:363c754893f0efe22480b4359a5956cf3bd3ce22742fc576973c61348308c2e4")
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, signing.SignatureTag(digest).Name(),
+				"registry.example.com/some/app:sha256-363c754893f0efe22480b4359a5956cf3bd3ce22742fc576973c61348308c2e4.sig")
+		})
+	})
+
+	when("#LoadPrivateKey", func() {
+		var key *ecdsa.PrivateKey
+
+		it.Before(func() {
+			var err error
+			key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			h.AssertNil(t, err)
+		})
+
+		it("loads unencrypted PKCS#8 keys", func() {
+			der, err := x509.MarshalPKCS8PrivateKey(key)
+			h.AssertNil(t, err)
+			path := writePEM(t, tmpDir, "PRIVATE KEY", der)
+
+			loaded, err := signing.LoadPrivateKey(path, nil)
+			h.AssertNil(t, err)
+			h.AssertTrue(t, key.PublicKey.Equal(loaded.Public()))
+		})
+
+		it("loads EC keys", func() {
+			der, err := x509.MarshalECPrivateKey(key)
+			h.AssertNil(t, err)
+			path := writePEM(t, tmpDir, "EC PRIVATE KEY", der)
+
+			loaded, err := signing.LoadPrivateKey(path, nil)
+			h.AssertNil(t, err)
+			h.AssertTrue(t, key.PublicKey.Equal(loaded.Public()))
+		})
+
+		when("the key is encrypted", func() {
+			var path string
+
+			it.Before(func() {
+				path = writePEM(t, tmpDir, "ENCRYPTED SIGSTORE PRIVATE KEY", encryptKey(t, key, []byte("some-password")))
+			})
+
+			it("decrypts the key with the password", func() {
+				loaded, err := signing.LoadPrivateKey(path, []byte("some-password"))
+				h.AssertNil(t, err)
+				h.AssertTrue(t, key.PublicKey.Equal(loaded.Public()))
+			})
+
+			it("errors with the wrong password", func() {
+				_, err := signing.LoadPrivateKey(path, []byte("wrong-password"))
+				h.AssertError(t, err, "invalid password")
+			})
+		})
+
+		it("errors for unsupported PEM types", func() {
+			path := writePEM(t, tmpDir, "CERTIFICATE", []byte("some-data"))
+
+			_, err := signing.LoadPrivateKey(path, nil)
+			h.AssertError(t, err, "unsupported private key type 'CERTIFICATE'")
+		})
+
+		it("errors when the file is not PEM", func() {
+			path := filepath.Join(tmpDir, "key")
+			h.AssertNil(t, os.WriteFile(path, []byte("not pem"), 0600))
+
+			_, err := signing.LoadPrivateKey(path, nil)
+			h.AssertError(t, err, "no PEM data found")
+		})
+	})
+
+	when("#Sign and #Verify", func() {
+		var (
+			server   *httptest.Server
+			digest   name.Digest
+			signer   *signing.Signer
+			verifier *signing.Verifier
+		)
+
+		it.Before(func() {
+			server = httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+			u, err := url.Parse(server.URL)
+			h.AssertNil(t, err)
+
+			imageRef, err := name.ParseReference(u.Host + "/some/app:latest")
+			h.AssertNil(t, err)
+
+			img, err := random.Image(1024, 1)
+			h.AssertNil(t, err)
+			h.AssertNil(t, remote.Write(imageRef, img))
+
+			imgDigest, err := img.Digest()
+			h.AssertNil(t, err)
+			digest = imageRef.Context().Digest(imgDigest.String())
+
+			key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			h.AssertNil(t, err)
+			signer = signing.NewSigner(key)
+			verifier = signing.NewVerifier(key.Public())
+		})
+
+		it.After(func() {
+			server.Close()
+		})
+
+		it("verifies a signed image", func() {
+			tag, err := signer.Sign(context.TODO(), digest, authn.DefaultKeychain)
+			h.AssertNil(t, err)
+			h.AssertEq(t, tag.Name(), signing.SignatureTag(digest).Name())
+
+			h.AssertNil(t, verifier.Verify(context.TODO(), digest, authn.DefaultKeychain))
+		})
+
+		it("keeps existing signatures", func() {
+			otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			h.AssertNil(t, err)
+
+			_, err = signing.NewSigner(otherKey).Sign(context.TODO(), digest, authn.DefaultKeychain)
+			h.AssertNil(t, err)
+			tag, err := signer.Sign(context.TODO(), digest, authn.DefaultKeychain)
+			h.AssertNil(t, err)
+
+			signatures, err := remote.Image(tag)
+			h.AssertNil(t, err)
+			layers, err := signatures.Layers()
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(layers), 2)
+
+			h.AssertNil(t, verifier.Verify(context.TODO(), digest, authn.DefaultKeychain))
+			h.AssertNil(t, signing.NewVerifier(otherKey.Public()).Verify(context.TODO(), digest, authn.DefaultKeychain))
+		})
+
+		it("verifies ed25519 signatures", func() {
+			pub, priv, err := ed25519.GenerateKey(rand.Reader)
+			h.AssertNil(t, err)
+
+			_, err = signing.NewSigner(priv).Sign(context.TODO(), digest, authn.DefaultKeychain)
+			h.AssertNil(t, err)
+
+			h.AssertNil(t, signing.NewVerifier(pub).Verify(context.TODO(), digest, authn.DefaultKeychain))
+		})
+
+		it("errors when the image is not signed", func() {
+			err := verifier.Verify(context.TODO(), digest, authn.DefaultKeychain)
+			h.AssertError(t, err, "no signatures found")
+		})
+
+		it("errors when the image is signed with another key", func() {
+			otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			h.AssertNil(t, err)
+			_, err = signing.NewSigner(otherKey).Sign(context.TODO(), digest, authn.DefaultKeychain)
+			h.AssertNil(t, err)
+
+			err = verifier.Verify(context.TODO(), digest, authn.DefaultKeychain)
+			h.AssertError(t, err, "no valid signatures found")
+		})
+
+		when("loading keys from files", func() {
+			it("verifies with the public key file", func() {
+				key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+				h.AssertNil(t, err)
+				der, err := x509.MarshalPKCS8PrivateKey(key)
+				h.AssertNil(t, err)
+				privatePath := writePEM(t, tmpDir, "PRIVATE KEY", der)
+				der, err = x509.MarshalPKIXPublicKey(key.Public())
+				h.AssertNil(t, err)
+				publicPath := writePEM(t, tmpDir, "PUBLIC KEY", der)
+
+				fileSigner, err := signing.LoadSigner(privatePath, nil)
+				h.AssertNil(t, err)
+				_, err = fileSigner.Sign(context.TODO(), digest, authn.DefaultKeychain)
+				h.AssertNil(t, err)
+
+				fileVerifier, err := signing.LoadVerifier(publicPath)
+				h.AssertNil(t, err)
+				h.AssertNil(t, fileVerifier.Verify(context.TODO(), digest, authn.DefaultKeychain))
+			})
+		})
+	})
+}
+
+func writePEM(t *testing.T, dir, pemType string, der []byte) string {
+	t.Helper()
+
+	f, err := os.CreateTemp(dir, "key")
+	h.AssertNil(t, err)
+	defer f.Close()
+
+	h.AssertNil(t, pem.Encode(f, &pem.Block{Type: pemType, Bytes: der}))
+	return f.Name()
+}
+
+// encryptKey encrypts the key the way 'cosign generate-key-pair' does, with light scrypt parameters.
+func encryptKey(t *testing.T, key crypto.PrivateKey, password []byte) []byte {
+	t.Helper()
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	h.AssertNil(t, err)
+
+	salt := make([]byte, 32)
+	_, err = rand.Read(salt)
+	h.AssertNil(t, err)
+	var nonce [24]byte
+	_, err = rand.Read(nonce[:])
+	h.AssertNil(t, err)
+
+	secret, err := scrypt.Key(password, salt, 1024, 8, 1, 32)
+	h.AssertNil(t, err)
+	var secretKey [32]byte
+	copy(secretKey[:], secret)
+
+	contents, err := json.Marshal(map[string]interface{}{
+		"kdf": map[string]interface{}{
+			"name":   "scrypt",
+			"params": map[string]int{"N": 1024, "r": 8, "p": 1},
+			"salt":   salt,
+		},
+		"cipher": map[string]interface{}{
+			"name":  "nacl/secretbox",
+			"nonce": nonce[:],
+		},
+		"ciphertext": secretbox.Seal(nil, der, &nonce, &secretKey),
+	})
+	h.AssertNil(t, err)
+	return contents
+}
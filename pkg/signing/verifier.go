@@ -0,0 +1,134 @@
+package signing
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// Verifier checks that images in a registry are signed by one of a set of public keys.
+type Verifier struct {
+	keys []crypto.PublicKey
+}
+
+// NewVerifier returns a Verifier accepting signatures made with any of the given public keys.
+func NewVerifier(keys ...crypto.PublicKey) *Verifier {
+	return &Verifier{keys: keys}
+}
+
+// LoadVerifier returns a Verifier accepting signatures made with any of the public keys at the given paths.
+func LoadVerifier(paths ...string) (*Verifier, error) {
+	var keys []crypto.PublicKey
+	for _, path := range paths {
+		key, err := LoadPublicKey(path)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return NewVerifier(keys...), nil
+}
+
+// Verify returns an error unless the image with the given digest has a signature made with one of the keys of
+// the verifier.
+func (v *Verifier) Verify(ctx context.Context, digest name.Digest, keychain authn.Keychain) error {
+	img, err := remote.Image(SignatureTag(digest), remote.WithContext(ctx), remote.WithAuthFromKeychain(keychain))
+	if err != nil {
+		var terr *transport.Error
+		if errors.As(err, &terr) && terr.StatusCode == http.StatusNotFound {
+			return errors.Errorf("no signatures found for %s", style.Symbol(digest.Name()))
+		}
+		return errors.Wrapf(err, "fetching signatures of %s", style.Symbol(digest.Name()))
+	}
+
+	manifest, err := img.Manifest()
+	if err != nil {
+		return errors.Wrapf(err, "reading signatures of %s", style.Symbol(digest.Name()))
+	}
+
+	for _, desc := range manifest.Layers {
+		if desc.MediaType != SimpleSigningMediaType {
+			continue
+		}
+
+		ok, err := v.verifyLayer(img, desc, digest)
+		if err != nil {
+			return errors.Wrapf(err, "reading signature of %s", style.Symbol(digest.Name()))
+		}
+		if ok {
+			return nil
+		}
+	}
+
+	return errors.Errorf("no valid signatures found for %s", style.Symbol(digest.Name()))
+}
+
+func (v *Verifier) verifyLayer(img v1.Image, desc v1.Descriptor, digest name.Digest) (bool, error) {
+	signature, err := base64.StdEncoding.DecodeString(desc.Annotations[SignatureAnnotation])
+	if err != nil || len(signature) == 0 {
+		return false, nil
+	}
+
+	layer, err := img.LayerByDigest(desc.Digest)
+	if err != nil {
+		return false, err
+	}
+	rc, err := layer.Compressed()
+	if err != nil {
+		return false, err
+	}
+	defer rc.Close()
+
+	payload, err := io.ReadAll(rc)
+	if err != nil {
+		return false, err
+	}
+
+	var p Payload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return false, nil
+	}
+	if p.Critical.Image.DockerManifestDigest != digest.DigestStr() {
+		return false, nil
+	}
+
+	for _, key := range v.keys {
+		if verifySignature(key, payload, signature) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func verifySignature(key crypto.PublicKey, payload, signature []byte) bool {
+	message, opts, err := signerOpts(key, payload)
+	if err != nil {
+		return false
+	}
+
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(k, message, signature)
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(k, opts.HashFunc(), message, signature) == nil
+	case ed25519.PublicKey:
+		return ed25519.Verify(k, message, signature)
+	default:
+		return false
+	}
+}
@@ -37,16 +37,21 @@ func (m *MockBlobDownloader) EXPECT() *MockBlobDownloaderMockRecorder {
 }
 
 // Download mocks base method.
-func (m *MockBlobDownloader) Download(arg0 context.Context, arg1 string) (blob.Blob, error) {
+func (m *MockBlobDownloader) Download(arg0 context.Context, arg1 string, arg2 ...blob.DownloadOption) (blob.Blob, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Download", arg0, arg1)
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Download", varargs...)
 	ret0, _ := ret[0].(blob.Blob)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Download indicates an expected call of Download.
-func (mr *MockBlobDownloaderMockRecorder) Download(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockBlobDownloaderMockRecorder) Download(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Download", reflect.TypeOf((*MockBlobDownloader)(nil).Download), arg0, arg1)
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Download", reflect.TypeOf((*MockBlobDownloader)(nil).Download), varargs...)
 }